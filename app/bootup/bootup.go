@@ -1,25 +1,32 @@
 package bootup
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"log"
+	"math/big"
 	"strconv"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gammazero/workerpool"
 	"github.com/itzmeanjan/harmony/app/config"
 	"github.com/itzmeanjan/harmony/app/data"
 	"github.com/itzmeanjan/harmony/app/graph"
 	"github.com/itzmeanjan/harmony/app/listen"
 	"github.com/itzmeanjan/harmony/app/networking"
+	"github.com/itzmeanjan/harmony/app/upstream"
 	"github.com/itzmeanjan/pub0sub/publisher"
 )
 
 // GetNetwork - Make RPC call for reading network ID
 func GetNetwork(ctx context.Context, rpc *rpc.Client) (uint64, error) {
 	var result string
-	if err := rpc.CallContext(ctx, &result, "net_version"); err != nil {
+	if err := upstream.Call(ctx, rpc, &result, "net_version"); err != nil {
 		return 0, err
 	}
 
@@ -31,6 +38,34 @@ func GetNetwork(ctx context.Context, rpc *rpc.Client) (uint64, error) {
 	return _result, nil
 }
 
+// GetChainID - Make RPC call for reading chain ID, to be used
+// when recovering sender of signed tx(s)
+func GetChainID(ctx context.Context, rpc *rpc.Client) (*big.Int, error) {
+	var result hexutil.Big
+	if err := upstream.Call(ctx, rpc, &result, "eth_chainId"); err != nil {
+		return nil, err
+	}
+
+	return (*big.Int)(&result), nil
+}
+
+// failuresOnly - Log writer, which lets only failures through, as marked
+// by `[❗️]`, dropping everything else
+type failuresOnly struct {
+	w io.Writer
+}
+
+// Write - Each invocation carries one log line, written by logger
+func (f *failuresOnly) Write(p []byte) (int, error) {
+
+	if !bytes.Contains(p, []byte("[❗️]")) {
+		return len(p), nil
+	}
+
+	return f.w.Write(p)
+
+}
+
 // SetGround - This is to be called when starting application
 // for doing basic ground work(s), so that all required resources
 // are available for further usage during application lifetime
@@ -40,12 +75,16 @@ func SetGround(ctx context.Context, file string) (*data.Resource, error) {
 		return nil, err
 	}
 
-	client, err := rpc.DialContext(ctx, config.Get("RPCUrl"))
+	if config.GetLogLevel() == "error" {
+		log.SetOutput(&failuresOnly{w: log.Writer()})
+	}
+
+	client, err := rpc.DialContext(ctx, config.GetRPCUrl())
 	if err != nil {
 		return nil, err
 	}
 
-	wsClient, err := ethclient.DialContext(ctx, config.Get("WSUrl"))
+	wsClient, err := ethclient.DialContext(ctx, config.GetWSUrl())
 	if err != nil {
 		return nil, err
 	}
@@ -61,69 +100,134 @@ func SetGround(ctx context.Context, file string) (*data.Resource, error) {
 		return nil, err
 	}
 
+	// Attempt to read chain ID, which is used for deriving
+	// sender of tx(s) received from peers
+	chainID, err := GetChainID(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	// Same codec instance to be used by all publishing/ subscribing
+	// sites, for serializing tx(s)
+	codec := data.NewCodec(config.GetPublishCodec())
+
 	// This is communication channel to be used between pending pool
 	// & queued pool, so that when new tx gets added into pending pool
 	// queued pool also gets notified & gets to update state if required
 	alreadyInPendingPoolChan := make(chan *data.MemPoolTx, 4096)
 	inPendingPoolChan := make(chan *data.MemPoolTx, 4096)
-	lastSeenBlockChan := make(chan uint64, 16)
+	lastSeenBlockChan := make(chan listen.Head, 16)
+
+	// Long-lived worker pool, shared by both pools, for filtering
+	// tx(s) while answering queries, so that go routines aren't
+	// spawned & torn down for each query
+	workers := workerpool.New(config.GetConcurrencyFactor())
 
 	// initialising pending pool
 	pendingPool := &data.PendingPool{
 		Transactions:             make(map[common.Hash]*data.MemPoolTx),
 		TxsFromAddress:           make(map[common.Address]data.TxList),
+		TxsByNonce:               make(data.NonceIndex),
 		DroppedTxs:               make(map[common.Hash]time.Time),
 		RemovedTxs:               make(map[common.Hash]time.Time),
-		AscTxsByGasPrice:         make(data.MemPoolTxsAsc, 0, config.GetPendingPoolSize()),
-		DescTxsByGasPrice:        make(data.MemPoolTxsDesc, 0, config.GetPendingPoolSize()),
+		TxsByGasPrice:            data.NewGasPriceTree(),
+		TxsByAge:                 data.NewPendingAgeTree(),
+		ContractCreationTxs:      make(data.MemPoolTxsDesc, 0, 1024),
 		Done:                     0,
 		LastSeenBlock:            0,
 		LastSeenAt:               time.Now().UTC(),
 		AddTxChan:                make(chan data.AddRequest, 1),
+		AddBatchChan:             make(chan data.AddBatchRequest, 1),
 		AddFromQueuedPoolChan:    make(chan data.AddRequest, 1),
 		RemoveTxChan:             make(chan data.RemoveRequest, 1),
 		AlreadyInPendingPoolChan: alreadyInPendingPoolChan,
 		InPendingPoolChan:        inPendingPoolChan,
 		TxExistsChan:             make(chan data.ExistsRequest, 1),
 		GetTxChan:                make(chan data.GetRequest, 1),
+		FindTxsChan:              make(chan data.FindTxsRequest, 1),
+		DuplicateTxsChan:         make(chan data.DuplicateTxsRequest, 1),
+		SameNonceChan:            make(chan data.SameNonceRequest, 1),
+		GasPriceRangeChan:        make(chan data.GasPriceRangeRequest, 1),
 		CountTxsChan:             make(chan data.CountRequest, 1),
 		ListTxsChan:              make(chan data.ListRequest, 1),
 		TxsFromAChan:             make(chan data.TxsFromARequest, 1),
+		CountFromChan:            make(chan data.CountFromRequest, 1),
+		TopSendersChan:           make(chan data.TopSendersRequest, 1),
+		ContractCreationsChan:    make(chan chan []*data.MemPoolTx, 1),
 		DoneChan:                 make(chan chan uint64, 1),
+		GasPriceStatsChan:        make(chan chan data.GasPriceStats, 1),
+		AggregatesChan:           make(chan chan data.PoolAggregates, 1),
+		StatsChan:                make(chan chan data.PoolStats, 1),
+		LatencySamplesChan:       make(chan chan []data.LatencySample, 1),
+		RecentTxChan:             make(chan data.GetRequest, 1),
+		EvaluateStuckChan:        make(chan data.StuckRequest, 1),
+		StuckTxsChan:             make(chan chan []*data.MemPoolTx, 1),
+		PruneSetChan:             make(chan data.PruneSetRequest, 1),
+		RecommendChan:            make(chan data.GasPriceRecommendRequest, 1),
 		SetLastSeenBlockChan:     lastSeenBlockChan,
 		LastSeenBlockChan:        make(chan chan data.LastSeenBlock, 1),
+		NewBlockChan:             make(chan struct{}, 1),
+		ReorgedChan:              make(chan []common.Hash, 1),
+		AgeWalkChan:              make(chan data.AgeWalkRequest, 1),
+		ResizedChan:              make(chan struct{}, 1),
+		StoppedChan:              make(chan struct{}),
+		Codec:                    codec,
 		PubSub:                   publisher,
 		RPC:                      client,
+		Workers:                  workers,
 	}
 
 	// initialising queued pool
 	queuedPool := &data.QueuedPool{
-		Transactions:      make(map[common.Hash]*data.MemPoolTx),
-		TxsFromAddress:    make(map[common.Address]data.TxList),
-		DroppedTxs:        make(map[common.Hash]time.Time),
-		RemovedTxs:        make(map[common.Hash]time.Time),
-		AscTxsByGasPrice:  make(data.MemPoolTxsAsc, 0, config.GetQueuedPoolSize()),
-		DescTxsByGasPrice: make(data.MemPoolTxsDesc, 0, config.GetQueuedPoolSize()),
-		AddTxChan:         make(chan data.AddRequest, 1),
-		RemoveTxChan:      make(chan data.RemovedUnstuckTx, 1),
-		TxExistsChan:      make(chan data.ExistsRequest, 1),
-		GetTxChan:         make(chan data.GetRequest, 1),
-		CountTxsChan:      make(chan data.CountRequest, 1),
-		ListTxsChan:       make(chan data.ListRequest, 1),
-		TxsFromAChan:      make(chan data.TxsFromARequest, 1),
-		PubSub:            publisher,
-		RPC:               client,
-		PendingPool:       pendingPool,
+		Transactions:       make(map[common.Hash]*data.MemPoolTx),
+		TxsFromAddress:     make(map[common.Address]data.TxList),
+		TxsByNonce:         make(data.NonceIndex),
+		DroppedTxs:         make(map[common.Hash]time.Time),
+		RemovedTxs:         make(map[common.Hash]time.Time),
+		TxsByGasPrice:      data.NewGasPriceTree(),
+		TxsByAge:           data.NewQueuedAgeTree(),
+		TxsByNonceGap:      data.NewNonceGapTree(),
+		AddTxChan:          make(chan data.AddRequest, 1),
+		RemoveTxChan:       make(chan data.RemovedUnstuckTx, 1),
+		TxExistsChan:       make(chan data.ExistsRequest, 1),
+		GetTxChan:          make(chan data.GetRequest, 1),
+		FindTxsChan:        make(chan data.FindTxsRequest, 1),
+		DuplicateTxsChan:   make(chan data.DuplicateTxsRequest, 1),
+		SameNonceChan:      make(chan data.SameNonceRequest, 1),
+		GasPriceRangeChan:  make(chan data.GasPriceRangeRequest, 1),
+		CountTxsChan:       make(chan data.CountRequest, 1),
+		ListTxsChan:        make(chan data.ListRequest, 1),
+		TxsFromAChan:       make(chan data.TxsFromARequest, 1),
+		CountFromChan:      make(chan data.CountFromRequest, 1),
+		TopSendersChan:     make(chan data.TopSendersRequest, 1),
+		AggregatesChan:     make(chan chan data.PoolAggregates, 1),
+		StatsChan:          make(chan chan data.PoolStats, 1),
+		SetSenderNonceChan: make(chan data.SenderNonce, 1),
+		BaseFeeChan:        make(chan *big.Int, 1),
+		GapReportChan:      make(chan chan []*data.SenderGap, 1),
+		SendersChan:        make(chan chan []common.Address, 1),
+		AgeWalkChan:        make(chan data.AgeWalkRequest, 1),
+		ResizedChan:        make(chan struct{}, 1),
+		StoppedChan:        make(chan struct{}),
+		Codec:              codec,
+		PubSub:             publisher,
+		RPC:                client,
+		Workers:            workers,
+		PendingPool:        pendingPool,
 	}
 
+	pendingPool.QueuedPool = queuedPool
+
 	pool := &data.MemPool{
 		Pending: pendingPool,
 		Queued:  queuedPool,
+		ChainID: chainID,
 	}
 
 	// Block head listener & pending pool pruner
 	// talks over this buffered channel
 	caughtTxsChan := make(chan listen.CaughtTxs, 16)
+	reorgChan := make(chan listen.Reorg, 16)
 	notFoundTxsChan := make(chan listen.CaughtTxs, 16)
 	confirmedTxsChan := make(chan data.ConfirmedTx, 4096)
 
@@ -133,10 +237,15 @@ func SetGround(ctx context.Context, file string) (*data.Resource, error) {
 	//
 	// After that this pool will also let (b) know that it can
 	// update state of txs, which have become unstuck
-	go pool.Pending.Prune(ctx, caughtTxsChan, confirmedTxsChan, notFoundTxsChan)
+	go pool.Pending.Prune(ctx, caughtTxsChan, reorgChan, confirmedTxsChan, notFoundTxsChan)
+	// Flags pending tx(s) which can't pay latest base fee
+	go pool.Pending.DetectStuck(ctx)
 	go pool.Queued.Start(ctx)
 	// (b)
 	go pool.Queued.Prune(ctx, confirmedTxsChan, alreadyInPendingPoolChan)
+	// Promotes queued tx(s), whose nonce gap is closed, without
+	// waiting for next poll
+	go pool.Queued.CheckUnstuck(ctx)
 
 	// This worker will supervise block header listener, so that it can keep
 	// track of their health & if they die due to some abnormal reasons
@@ -146,7 +255,7 @@ func SetGround(ctx context.Context, file string) (*data.Resource, error) {
 		var died bool
 
 		healthChan := make(chan struct{})
-		go listen.SubscribeHead(ctx, wsClient, pool.Pending.GetLastSeenBlock().Number, caughtTxsChan, lastSeenBlockChan, healthChan)
+		go listen.SubscribeHead(ctx, wsClient, pool.Pending.GetLastSeenBlock().Number, config.GetReorgDepth(), caughtTxsChan, lastSeenBlockChan, reorgChan, healthChan)
 
 		for {
 
@@ -155,7 +264,7 @@ func SetGround(ctx context.Context, file string) (*data.Resource, error) {
 				<-time.After(time.Duration(5) * time.Second)
 
 				healthChan = make(chan struct{})
-				go listen.SubscribeHead(ctx, wsClient, pool.Pending.GetLastSeenBlock().Number, caughtTxsChan, lastSeenBlockChan, healthChan)
+				go listen.SubscribeHead(ctx, wsClient, pool.Pending.GetLastSeenBlock().Number, config.GetReorgDepth(), caughtTxsChan, lastSeenBlockChan, reorgChan, healthChan)
 
 				died = false
 			}
@@ -181,6 +290,27 @@ func SetGround(ctx context.Context, file string) (*data.Resource, error) {
 
 	go data.TrackNotFoundTxs(ctx, inPendingPoolChan, notFoundTxsChan, caughtTxsChan)
 
+	// Pool state persisted during last run, if asked to, is read back here,
+	// it'll be reconciled with first mempool content fetched
+	var restored *data.PoolState
+
+	if file := config.GetStateFile(); len(file) != 0 {
+
+		if config.GetRestoreStateOnBoot() {
+
+			state, err := data.ReadState(file)
+			if err != nil {
+				log.Printf("[❗️] Skipping restoration of pool state : %s\n", err.Error())
+			} else {
+				restored = state
+			}
+
+		}
+
+		go pool.PersistState(ctx, file, time.Duration(config.GetStateSnapshotPeriod())*time.Millisecond)
+
+	}
+
 	// Passed this mempool handle to graphql query resolver
 	if err := graph.InitMemPool(pool); err != nil {
 		return nil, err
@@ -192,6 +322,18 @@ func SetGround(ctx context.Context, file string) (*data.Resource, error) {
 		return nil, err
 	}
 
+	// Pubsub messages to be deserialized using same codec, which
+	// is used for publishing
+	if err := graph.InitCodec(codec); err != nil {
+		return nil, err
+	}
+
+	// Tx(s) sent to/ received from peers are serialized using
+	// same codec
+	if err := networking.InitCodec(codec); err != nil {
+		return nil, err
+	}
+
 	// Passing parent context to graphQL subscribers, so that
 	// graceful system shutdown can be performed
 	graph.InitParentContext(ctx)
@@ -199,10 +341,12 @@ func SetGround(ctx context.Context, file string) (*data.Resource, error) {
 	networking.InitParentContext(ctx)
 
 	return &data.Resource{
-		RPCClient: client,
-		WSClient:  wsClient,
-		Pool:      pool,
-		StartedAt: time.Now().UTC(),
-		NetworkID: network}, nil
+		RPCClient:     client,
+		WSClient:      wsClient,
+		Pool:          pool,
+		StartedAt:     time.Now().UTC(),
+		NetworkID:     network,
+		Codec:         codec,
+		RestoredState: restored}, nil
 
 }
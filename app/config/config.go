@@ -1,19 +1,94 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"log"
-	"math"
-	"runtime"
+	"net"
+	"os"
+	"strings"
 
 	"github.com/spf13/viper"
 )
 
-// Read - Reading .env file content, during application start up
+// envPrefix - Any config key can be set/ overridden using environment
+// variable named with this prefix, followed by upper cased key, joined
+// with `_` e.g. `HARMONY_RPCURL`
+const envPrefix = "HARMONY"
+
+// envKeyReplacer - Characters in config key, not allowed in environment
+// variable name, are replaced
+var envKeyReplacer = strings.NewReplacer(".", "_", "-", "_")
+
+// requiredKeys - Config keys which must be set, either in file or in
+// environment, for `harmony` to start
+var requiredKeys = []string{"RPCUrl", "WSUrl", "Pub0SubHost", "Pub0SubPort"}
+
+// Read - Reading .env file content, during application start up, where
+// environment variables take precedence over what's in file, so that
+// secrets need not be written to file
+//
+// Whole configuration is validated, failing with all problems found, so
+// that those can be fixed in one go, where keys falling back to default
+// value are reported together
+//
+// Absent file is fine, as long as all required keys are set in environment,
+// which is handy when running inside container
 func Read(file string) error {
+	viper.SetEnvPrefix(envPrefix)
+	viper.SetEnvKeyReplacer(envKeyReplacer)
+	viper.AutomaticEnv()
+
 	viper.SetConfigFile(file)
 
-	return viper.ReadInConfig()
+	if _, err := os.Stat(file); !errors.Is(err, os.ErrNotExist) {
+
+		if err := viper.ReadInConfig(); err != nil {
+			return err
+		}
+
+	} else {
+
+		missing := make([]string, 0, len(requiredKeys))
+		for _, key := range requiredKeys {
+			if !viper.IsSet(key) {
+				missing = append(missing, envName(key))
+			}
+		}
+
+		if len(missing) != 0 {
+			return fmt.Errorf("config file `%s` not found & required environment variable(s) not set : %s", file, strings.Join(missing, ", "))
+		}
+
+		log.Printf("[❗️] Config file `%s` not found, reading config from environment\n", file)
+
+	}
+
+	c, defaults, problems := load()
+	if len(problems) != 0 {
+		return fmt.Errorf("invalid config, %d problem(s) found :\n\t- %s", len(problems), strings.Join(problems, "\n\t- "))
+	}
+
+	if len(defaults) != 0 {
+		log.Printf("[⚙️] Using default value for %d config key(s) : %s\n", len(defaults), strings.Join(defaults, ", "))
+	}
+
+	configFile = file
+	current.Store(c)
+	return nil
+}
+
+// Override - Sets value of config key, taking precedence over both
+// environment variable & config file, meant to be used for command line
+// flags, before config is read
+func Override(key string, value string) {
+	viper.Set(key, value)
+}
+
+// envName - Name of environment variable, which can be used for setting
+// given config key
+func envName(key string) string {
+	return envPrefix + "_" + strings.ToUpper(envKeyReplacer.Replace(key))
 }
 
 // Get - Get config value by key
@@ -36,6 +111,21 @@ func GetBool(key string) bool {
 	return viper.GetBool(key)
 }
 
+// GetRPCUrl - URI of `txpool` RPC API enabled Ethereum node
+func GetRPCUrl() string {
+
+	return loaded().RPCUrl
+
+}
+
+// GetWSUrl - Websocket URI of Ethereum node, to be used for listening to
+// newly mined block headers
+func GetWSUrl() string {
+
+	return loaded().WSUrl
+
+}
+
 // GetMemPoolPollingPeriod - Read mempool polling period & attempt to
 // parse it to string, where it's expected that this period will be
 // provided in form of time duration with millisecond level precision
@@ -48,33 +138,159 @@ func GetBool(key string) bool {
 // 1000ms & again get to work
 func GetMemPoolPollingPeriod() uint64 {
 
-	if period := GetUint("MemPoolPollingPeriod"); period != 0 {
-		return period
-	}
+	return loaded().MemPoolPollingPeriod
+
+}
+
+// GetRPCTimeout - Each outbound RPC call to be given up after these
+// many milliseconds, so that hung node doesn't stall workers forever
+func GetRPCTimeout() uint64 {
 
-	return 1000
+	return loaded().RPCTimeout
+
+}
+
+// GetRPCRetries - Outbound RPC call failing due to transient reason, like
+// timeout/ network error, to be retried at max these many times, where
+// explicitly set `0` disables retrying
+func GetRPCRetries() uint64 {
+
+	return loaded().RPCRetries
+
+}
+
+// GetUpstreamClient - Which Ethereum client `harmony` is talking to, so that
+// quirks in its `txpool_content` response can be taken care of, where `auto`
+// asks to detect it using `web3_clientVersion`
+func GetUpstreamClient() string {
+
+	return loaded().UpstreamClient
+
+}
+
+// GetMemPoolPollingPeriodMin - Mempool polling interval never to go below these
+// many milliseconds, when being adapted, it's also where it starts from
+//
+// If nothing is provided, `MemPoolPollingPeriod` is used
+func GetMemPoolPollingPeriodMin() uint64 {
+
+	return loaded().MemPoolPollingPeriodMin
+
+}
+
+// GetMemPoolPollingPeriodMax - Mempool polling interval never to go above these
+// many milliseconds, when being adapted, while it's never below min interval
+func GetMemPoolPollingPeriodMax() uint64 {
+
+	return loaded().MemPoolPollingPeriodMax
+
+}
+
+// GetMemPoolPollingBurst - When at least these many new tx(s) are found in
+// single poll, polling interval is shrunk
+func GetMemPoolPollingBurst() uint64 {
+
+	return loaded().MemPoolPollingBurst
+
+}
+
+// GetMemPoolMaxStaleness - Even if cheap `txpool_status` check says nothing
+// has changed, full mempool content to be fetched, when last fetch is older
+// than these many milliseconds
+func GetMemPoolMaxStaleness() uint64 {
+
+	return loaded().MemPoolMaxStaleness
+
+}
+
+// GetMemPoolFullResyncPolls - Once in every `N` polls, all tx(s) found in
+// mempool content to be pushed into pools, not only those which weren't
+// seen in previous poll
+func GetMemPoolFullResyncPolls() uint64 {
+
+	return loaded().MemPoolFullResyncPolls
+
+}
+
+// GetPollerBackoffInitial - When mempool poller dies, new one to be spawned
+// after waiting for these many milliseconds, which is doubled after each
+// consecutive failure
+func GetPollerBackoffInitial() uint64 {
+
+	return loaded().PollerBackoffInitial
+
+}
+
+// GetPollerBackoffMax - Upper bound on how long to wait, in milliseconds,
+// before spawning new mempool poller
+func GetPollerBackoffMax() uint64 {
+
+	return loaded().PollerBackoffMax
+
+}
+
+// GetPollerMaxFailures - After these many consecutive failures of mempool
+// poller, no new one is spawned & `harmony` shuts down
+func GetPollerMaxFailures() uint64 {
+
+	return loaded().PollerMaxFailures
 
 }
 
 // GetPendingPoolSize - Max #-of pending pool txs can be living in memory
 func GetPendingPoolSize() uint64 {
 
-	if size := GetUint("PendingPoolSize"); size != 0 {
-		return size
-	}
-
-	return 1024
+	return loaded().PendingPoolSize
 
 }
 
 // GetQueuedPoolSize - Max #-of queued pool txs can be living in memory
 func GetQueuedPoolSize() uint64 {
 
-	if size := GetUint("QueuedPoolSize"); size != 0 {
-		return size
-	}
+	return loaded().QueuedPoolSize
 
-	return 1024
+}
+
+// GetMaxTxsPerAddress - Max #-of pending tx(s) from same sender address, which
+// can be living in pool at a time, so that one sender can't fill up whole pool
+//
+// If nothing is provided, no such cap is enforced
+func GetMaxTxsPerAddress() uint64 {
+
+	return loaded().MaxTxsPerAddress
+
+}
+
+// GetBlockGasLimit - Gas limit of block, used for estimating how many
+// pending tx(s) can fit in next few blocks, while recommending gas price
+func GetBlockGasLimit() uint64 {
+
+	return loaded().BlockGasLimit
+
+}
+
+// GetGasPriceStatsPeriod - Gas price statistics of pending pool to be
+// recomputed at max once in every `X` milliseconds, within that period
+// cached copy is served
+func GetGasPriceStatsPeriod() uint64 {
+
+	return loaded().GasPriceStatsPeriod
+
+}
+
+// GetConfirmationLatencySamples - Pending duration of these many recently
+// confirmed tx(s) to be kept, for computing confirmation latency stats
+func GetConfirmationLatencySamples() uint64 {
+
+	return loaded().ConfirmationLatencySamples
+
+}
+
+// GetRecentTxsBuffer - These many tx(s), which have recently left pending
+// pool, to be remembered, so that they can still be looked up
+func GetRecentTxsBuffer() uint64 {
+
+	return loaded().RecentTxsBuffer
 
 }
 
@@ -82,12 +298,7 @@ func GetQueuedPoolSize() uint64 {
 // where newly added pending pool tx(s) to be published
 func GetPendingTxEntryPublishTopic() string {
 
-	if v := Get("PendingTxEntryTopic"); len(v) != 0 {
-		return v
-	}
-
-	log.Printf("[❗️] Failed to get topic for publishing new pending tx, using `pending_pool_entry`\n")
-	return "pending_pool_entry"
+	return loaded().PendingTxEntryTopic
 
 }
 
@@ -95,12 +306,81 @@ func GetPendingTxEntryPublishTopic() string {
 // where tx(s) removed from pending pool to be published
 func GetPendingTxExitPublishTopic() string {
 
-	if v := Get("PendingTxExitTopic"); len(v) != 0 {
-		return v
-	}
+	return loaded().PendingTxExitTopic
+
+}
+
+// GetPendingTxReplacementPublishTopic - Read provided topic name from `.env` file
+// where pending pool tx(s), replaced by fee bumped tx, to be published
+func GetPendingTxReplacementPublishTopic() string {
+
+	return loaded().PendingTxReplacementTopic
+
+}
 
-	log.Printf("[❗️] Failed to get topic for publishing tx removed from pending pool, using `pending_pool_exit`\n")
-	return "pending_pool_exit"
+// GetPendingTxStuckPublishTopic - Read provided topic name from `.env` file
+// where pending tx(s), which can't pay latest base fee, to be published
+func GetPendingTxStuckPublishTopic() string {
+
+	return loaded().PendingTxStuckTopic
+
+}
+
+// GetPendingTxReorgedPublishTopic - Read provided topic name from `.env` file
+// where tx(s), re-admitted into pending pool after chain reorganization,
+// to be published
+func GetPendingTxReorgedPublishTopic() string {
+
+	return loaded().PendingTxReorgedTopic
+
+}
+
+// GetReorgDepth - Hashes of these many recent blocks to be remembered, along
+// with tx(s) pruned after seeing them, so that chain reorganization, not
+// deeper than this, can be handled
+func GetReorgDepth() uint64 {
+
+	return loaded().ReorgDepth
+
+}
+
+// GetQueuedToPendingPublishTopic - Read provided topic name from `.env` file
+// where tx(s) promoted from queued pool to pending pool to be published
+func GetQueuedToPendingPublishTopic() string {
+
+	return loaded().QueuedToPendingTopic
+
+}
+
+// GetStuckTxAfter - Pending tx, which can't pay latest base fee, to be
+// flagged as stuck, only after it has been pending for `X` milliseconds
+func GetStuckTxAfter() uint64 {
+
+	return loaded().StuckTxAfter
+
+}
+
+// GetStuckTxCheckPeriod - Latest base fee to be fetched & pending pool to
+// be checked for stuck tx(s), every `X` milliseconds
+func GetStuckTxCheckPeriod() uint64 {
+
+	return loaded().StuckTxCheckPeriod
+
+}
+
+// GetUnstuckCheckPeriod - Queued pool to be checked for tx(s), which are
+// not stuck anymore, every `X` milliseconds
+func GetUnstuckCheckPeriod() uint64 {
+
+	return loaded().UnstuckCheckPeriod
+
+}
+
+// GetUnstuckCheckRPCBudget - At max these many senders' account nonce to be
+// fetched, in each round of checking queued pool for unstuck tx(s)
+func GetUnstuckCheckRPCBudget() uint64 {
+
+	return loaded().UnstuckCheckRPCBudget
 
 }
 
@@ -108,12 +388,7 @@ func GetPendingTxExitPublishTopic() string {
 // where newly added queued pool tx(s) to be published
 func GetQueuedTxEntryPublishTopic() string {
 
-	if v := Get("QueuedTxEntryTopic"); len(v) != 0 {
-		return v
-	}
-
-	log.Printf("[❗️] Failed to get topic for publishing new queued tx, using `queued_pool_entry`\n")
-	return "queued_pool_entry"
+	return loaded().QueuedTxEntryTopic
 
 }
 
@@ -121,12 +396,142 @@ func GetQueuedTxEntryPublishTopic() string {
 // where tx(s) removed from queued pool to be published
 func GetQueuedTxExitPublishTopic() string {
 
-	if v := Get("QueuedTxExitTopic"); len(v) != 0 {
-		return v
-	}
+	return loaded().QueuedTxExitTopic
+
+}
 
-	log.Printf("[❗️] Failed to get topic for publishing tx removed from queued pool, using `queued_pool_exit`\n")
-	return "queued_pool_exit"
+// GetPublishPendingEntry - Whether tx(s) joining pending pool to be
+// published, which is the case, unless explicitly disabled
+func GetPublishPendingEntry() bool {
+
+	return loaded().PublishPendingEntry
+
+}
+
+// GetPublishPendingExit - Whether tx(s) leaving pending pool to be
+// published, which is the case, unless explicitly disabled
+func GetPublishPendingExit() bool {
+
+	return loaded().PublishPendingExit
+
+}
+
+// GetPublishQueuedEntry - Whether tx(s) joining queued pool to be
+// published, which is the case, unless explicitly disabled
+func GetPublishQueuedEntry() bool {
+
+	return loaded().PublishQueuedEntry
+
+}
+
+// GetPublishQueuedExit - Whether tx(s) leaving queued pool to be
+// published, which is the case, unless explicitly disabled
+func GetPublishQueuedExit() bool {
+
+	return loaded().PublishQueuedExit
+
+}
+
+// GetPublishPendingReplacement - Whether pending tx(s), replaced by fee
+// bumped tx, to be published, which is the case, unless explicitly disabled
+func GetPublishPendingReplacement() bool {
+
+	return loaded().PublishPendingReplacement
+
+}
+
+// GetPublishPendingStuck - Whether pending tx(s), flagged as stuck, to be
+// published, which is the case, unless explicitly disabled
+func GetPublishPendingStuck() bool {
+
+	return loaded().PublishPendingStuck
+
+}
+
+// GetPublishPendingReorged - Whether tx(s), re-admitted into pending pool
+// after chain reorganization, to be published, which is the case, unless
+// explicitly disabled
+func GetPublishPendingReorged() bool {
+
+	return loaded().PublishPendingReorged
+
+}
+
+// GetPublishQueuedToPending - Whether tx(s) promoted from queued pool to
+// pending pool to be published, which is the case, unless explicitly disabled
+func GetPublishQueuedToPending() bool {
+
+	return loaded().PublishQueuedToPending
+
+}
+
+// GetPublishCodec - Codec to be used for serializing tx(s) being published
+// on pubsub topics & sent to peers, either of {msgpack, json, protobuf}
+//
+// If nothing is provided, `msgpack` is used
+func GetPublishCodec() string {
+
+	return loaded().PublishCodec
+
+}
+
+// GetPublishBatchSize - #-of tx(s) to be published together on pubsub topic,
+// as one message, when lots of tx(s) join/ leave pool in one go
+//
+// Batching is only supported with `msgpack`/ `json` codec, otherwise/ by default
+// each tx is published as seperate message
+func GetPublishBatchSize() uint64 {
+
+	return loaded().PublishBatchSize
+
+}
+
+// GetPublishBatchPeriod - Accumulated batch of tx(s) to be published every
+// `X` milliseconds, even if batch size is not reached
+func GetPublishBatchPeriod() uint64 {
+
+	return loaded().PublishBatchPeriod
+
+}
+
+// GetCompressionThreshold - Serialized tx(s)/ batches larger than `N` bytes
+// to be snappy compressed, before being published on pubsub topics & sent
+// to peers
+//
+// If nothing is provided, compression stays disabled
+func GetCompressionThreshold() uint64 {
+
+	return loaded().CompressionThreshold
+
+}
+
+// GetEvictionPolicy - When pool is full, tx to be dropped for making room
+// for new one, is picked following this policy, one of
+//
+// - lowest-gas : Tx with lowest gas price paid
+// - oldest : Oldest tx living in pool
+// - oldest-lowest-gas : Oldest tx among those paying lowest gas price
+//
+// Same policy is applied to queued pool too, unless it's configured
+// separately, by default `lowest-gas` is used
+func GetEvictionPolicy() string {
+
+	return loaded().PendingPoolEvictionPolicy
+
+}
+
+// GetQueuedEvictionPolicy - When queued pool is full, tx to be dropped for
+// making room for new one, is picked following this policy, one of
+//
+// - lowest-gas : Tx with lowest gas price paid
+// - oldest : Oldest tx living in pool
+// - oldest-lowest-gas : Oldest tx among those paying lowest gas price
+// - largest-nonce-gap : Tx farthest from becoming executable
+//
+// If not set, same policy as pending pool is followed
+func GetQueuedEvictionPolicy() string {
+
+	return loaded().QueuedPoolEvictionPolicy
 
 }
 
@@ -135,28 +540,215 @@ func GetQueuedTxExitPublishTopic() string {
 // @note You can set floating point value for `ConcurrencyFactor` ( > 0 )
 func GetConcurrencyFactor() int {
 
-	f := int(math.Ceil(GetFloat("ConcurrencyFactor") * float64(runtime.NumCPU())))
-	if f <= 0 {
+	return loaded().ConcurrencyFactor
 
-		log.Printf("[❗️] Bad concurrency factor, using unit sized pool\n")
-		return 1
+}
 
-	}
+// GetParallelFilterThreshold - Pool tx(s) are filtered on shared worker
+// pool, only when there're at least these many of them, otherwise filtered
+// sequentially, because fan-out overhead dominates for cheap predicates
+func GetParallelFilterThreshold() uint64 {
 
-	return f
+	return loaded().ParallelFilterThreshold
 
 }
 
-// GetPortNumber - Attempts to read user preferred port number
-// for running harmony as a service, if failing/ port lesser than 1024
-// uses default value `7000`
-func GetPortNumber() uint64 {
+// GetStateFile - Path to file where pool state to be persisted periodically,
+// so that tx timestamps survive restarts
+//
+// When not set, pool state isn't persisted
+func GetStateFile() string {
 
-	if port := GetUint("Port"); port > 1024 {
-		return port
-	}
+	return loaded().StateFile
+
+}
+
+// GetStateSnapshotPeriod - Pool state to be persisted every `X` milliseconds,
+// given state file is configured
+func GetStateSnapshotPeriod() uint64 {
+
+	return loaded().StateSnapshotPeriod
+
+}
+
+// GetRestoreStateOnBoot - Whether last persisted pool state to be restored
+// when starting up, so that tx(s) still living in mempool keep their
+// original timestamps
+func GetRestoreStateOnBoot() bool {
+
+	return loaded().RestoreStateOnBoot
 
-	return 7000
+}
+
+// GetDisappearedTxProbeBlocks - Pending tx(s) disappeared from mempool
+// content, still living in pool after these many blocks, are checked with
+// node, whether they're dropped
+func GetDisappearedTxProbeBlocks() uint64 {
+
+	return loaded().DisappearedTxProbeBlocks
+
+}
+
+// GetPruneByReceipt - Whether pending pool pruner to fetch receipt of each
+// prunable tx, for deciding whether it got confirmed or dropped, instead of
+// deciding it from content of mined block alone
+func GetPruneByReceipt() bool {
+
+	return loaded().PruneByReceipt
+
+}
+
+// GetExportDirectory - Pool snapshots, exported on demand for debugging,
+// to be placed under this directory
+func GetExportDirectory() string {
+
+	return loaded().ExportDirectory
+
+}
+
+// GetAdminToken - Bearer token, which must be presented for invoking
+// admin API, when not set admin API stays disabled
+func GetAdminToken() string {
+
+	return loaded().AdminToken
+
+}
+
+// GetAPIKeys - API keys, each given as `label:key`, one of which must be
+// presented by clients, when set along with/ without `APIKeysFile`
+func GetAPIKeys() []string {
+
+	return loaded().APIKeys
+
+}
+
+// GetAPIKeysFile - Path to file holding API keys, one `label:key` per line
+func GetAPIKeysFile() string {
+
+	return loaded().APIKeysFile
+
+}
+
+// GetRateLimit - Max #-of requests per second, each client can make,
+// where zero/ negative disables rate limiting
+func GetRateLimit() float64 {
+
+	return loaded().RateLimit
+
+}
+
+// GetRateLimitBurst - Max #-of requests, client can make in quick
+// succession, after staying idle for a while, never lesser than one
+// second worth of requests
+func GetRateLimitBurst() uint64 {
+
+	return loaded().RateLimitBurst
+
+}
+
+// GetTrustedProxies - Address ranges of reverse proxies, client address is
+// taken from `X-Forwarded-For` header of, when request comes through them.
+// Empty when server faces clients directly
+func GetTrustedProxies() []*net.IPNet {
+
+	return loaded().TrustedProxies
+
+}
+
+// GetTLSCertFile - Path to PEM encoded certificate ( chain ), to be presented
+// by HTTP server, TLS is enabled only when it's set along with key file
+func GetTLSCertFile() string {
+
+	return loaded().TLSCertFile
+
+}
+
+// GetTLSKeyFile - Path to PEM encoded private key of certificate
+func GetTLSKeyFile() string {
+
+	return loaded().TLSKeyFile
+
+}
+
+// IsTLSEnabled - Whether HTTP server to be serving over TLS only
+func IsTLSEnabled() bool {
+
+	return len(GetTLSCertFile()) != 0 && len(GetTLSKeyFile()) != 0
+
+}
+
+// GetTLSRedirectPort - Port ( > 1024 ), on which plaintext HTTP requests are
+// to be redirected to HTTPS, when TLS is enabled, zero denotes plaintext
+// requests not to be served at all
+func GetTLSRedirectPort() uint64 {
+
+	return loaded().TLSRedirectPort
+
+}
+
+// GetStreamReplayBuffer - #-of most recent mempool change events to be kept,
+// so that SSE clients reconnecting with `Last-Event-ID` can resume
+func GetStreamReplayBuffer() uint64 {
+
+	return loaded().StreamReplayBuffer
+
+}
+
+// GetStreamClientBuffer - #-of events, SSE client can fall behind by,
+// before it's disconnected
+func GetStreamClientBuffer() uint64 {
+
+	return loaded().StreamClientBuffer
+
+}
+
+// GetGraphQLMaxResults - Listing query resolving to more than these many
+// tx(s) is rejected, asking client to paginate
+func GetGraphQLMaxResults() uint64 {
+
+	return loaded().GraphQLMaxResults
+
+}
+
+// GetGraphQLTimeout - Query resolver gets these many milliseconds, before
+// it's given up on
+func GetGraphQLTimeout() uint64 {
+
+	return loaded().GraphQLTimeout
+
+}
+
+// GetGraphQLMaxConcurrent - At max these many listing query resolvers to
+// be run at a time, others wait for their turn, until timeout
+func GetGraphQLMaxConcurrent() uint64 {
+
+	return loaded().GraphQLMaxConcurrent
+
+}
+
+// GetGraphQLMaxComplexity - Query with complexity, as computed from its
+// selection set, above this is rejected, before being resolved
+func GetGraphQLMaxComplexity() uint64 {
+
+	return loaded().GraphQLMaxComplexity
+
+}
+
+// GetLogLevel - Either of {info, error}, where with `error` only failures
+// are logged
+//
+// If nothing is provided, `info` is used
+func GetLogLevel() string {
+
+	return loaded().LogLevel
+
+}
+
+// GetPortNumber - User preferred port number ( > 1024 ) for running
+// harmony as a service, if not provided uses default value `7000`
+func GetPortNumber() uint64 {
+
+	return loaded().Port
 
 }
 
@@ -164,11 +756,33 @@ func GetPortNumber() uint64 {
 // for communicating with peers over P2P network
 func GetNetworkingPort() uint64 {
 
-	if port := GetUint("NetworkingPort"); port > 1024 {
-		return port
-	}
+	return loaded().NetworkingPort
+
+}
+
+// GetNetworkingListenIP - IPv4 address libp2p service to be listening on,
+// which is loopback address, unless configured otherwise
+func GetNetworkingListenIP() string {
+
+	return loaded().NetworkingListenIP
+
+}
+
+// GetNetworkingNATTraversal - Whether port mapping to be attempted on NAT
+// device & AutoNAT service to be run, helping peers figure out their
+// reachability
+func GetNetworkingNATTraversal() bool {
+
+	return loaded().NetworkingNATTraversal
 
-	return 7001
+}
+
+// GetNetworkingRelays - Multi addresses of circuit relays, to be used for
+// being reachable when behind NAT, which can be given as comma separated list
+// or as list, in config file
+func GetNetworkingRelays() []string {
+
+	return loaded().NetworkingRelays
 
 }
 
@@ -176,19 +790,105 @@ func GetNetworkingPort() uint64 {
 // & also sending messages when communicating with peer
 func GetNetworkingStream() string {
 
-	if v := Get("NetworkingStream"); len(v) != 0 {
-		return v
+	return loaded().NetworkingStream
+
+}
+
+// GetNetworkingTransport - How mempool changes are propagated to peers, either
+// over dedicated stream with each peer i.e. `stream` or over libp2p gossipsub
+// topic i.e. `gossipsub`, where former one is default
+func GetNetworkingTransport() string {
+
+	return loaded().NetworkingTransport
+
+}
+
+// GetBootstrapPeers - Attempts to get user supplied bootstrap node multi
+// addresses, so that this node can connect to them, which can be given as
+// comma separated list or as list, in config file
+func GetBootstrapPeers() []string {
+
+	return loaded().NetworkingBootstrap
+
+}
+
+// GetStaticPeers - Multi addresses of peers, to be dialed directly & kept
+// connected with, bypassing discovery, which can be given as comma separated
+// list or as list, in config file
+func GetStaticPeers() []string {
+
+	return loaded().StaticPeers
+
+}
+
+// GetDisableDiscovery - Whether DHT based peer discovery is to be skipped,
+// which is honoured only when static peers are given
+func GetDisableDiscovery() bool {
+
+	return loaded().DisableDiscovery
+
+}
+
+// getList - Reads value of key, which can be given as comma separated list
+// or as list, in config file, skipping empty entries
+func getList(key string) []string {
+
+	list := make([]string, 0)
+
+	for _, v := range viper.GetStringSlice(key) {
+		for _, entry := range strings.Split(v, ",") {
+
+			if entry = strings.TrimSpace(entry); len(entry) != 0 {
+				list = append(list, entry)
+			}
+
+		}
 	}
 
-	return "/harmony/v1.0.0"
+	return list
+
+}
+
+// GetDefaultBootstrapPeers - Whether IPFS provided default bootstrap nodes
+// are to be connected with, along with user supplied ones, which is
+// turned off, unless explicitly asked for
+func GetDefaultBootstrapPeers() bool {
+
+	return loaded().NetworkingDefaultBootstrap
+
+}
+
+// GetPeerAllowlist - When non-empty, only peers with these identifiers are
+// accepted/ dialed, which can be given as comma separated list or as list,
+// in config file
+func GetPeerAllowlist() []string {
+
+	return loaded().PeerAllowlist
+
+}
+
+// GetNetworkingIdentity - Path to file holding private key of this node,
+// so that it keeps same peer identifier across restarts, which is created
+// if not present
+func GetNetworkingIdentity() string {
+
+	return loaded().NetworkingIdentity
+
+}
+
+// GetRegenerateIdentity - Whether private key kept in identity file to be
+// replaced with freshly generated one, on this boot up, changing peer identifier
+func GetRegenerateIdentity() bool {
+
+	return loaded().RegenerateIdentity
 
 }
 
-// GetBootstrapPeer - Attempts to get user supplied bootstrap node identifier
-// so that this node can connect to it
-func GetBootstrapPeer() string {
+// GetNetworkingPSK - Hex encoded 32 bytes pre-shared key, when set, only
+// nodes knowing it can connect with each other
+func GetNetworkingPSK() string {
 
-	return Get("NetworkingBootstrap")
+	return loaded().NetworkingPSK
 
 }
 
@@ -196,11 +896,7 @@ func GetBootstrapPeer() string {
 // them with & this node will attempt to find other peers of same kind using this string
 func GetNetworkingRendezvous() string {
 
-	if v := Get("NetworkingRendezvous"); len(v) != 0 {
-		return v
-	}
-
-	return "harmony"
+	return loaded().NetworkingRendezvous
 
 }
 
@@ -209,14 +905,7 @@ func GetNetworkingRendezvous() string {
 // 2 => Server mode ( This peer can act an rendezvous point )
 func GetPeerDiscoveryMode() uint64 {
 
-	if v := GetUint("NetworkingDiscoveryMode"); v > 0 && v < 3 {
-		return v
-	}
-
-	// By default it's going to work as client i.e. won't help
-	// others in discoverying peers, when some other node
-	// attempts to use this node as rendezvous point
-	return 1
+	return loaded().NetworkingDiscoveryMode
 
 }
 
@@ -227,7 +916,160 @@ func GetPeerDiscoveryMode() uint64 {
 // Consider putting `true`, if you're interested, otherwise ignore
 func GetNetworkingChoice() bool {
 
-	return GetBool("NetworkingEnabled")
+	return loaded().NetworkingEnabled
+
+}
+
+// GetAcceptUnprotectedPeerTxs - Whether tx(s) received from peers, which are
+// not replay protected ( i.e. pre EIP-155 ), can be accepted or not, because
+// their chain can't be verified
+//
+// By default they're accepted
+func GetAcceptUnprotectedPeerTxs() bool {
+
+	return loaded().AcceptUnprotectedPeerTxs
+
+}
+
+// GetMaxBadTxsPerPeer - After receiving these many bad tx(s) i.e. signed for
+// some other chain, from same peer, in quick succession, it gets banned
+func GetMaxBadTxsPerPeer() uint64 {
+
+	return loaded().MaxBadTxsPerPeer
+
+}
+
+// GetAcceptUnsignedPeerMessages - Whether chunks not wrapped in signed
+// envelope are to be accepted from peers, which is meant to be turned on
+// only during rolling out signing, across cluster
+func GetAcceptUnsignedPeerMessages() bool {
+
+	return loaded().AcceptUnsignedPeerMessages
+
+}
+
+// GetPeerMaxUndecodableChunks - Peer sending these many chunks, which can't
+// be decompressed/ deserialized, in quick succession, gets banned
+func GetPeerMaxUndecodableChunks() uint64 {
+
+	return loaded().PeerMaxUndecodableChunks
+
+}
+
+// GetPeerScoreHalfLife - Misbehaviour score of peer halves every these
+// many milliseconds
+func GetPeerScoreHalfLife() uint64 {
+
+	return loaded().PeerScoreHalfLife
+
+}
+
+// GetMaxPeerMessageSize - Length prefixed message announced by peer, to be
+// at max these many bytes, anything bigger is considered to be protocol
+// violation, so that peer can't make us allocate arbitrarily large buffer
+func GetMaxPeerMessageSize() uint64 {
+
+	return loaded().MaxPeerMessageSize
+
+}
+
+// GetPeerPenaltyPeriod - Peer violating protocol is neither accepted nor
+// dialed for these many milliseconds
+func GetPeerPenaltyPeriod() uint64 {
+
+	return loaded().PeerPenaltyPeriod
+
+}
+
+// GetPeerDiscoveryPeriod - Every these many milliseconds, self is
+// re-advertised with rendezvous & peers are looked for
+func GetPeerDiscoveryPeriod() uint64 {
+
+	return loaded().PeerDiscoveryPeriod
+
+}
+
+// GetPeerDiscoveryPeriodMax - When peer table is full, looking for peers
+// is backed off, doubling wait time after each round, up to these many
+// milliseconds
+func GetPeerDiscoveryPeriodMax() uint64 {
+
+	return loaded().PeerDiscoveryPeriodMax
+
+}
+
+// GetPeerRedialBackoffInitial - Dropped peer to be attempted to be
+// reconnected with, after waiting for these many milliseconds, which
+// is doubled after each failed attempt
+func GetPeerRedialBackoffInitial() uint64 {
+
+	return loaded().PeerRedialBackoffInitial
+
+}
+
+// GetPeerRedialAttempts - Dropped peer is given up, after these many failed
+// attempts to reconnect with it
+func GetPeerRedialAttempts() uint64 {
+
+	return loaded().PeerRedialAttempts
+
+}
+
+// GetPeerRedialBackoffMax - Upper bound on how long to wait, in milliseconds,
+// before attempting to reconnect with dropped peer
+func GetPeerRedialBackoffMax() uint64 {
+
+	return loaded().PeerRedialBackoffMax
+
+}
+
+// GetPeerPingInterval - Keepalive ping to be sent to peer, after not sending
+// anything to it for these many milliseconds
+func GetPeerPingInterval() uint64 {
+
+	return loaded().PeerPingInterval
+
+}
+
+// GetPeerMaxMissedPings - Peer not sending anything, not even ping, for
+// these many ping intervals, is considered dead. Same applies to peer
+// not draining what we're sending
+func GetPeerMaxMissedPings() uint64 {
+
+	return loaded().PeerMaxMissedPings
+
+}
+
+// GetPeerBatchSize - Mempool changes, queued up for peer, are coalesced into
+// single frame, until it grows to these many bytes
+func GetPeerBatchSize() uint64 {
+
+	return loaded().PeerBatchSize
+
+}
+
+// GetPeerBatchDelay - Mempool changes, queued up for peer, are coalesced into
+// single frame, for at max these many milliseconds
+func GetPeerBatchDelay() uint64 {
+
+	return loaded().PeerBatchDelay
+
+}
+
+// GetMaxPeers - At max these many peers to be connected with, at a time,
+// when there's no room, newcomers are turned away
+func GetMaxPeers() uint64 {
+
+	return loaded().MaxPeers
+
+}
+
+// GetMaxInboundPeers - At max these many peer slots can be taken by peers
+// connecting to us, so that we can't be fully eclipsed by them, defaults
+// to half of max peers, while never going beyond that
+func GetMaxInboundPeers() uint64 {
+
+	return loaded().MaxInboundPeers
 
 }
 
@@ -235,8 +1077,5 @@ func GetNetworkingChoice() bool {
 // port, to be used for pub/sub message
 // passing purpose
 func GetPub0SubAddress() string {
-	host := Get("Pub0SubHost")
-	port := GetUint("Pub0SubPort")
-
-	return fmt.Sprintf("%s:%d", host, port)
+	return fmt.Sprintf("%s:%d", loaded().Pub0SubHost, loaded().Pub0SubPort)
 }
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// requiredEnv - Environment variables, which alone are enough for config
+// to be read, when there's no file
+var requiredEnv = map[string]string{
+	"HARMONY_RPCURL":      "http://localhost:8545",
+	"HARMONY_WSURL":       "ws://localhost:8546",
+	"HARMONY_PUB0SUBHOST": "127.0.0.1",
+	"HARMONY_PUB0SUBPORT": "13000",
+}
+
+// readConfig - Reads config from file with given content, placed in test's
+// own directory, where empty content means there's no file at all
+//
+// Whatever was read is forgotten, once test is done
+func readConfig(t *testing.T, content string) error {
+	t.Helper()
+
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	file := filepath.Join(t.TempDir(), ".env")
+	if len(content) != 0 {
+
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+	}
+
+	return Read(file)
+}
+
+// setEnv - Sets all given environment variables, for test's life time
+func setEnv(t *testing.T, kv map[string]string) {
+	t.Helper()
+
+	for k, v := range kv {
+		t.Setenv(k, v)
+	}
+}
+
+func TestReadWithoutFile(t *testing.T) {
+
+	setEnv(t, requiredEnv)
+
+	if err := readConfig(t, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if GetRPCUrl() != requiredEnv["HARMONY_RPCURL"] || GetWSUrl() != requiredEnv["HARMONY_WSURL"] {
+		t.Fatalf("expected node URLs from environment, got %s & %s", GetRPCUrl(), GetWSUrl())
+	}
+
+	if GetPendingPoolSize() != 1024 {
+		t.Fatalf("expected default pending pool size, got %d", GetPendingPoolSize())
+	}
+
+}
+
+func TestReadWithoutFileMissingEnv(t *testing.T) {
+
+	setEnv(t, map[string]string{
+		"HARMONY_RPCURL": "http://localhost:8545",
+		"HARMONY_WSURL":  "ws://localhost:8546",
+	})
+
+	err := readConfig(t, "")
+	if err == nil {
+		t.Fatal("expected absent file without required environment to fail")
+	}
+
+	for _, name := range []string{"HARMONY_PUB0SUBHOST", "HARMONY_PUB0SUBPORT"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Fatalf("expected error to name `%s`, got %q", name, err.Error())
+		}
+	}
+
+	if strings.Contains(err.Error(), "HARMONY_RPCURL") {
+		t.Fatalf("expected error not to name variable which is set, got %q", err.Error())
+	}
+
+}
+
+func TestEnvOverridesFile(t *testing.T) {
+
+	setEnv(t, map[string]string{
+		"HARMONY_RPCURL":          "http://node:8545",
+		"HARMONY_PENDINGPOOLSIZE": "4096",
+		"HARMONY_ADMINTOKEN":      "from-env",
+	})
+
+	file := strings.Join([]string{
+		"RPCUrl=http://localhost:8545",
+		"WSUrl=ws://localhost:8546",
+		"Pub0SubHost=127.0.0.1",
+		"Pub0SubPort=13000",
+		"PendingPoolSize=2048",
+		"QueuedPoolSize=512",
+		"AdminToken=from-file",
+	}, "\n")
+
+	if err := readConfig(t, file); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"env overrides file", GetRPCUrl(), "http://node:8545"},
+		{"env overrides file for count", GetPendingPoolSize(), uint64(4096)},
+		{"secret prefers env", GetAdminToken(), "from-env"},
+		{"file used when env not set", GetWSUrl(), "ws://localhost:8546"},
+		{"file used when env not set for count", GetQueuedPoolSize(), uint64(512)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+
+			if c.got != c.want {
+				t.Fatalf("expected %v, got %v", c.want, c.got)
+			}
+
+		})
+	}
+
+}
+
+func TestOverrideTakesPrecedence(t *testing.T) {
+
+	setEnv(t, requiredEnv)
+	setEnv(t, map[string]string{"HARMONY_PENDINGPOOLSIZE": "4096"})
+
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	Override("PendingPoolSize", "8192")
+
+	if err := Read(filepath.Join(t.TempDir(), ".env")); err != nil {
+		t.Fatal(err)
+	}
+
+	if GetPendingPoolSize() != 8192 {
+		t.Fatalf("expected overridden value to win over environment, got %d", GetPendingPoolSize())
+	}
+
+}
+
+func TestEnvName(t *testing.T) {
+
+	cases := map[string]string{
+		"RPCUrl":         "HARMONY_RPCURL",
+		"Pub0SubPort":    "HARMONY_PUB0SUBPORT",
+		"Networking.PSK": "HARMONY_NETWORKING_PSK",
+		"max-peers":      "HARMONY_MAX_PEERS",
+	}
+
+	for key, want := range cases {
+		if got := envName(key); got != want {
+			t.Fatalf("`%s` : expected %s, got %s", key, want, got)
+		}
+	}
+
+}
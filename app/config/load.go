@@ -0,0 +1,792 @@
+package config
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"net/url"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/multiformats/go-multiaddr"
+	"github.com/spf13/viper"
+)
+
+// Config - Typed view of whole configuration, populated & validated once,
+// when it's read during start up, so that getters don't need to parse &
+// fall back to defaults on each invocation
+type Config struct {
+	// Upstream node
+	RPCUrl         string
+	WSUrl          string
+	UpstreamClient string
+	RPCTimeout     uint64
+	RPCRetries     uint64
+
+	// Mempool polling
+	MemPoolPollingPeriod    uint64
+	MemPoolPollingPeriodMin uint64
+	MemPoolPollingPeriodMax uint64
+	MemPoolPollingBurst     uint64
+	MemPoolMaxStaleness     uint64
+	MemPoolFullResyncPolls  uint64
+	PollerBackoffInitial    uint64
+	PollerBackoffMax        uint64
+	PollerMaxFailures       uint64
+
+	// Pools
+	PendingPoolSize            uint64
+	QueuedPoolSize             uint64
+	PendingPoolEvictionPolicy  string
+	QueuedPoolEvictionPolicy   string
+	MaxTxsPerAddress           uint64
+	BlockGasLimit              uint64
+	GasPriceStatsPeriod        uint64
+	ConfirmationLatencySamples uint64
+	RecentTxsBuffer            uint64
+	ReorgDepth                 uint64
+	StuckTxAfter               uint64
+	StuckTxCheckPeriod         uint64
+	UnstuckCheckPeriod         uint64
+	UnstuckCheckRPCBudget      uint64
+	ConcurrencyFactor          int
+	ParallelFilterThreshold    uint64
+	StateFile                  string
+	StateSnapshotPeriod        uint64
+	RestoreStateOnBoot         bool
+	PruneByReceipt             bool
+	DisappearedTxProbeBlocks   uint64
+	ExportDirectory            string
+
+	// Pub/Sub
+	PendingTxEntryTopic       string
+	PendingTxExitTopic        string
+	PendingTxReplacementTopic string
+	PendingTxStuckTopic       string
+	PendingTxReorgedTopic     string
+	QueuedToPendingTopic      string
+	QueuedTxEntryTopic        string
+	QueuedTxExitTopic         string
+	PublishPendingEntry       bool
+	PublishPendingExit        bool
+	PublishQueuedEntry        bool
+	PublishQueuedExit         bool
+	PublishPendingReplacement bool
+	PublishPendingStuck       bool
+	PublishPendingReorged     bool
+	PublishQueuedToPending    bool
+	PublishCodec              string
+	PublishBatchSize          uint64
+	PublishBatchPeriod        uint64
+	CompressionThreshold      uint64
+	Pub0SubHost               string
+	Pub0SubPort               uint64
+
+	// HTTP server
+	Port                 uint64
+	AdminToken           string
+	APIKeys              []string
+	APIKeysFile          string
+	RateLimit            float64
+	RateLimitBurst       uint64
+	TrustedProxies       []*net.IPNet
+	TLSCertFile          string
+	TLSKeyFile           string
+	TLSRedirectPort      uint64
+	StreamReplayBuffer   uint64
+	StreamClientBuffer   uint64
+	GraphQLMaxResults    uint64
+	GraphQLTimeout       uint64
+	GraphQLMaxConcurrent uint64
+	GraphQLMaxComplexity uint64
+
+	// Logging
+	LogLevel string
+
+	// P2P networking
+	NetworkingEnabled          bool
+	NetworkingPort             uint64
+	NetworkingListenIP         string
+	NetworkingNATTraversal     bool
+	NetworkingRelays           []string
+	NetworkingStream           string
+	NetworkingTransport        string
+	NetworkingBootstrap        []string
+	NetworkingDefaultBootstrap bool
+	StaticPeers                []string
+	DisableDiscovery           bool
+	PeerAllowlist              []string
+	NetworkingIdentity         string
+	RegenerateIdentity         bool
+	NetworkingPSK              string
+	NetworkingRendezvous       string
+	NetworkingDiscoveryMode    uint64
+	AcceptUnprotectedPeerTxs   bool
+	MaxBadTxsPerPeer           uint64
+	AcceptUnsignedPeerMessages bool
+	PeerMaxUndecodableChunks   uint64
+	PeerScoreHalfLife          uint64
+	MaxPeerMessageSize         uint64
+	PeerPenaltyPeriod          uint64
+	PeerDiscoveryPeriod        uint64
+	PeerDiscoveryPeriodMax     uint64
+	PeerRedialBackoffInitial   uint64
+	PeerRedialAttempts         uint64
+	PeerRedialBackoffMax       uint64
+	PeerPingInterval           uint64
+	PeerMaxMissedPings         uint64
+	PeerBatchSize              uint64
+	PeerBatchDelay             uint64
+	MaxPeers                   uint64
+	MaxInboundPeers            uint64
+}
+
+// current - Configuration in use, replaced as a whole, when it's read
+// during start up & when dynamic keys are reloaded
+var current atomic.Value
+
+// configFile - Path to file configuration was read from, to be read again
+// when reloading
+var configFile string
+
+// loaded - Configuration in use, where zero value is returned, if it's not
+// read yet
+func loaded() *Config {
+
+	if c, ok := current.Load().(*Config); ok {
+		return c
+	}
+
+	return &Config{}
+
+}
+
+// periodKeys - Config keys holding milliseconds, which can also be set as
+// duration e.g. `750ms`, `2s`
+var periodKeys = map[string]bool{
+	"RPCTimeout":               true,
+	"MemPoolPollingPeriod":     true,
+	"MemPoolPollingPeriodMin":  true,
+	"MemPoolPollingPeriodMax":  true,
+	"MemPoolMaxStaleness":      true,
+	"PollerBackoffInitial":     true,
+	"PollerBackoffMax":         true,
+	"GasPriceStatsPeriod":      true,
+	"StuckTxAfter":             true,
+	"StuckTxCheckPeriod":       true,
+	"UnstuckCheckPeriod":       true,
+	"StateSnapshotPeriod":      true,
+	"PublishBatchPeriod":       true,
+	"GraphQLTimeout":           true,
+	"PeerScoreHalfLife":        true,
+	"PeerPenaltyPeriod":        true,
+	"PeerDiscoveryPeriod":      true,
+	"PeerDiscoveryPeriodMax":   true,
+	"PeerRedialBackoffInitial": true,
+	"PeerRedialBackoffMax":     true,
+	"PeerPingInterval":         true,
+	"PeerBatchDelay":           true,
+}
+
+// countKeys - Config keys holding #-of entries, which can also be set
+// with `k` ( thousand ) or `m` ( million ) suffix e.g. `50k`, `1m`
+var countKeys = map[string]bool{
+	"PendingPoolSize": true,
+	"QueuedPoolSize":  true,
+}
+
+// countSuffixes - Multipliers of suffixes accepted by count keys
+var countSuffixes = map[string]int64{
+	"k": 1_000,
+	"m": 1_000_000,
+}
+
+// parseUint - Non-negative integer value `v` of config key, where period
+// keys also accept duration, converted to milliseconds & count keys also
+// accept suffixed count. Plain integer is always accepted as it's
+func parseUint(key string, v string) (uint64, error) {
+
+	if n, err := strconv.ParseUint(v, 10, 64); err == nil {
+		return n, nil
+	}
+
+	switch {
+
+	case periodKeys[key]:
+
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 || d%time.Millisecond != 0 {
+			return 0, fmt.Errorf("`%s` must be milliseconds or duration in whole milliseconds e.g. `750ms`, `2s`, found `%s`", key, v)
+		}
+
+		return uint64(d / time.Millisecond), nil
+
+	case countKeys[key]:
+
+		lower := strings.ToLower(v)
+		for suffix, multiplier := range countSuffixes {
+
+			if !strings.HasSuffix(lower, suffix) {
+				continue
+			}
+
+			// Parsed as fraction, so that `1.1k` is exactly `1100`
+			mantissa := strings.TrimSuffix(lower, suffix)
+			if strings.Contains(mantissa, "/") {
+				break
+			}
+
+			r, ok := new(big.Rat).SetString(mantissa)
+			if !ok {
+				break
+			}
+
+			r.Mul(r, new(big.Rat).SetInt64(multiplier))
+			if r.Sign() < 0 || !r.IsInt() || !r.Num().IsUint64() {
+				break
+			}
+
+			return r.Num().Uint64(), nil
+
+		}
+
+		return 0, fmt.Errorf("`%s` must be count e.g. `1024`, `50k`, `1m`, found `%s`", key, v)
+
+	}
+
+	return 0, fmt.Errorf("`%s` must be a non-negative integer, found `%s`", key, v)
+
+}
+
+// loader - Reads typed values of config keys, remembering keys for which
+// default value is applied & problems found, so that all of them can be
+// reported together
+type loader struct {
+	defaults []string
+	problems []string
+}
+
+// problem - Remembers one more reason why config can't be used
+func (l *loader) problem(format string, args ...interface{}) {
+
+	l.problems = append(l.problems, fmt.Sprintf(format, args...))
+
+}
+
+// raw - Value of key, as it's set, empty when it's not set
+func (l *loader) raw(key string) string {
+
+	return strings.TrimSpace(viper.GetString(key))
+
+}
+
+// optionalUint - Non-negative integer value of key, where absent value is
+// zero, without any default being applied
+func (l *loader) optionalUint(key string) uint64 {
+
+	v := l.raw(key)
+	if len(v) == 0 {
+		return 0
+	}
+
+	n, err := parseUint(key, v)
+	if err != nil {
+
+		l.problem("%s", err.Error())
+		return 0
+
+	}
+
+	return n
+
+}
+
+// uintOr - Non-negative integer value of key, where absent/ zero value
+// falls back to `def`
+func (l *loader) uintOr(key string, def uint64) uint64 {
+
+	if v := l.optionalUint(key); v != 0 {
+		return v
+	}
+
+	l.defaults = append(l.defaults, key)
+	return def
+
+}
+
+// positive - Positive integer value of key, where absent value falls back
+// to `def`, but explicitly set zero is a problem
+func (l *loader) positive(key string, def uint64) uint64 {
+
+	v := l.raw(key)
+	if len(v) == 0 {
+
+		l.defaults = append(l.defaults, key)
+		return def
+
+	}
+
+	n, err := parseUint(key, v)
+	if err != nil {
+
+		l.problem("%s", err.Error())
+		return def
+
+	}
+
+	if n == 0 {
+
+		l.problem("`%s` must be positive, found `%s`", key, v)
+		return def
+
+	}
+
+	return n
+
+}
+
+// port - TCP port ( > 1024 ) of key, where absent/ zero value falls back
+// to `def`
+func (l *loader) port(key string, def uint64) uint64 {
+
+	v := l.raw(key)
+	if len(v) == 0 || v == "0" {
+
+		if def != 0 {
+			l.defaults = append(l.defaults, key)
+		}
+		return def
+
+	}
+
+	n, err := strconv.ParseUint(v, 10, 64)
+	if err != nil || n <= 1024 || n > math.MaxUint16 {
+
+		l.problem("`%s` must be a port within (1024, 65535], found `%s`", key, v)
+		return def
+
+	}
+
+	return n
+
+}
+
+// float - Floating point value of key, where absent value is zero
+func (l *loader) float(key string) float64 {
+
+	v := l.raw(key)
+	if len(v) == 0 {
+		return 0
+	}
+
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+
+		l.problem("`%s` must be a number, found `%s`", key, v)
+		return 0
+
+	}
+
+	return f
+
+}
+
+// boolOr - Boolean value of key, where absent value falls back to `def`
+func (l *loader) boolOr(key string, def bool) bool {
+
+	v := l.raw(key)
+	if len(v) == 0 {
+		return def
+	}
+
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+
+		l.problem("`%s` must be either of {true, false}, found `%s`", key, v)
+		return def
+
+	}
+
+	return b
+
+}
+
+// stringOr - Value of key, where absent value falls back to `def`
+func (l *loader) stringOr(key string, def string) string {
+
+	if v := l.raw(key); len(v) != 0 {
+		return v
+	}
+
+	l.defaults = append(l.defaults, key)
+	return def
+
+}
+
+// oneOf - Value of key, which must be one of `allowed`, where absent value
+// falls back to `def`
+func (l *loader) oneOf(key string, def string, allowed ...string) string {
+
+	v := l.raw(key)
+	if len(v) == 0 {
+
+		l.defaults = append(l.defaults, key)
+		return def
+
+	}
+
+	for _, a := range allowed {
+		if v == a {
+			return v
+		}
+	}
+
+	l.problem("`%s` must be one of {%s}, found `%s`", key, strings.Join(allowed, ", "), v)
+	return def
+
+}
+
+// multiaddrs - List value of key, where each entry must be valid multi
+// address
+func (l *loader) multiaddrs(key string) []string {
+
+	list := getList(key)
+
+	for _, v := range list {
+
+		if _, err := multiaddr.NewMultiaddr(v); err != nil {
+			l.problem("`%s` has bad multi address `%s` : %s", key, v, err.Error())
+		}
+
+	}
+
+	return list
+
+}
+
+// cidrs - List value of key, where each entry must be either CIDR or plain
+// IP address, which is considered as range of single address
+func (l *loader) cidrs(key string) []*net.IPNet {
+
+	list := getList(key)
+	ranges := make([]*net.IPNet, 0, len(list))
+
+	for _, v := range list {
+
+		if ip := net.ParseIP(v); ip != nil {
+
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+
+			ranges = append(ranges, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+
+		}
+
+		_, ipRange, err := net.ParseCIDR(v)
+		if err != nil {
+			l.problem("`%s` has bad CIDR `%s` : %s", key, v, err.Error())
+			continue
+		}
+
+		ranges = append(ranges, ipRange)
+
+	}
+
+	return ranges
+
+}
+
+// required - Value of key, which must be set
+func (l *loader) required(key string) string {
+
+	v := l.raw(key)
+	if len(v) == 0 {
+		l.problem("`%s` must be set", key)
+	}
+
+	return v
+
+}
+
+// endpoint - Value of key, which must be URL with one of given schemes
+func (l *loader) endpoint(key string, schemes ...string) string {
+
+	v := l.required(key)
+	if len(v) == 0 {
+		return v
+	}
+
+	u, err := url.Parse(v)
+	if err != nil {
+
+		l.problem("`%s` must be URL, found `%s` : %s", key, v, err.Error())
+		return v
+
+	}
+
+	for _, s := range schemes {
+		if u.Scheme == s {
+			return v
+		}
+	}
+
+	l.problem("`%s` must be URL with scheme one of {%s}, found `%s`", key, strings.Join(schemes, ", "), v)
+	return v
+
+}
+
+// load - Reads whole configuration, applying defaults & validating it,
+// returning all problems found, instead of stopping at first one
+func load() (*Config, []string, []string) {
+
+	l := &loader{}
+	c := &Config{}
+
+	c.RPCUrl = l.endpoint("RPCUrl", "http", "https", "ws", "wss")
+	c.WSUrl = l.endpoint("WSUrl", "ws", "wss")
+	c.UpstreamClient = l.oneOf("UpstreamClient", "auto", "auto", "geth", "erigon", "nethermind", "besu")
+	c.RPCTimeout = l.uintOr("RPCTimeout", 5000)
+
+	// Explicitly set `0` disables retrying
+	c.RPCRetries = 2
+	if viper.IsSet("RPCRetries") {
+		c.RPCRetries = l.optionalUint("RPCRetries")
+	}
+
+	c.MemPoolPollingPeriod = l.positive("MemPoolPollingPeriod", 1000)
+
+	c.MemPoolPollingPeriodMin = c.MemPoolPollingPeriod
+	if v := l.optionalUint("MemPoolPollingPeriodMin"); v != 0 {
+		c.MemPoolPollingPeriodMin = v
+	}
+
+	c.MemPoolPollingPeriodMax = 10000
+	if c.MemPoolPollingPeriodMin > c.MemPoolPollingPeriodMax {
+		c.MemPoolPollingPeriodMax = c.MemPoolPollingPeriodMin
+	}
+	if v := l.optionalUint("MemPoolPollingPeriodMax"); v >= c.MemPoolPollingPeriodMin {
+		c.MemPoolPollingPeriodMax = v
+	}
+
+	c.MemPoolPollingBurst = l.uintOr("MemPoolPollingBurst", 100)
+	c.MemPoolMaxStaleness = l.uintOr("MemPoolMaxStaleness", 30000)
+	c.MemPoolFullResyncPolls = l.uintOr("MemPoolFullResyncPolls", 60)
+	c.PollerBackoffInitial = l.uintOr("PollerBackoffInitial", 1000)
+	c.PollerBackoffMax = l.uintOr("PollerBackoffMax", 60000)
+	c.PollerMaxFailures = l.uintOr("PollerMaxFailures", 10)
+
+	c.PendingPoolSize = l.positive("PendingPoolSize", 1024)
+	c.QueuedPoolSize = l.positive("QueuedPoolSize", 1024)
+	c.PendingPoolEvictionPolicy = l.oneOf("PendingPoolEvictionPolicy", "lowest-gas", "lowest-gas", "oldest", "oldest-lowest-gas")
+
+	// Same policy as pending pool, unless configured separately
+	c.QueuedPoolEvictionPolicy = c.PendingPoolEvictionPolicy
+	if len(l.raw("QueuedPoolEvictionPolicy")) != 0 {
+		c.QueuedPoolEvictionPolicy = l.oneOf("QueuedPoolEvictionPolicy", c.PendingPoolEvictionPolicy, "lowest-gas", "oldest", "oldest-lowest-gas", "largest-nonce-gap")
+	}
+
+	c.MaxTxsPerAddress = l.optionalUint("MaxTxsPerAddress")
+	c.BlockGasLimit = l.uintOr("BlockGasLimit", 15_000_000)
+	c.GasPriceStatsPeriod = l.uintOr("GasPriceStatsPeriod", 2000)
+	c.ConfirmationLatencySamples = l.uintOr("ConfirmationLatencySamples", 10000)
+	c.RecentTxsBuffer = l.uintOr("RecentTxsBuffer", 4096)
+	c.ReorgDepth = l.uintOr("ReorgDepth", 12)
+	c.StuckTxAfter = l.uintOr("StuckTxAfter", 300000)
+	c.StuckTxCheckPeriod = l.uintOr("StuckTxCheckPeriod", 15000)
+	c.UnstuckCheckPeriod = l.uintOr("UnstuckCheckPeriod", 5000)
+	c.UnstuckCheckRPCBudget = l.uintOr("UnstuckCheckRPCBudget", 100)
+
+	c.ConcurrencyFactor = 1
+	if v := l.raw("ConcurrencyFactor"); len(v) == 0 {
+		l.defaults = append(l.defaults, "ConcurrencyFactor")
+	} else if f := l.float("ConcurrencyFactor"); f > 0 {
+		c.ConcurrencyFactor = int(math.Ceil(f * float64(runtime.NumCPU())))
+	} else {
+		l.problem("`ConcurrencyFactor` must be positive, found `%s`", v)
+	}
+
+	c.ParallelFilterThreshold = l.uintOr("ParallelFilterThreshold", 512)
+	c.StateFile = l.raw("StateFile")
+	c.StateSnapshotPeriod = l.uintOr("StateSnapshotPeriod", 60000)
+	c.RestoreStateOnBoot = l.boolOr("RestoreStateOnBoot", false)
+	c.PruneByReceipt = l.boolOr("PruneByReceipt", false)
+	c.DisappearedTxProbeBlocks = l.positive("DisappearedTxProbeBlocks", 2)
+	c.ExportDirectory = l.stringOr("ExportDirectory", "exports")
+
+	c.PendingTxEntryTopic = l.stringOr("PendingTxEntryTopic", "pending_pool_entry")
+	c.PendingTxExitTopic = l.stringOr("PendingTxExitTopic", "pending_pool_exit")
+	c.PendingTxReplacementTopic = l.stringOr("PendingTxReplacementTopic", "pending_pool_replacement")
+	c.PendingTxStuckTopic = l.stringOr("PendingTxStuckTopic", "pending_pool_stuck")
+	c.PendingTxReorgedTopic = l.stringOr("PendingTxReorgedTopic", "pending_pool_reorged")
+	c.QueuedToPendingTopic = l.stringOr("QueuedToPendingTopic", "queued_to_pending")
+	c.QueuedTxEntryTopic = l.stringOr("QueuedTxEntryTopic", "queued_pool_entry")
+	c.QueuedTxExitTopic = l.stringOr("QueuedTxExitTopic", "queued_pool_exit")
+	c.PublishPendingEntry = l.boolOr("PublishPendingEntry", true)
+	c.PublishPendingExit = l.boolOr("PublishPendingExit", true)
+	c.PublishQueuedEntry = l.boolOr("PublishQueuedEntry", true)
+	c.PublishQueuedExit = l.boolOr("PublishQueuedExit", true)
+	c.PublishPendingReplacement = l.boolOr("PublishPendingReplacement", true)
+	c.PublishPendingStuck = l.boolOr("PublishPendingStuck", true)
+	c.PublishPendingReorged = l.boolOr("PublishPendingReorged", true)
+	c.PublishQueuedToPending = l.boolOr("PublishQueuedToPending", true)
+	c.PublishCodec = l.oneOf("PublishCodec", "msgpack", "msgpack", "json", "protobuf")
+
+	// Batching isn't supported with `protobuf` codec
+	c.PublishBatchSize = 1
+	if v := l.optionalUint("PublishBatchSize"); v > 1 && c.PublishCodec != "protobuf" {
+		c.PublishBatchSize = v
+	}
+
+	c.PublishBatchPeriod = l.uintOr("PublishBatchPeriod", 100)
+	c.CompressionThreshold = l.optionalUint("CompressionThreshold")
+
+	c.Pub0SubHost = l.required("Pub0SubHost")
+	if c.Pub0SubPort = l.optionalUint("Pub0SubPort"); c.Pub0SubPort == 0 || c.Pub0SubPort > math.MaxUint16 {
+		l.problem("`Pub0SubPort` must be a port within (0, 65535], found `%s`", l.raw("Pub0SubPort"))
+	}
+
+	c.Port = l.port("Port", 7000)
+	c.AdminToken = l.raw("AdminToken")
+	c.APIKeys = getList("APIKeys")
+	c.APIKeysFile = l.raw("APIKeysFile")
+
+	if c.RateLimit = l.float("RateLimit"); c.RateLimit < 0 {
+		c.RateLimit = 0
+	}
+
+	// Never lesser than one second worth of requests
+	c.RateLimitBurst = uint64(math.Ceil(c.RateLimit))
+	if c.RateLimitBurst == 0 {
+		c.RateLimitBurst = 1
+	}
+	if v := l.optionalUint("RateLimitBurst"); v >= c.RateLimitBurst {
+		c.RateLimitBurst = v
+	}
+
+	c.TrustedProxies = l.cidrs("TrustedProxies")
+
+	c.TLSCertFile = l.raw("TLSCertFile")
+	c.TLSKeyFile = l.raw("TLSKeyFile")
+	if (len(c.TLSCertFile) == 0) != (len(c.TLSKeyFile) == 0) {
+		l.problem("`TLSCertFile` & `TLSKeyFile` must be set together")
+	}
+
+	c.TLSRedirectPort = l.port("TLSRedirectPort", 0)
+	c.StreamReplayBuffer = l.uintOr("StreamReplayBuffer", 1024)
+	c.StreamClientBuffer = l.uintOr("StreamClientBuffer", 256)
+	c.GraphQLMaxResults = l.uintOr("GraphQLMaxResults", 5000)
+	c.GraphQLTimeout = l.uintOr("GraphQLTimeout", 10000)
+	c.GraphQLMaxConcurrent = l.uintOr("GraphQLMaxConcurrent", 32)
+	c.GraphQLMaxComplexity = l.uintOr("GraphQLMaxComplexity", 1000)
+
+	c.LogLevel = l.oneOf("LogLevel", "info", "info", "error")
+
+	c.NetworkingEnabled = l.boolOr("NetworkingEnabled", false)
+
+	// Networking fields are ignored, unless it's enabled, so those are
+	// neither validated nor reported as defaulted
+	if c.NetworkingEnabled {
+		loadNetworking(l, c)
+	} else {
+		loadNetworking(&loader{}, c)
+	}
+
+	return c, l.defaults, l.problems
+
+}
+
+// loadNetworking - Reads P2P networking part of configuration, where only
+// problems, which would otherwise surface when networking is being set up,
+// are found
+func loadNetworking(l *loader, c *Config) {
+
+	c.NetworkingPort = l.port("NetworkingPort", 7001)
+
+	c.NetworkingListenIP = l.stringOr("NetworkingListenIP", "127.0.0.1")
+	if ip := net.ParseIP(c.NetworkingListenIP); ip == nil || ip.To4() == nil {
+		l.problem("`NetworkingListenIP` must be IPv4 address, found `%s`", c.NetworkingListenIP)
+	}
+
+	c.NetworkingNATTraversal = l.boolOr("NetworkingNATTraversal", false)
+	c.NetworkingRelays = l.multiaddrs("NetworkingRelays")
+	c.NetworkingStream = l.stringOr("NetworkingStream", "/harmony/v1.0.0")
+
+	c.NetworkingTransport = "stream"
+	if v := strings.ToLower(l.raw("NetworkingTransport")); v == "gossipsub" {
+		c.NetworkingTransport = v
+	} else if len(v) != 0 && v != "stream" {
+		l.problem("`NetworkingTransport` must be one of {stream, gossipsub}, found `%s`", v)
+	}
+
+	c.NetworkingBootstrap = l.multiaddrs("NetworkingBootstrap")
+	c.NetworkingDefaultBootstrap = l.boolOr("NetworkingDefaultBootstrap", false)
+	c.StaticPeers = l.multiaddrs("StaticPeers")
+	c.DisableDiscovery = l.boolOr("DisableDiscovery", false)
+	c.PeerAllowlist = getList("PeerAllowlist")
+	c.NetworkingIdentity = l.raw("NetworkingIdentity")
+	c.RegenerateIdentity = l.boolOr("RegenerateIdentity", false)
+
+	c.NetworkingPSK = strings.TrimPrefix(l.raw("NetworkingPSK"), "0x")
+	if len(c.NetworkingPSK) != 0 {
+
+		if key, err := hex.DecodeString(c.NetworkingPSK); err != nil || len(key) != 32 {
+			l.problem("`NetworkingPSK` must be hex encoded 32 bytes")
+		}
+
+	}
+
+	c.NetworkingRendezvous = l.stringOr("NetworkingRendezvous", "harmony")
+
+	// By default it's going to work as client i.e. won't help others
+	// in discoverying peers
+	c.NetworkingDiscoveryMode = 1
+	if v := l.optionalUint("NetworkingDiscoveryMode"); v == 1 || v == 2 {
+		c.NetworkingDiscoveryMode = v
+	} else if v != 0 {
+		l.problem("`NetworkingDiscoveryMode` must be one of {1, 2}, found `%d`", v)
+	}
+
+	c.AcceptUnprotectedPeerTxs = l.boolOr("AcceptUnprotectedPeerTxs", true)
+	c.MaxBadTxsPerPeer = l.uintOr("MaxBadTxsPerPeer", 16)
+	c.AcceptUnsignedPeerMessages = l.boolOr("AcceptUnsignedPeerMessages", false)
+	c.PeerMaxUndecodableChunks = l.uintOr("PeerMaxUndecodableChunks", 10)
+	c.PeerScoreHalfLife = l.uintOr("PeerScoreHalfLife", 600000)
+	c.MaxPeerMessageSize = l.uintOr("MaxPeerMessageSize", 512*1024)
+	c.PeerPenaltyPeriod = l.uintOr("PeerPenaltyPeriod", 3600000)
+	c.PeerDiscoveryPeriod = l.uintOr("PeerDiscoveryPeriod", 120000)
+
+	c.PeerDiscoveryPeriodMax = 600000
+	if c.PeerDiscoveryPeriod > c.PeerDiscoveryPeriodMax {
+		c.PeerDiscoveryPeriodMax = c.PeerDiscoveryPeriod
+	}
+	if v := l.optionalUint("PeerDiscoveryPeriodMax"); v >= c.PeerDiscoveryPeriod {
+		c.PeerDiscoveryPeriodMax = v
+	}
+
+	c.PeerRedialBackoffInitial = l.uintOr("PeerRedialBackoffInitial", 1000)
+	c.PeerRedialAttempts = l.uintOr("PeerRedialAttempts", 10)
+	c.PeerRedialBackoffMax = l.uintOr("PeerRedialBackoffMax", 300000)
+	c.PeerPingInterval = l.uintOr("PeerPingInterval", 15000)
+	c.PeerMaxMissedPings = l.uintOr("PeerMaxMissedPings", 3)
+	c.PeerBatchSize = l.uintOr("PeerBatchSize", 64*1024)
+	c.PeerBatchDelay = l.uintOr("PeerBatchDelay", 50)
+	c.MaxPeers = l.uintOr("MaxPeers", 32)
+
+	// Defaults to half of max peers, while never going beyond that
+	c.MaxInboundPeers = c.MaxPeers / 2
+	if c.MaxInboundPeers == 0 {
+		c.MaxInboundPeers = 1
+	}
+	if v := l.optionalUint("MaxInboundPeers"); v != 0 && v <= c.MaxPeers {
+		c.MaxInboundPeers = v
+	}
+
+}
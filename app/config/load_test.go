@@ -0,0 +1,100 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseUint(t *testing.T) {
+
+	cases := []struct {
+		name    string
+		key     string
+		value   string
+		want    uint64
+		wantErr bool
+	}{
+		{"plain period", "MemPoolPollingPeriod", "1000", 1000, false},
+		{"plain count", "PendingPoolSize", "4096", 4096, false},
+		{"plain other", "MaxPeers", "32", 32, false},
+		{"zero", "MaxTxsPerAddress", "0", 0, false},
+
+		{"milliseconds", "MemPoolPollingPeriod", "750ms", 750, false},
+		{"seconds", "MemPoolPollingPeriod", "2s", 2000, false},
+		{"fractional seconds", "RPCTimeout", "1.5s", 1500, false},
+		{"compound duration", "StuckTxAfter", "1m30s", 90000, false},
+
+		{"thousands", "PendingPoolSize", "50k", 50_000, false},
+		{"millions", "QueuedPoolSize", "1m", 1_000_000, false},
+		{"upper cased suffix", "PendingPoolSize", "50K", 50_000, false},
+		{"fractional thousands", "PendingPoolSize", "1.1k", 1100, false},
+
+		{"sub millisecond duration", "MemPoolPollingPeriod", "1500us", 0, true},
+		{"negative duration", "MemPoolPollingPeriod", "-2s", 0, true},
+		{"bad duration", "MemPoolPollingPeriod", "2 seconds", 0, true},
+		{"count for period", "MemPoolPollingPeriod", "50k", 0, true},
+		{"fractional count", "PendingPoolSize", "1.0001k", 0, true},
+		{"negative count", "PendingPoolSize", "-1k", 0, true},
+		{"ratio count", "PendingPoolSize", "1/2k", 0, true},
+		{"unknown suffix", "PendingPoolSize", "2g", 0, true},
+		{"duration for count", "PendingPoolSize", "2s", 0, true},
+		{"duration for other", "MaxPeers", "2s", 0, true},
+		{"count for other", "MaxPeers", "1k", 0, true},
+		{"negative integer", "MaxPeers", "-1", 0, true},
+		{"empty", "MaxPeers", "", 0, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+
+			got, err := parseUint(c.key, c.value)
+
+			if c.wantErr {
+
+				if err == nil {
+					t.Fatalf("expected `%s` = `%s` to be rejected, got %d", c.key, c.value, got)
+				}
+
+				// Error must point to both key & what's wrong with it
+				if !strings.Contains(err.Error(), "`"+c.key+"`") || !strings.Contains(err.Error(), "`"+c.value+"`") {
+					t.Fatalf("expected error to name key & value, got %q", err.Error())
+				}
+
+				return
+
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != c.want {
+				t.Fatalf("expected %d, got %d", c.want, got)
+			}
+
+		})
+	}
+
+}
+
+func TestPeriodAndCountKeysAreConfigFields(t *testing.T) {
+
+	fields := make(map[string]bool)
+
+	typ := reflect.TypeOf(Config{})
+	for i := 0; i < typ.NumField(); i++ {
+		fields[typ.Field(i).Name] = typ.Field(i).Type.Kind() == reflect.Uint64
+	}
+
+	for _, keys := range []map[string]bool{periodKeys, countKeys} {
+		for key := range keys {
+
+			if !fields[key] {
+				t.Fatalf("expected `%s` to be non-negative integer config field", key)
+			}
+
+		}
+	}
+
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// dynamicKeys - Config fields, which can be changed while `harmony` keeps
+// running, any other change needs restart to take effect
+var dynamicKeys = map[string]bool{
+	"MemPoolPollingPeriod":      true,
+	"MemPoolPollingPeriodMin":   true,
+	"MemPoolPollingPeriodMax":   true,
+	"PendingPoolSize":           true,
+	"QueuedPoolSize":            true,
+	"PendingTxEntryTopic":       true,
+	"PendingTxExitTopic":        true,
+	"PendingTxReplacementTopic": true,
+	"PendingTxStuckTopic":       true,
+	"PendingTxReorgedTopic":     true,
+	"QueuedToPendingTopic":      true,
+	"QueuedTxEntryTopic":        true,
+	"QueuedTxExitTopic":         true,
+	"PublishPendingEntry":       true,
+	"PublishPendingExit":        true,
+	"PublishQueuedEntry":        true,
+	"PublishQueuedExit":         true,
+	"PublishPendingReplacement": true,
+	"PublishPendingStuck":       true,
+	"PublishPendingReorged":     true,
+	"PublishQueuedToPending":    true,
+}
+
+// Reload - Reads config file again & compares it against configuration in
+// use, applying changes to dynamic keys, all at once. Returns keys whose
+// change got applied & keys whose change needs restart
+//
+// When config is invalid, nothing is applied
+func Reload() ([]string, []string, error) {
+
+	if len(configFile) != 0 {
+
+		if _, err := os.Stat(configFile); !errors.Is(err, os.ErrNotExist) {
+
+			if err := viper.ReadInConfig(); err != nil {
+				return nil, nil, err
+			}
+
+		}
+
+	}
+
+	next, _, problems := load()
+	if len(problems) != 0 {
+		return nil, nil, fmt.Errorf("invalid config, %d problem(s) found :\n\t- %s", len(problems), strings.Join(problems, "\n\t- "))
+	}
+
+	prev := loaded()
+	applied := *prev
+
+	prevV := reflect.ValueOf(prev).Elem()
+	nextV := reflect.ValueOf(next).Elem()
+	appliedV := reflect.ValueOf(&applied).Elem()
+
+	changed := make([]string, 0)
+	restart := make([]string, 0)
+
+	for i := 0; i < prevV.NumField(); i++ {
+
+		if reflect.DeepEqual(prevV.Field(i).Interface(), nextV.Field(i).Interface()) {
+			continue
+		}
+
+		key := prevV.Type().Field(i).Name
+		if !dynamicKeys[key] {
+
+			restart = append(restart, key)
+			continue
+
+		}
+
+		appliedV.Field(i).Set(nextV.Field(i))
+		changed = append(changed, key)
+
+	}
+
+	if len(changed) != 0 {
+		current.Store(&applied)
+	}
+
+	return changed, restart, nil
+
+}
+
+// Watch - Reloads config each time process receives SIGHUP or config file
+// gets modified, as seen by checking its modification time every `period`,
+// until `ctx` is done. Keys whose change got applied are handed over to
+// `onChange`, so that those can be acted upon
+func Watch(ctx context.Context, period time.Duration, onChange func([]string)) {
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	modifiedAt := func() time.Time {
+
+		if len(configFile) == 0 {
+			return time.Time{}
+		}
+
+		info, err := os.Stat(configFile)
+		if err != nil {
+			return time.Time{}
+		}
+
+		return info.ModTime()
+
+	}
+
+	lastModified := modifiedAt()
+
+	reload := func() {
+
+		changed, restart, err := Reload()
+		if err != nil {
+
+			log.Printf("[❗️] Failed to reload config, keeping older one : %s\n", err.Error())
+			return
+
+		}
+
+		if len(restart) != 0 {
+			log.Printf("[❗️] Config key(s) changed, which need restart to take effect : %s\n", strings.Join(restart, ", "))
+		}
+
+		if len(changed) == 0 {
+			return
+		}
+
+		log.Printf("[⚙️] Reloaded config key(s) : %s\n", strings.Join(changed, ", "))
+		onChange(changed)
+
+	}
+
+	for {
+
+		select {
+
+		case <-ctx.Done():
+			return
+
+		case <-hup:
+			lastModified = modifiedAt()
+			reload()
+
+		case <-ticker.C:
+
+			if modified := modifiedAt(); !modified.Equal(lastModified) {
+
+				lastModified = modified
+				reload()
+
+			}
+
+		}
+
+	}
+
+}
@@ -0,0 +1,75 @@
+package data
+
+import (
+	"context"
+	"time"
+)
+
+// Visitor - Invoked with copy of tx, while walking over pool, where
+// returning false stops walk
+type Visitor func(tx *MemPoolTx) bool
+
+// snapshotByAge - Given age ordered tree of tx(s), copies those living in
+// pool for more than or equals to `age`, oldest first, if `older` is set,
+// otherwise those living in pool for less than or equals to `age`,
+// freshest first, while copying at max `limit` of them, unless it's 0
+//
+// Walk stops as soon as first non-matching tx is seen, so only matching
+// tx(s) are ever copied
+//
+// @note Supposed to be invoked from pool's own life cycle manager go routine
+func snapshotByAge(byAge *AgeTree, age time.Duration, older bool, limit uint64) []*MemPoolTx {
+
+	var result []*MemPoolTx
+	now := time.Now().UTC()
+
+	matches := func(tx *MemPoolTx) bool {
+
+		if older {
+			return now.Sub(byAge.joinedAt(tx)) >= age
+		}
+
+		return now.Sub(byAge.joinedAt(tx)) <= age
+
+	}
+
+	collect := func(tx *MemPoolTx) bool {
+
+		if !matches(tx) {
+			return false
+		}
+
+		result = append(result, tx.Clone())
+		return limit == 0 || uint64(len(result)) < limit
+
+	}
+
+	if older {
+		byAge.ascend(collect)
+	} else {
+		byAge.descend(collect)
+	}
+
+	return result
+
+}
+
+// visitAll - Invokes visitor with each tx, in order, on caller's go routine,
+// until it asks to stop or `ctx` is done
+func visitAll(ctx context.Context, txs []*MemPoolTx, visit Visitor) error {
+
+	for _, tx := range txs {
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if !visit(tx) {
+			break
+		}
+
+	}
+
+	return nil
+
+}
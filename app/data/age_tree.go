@@ -0,0 +1,247 @@
+package data
+
+import (
+	"bytes"
+	"hash/maphash"
+	"time"
+)
+
+// ageNode - One tx living in age ordered tree, along with time it joined
+// pool at, cached so that it's not looked up during each comparison
+type ageNode struct {
+	tx       *MemPoolTx
+	at       time.Time
+	priority uint64
+	size     int
+	left     *ageNode
+	right    *ageNode
+}
+
+// AgeTree - Tx(s) living in pool, ordered as per time they joined pool at,
+// where ties are broken using tx hash, so that ordering stays stable
+//
+// Same as `GasPriceTree`, it's a treap keeping size of each subtree, so that
+// insertion & removal are logarithmic, instead of shifting whole slice, while
+// oldest tx is found by walking down left spine
+//
+// @note Not concurrent safe, supposed to be used only from pool's own
+// life cycle manager go routine
+type AgeTree struct {
+	root       *ageNode
+	priorities maphash.Hash
+	joinedAt   func(*MemPoolTx) time.Time
+}
+
+// NewAgeTree - Creates empty age ordered tree, where `joinedAt` tells when
+// tx joined pool
+func NewAgeTree(joinedAt func(*MemPoolTx) time.Time) *AgeTree {
+	return &AgeTree{joinedAt: joinedAt}
+}
+
+// NewPendingAgeTree - Age ordered tree of pending pool, where tx is aged
+// from when it was first seen pending
+func NewPendingAgeTree() *AgeTree {
+	return NewAgeTree(func(tx *MemPoolTx) time.Time {
+		return tx.PendingFrom
+	})
+}
+
+// NewQueuedAgeTree - Age ordered tree of queued pool, where tx is aged
+// from when it was queued
+func NewQueuedAgeTree() *AgeTree {
+	return NewAgeTree(func(tx *MemPoolTx) time.Time {
+		return tx.QueuedAt
+	})
+}
+
+// ageSizeOf - #-of tx(s) in subtree rooted at given node
+func ageSizeOf(n *ageNode) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.size
+}
+
+// resize - Recomputes size of subtree, after its children are updated
+func (n *ageNode) resize() {
+	n.size = 1 + ageSizeOf(n.left) + ageSizeOf(n.right)
+}
+
+// less - Checks whether this node is to be placed before tx which joined
+// pool at given time, with given hash
+func (n *ageNode) less(at time.Time, hash []byte) bool {
+
+	if !n.at.Equal(at) {
+		return n.at.Before(at)
+	}
+
+	return bytes.Compare(n.tx.Hash.Bytes(), hash) < 0
+
+}
+
+// splitAge - Splits subtree into two, where left one holds all tx(s)
+// ordered before given key & right one holds rest
+func splitAge(n *ageNode, at time.Time, hash []byte) (*ageNode, *ageNode) {
+
+	if n == nil {
+		return nil, nil
+	}
+
+	if n.less(at, hash) {
+
+		l, r := splitAge(n.right, at, hash)
+		n.right = l
+		n.resize()
+
+		return n, r
+
+	}
+
+	l, r := splitAge(n.left, at, hash)
+	n.left = r
+	n.resize()
+
+	return l, n
+
+}
+
+// mergeAge - Merges two subtrees, where all tx(s) of left one are
+// ordered before all tx(s) of right one
+func mergeAge(l *ageNode, r *ageNode) *ageNode {
+
+	if l == nil {
+		return r
+	}
+
+	if r == nil {
+		return l
+	}
+
+	if l.priority > r.priority {
+
+		l.right = mergeAge(l.right, r)
+		l.resize()
+
+		return l
+
+	}
+
+	r.left = mergeAge(l, r.left)
+	r.resize()
+
+	return r
+
+}
+
+// len - #-of tx(s) present in tree
+func (a *AgeTree) len() int {
+	return ageSizeOf(a.root)
+}
+
+// insert - Puts tx in tree, keeping it ordered
+//
+// Tx(s) seen in same poll share timestamp, so priority is salted hash,
+// same as it's done in gas price tree
+func (a *AgeTree) insert(tx *MemPoolTx) {
+
+	a.priorities.Reset()
+	a.priorities.Write(tx.Hash[:])
+
+	node := &ageNode{
+		tx:       tx,
+		at:       a.joinedAt(tx),
+		priority: a.priorities.Sum64(),
+		size:     1,
+	}
+
+	l, r := splitAge(a.root, node.at, tx.Hash.Bytes())
+	a.root = mergeAge(mergeAge(l, node), r)
+
+}
+
+// remove - Removes tx from tree, returning whether it was present
+func (a *AgeTree) remove(tx *MemPoolTx) bool {
+
+	var removed bool
+	a.root = removeAgeNode(a.root, a.joinedAt(tx), tx.Hash.Bytes(), &removed)
+
+	return removed
+
+}
+
+// removeAgeNode - Finds node holding tx with given key in subtree & replaces
+// it with merged children, while updating sizes along the path
+func removeAgeNode(n *ageNode, at time.Time, hash []byte, removed *bool) *ageNode {
+
+	if n == nil {
+		return nil
+	}
+
+	if n.at.Equal(at) && bytes.Equal(n.tx.Hash.Bytes(), hash) {
+
+		*removed = true
+		return mergeAge(n.left, n.right)
+
+	}
+
+	if n.less(at, hash) {
+		n.right = removeAgeNode(n.right, at, hash, removed)
+	} else {
+		n.left = removeAgeNode(n.left, at, hash, removed)
+	}
+
+	n.resize()
+	return n
+
+}
+
+// oldest - Tx which joined pool first, nil when tree is empty
+func (a *AgeTree) oldest() *MemPoolTx {
+
+	n := a.root
+	if n == nil {
+		return nil
+	}
+
+	for n.left != nil {
+		n = n.left
+	}
+
+	return n.tx
+
+}
+
+// ascend - Visits tx(s) oldest first, until visitor asks to stop
+func (a *AgeTree) ascend(visit func(*MemPoolTx) bool) {
+	ascendAge(a.root, visit)
+}
+
+// ascendAge - In-order traversal of subtree, returns false when visitor
+// asked to stop
+func ascendAge(n *ageNode, visit func(*MemPoolTx) bool) bool {
+
+	if n == nil {
+		return true
+	}
+
+	return ascendAge(n.left, visit) && visit(n.tx) && ascendAge(n.right, visit)
+
+}
+
+// descend - Visits tx(s) freshest first, until visitor asks to stop
+func (a *AgeTree) descend(visit func(*MemPoolTx) bool) {
+	descendAge(a.root, visit)
+}
+
+// descendAge - Reverse in-order traversal of subtree, returns false when
+// visitor asked to stop
+func descendAge(n *ageNode, visit func(*MemPoolTx) bool) bool {
+
+	if n == nil {
+		return true
+	}
+
+	return descendAge(n.right, visit) && visit(n.tx) && descendAge(n.left, visit)
+
+}
@@ -0,0 +1,165 @@
+package data
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// agedTxs - `n` tx(s), pending since one of `polls` consecutive polls, so
+// that many of them share same timestamp, as they do when seen in same poll
+func agedTxs(n int, polls int) []*MemPoolTx {
+
+	start := time.Now().UTC().Add(-time.Hour)
+
+	txs := makeTxs(n, n/10+1)
+	for i, tx := range txs {
+		tx.PendingFrom = start.Add(time.Duration(i%polls) * time.Second)
+	}
+
+	return txs
+
+}
+
+// ageDepthOf - Length of longest path from given node to some leaf
+func ageDepthOf(n *ageNode) int {
+
+	if n == nil {
+		return 0
+	}
+
+	l, r := ageDepthOf(n.left), ageDepthOf(n.right)
+	if l > r {
+		return l + 1
+	}
+
+	return r + 1
+
+}
+
+// ordered - Checks whether tx(s) are ordered oldest first, where those
+// joining pool at same time are ordered by hash
+func ordered(a *MemPoolTx, b *MemPoolTx) bool {
+
+	if !a.PendingFrom.Equal(b.PendingFrom) {
+		return a.PendingFrom.Before(b.PendingFrom)
+	}
+
+	return bytes.Compare(a.Hash.Bytes(), b.Hash.Bytes()) < 0
+
+}
+
+func TestAgeTreeOrdersByAge(t *testing.T) {
+
+	txs := agedTxs(1000, 7)
+
+	tree := NewPendingAgeTree()
+	for _, tx := range txs {
+		tree.insert(tx)
+	}
+
+	if tree.len() != len(txs) {
+		t.Fatalf("expected %d tx(s) in tree, got %d", len(txs), tree.len())
+	}
+
+	asc := make([]*MemPoolTx, 0, len(txs))
+	tree.ascend(func(tx *MemPoolTx) bool {
+		asc = append(asc, tx)
+		return true
+	})
+
+	for i := 1; i < len(asc); i++ {
+		if !ordered(asc[i-1], asc[i]) {
+			t.Fatalf("rank %d : expected tx(s) to be ordered oldest first, ties by hash", i)
+		}
+	}
+
+	if tree.oldest() != asc[0] {
+		t.Fatalf("expected oldest tx %s, got %s", asc[0].Hash, tree.oldest().Hash)
+	}
+
+	desc := make([]*MemPoolTx, 0, len(txs))
+	tree.descend(func(tx *MemPoolTx) bool {
+		desc = append(desc, tx)
+		return len(desc) < 10
+	})
+
+	if len(desc) != 10 {
+		t.Fatalf("expected walk to stop after 10 tx(s), got %d", len(desc))
+	}
+
+	for i, tx := range desc {
+		if tx != asc[len(asc)-1-i] {
+			t.Fatalf("rank %d : expected tx(s) to be ordered freshest first", i)
+		}
+	}
+
+	// Removing oldest one reveals next oldest, even when both share timestamp
+	if !tree.remove(asc[0]) || tree.remove(asc[0]) {
+		t.Fatalf("expected %s to be removed exactly once", asc[0].Hash)
+	}
+
+	if tree.oldest() != asc[1] || tree.len() != len(txs)-1 {
+		t.Fatalf("expected %s to be oldest after removal, got %s", asc[1].Hash, tree.oldest().Hash)
+	}
+
+	for _, tx := range asc[1:] {
+		if !tree.remove(tx) {
+			t.Fatalf("expected %s to be removed", tx.Hash)
+		}
+	}
+
+	if tree.len() != 0 || tree.oldest() != nil {
+		t.Fatalf("expected tree to be empty, got %d tx(s)", tree.len())
+	}
+
+}
+
+// Whole batch seen in one poll shares timestamp, which must not turn tree
+// into linked list
+func TestAgeTreeDepthSamePoll(t *testing.T) {
+
+	const n = 20_000
+
+	tree := NewPendingAgeTree()
+	for _, tx := range agedTxs(n, 1) {
+		tree.insert(tx)
+	}
+
+	if tree.len() != n {
+		t.Fatalf("expected %d tx(s) in tree, got %d", n, tree.len())
+	}
+
+	// Expected depth of treap is ~3 * log2(n), i.e. ~43 for 20k nodes
+	if depth := ageDepthOf(tree.root); depth > 100 {
+		t.Fatalf("expected logarithmic depth for %d tx(s) seen in same poll, got %d", n, depth)
+	}
+
+}
+
+// Re-inserting tx right after removing it keeps pool size steady, where
+// sorted slice used to shift on both
+func BenchmarkAgeInsertRemove(b *testing.B) {
+
+	for _, n := range benchSizes {
+
+		txs := agedTxs(n, 100)
+
+		tree := NewPendingAgeTree()
+		for _, tx := range txs {
+			tree.insert(tx)
+		}
+
+		b.Run("tree/"+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tx := txs[i%n]
+
+				tree.remove(tx)
+				tree.insert(tx)
+			}
+		})
+
+	}
+
+}
@@ -0,0 +1,148 @@
+package data
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// PoolAggregates - Totals over all tx(s) living in pool
+//
+// @note `TotalGas` is sum of gas limits i.e. gas demanded, while
+// `TotalValue` is in wei. `SenderEntries` is cardinality of per sender
+// index, which must follow `UniqueSenders`
+type PoolAggregates struct {
+	Count             uint64
+	TotalGas          uint64
+	TotalValue        *big.Int
+	UniqueSenders     uint64
+	ContractCreations uint64
+	ByType            map[uint64]uint64
+	SenderEntries     uint64
+}
+
+// MemPoolAggregates - Totals over tx(s) living in pending & queued pools
+type MemPoolAggregates struct {
+	Pending PoolAggregates
+	Queued  PoolAggregates
+}
+
+// aggregates - Running totals, kept up-to-date as tx(s) join/ leave
+// pool, so that reading those doesn't require scanning whole pool, where
+// zero value denotes totals of empty pool
+//
+// @note Not concurrent safe, supposed to be used only from pool's own
+// life cycle manager go routine
+type aggregates struct {
+	gas               uint64
+	value             big.Int
+	senders           uint64
+	contractCreations uint64
+	types             map[uint64]uint64
+	// Cumulative #-of tx(s) joined/ left pool, since start up
+	joined uint64
+	left   uint64
+}
+
+// added - Accounts for tx which just joined pool, given how many
+// tx(s) its sender has in pool now, including this one
+func (a *aggregates) added(tx *MemPoolTx, fromSender int) {
+
+	a.joined++
+	a.gas += uint64(tx.Gas)
+	a.value.Add(&a.value, bigOrZero(tx.Value))
+
+	if fromSender == 1 {
+		a.senders++
+	}
+
+	if tx.IsContractCreation() {
+		a.contractCreations++
+	}
+
+	if a.types == nil {
+		a.types = make(map[uint64]uint64)
+	}
+
+	a.types[uint64(tx.Type)]++
+
+}
+
+// removed - Accounts for tx which just left pool, given how many
+// tx(s) its sender still has in pool
+func (a *aggregates) removed(tx *MemPoolTx, fromSender int) {
+
+	a.left++
+	a.gas -= uint64(tx.Gas)
+	a.value.Sub(&a.value, bigOrZero(tx.Value))
+
+	if fromSender == 0 {
+		a.senders--
+	}
+
+	if tx.IsContractCreation() {
+		a.contractCreations--
+	}
+
+	if a.types[uint64(tx.Type)] <= 1 {
+		delete(a.types, uint64(tx.Type))
+	} else {
+		a.types[uint64(tx.Type)]--
+	}
+
+}
+
+// snapshot - Copy of running totals, safe to be handed over to
+// other go routines, given #-of tx(s) & #-of entries in per sender
+// index of pool
+func (a *aggregates) snapshot(count int, senderEntries int) PoolAggregates {
+
+	types := make(map[uint64]uint64, len(a.types))
+	for t, n := range a.types {
+		types[t] = n
+	}
+
+	return PoolAggregates{
+		Count:             uint64(count),
+		TotalGas:          a.gas,
+		TotalValue:        new(big.Int).Set(&a.value),
+		UniqueSenders:     a.senders,
+		ContractCreations: a.contractCreations,
+		ByType:            types,
+		SenderEntries:     uint64(senderEntries),
+	}
+
+}
+
+// Types - Tx types present in pool, in ascending order
+func (p PoolAggregates) Types() []uint64 {
+
+	types := make([]uint64, 0, len(p.ByType))
+	for t := range p.ByType {
+		types = append(types, t)
+	}
+
+	sort.Slice(types, func(i, j int) bool {
+		return types[i] < types[j]
+	})
+
+	return types
+
+}
+
+// TypeCounts - Human readable per type tx counts, to be used in logs
+func (p PoolAggregates) TypeCounts() string {
+
+	if len(p.ByType) == 0 {
+		return "none"
+	}
+
+	counts := make([]string, 0, len(p.ByType))
+	for _, t := range p.Types() {
+		counts = append(counts, fmt.Sprintf("type %d : %d", t, p.ByType[t]))
+	}
+
+	return strings.Join(counts, ", ")
+
+}
@@ -0,0 +1,162 @@
+package data
+
+import (
+	"log"
+	"sync/atomic"
+
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/pub0sub/ops"
+	"github.com/itzmeanjan/pub0sub/publisher"
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+// TxBatch - Serialized tx(s), accumulated for being published on same
+// pubsub topic, together, as one message, so that when thousands of tx(s)
+// join/ leave pool in one go, hub doesn't get hammered with tiny messages
+//
+// @note Not concurrent safe, supposed to be used only from pool's own
+// life cycle manager go routine
+type TxBatch struct {
+	Codec Codec
+	Topic func() string
+	Txs   [][]byte
+	// Pool's counter of messages published on topic, to be
+	// incremented atomically
+	Published *uint64
+}
+
+// NewTxBatch - Creates empty batch, to be published on topic
+// returned by given config getter, counting each published
+// message in `published`
+func NewTxBatch(codec Codec, topic func() string, published *uint64) *TxBatch {
+	return &TxBatch{
+		Codec:     codec,
+		Topic:     topic,
+		Txs:       make([][]byte, 0, config.GetPublishBatchSize()),
+		Published: published,
+	}
+}
+
+// Enabled - Batching is enabled only when user has asked to
+// publish more than one tx per message & codec supports it
+func (t *TxBatch) Enabled() bool {
+
+	if _, ok := t.Codec.(BatchCodec); !ok {
+		return false
+	}
+
+	return config.GetPublishBatchSize() > 1
+
+}
+
+// Append - Puts serialized tx into batch & flushes it when
+// batch size is reached
+func (t *TxBatch) Append(pub *publisher.Publisher, data []byte) {
+
+	t.Txs = append(t.Txs, data)
+
+	if uint64(len(t.Txs)) >= config.GetPublishBatchSize() {
+		t.Flush(pub)
+	}
+
+}
+
+// Flush - Publishes all accumulated tx(s) as one message
+// & empties batch
+func (t *TxBatch) Flush(pub *publisher.Publisher) {
+
+	if len(t.Txs) == 0 {
+		return
+	}
+
+	defer func() {
+		t.Txs = t.Txs[:0]
+	}()
+
+	data, err := t.Codec.(BatchCodec).EncodeBatch(t.Txs)
+	if err != nil {
+		log.Printf("[❗️] Failed to serialize batch of tx(s) : %s\n", err.Error())
+		return
+	}
+
+	if _, err := pub.Publish(&ops.Msg{
+		Topics: []string{t.Topic()},
+		Data:   MaybeCompress(data),
+	}); err != nil {
+		log.Printf("[❗️] Failed to publish batch of %d tx(s) : %s\n", len(t.Txs), err.Error())
+		return
+	}
+
+	atomic.AddUint64(t.Published, 1)
+
+}
+
+// ToMessagePackBatch - Serialize multiple tx(s) into one message pack
+// encoded byte array i.e. array of tx(s)
+func ToMessagePackBatch(txs []*MemPoolTx) ([]byte, error) {
+
+	return msgpack.Marshal(txs)
+
+}
+
+// FromMessagePackBatch - Given message pack encoded array of tx(s),
+// attempts to deserialize into list of structured tx(s)
+func FromMessagePackBatch(data []byte) ([]*MemPoolTx, error) {
+
+	var txs []*MemPoolTx
+
+	if err := msgpack.Unmarshal(data, &txs); err != nil {
+		return nil, err
+	}
+
+	for _, tx := range txs {
+		tx.toUTC()
+	}
+
+	return txs, nil
+
+}
+
+// isMessagePackBatch - Batched payload is message pack encoded array,
+// while single tx is encoded as map
+func isMessagePackBatch(data []byte) bool {
+
+	if len(data) == 0 {
+		return false
+	}
+
+	return msgpcode.IsFixedArray(data[0]) || data[0] == msgpcode.Array16 || data[0] == msgpcode.Array32
+
+}
+
+// publishTx - Publishes serialized tx on topic, through batch, when one is
+// given & batching is enabled, otherwise as its own message, counting each
+// published message in `published`, where `what` describes tx in log
+//
+// Every pool topic goes through it, so that each one is counted same way,
+// while callers decide whether publishing on it is enabled
+func publishTx(pub *publisher.Publisher, codec Codec, batch *TxBatch, topic string, published *uint64, msg *MemPoolTx, what string) {
+
+	data, err := codec.Encode(msg)
+	if err != nil {
+		log.Printf("[❗️] Failed to serialize tx : %s\n", err.Error())
+		return
+	}
+
+	if batch != nil && batch.Enabled() {
+		batch.Append(pub, data)
+		return
+	}
+
+	if _, err := pub.Publish(&ops.Msg{
+		Topics: []string{topic},
+		Data:   MaybeCompress(data),
+	}); err != nil {
+		log.Printf("[❗️] Failed to publish %s : %s\n", what, err.Error())
+		return
+	}
+
+	atomic.AddUint64(published, 1)
+
+}
@@ -0,0 +1,201 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec - Serializes tx(s) being published on pubsub topics & sent to
+// peers, and deserializes them back on receiving end
+//
+// One instance is created during bootup & shared among all publishing/
+// subscribing sites
+type Codec interface {
+	Encode(*MemPoolTx) ([]byte, error)
+	Decode([]byte) (*MemPoolTx, error)
+}
+
+// BatchCodec - Codec which is also capable of packing multiple serialized
+// tx(s) into one message & unpacking them
+type BatchCodec interface {
+	Codec
+	EncodeBatch([][]byte) ([]byte, error)
+	DecodeBatch([]byte) ([]*MemPoolTx, error)
+	IsBatch([]byte) bool
+}
+
+// NewCodec - Given name of codec, as read from config, returns
+// codec instance to be used for serialization
+func NewCodec(name string) Codec {
+
+	switch name {
+
+	case "json":
+		return JSONCodec{}
+
+	case "protobuf":
+		return ProtobufCodec{}
+
+	default:
+		return MessagePackCodec{}
+
+	}
+
+}
+
+// MessagePackCodec - Serializes tx(s) in message pack format
+type MessagePackCodec struct{}
+
+// Encode - Serialize tx to message pack encoded byte array
+func (MessagePackCodec) Encode(tx *MemPoolTx) ([]byte, error) {
+	return tx.ToMessagePack()
+}
+
+// Decode - Deserialize message pack encoded byte array to tx
+func (MessagePackCodec) Decode(data []byte) (*MemPoolTx, error) {
+	return FromMessagePack(data)
+}
+
+// EncodeBatch - Packs message pack encoded tx(s) as message pack array
+func (MessagePackCodec) EncodeBatch(txs [][]byte) ([]byte, error) {
+
+	raw := make([]msgpack.RawMessage, 0, len(txs))
+	for _, tx := range txs {
+		raw = append(raw, tx)
+	}
+
+	return msgpack.Marshal(raw)
+
+}
+
+// DecodeBatch - Unpacks message pack array of tx(s)
+func (MessagePackCodec) DecodeBatch(data []byte) ([]*MemPoolTx, error) {
+	return FromMessagePackBatch(data)
+}
+
+// IsBatch - Batched payload is message pack encoded array, while single
+// tx is encoded as map
+func (MessagePackCodec) IsBatch(data []byte) bool {
+	return isMessagePackBatch(data)
+}
+
+// JSONCodec - Serializes tx(s) in JSON format, which is easier to
+// inspect, while debugging
+type JSONCodec struct{}
+
+// Encode - Serialize tx to JSON encoded byte array
+func (JSONCodec) Encode(tx *MemPoolTx) ([]byte, error) {
+	return json.Marshal(tx)
+}
+
+// Decode - Deserialize JSON encoded byte array to tx
+func (JSONCodec) Decode(data []byte) (*MemPoolTx, error) {
+
+	var tx MemPoolTx
+
+	if err := json.Unmarshal(data, &tx); err != nil {
+		return nil, err
+	}
+
+	return &tx, nil
+
+}
+
+// EncodeBatch - Packs JSON encoded tx(s) as JSON array
+func (JSONCodec) EncodeBatch(txs [][]byte) ([]byte, error) {
+
+	raw := make([]json.RawMessage, 0, len(txs))
+	for _, tx := range txs {
+		raw = append(raw, tx)
+	}
+
+	return json.Marshal(raw)
+
+}
+
+// DecodeBatch - Unpacks JSON array of tx(s)
+func (JSONCodec) DecodeBatch(data []byte) ([]*MemPoolTx, error) {
+
+	var txs []*MemPoolTx
+
+	if err := json.Unmarshal(data, &txs); err != nil {
+		return nil, err
+	}
+
+	return txs, nil
+
+}
+
+// IsBatch - Batched payload is JSON array, while single tx is
+// encoded as JSON object
+func (JSONCodec) IsBatch(data []byte) bool {
+
+	data = bytes.TrimLeft(data, " \t\r\n")
+	return len(data) != 0 && data[0] == '['
+
+}
+
+// ProtobufCodec - Serializes tx(s) in protocol buffer format, following
+// schema defined in `tx.proto`
+//
+// @note Batching is not supported with this codec
+type ProtobufCodec struct{}
+
+// Encode - Serialize tx to protocol buffer encoded byte array
+func (ProtobufCodec) Encode(tx *MemPoolTx) ([]byte, error) {
+	return tx.ToProtobuf()
+}
+
+// Decode - Deserialize protocol buffer encoded byte array to tx
+func (ProtobufCodec) Decode(data []byte) (*MemPoolTx, error) {
+	return FromProtobuf(data)
+}
+
+// SerializeMany - Serializes list of tx(s) into one message, as batch if
+// more than one tx present, otherwise as single tx
+func SerializeMany(codec Codec, txs []*MemPoolTx) ([]byte, error) {
+
+	if len(txs) == 1 {
+		return codec.Encode(txs[0])
+	}
+
+	_codec, ok := codec.(BatchCodec)
+	if !ok {
+		return nil, errors.New("codec doesn't support batching")
+	}
+
+	encoded := make([][]byte, 0, len(txs))
+	for _, tx := range txs {
+
+		data, err := _codec.Encode(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		encoded = append(encoded, data)
+
+	}
+
+	return _codec.EncodeBatch(encoded)
+
+}
+
+// DeserializeMany - Given either single or batched payload, attempts to
+// deserialize it into list of structured tx(s)
+func DeserializeMany(codec Codec, data []byte) ([]*MemPoolTx, error) {
+
+	if _codec, ok := codec.(BatchCodec); ok && _codec.IsBatch(data) {
+		return _codec.DecodeBatch(data)
+	}
+
+	tx, err := codec.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*MemPoolTx{tx}, nil
+
+}
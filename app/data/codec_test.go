@@ -0,0 +1,226 @@
+package data
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// sampleTx - Dynamic fee tx with every field set, so that codecs dropping
+// any of them get caught
+func sampleTx() *MemPoolTx {
+
+	blockHash := common.HexToHash("0xb10c")
+	to := common.HexToAddress("0x63ec5767f54f6943750a70eb6117ea2d9ca77313")
+	idx := hexutil.Uint64(7)
+	at := time.Date(2021, time.May, 4, 10, 20, 30, 123456789, time.UTC)
+
+	return &MemPoolTx{
+		BlockHash:            &blockHash,
+		BlockNumber:          (*hexutil.Big)(big.NewInt(12_000_000)),
+		From:                 common.HexToAddress("0x1a2b"),
+		Gas:                  21000,
+		GasPrice:             gwei(120),
+		MaxFeePerGas:         gwei(120),
+		MaxPriorityFeePerGas: gwei(2),
+		Hash:                 common.HexToHash("0x9b4f"),
+		Input:                hexutil.Bytes{0xa9, 0x05, 0x9c, 0xbb},
+		Nonce:                42,
+		To:                   &to,
+		TransactionIndex:     &idx,
+		Value:                (*hexutil.Big)(big.NewInt(1_000_000)),
+		Type:                 2,
+		ChainID:              (*hexutil.Big)(big.NewInt(1)),
+		AccessList: &types.AccessList{
+			{Address: to, StorageKeys: []common.Hash{common.HexToHash("0x01"), common.HexToHash("0x02")}},
+		},
+		V:              (*hexutil.Big)(big.NewInt(1)),
+		R:              (*hexutil.Big)(big.NewInt(0xdead)),
+		S:              (*hexutil.Big)(big.NewInt(0xbeef)),
+		QueuedAt:       at,
+		UnstuckAt:      at.Add(time.Second),
+		PendingFrom:    at.Add(2 * time.Second),
+		ConfirmedAt:    at.Add(3 * time.Second),
+		DroppedAt:      at.Add(4 * time.Second),
+		Pool:           "pending",
+		ReceivedFrom:   "QmPeer",
+		ReplacedBy:     common.HexToHash("0xfeed"),
+		EvictionReason: "replaced",
+		Promoted:       true,
+	}
+
+}
+
+// assertSameTx - Fails test unless both tx(s) carry same fields
+func assertSameTx(t *testing.T, want *MemPoolTx, got *MemPoolTx) {
+	t.Helper()
+
+	if got == nil {
+		t.Fatal("expected tx, got nil")
+	}
+
+	_want, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_got, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(_want) != string(_got) {
+		t.Fatalf("tx changed in round trip\nwant : %s\ngot  : %s", _want, _got)
+	}
+}
+
+func TestMessagePackRoundTrip(t *testing.T) {
+
+	want := sampleTx()
+
+	data, err := want.ToMessagePack()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := FromMessagePack(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSameTx(t, want, got)
+
+}
+
+func TestMessagePackMalformed(t *testing.T) {
+
+	tx, err := FromMessagePack([]byte{0xc1})
+	if err == nil {
+		t.Fatal("expected error for malformed message pack payload")
+	}
+
+	if tx != nil {
+		t.Fatal("expected no tx for malformed message pack payload")
+	}
+
+}
+
+func TestProtobufRoundTrip(t *testing.T) {
+
+	want := sampleTx()
+
+	data, err := want.ToProtobuf()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := FromProtobuf(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSameTx(t, want, got)
+
+}
+
+// Optional fields left unset must stay unset on receiving end, instead of
+// being decoded as zero values
+func TestProtobufOptionalFields(t *testing.T) {
+
+	want := legacyTx(1, 30)
+	want.Input = hexutil.Bytes{}
+
+	data, err := want.ToProtobuf()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := FromProtobuf(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.To != nil || got.BlockHash != nil || got.MaxFeePerGas != nil || got.AccessList != nil {
+		t.Fatal("expected absent optional fields to stay absent")
+	}
+
+	if !got.QueuedAt.IsZero() || got.IsReplaced() {
+		t.Fatal("expected unset timestamp & replacement to stay unset")
+	}
+
+	assertSameTx(t, want, got)
+
+}
+
+func TestProtobufMissingHash(t *testing.T) {
+
+	data, err := (&MemPoolTx{}).ToProtobuf()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := FromProtobuf(data); err == nil {
+		t.Fatal("expected error for message without tx hash")
+	}
+
+}
+
+func TestProtobufCodecRejectsBatch(t *testing.T) {
+
+	if _, err := SerializeMany(NewCodec("protobuf"), []*MemPoolTx{legacyTx(1, 1), legacyTx(2, 1)}); err == nil {
+		t.Fatal("expected protobuf codec to refuse batching")
+	}
+
+}
+
+// Pool timestamps are what consumers compute waiting time from, so every
+// codec must bring them back same, in UTC, batched or not
+func TestCodecsKeepTimestamps(t *testing.T) {
+
+	for _, name := range []string{"msgpack", "json", "protobuf"} {
+		t.Run(name, func(t *testing.T) {
+
+			codec := NewCodec(name)
+
+			batch := []*MemPoolTx{sampleTx()}
+			if _, ok := codec.(BatchCodec); ok {
+				second := sampleTx()
+				second.Hash = common.HexToHash("0x9b50")
+				second.PendingFrom = second.PendingFrom.Add(time.Minute)
+
+				batch = append(batch, second)
+			}
+
+			data, err := SerializeMany(codec, batch)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := DeserializeMany(codec, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(got) != len(batch) {
+				t.Fatalf("expected %d tx(s), got %d", len(batch), len(got))
+			}
+
+			for i, want := range batch {
+
+				assertSameTx(t, want, got[i])
+
+				if got[i].PendingFrom.Location() != time.UTC || !got[i].PendingFrom.Equal(want.PendingFrom) || !got[i].QueuedAt.Equal(want.QueuedAt) {
+					t.Fatalf("expected timestamps to be kept in UTC, got %s", got[i].PendingFrom)
+				}
+
+			}
+
+		})
+	}
+
+}
@@ -1,8 +1,10 @@
 package data
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log"
 	"math/big"
 	"runtime"
 	"strings"
@@ -107,9 +109,15 @@ func HexToDecimal(num hexutil.Uint64) string {
 
 // BigHexToBigDecimal - Given a hex encoded big number, converts it to
 // decimal big integer
+//
+// @note Absent number is considered to be zero
 func BigHexToBigDecimal(num *hexutil.Big) *big.Int {
 
 	_num := big.NewInt(0)
+	if num == nil {
+		return _num
+	}
+
 	_num.SetString(remove0x(num.String()), 16)
 
 	return _num
@@ -175,3 +183,110 @@ func NumericGasPriceGwei(num *hexutil.Big) float64 {
 	gp, _ := _res.Float64()
 	return gp
 }
+
+// NumericValueEther - Amount in wei, converted to ether unit &
+// represented as floating point ( double precision )
+func NumericValueEther(num *big.Int) float64 {
+	_num, err := BigIntToBigFloat(num)
+	if err != nil {
+		return 0.0
+	}
+
+	_den := big.NewFloat(1e18)
+	_res := big.NewFloat(0)
+	_res.Quo(_num, _den)
+
+	v, _ := _res.Float64()
+	return v
+}
+
+// supervise - Keeps running pool's life cycle manager loop, respawning it
+// when it panics, due to some malformed tx, until context gets cancelled
+//
+// @note State lives in pool itself, so it's not lost, only in-flight
+// request, which caused panic, is dropped
+func supervise(ctx context.Context, name string, loop func(context.Context)) {
+
+	for {
+
+		panicked := func() (panicked bool) {
+
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("[❗️] Recovered from panic in %s : %v\n", name, r)
+					panicked = true
+				}
+			}()
+
+			loop(ctx)
+			return
+
+		}()
+
+		if !panicked || ctx.Err() != nil {
+			return
+		}
+
+	}
+
+}
+
+// request - Sends request, built around fresh response channel, to pool's
+// life cycle manager & waits for its response
+//
+// Every accessor goes through it, so that none of them blocks forever. It
+// gives up as soon as `ctx` is done or pool has stopped, while sending or
+// waiting, whichever comes first. Response which is already there is
+// preferred over pool being stopped.
+func request[Req any, Resp any](ctx context.Context, stopped <-chan struct{}, ch chan<- Req, build func(chan Resp) Req) (Resp, error) {
+
+	var zero Resp
+
+	// Request channels are buffered, so stopped pool could still take
+	// one, which is never going to be answered
+	select {
+	case <-stopped:
+		return zero, ErrPoolStopped
+	default:
+	}
+
+	respChan := make(chan Resp, 1)
+
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case <-stopped:
+		return zero, ErrPoolStopped
+	case ch <- build(respChan):
+	}
+
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case v := <-respChan:
+		return v, nil
+	case <-stopped:
+	}
+
+	select {
+	case v := <-respChan:
+		return v, nil
+	default:
+		return zero, ErrPoolStopped
+	}
+
+}
+
+// drain - Discards requests left in channel, once pool has stopped, whose
+// senders are already giving up with `ErrPoolStopped`
+func drain[T any](ch chan T) {
+
+	for {
+		select {
+		case <-ch:
+		default:
+			return
+		}
+	}
+
+}
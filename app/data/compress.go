@@ -0,0 +1,79 @@
+package data
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+
+	"github.com/golang/snappy"
+	"github.com/itzmeanjan/harmony/app/config"
+)
+
+// snappyMagic - Stream identifier chunk, with which every snappy framed
+// payload starts. None of supported codecs can produce payload starting
+// with `0xff`, so it can be used for telling compressed payloads apart
+var snappyMagic = []byte("\xff\x06\x00\x00sNaPpY")
+
+// IsCompressed - Checks whether payload is snappy compressed or not
+func IsCompressed(data []byte) bool {
+
+	return bytes.HasPrefix(data, snappyMagic)
+
+}
+
+// Compress - Compresses payload using snappy framing format
+func Compress(data []byte) ([]byte, error) {
+
+	buf := bytes.NewBuffer(make([]byte, 0, len(data)/2))
+	writer := snappy.NewBufferedWriter(buf)
+
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+
+}
+
+// Decompress - Decompresses snappy framed payload
+func Decompress(data []byte) ([]byte, error) {
+
+	return ioutil.ReadAll(snappy.NewReader(bytes.NewReader(data)))
+
+}
+
+// MaybeCompress - Compresses payload only when compression is enabled &
+// payload is larger than configured threshold
+//
+// If compression fails for some reason, payload is returned as it's
+func MaybeCompress(data []byte) []byte {
+
+	threshold := config.GetCompressionThreshold()
+	if threshold == 0 || uint64(len(data)) <= threshold {
+		return data
+	}
+
+	compressed, err := Compress(data)
+	if err != nil {
+		log.Printf("[❗️] Failed to compress payload : %s\n", err.Error())
+		return data
+	}
+
+	return compressed
+
+}
+
+// MaybeDecompress - Decompresses payload, only if it's snappy compressed
+func MaybeDecompress(data []byte) ([]byte, error) {
+
+	if !IsCompressed(data) {
+		return data, nil
+	}
+
+	return Decompress(data)
+
+}
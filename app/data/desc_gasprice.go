@@ -3,7 +3,7 @@ package data
 // MemPoolTxsDesc - List of mempool tx(s)
 //
 // @note This structure to be used for sorting tx(s)
-// in descending way, using gas price they're paying
+// in descending way, using effective gas price they're paying
 type MemPoolTxsDesc []*MemPoolTx
 
 // len - Number of txs present in slice
@@ -32,7 +32,7 @@ func (m MemPoolTxsDesc) findInsertionPoint(low int, high int, tx *MemPoolTx) int
 
 	if low == high {
 
-		if !(BigHexToBigDecimal(m[low].GasPrice).Cmp(BigHexToBigDecimal(tx.GasPrice)) > 0) {
+		if !(m[low].EffectiveGasPrice(nil).Cmp(tx.EffectiveGasPrice(nil)) > 0) {
 			return low
 		}
 
@@ -41,7 +41,7 @@ func (m MemPoolTxsDesc) findInsertionPoint(low int, high int, tx *MemPoolTx) int
 	}
 
 	mid := (low + high) / 2
-	if !(BigHexToBigDecimal(m[mid].GasPrice).Cmp(BigHexToBigDecimal(tx.GasPrice)) > 0) {
+	if !(m[mid].EffectiveGasPrice(nil).Cmp(tx.EffectiveGasPrice(nil)) > 0) {
 
 		return m.findInsertionPoint(low, mid, tx)
 
@@ -69,7 +69,7 @@ func (m MemPoolTxsDesc) findTx(low int, high int, tx *MemPoolTx) int {
 	}
 
 	mid := (low + high) / 2
-	if !(BigHexToBigDecimal(m[mid].GasPrice).Cmp(BigHexToBigDecimal(tx.GasPrice)) > 0) {
+	if !(m[mid].EffectiveGasPrice(nil).Cmp(tx.EffectiveGasPrice(nil)) > 0) {
 		return m.findTx(low, mid, tx)
 	}
 
@@ -0,0 +1,78 @@
+package data
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// pollSnapshot - Hashes of tx(s) seen in previous poll of mempool content,
+// for finding out what has changed since then
+//
+// @note Supposed to be accessed only by mempool poller
+type pollSnapshot struct {
+	pending map[common.Hash]struct{}
+	queued  map[common.Hash]struct{}
+	// #-of polls processed so far
+	polls uint64
+}
+
+// hashesOf - Set of hashes present in one section of mempool content
+func hashesOf(txs map[string]map[string]*MemPoolTx) map[common.Hash]struct{} {
+
+	hashes := make(map[common.Hash]struct{})
+
+	for keyO := range txs {
+		for keyI := range txs[keyO] {
+			hashes[txs[keyO][keyI].Hash] = struct{}{}
+		}
+	}
+
+	return hashes
+
+}
+
+// newlySeen - Tx(s) present in one section of mempool content, but not in
+// previous poll, where nil `previous` makes all of them new
+func newlySeen(txs map[string]map[string]*MemPoolTx, previous map[common.Hash]struct{}) []*MemPoolTx {
+
+	added := make([]*MemPoolTx, 0)
+
+	for keyO := range txs {
+		for keyI := range txs[keyO] {
+
+			tx := txs[keyO][keyI]
+
+			if _, ok := previous[tx.Hash]; ok {
+				continue
+			}
+
+			added = append(added, tx)
+
+		}
+	}
+
+	return added
+
+}
+
+// disappeared - Hashes present in previous poll, which are not present in
+// any section of current mempool content
+func disappeared(previous map[common.Hash]struct{}, current ...map[common.Hash]struct{}) []common.Hash {
+
+	gone := make([]common.Hash, 0)
+
+OUTER:
+	for hash := range previous {
+
+		for _, hashes := range current {
+			if _, ok := hashes[hash]; ok {
+				continue OUTER
+			}
+		}
+
+		gone = append(gone, hash)
+
+	}
+
+	return gone
+
+}
@@ -0,0 +1,110 @@
+package data
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+// overlappingPolls - Two consecutive polls of `n` pending tx(s) each, where
+// `shared` of them are present in both
+func overlappingPolls(n int, shared int) (map[string]map[string]*MemPoolTx, map[string]map[string]*MemPoolTx) {
+
+	txs := makeTxs(2*n-shared, n/10+1)
+	return pollOf(txs[:n]), pollOf(txs[n-shared:])
+
+}
+
+func TestPollDiff(t *testing.T) {
+
+	first, second := overlappingPolls(1000, 900)
+	previous, current := hashesOf(first), hashesOf(second)
+
+	if len(previous) != 1000 || len(current) != 1000 {
+		t.Fatalf("expected 1000 tx(s) in each poll, got %d & %d", len(previous), len(current))
+	}
+
+	added := newlySeen(second, previous)
+	if len(added) != 100 {
+		t.Fatalf("expected 100 tx(s) to be newly seen, got %d", len(added))
+	}
+
+	for _, tx := range added {
+		if _, ok := previous[tx.Hash]; ok {
+			t.Fatalf("expected %s not to be newly seen, it was in previous poll", tx.Hash)
+		}
+	}
+
+	gone := disappeared(previous, current)
+	if len(gone) != 100 {
+		t.Fatalf("expected 100 tx(s) to have disappeared, got %d", len(gone))
+	}
+
+	for _, hash := range gone {
+		if _, ok := current[hash]; ok {
+			t.Fatalf("expected %s not to have disappeared, it's in current poll", hash)
+		}
+	}
+
+	if all := newlySeen(second, nil); len(all) != 1000 {
+		t.Fatalf("expected every tx to be newly seen without previous poll, got %d", len(all))
+	}
+
+}
+
+// Node's mempool barely changes between two polls, where 90% of tx(s) are
+// same, so that only diff needs to be pushed into pool, unless it's time for
+// full resync, which is every poll, when set to 1
+func BenchmarkProcessOverlapping(b *testing.B) {
+
+	for _, n := range []int{10_000, 50_000} {
+
+		first, second := overlappingPolls(n, n*9/10)
+		polls := []map[string]map[string]*MemPoolTx{first, second}
+
+		for _, mode := range []struct {
+			name   string
+			resync string
+		}{
+			{"diff", "60"},
+			{"full", "1"},
+		} {
+
+			b.Run(mode.name+"/"+strconv.Itoa(n), func(b *testing.B) {
+
+				withConfig(b, map[string]string{"PendingPoolSize": strconv.Itoa(2 * n), "MemPoolFullResyncPolls": mode.resync})
+
+				pool := newTestPools(b)
+				ctx, cancel := context.WithCancel(context.Background())
+				b.Cleanup(cancel)
+
+				// Nobody prunes here, so disappeared tx(s) are discarded
+				go func() {
+					for {
+						select {
+						case <-ctx.Done():
+							return
+						case <-pool.Pending.candidates.ready():
+							pool.Pending.candidates.take()
+						}
+					}
+				}()
+
+				empty := make(map[string]map[string]*MemPoolTx)
+				for _, poll := range polls {
+					pool.Process(ctx, poll, empty)
+				}
+
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					pool.Process(ctx, polls[i%2], empty)
+				}
+
+			})
+
+		}
+
+	}
+
+}
@@ -0,0 +1,77 @@
+package data
+
+import "math/big"
+
+// Eviction policies, one of which is followed, when pool is full &
+// some tx needs to be dropped, for making room for new one
+const (
+	LowestGasPrice       = "lowest-gas"
+	Oldest               = "oldest"
+	OldestLowestGasPrice = "oldest-lowest-gas"
+	LargestNonceGap      = "largest-nonce-gap"
+)
+
+// SenderCap - Eviction reason of tx, dropped for making room for newer one
+// from same sender, who has reached its cap, which isn't pool wide policy
+const SenderCap = "sender-cap"
+
+// pickEvictable - Given tx(s) living in pool, ordered ascending as per gas
+// price & time they joined pool at, picks tx to be evicted, as per policy
+//
+// @note Pool must not be empty
+func pickEvictable(policy string, byGasPrice *GasPriceTree, byAge *AgeTree) *MemPoolTx {
+
+	switch policy {
+
+	case Oldest:
+
+		return byAge.oldest()
+
+	case OldestLowestGasPrice:
+
+		// All tx(s) paying same lowest gas price are placed
+		// together in front, oldest among them to be picked
+		picked := byGasPrice.at(0)
+		lowest := byGasPrice.priceOf(picked)
+
+		byGasPrice.ascend(1, func(tx *MemPoolTx) bool {
+
+			if byGasPrice.priceOf(tx).Cmp(lowest) != 0 {
+				return false
+			}
+
+			if byAge.joinedAt(tx).Before(byAge.joinedAt(picked)) {
+				picked = tx
+			}
+
+			return true
+
+		})
+
+		return picked
+
+	default:
+
+		return byGasPrice.at(0)
+
+	}
+
+}
+
+// cheapestOf - Picks tx paying lowest gas price at given base fee, from
+// given non-empty slice of tx(s)
+func cheapestOf(txs []*MemPoolTx, baseFee *big.Int) *MemPoolTx {
+
+	picked := txs[0]
+
+	for i := 1; i < len(txs); i++ {
+
+		if txs[i].EffectiveGasPrice(baseFee).Cmp(picked.EffectiveGasPrice(baseFee)) < 0 {
+			picked = txs[i]
+		}
+
+	}
+
+	return picked
+
+}
@@ -0,0 +1,140 @@
+package data
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// queuedTx - Legacy tx with given hash byte, sender, nonce & gas price in
+// gwei, queued at given time
+func queuedTx(b byte, from int, nonce uint64, gasPrice int64, queuedAt time.Time) *MemPoolTx {
+
+	tx := legacyTx(b, gasPrice)
+	tx.From = txAddress(from)
+	tx.Nonce = hexutil.Uint64(nonce)
+	tx.QueuedAt = queuedAt
+
+	return tx
+
+}
+
+// Queued pool is filled up to its capacity, then one more tx is added, which
+// must evict exactly one tx, as per configured policy
+func TestQueuedEviction(t *testing.T) {
+
+	now := time.Now().UTC().Round(0)
+
+	cases := []struct {
+		name    string
+		policy  string
+		txs     []*MemPoolTx
+		evicted byte
+	}{
+		{
+			name:    "lowest gas price",
+			policy:  LowestGasPrice,
+			txs:     []*MemPoolTx{queuedTx(1, 1, 0, 30, now.Add(-3*time.Minute)), queuedTx(2, 2, 0, 10, now.Add(-2*time.Minute)), queuedTx(3, 3, 0, 20, now.Add(-time.Minute))},
+			evicted: 2,
+		},
+		{
+			name:    "lowest gas price, tie broken by hash",
+			policy:  LowestGasPrice,
+			txs:     []*MemPoolTx{queuedTx(1, 1, 0, 30, now.Add(-time.Minute)), queuedTx(3, 2, 0, 10, now.Add(-time.Minute)), queuedTx(2, 3, 0, 10, now.Add(-time.Minute))},
+			evicted: 2,
+		},
+		{
+			name:    "oldest",
+			policy:  Oldest,
+			txs:     []*MemPoolTx{queuedTx(1, 1, 0, 50, now.Add(-time.Minute)), queuedTx(2, 2, 0, 10, now.Add(-2*time.Minute)), queuedTx(3, 3, 0, 90, now.Add(-3*time.Minute))},
+			evicted: 3,
+		},
+		{
+			name:    "oldest, tie broken by hash",
+			policy:  Oldest,
+			txs:     []*MemPoolTx{queuedTx(3, 1, 0, 50, now.Add(-3*time.Minute)), queuedTx(2, 2, 0, 10, now.Add(-3*time.Minute)), queuedTx(1, 3, 0, 90, now.Add(-time.Minute))},
+			evicted: 2,
+		},
+		{
+			name:    "oldest among lowest gas price",
+			policy:  OldestLowestGasPrice,
+			txs:     []*MemPoolTx{queuedTx(1, 1, 0, 10, now.Add(-time.Minute)), queuedTx(2, 2, 0, 10, now.Add(-2*time.Minute)), queuedTx(3, 3, 0, 20, now.Add(-3*time.Minute))},
+			evicted: 2,
+		},
+		{
+			name:    "oldest among lowest gas price, tie broken by hash",
+			policy:  OldestLowestGasPrice,
+			txs:     []*MemPoolTx{queuedTx(2, 1, 0, 10, now.Add(-2*time.Minute)), queuedTx(1, 2, 0, 10, now.Add(-2*time.Minute)), queuedTx(3, 3, 0, 20, now.Add(-3*time.Minute))},
+			evicted: 1,
+		},
+		{
+			name:    "largest nonce gap",
+			policy:  LargestNonceGap,
+			txs:     []*MemPoolTx{queuedTx(1, 1, 0, 10, now.Add(-time.Minute)), queuedTx(2, 1, 4, 50, now.Add(-time.Minute)), queuedTx(3, 2, 2, 10, now.Add(-3*time.Minute))},
+			evicted: 2,
+		},
+		{
+			name:    "largest nonce gap, tie broken by gas price",
+			policy:  LargestNonceGap,
+			txs:     []*MemPoolTx{queuedTx(1, 1, 0, 10, now.Add(-time.Minute)), queuedTx(2, 1, 2, 50, now.Add(-time.Minute)), queuedTx(3, 2, 5, 10, now.Add(-time.Minute)), queuedTx(4, 2, 7, 20, now.Add(-time.Minute))},
+			evicted: 4,
+		},
+		{
+			name:    "largest nonce gap, tie broken by hash",
+			policy:  LargestNonceGap,
+			txs:     []*MemPoolTx{queuedTx(1, 1, 0, 10, now.Add(-time.Minute)), queuedTx(3, 1, 2, 20, now.Add(-time.Minute)), queuedTx(4, 2, 5, 10, now.Add(-time.Minute)), queuedTx(2, 2, 7, 20, now.Add(-time.Minute))},
+			evicted: 2,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+
+			withConfig(t, map[string]string{"QueuedPoolSize": strconv.Itoa(len(c.txs)), "QueuedPoolEvictionPolicy": c.policy})
+
+			pool := newTestPools(t)
+
+			left := make(chan *MemPoolTx, len(c.txs))
+			pool.Queued.RegisterRemoveHook(func(tx *MemPoolTx) {
+				left <- tx
+			})
+
+			for _, tx := range c.txs {
+				if !pool.Queued.Add(context.Background(), tx) {
+					t.Fatalf("expected %s to be admitted", tx.Hash)
+				}
+			}
+
+			incoming := queuedTx(9, 9, 0, 100, now)
+			if !pool.Queued.Add(context.Background(), incoming) {
+				t.Fatal("expected tx to be admitted into full pool, evicting some other tx")
+			}
+
+			var evicted *MemPoolTx
+			select {
+			case evicted = <-left:
+			case <-time.After(time.Second):
+				t.Fatal("expected remove hook to be invoked for evicted tx")
+			}
+
+			if evicted.Hash[0] != c.evicted {
+				t.Fatalf("expected tx %d to be evicted, got %d", c.evicted, evicted.Hash[0])
+			}
+
+			if evicted.Pool != "dropped" || evicted.EvictionReason != c.policy {
+				t.Fatalf("expected evicted tx to be marked dropped, following `%s`, got pool `%s`, reason `%s`", c.policy, evicted.Pool, evicted.EvictionReason)
+			}
+
+			for _, tx := range append(c.txs, incoming) {
+				if survived := pool.Queued.Exists(tx.Hash); survived == (tx.Hash[0] == c.evicted) {
+					t.Fatalf("tx %d : expected only tx %d to be evicted", tx.Hash[0], c.evicted)
+				}
+			}
+
+		})
+	}
+
+}
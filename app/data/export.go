@@ -0,0 +1,101 @@
+package data
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Export - Dumps current content of both pools, into timestamped JSON file,
+// placed under given directory, returning path to that file
+//
+// Each pool's content is copied by its own life cycle manager, in one go,
+// while tx(s) are encoded one after another, straight into file, so that
+// whole serialized payload is never held in memory
+//
+// Meant to be used for post-mortem debugging, when node misbehaves
+func (m *MemPool) Export(dir string) (string, error) {
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	state := m.Snapshot()
+
+	file := filepath.Join(dir, fmt.Sprintf("harmony-%s.json", state.TakenAt.Format("20060102T150405.000Z")))
+
+	fd, err := os.Create(file)
+	if err != nil {
+		return "", err
+	}
+
+	if err := writeExport(fd, state); err != nil {
+
+		fd.Close()
+		os.Remove(file)
+		return "", err
+
+	}
+
+	if err := fd.Close(); err != nil {
+		return "", err
+	}
+
+	return file, nil
+
+}
+
+// writeExport - Stream encodes pool state into file, where tx(s) are
+// written one by one, instead of marshalling whole state at once
+func writeExport(fd *os.File, state *PoolState) error {
+
+	w := bufio.NewWriter(fd)
+	enc := json.NewEncoder(w)
+
+	if _, err := fmt.Fprintf(w, "{\"takenAt\":\"%s\",\"pendingCount\":%d,\"queuedCount\":%d", state.TakenAt.Format(time.RFC3339Nano), len(state.Pending), len(state.Queued)); err != nil {
+		return err
+	}
+
+	writeTxs := func(name string, txs []*MemPoolTx) error {
+
+		if _, err := fmt.Fprintf(w, ",\"%s\":[", name); err != nil {
+			return err
+		}
+
+		for i, tx := range txs {
+
+			if i != 0 {
+				if _, err := w.WriteString(","); err != nil {
+					return err
+				}
+			}
+
+			if err := enc.Encode(tx); err != nil {
+				return err
+			}
+
+		}
+
+		_, err := w.WriteString("]")
+		return err
+
+	}
+
+	if err := writeTxs("pending", state.Pending); err != nil {
+		return err
+	}
+
+	if err := writeTxs("queued", state.Queued); err != nil {
+		return err
+	}
+
+	if _, err := w.WriteString("}\n"); err != nil {
+		return err
+	}
+
+	return w.Flush()
+
+}
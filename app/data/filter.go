@@ -0,0 +1,91 @@
+package data
+
+import (
+	"sync"
+
+	"github.com/gammazero/workerpool"
+	"github.com/itzmeanjan/harmony/app/config"
+)
+
+// filterTxs - Runs predicate over given tx(s), on pool's long-lived worker
+// pool, returning those satisfying it, in same order as they're given
+//
+// Tx(s) are split into one chunk per worker, so that #-of submitted tasks
+// doesn't grow with pool size & nothing is sent over channel per tx
+//
+// When there're fewer tx(s) than configured threshold, those are
+// filtered sequentially, on caller's go routine
+func filterTxs(wp *workerpool.WorkerPool, txs []*MemPoolTx, pred func(*MemPoolTx) bool) []*MemPoolTx {
+
+	if len(txs) == 0 {
+		return nil
+	}
+
+	if uint64(len(txs)) < config.GetParallelFilterThreshold() {
+		return filterTxsSequentially(txs, pred)
+	}
+
+	keep := make([]bool, len(txs))
+
+	size := (len(txs) + config.GetConcurrencyFactor() - 1) / config.GetConcurrencyFactor()
+
+	var wg sync.WaitGroup
+
+	for start := 0; start < len(txs); start += size {
+
+		end := start + size
+		if end > len(txs) {
+			end = len(txs)
+		}
+
+		wg.Add(1)
+
+		func(start int, end int) {
+
+			wp.Submit(func() {
+
+				defer wg.Done()
+
+				for i := start; i < end; i++ {
+					keep[i] = pred(txs[i])
+				}
+
+			})
+
+		}(start, end)
+
+	}
+
+	wg.Wait()
+
+	result := make([]*MemPoolTx, 0, len(txs))
+
+	for i := 0; i < len(txs); i++ {
+
+		if keep[i] {
+			result = append(result, txs[i])
+		}
+
+	}
+
+	return result
+
+}
+
+// filterTxsSequentially - Runs predicate over given tx(s), one after another,
+// returning those satisfying it, in same order as they're given
+func filterTxsSequentially(txs []*MemPoolTx, pred func(*MemPoolTx) bool) []*MemPoolTx {
+
+	result := make([]*MemPoolTx, 0, len(txs))
+
+	for i := 0; i < len(txs); i++ {
+
+		if pred(txs[i]) {
+			result = append(result, txs[i])
+		}
+
+	}
+
+	return result
+
+}
@@ -0,0 +1,150 @@
+package data
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/gammazero/workerpool"
+	"github.com/itzmeanjan/harmony/app/config"
+)
+
+// filterSizes - #-of tx(s) being filtered, in benchmarks
+var filterSizes = []int{10, 1_000, 100_000}
+
+// expensive - Predicate which looks at each tx, same as most query filters do
+func expensive(tx *MemPoolTx) bool {
+	return tx.HasGasPriceMoreThan(250)
+}
+
+// filterTxsPerCall - How tx(s) used to be filtered, before pools shared one
+// long-lived worker pool, spinning up & tearing down workers on each call
+func filterTxsPerCall(txs []*MemPoolTx, pred func(*MemPoolTx) bool) []*MemPoolTx {
+
+	wp := workerpool.New(config.GetConcurrencyFactor())
+	keep := make([]bool, len(txs))
+
+	var wg sync.WaitGroup
+	for i := range txs {
+
+		wg.Add(1)
+
+		func(i int) {
+			wp.Submit(func() {
+				defer wg.Done()
+
+				keep[i] = pred(txs[i])
+			})
+		}(i)
+
+	}
+
+	wg.Wait()
+	wp.Stop()
+
+	result := make([]*MemPoolTx, 0, len(txs))
+	for i := range txs {
+		if keep[i] {
+			result = append(result, txs[i])
+		}
+	}
+
+	return result
+
+}
+
+func TestFilterTxsKeepsOrder(t *testing.T) {
+
+	withConfig(t, map[string]string{"ConcurrencyFactor": "1", "ParallelFilterThreshold": "1"})
+
+	wp := workerpool.New(config.GetConcurrencyFactor())
+	defer wp.Stop()
+
+	txs := makeTxs(10_000, 100)
+
+	want := filterTxsPerCall(txs, expensive)
+	got := filterTxs(wp, txs, expensive)
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %d tx(s), got %d", len(want), len(got))
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("index %d : expected %s, got %s", i, want[i].Hash, got[i].Hash)
+		}
+	}
+
+}
+
+func BenchmarkFilterWorkerPool(b *testing.B) {
+
+	withConfig(b, map[string]string{"ConcurrencyFactor": "1", "ParallelFilterThreshold": "1"})
+
+	wp := workerpool.New(config.GetConcurrencyFactor())
+	defer wp.Stop()
+
+	for _, n := range filterSizes {
+
+		txs := makeTxs(n, n)
+
+		b.Run("shared/"+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				filterTxs(wp, txs, expensive)
+			}
+		})
+
+		b.Run("per-call/"+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				filterTxsPerCall(txs, expensive)
+			}
+		})
+
+	}
+
+}
+
+// Below crossover point, handing tx(s) over to workers costs more than
+// filtering them on caller's go routine, which is what threshold is for
+func BenchmarkFilterSequential(b *testing.B) {
+
+	withConfig(b, map[string]string{"ConcurrencyFactor": "1", "ParallelFilterThreshold": "1"})
+
+	wp := workerpool.New(config.GetConcurrencyFactor())
+	defer wp.Stop()
+
+	for _, n := range filterSizes {
+
+		txs := makeTxs(n, n)
+
+		b.Run("sequential/"+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				filterTxsSequentially(txs, expensive)
+			}
+		})
+
+		b.Run("parallel/"+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				filterTxs(wp, txs, expensive)
+			}
+		})
+
+	}
+
+}
+
+func TestFilterTxsBelowThreshold(t *testing.T) {
+
+	withConfig(t, map[string]string{"ParallelFilterThreshold": "100"})
+
+	// Stopped pool can't run anything, so below threshold tx(s) must be
+	// filtered without touching it
+	wp := workerpool.New(1)
+	wp.Stop()
+
+	txs := makeTxs(99, 10)
+	if got, want := filterTxs(wp, txs, expensive), filterTxsSequentially(txs, expensive); len(got) != len(want) {
+		t.Fatalf("expected %d tx(s), got %d", len(want), len(got))
+	}
+
+}
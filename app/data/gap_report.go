@@ -0,0 +1,134 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// SenderGap - Why tx(s) of one sender are sitting in queued pool i.e. which
+// nonce is missing, keeping them from becoming executable
+type SenderGap struct {
+	Address common.Address
+	// Next nonce to be used by sender, as seen by upstream node
+	ExecutableNonce hexutil.Uint64
+	// Nonces of sender's tx(s) living in queued pool, ascending ordered
+	Queued []hexutil.Uint64
+	// Smallest nonce, at/ after executable one, not present in queued pool
+	BlockingNonce hexutil.Uint64
+	// #-of queued tx(s) which can't be picked up until blocking nonce shows up
+	Blocked uint64
+	// Whether executable nonce was found in cache
+	known bool
+}
+
+// resolve - Finds out smallest missing nonce & #-of queued
+// tx(s) blocked by it, once executable nonce is known
+func (s *SenderGap) resolve() {
+
+	next := s.ExecutableNonce
+
+	for _, nonce := range s.Queued {
+
+		if nonce < next {
+			continue
+		}
+
+		if nonce > next {
+			break
+		}
+
+		next = nonce + 1
+
+	}
+
+	s.BlockingNonce = next
+	s.Blocked = 0
+
+	for _, nonce := range s.Queued {
+		if nonce > next {
+			s.Blocked++
+		}
+	}
+
+}
+
+// gapsOf - Given per sender index of queued pool & cached account nonces,
+// prepares gap report entry for each sender, where executable nonce is
+// filled in only when it's found in cache
+//
+// @note Supposed to be invoked from pool's own life cycle manager go routine
+func gapsOf(index map[common.Address]TxList, nonces map[common.Address]hexutil.Uint64) []*SenderGap {
+
+	gaps := make([]*SenderGap, 0, len(index))
+
+	for addr, txs := range index {
+
+		if txs.len() == 0 {
+			continue
+		}
+
+		nonce, known := nonces[addr]
+
+		gaps = append(gaps, &SenderGap{
+			Address:         addr,
+			ExecutableNonce: nonce,
+			Queued:          uniqueNonces(txs.get()),
+			known:           known,
+		})
+
+	}
+
+	return gaps
+
+}
+
+// GapReport - For each sender having tx(s) in queued pool, reports which nonce
+// is missing & how many of its queued tx(s) are blocked by that, descending
+// ordered as per #-of blocked tx(s)
+//
+// Executable nonce is read from what unstuck checker has cached, only senders
+// not seen by it yet, are looked up using RPC
+func (q *QueuedPool) GapReport(ctx context.Context) ([]*SenderGap, error) {
+
+	gaps, err := request(ctx, q.StoppedChan, q.GapReportChan, func(respChan chan []*SenderGap) chan []*SenderGap {
+		return respChan
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, gap := range gaps {
+
+		if !gap.known {
+
+			nonce, err := AccountNonce(ctx, q.RPC, gap.Address)
+			if err != nil {
+				return nil, err
+			}
+
+			gap.ExecutableNonce = nonce
+			q.setSenderNonce(ctx, gap.Address, nonce)
+
+		}
+
+		gap.resolve()
+
+	}
+
+	sort.Slice(gaps, func(i, j int) bool {
+
+		if gaps[i].Blocked != gaps[j].Blocked {
+			return gaps[i].Blocked > gaps[j].Blocked
+		}
+
+		return bytes.Compare(gaps[i].Address.Bytes(), gaps[j].Address.Bytes()) < 0
+
+	})
+
+	return gaps, nil
+
+}
@@ -0,0 +1,207 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/harmony/app/upstream"
+)
+
+// Recommendation tiers, denoting within how many blocks' worth of gas
+// tx is expected to be included
+const (
+	RapidBlocks    uint64 = 1
+	FastBlocks     uint64 = 2
+	StandardBlocks uint64 = 3
+	SlowBlocks     uint64 = 5
+)
+
+// GasPriceRecommendation - Gas price ( in wei ) to be paid for getting
+// included within respective tier's #-of blocks, as seen from current
+// state of pending pool
+type GasPriceRecommendation struct {
+	Rapid    string `json:"rapid"`
+	Fast     string `json:"fast"`
+	Standard string `json:"standard"`
+	Slow     string `json:"slow"`
+	BaseFee  string `json:"baseFee,omitempty"`
+}
+
+// pricedGas - Price paid by tx & how much gas it may consume
+type pricedGas struct {
+	price *big.Int
+	gas   uint64
+}
+
+// recommendGasPrice - Given tx(s) ordered as per gas price paid & ascending
+// ordered gas budgets, walks down from highest paying tx accumulating gas,
+// for finding out price of tx which exhausts each budget
+//
+// When base fee is known, effective gas price is considered, tx(s) which
+// can't pay base fee are skipped & floor price becomes base fee. If pool
+// doesn't have enough tx(s) to exhaust budget, floor price is recommended.
+func recommendGasPrice(txs *GasPriceTree, baseFee *big.Int, budgets []uint64) []*big.Int {
+
+	var required uint64
+	if len(budgets) != 0 {
+		required = budgets[len(budgets)-1]
+	}
+
+	candidates := make([]pricedGas, 0)
+
+	// Tree is keyed on effective gas price at base fee of block it has
+	// seen last, when given base fee differs, order of effective gas
+	// price differs too, so whole pool needs to be seen
+	keyed := txs.keyedOn(baseFee)
+
+	var seen uint64
+	txs.descend(0, func(tx *MemPoolTx) bool {
+
+		price := tx.EffectiveGasPrice(baseFee)
+		if baseFee != nil && price.Cmp(baseFee) < 0 {
+			// Rest of them can't pay base fee either
+			return !keyed
+		}
+
+		candidates = append(candidates, pricedGas{price: price, gas: uint64(tx.Gas)})
+		seen += uint64(tx.Gas)
+
+		return !keyed || seen < required
+
+	})
+
+	if !keyed {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].price.Cmp(candidates[j].price) > 0
+		})
+	}
+
+	floor := big.NewInt(0)
+	if baseFee != nil {
+		floor = baseFee
+	} else if txs.len() != 0 {
+		floor = txs.priceOf(txs.at(0))
+	}
+
+	res := make([]*big.Int, len(budgets))
+
+	var used uint64
+	k := 0
+
+	for _, c := range candidates {
+
+		if k >= len(budgets) {
+			break
+		}
+
+		used += c.gas
+
+		for k < len(budgets) && used >= budgets[k] {
+			res[k] = c.price
+			k++
+		}
+
+	}
+
+	for ; k < len(budgets); k++ {
+		res[k] = new(big.Int).Set(floor)
+	}
+
+	return res
+
+}
+
+// LatestBaseFee - Fetches base fee of latest block from upstream node,
+// which is nil if chain hasn't yet activated EIP-1559
+func LatestBaseFee(ctx context.Context, rpc *rpc.Client) (*big.Int, error) {
+
+	var result struct {
+		BaseFeePerGas *hexutil.Big `json:"baseFeePerGas"`
+	}
+
+	if err := upstream.Call(ctx, rpc, &result, "eth_getBlockByNumber", "latest", false); err != nil {
+		return nil, err
+	}
+
+	if result.BaseFeePerGas == nil {
+		return nil, nil
+	}
+
+	return result.BaseFeePerGas.ToInt(), nil
+
+}
+
+// RecommendedGasPrices - Gas price to be paid for getting included within
+// each of given #-of blocks, computed over consistent snapshot of pending pool
+func (m *MemPool) RecommendedGasPrices(ctx context.Context, blocks ...uint64) ([]*big.Int, *big.Int, error) {
+
+	for i := 1; i < len(blocks); i++ {
+		if blocks[i] < blocks[i-1] {
+			return nil, nil, errors.New("#-of blocks must be ascending ordered")
+		}
+	}
+
+	baseFee, err := LatestBaseFee(ctx, m.Pending.RPC)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	budgets := make([]uint64, 0, len(blocks))
+	for _, b := range blocks {
+		budgets = append(budgets, b*config.GetBlockGasLimit())
+	}
+
+	prices, err := m.Pending.RecommendGasPriceWithContext(ctx, baseFee, budgets)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return prices, baseFee, nil
+
+}
+
+// RecommendedGasPrice - Gas price to be paid for getting included within
+// next `blocks` #-of blocks, given current state of pending pool
+func (m *MemPool) RecommendedGasPrice(ctx context.Context, blocks uint64) (*big.Int, error) {
+
+	if blocks == 0 {
+		return nil, errors.New("#-of blocks must be non-zero")
+	}
+
+	prices, _, err := m.RecommendedGasPrices(ctx, blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	return prices[0], nil
+
+}
+
+// GasPriceRecommendation - Gas price to be paid for getting included within
+// each of `rapid`, `fast`, `standard` & `slow` tier's #-of blocks
+func (m *MemPool) GasPriceRecommendation(ctx context.Context) (*GasPriceRecommendation, error) {
+
+	prices, baseFee, err := m.RecommendedGasPrices(ctx, RapidBlocks, FastBlocks, StandardBlocks, SlowBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &GasPriceRecommendation{
+		Rapid:    prices[0].String(),
+		Fast:     prices[1].String(),
+		Standard: prices[2].String(),
+		Slow:     prices[3].String(),
+	}
+
+	if baseFee != nil {
+		rec.BaseFee = baseFee.String()
+	}
+
+	return rec, nil
+
+}
@@ -0,0 +1,88 @@
+package data
+
+import (
+	"context"
+	"math/big"
+	"testing"
+)
+
+// syntheticPool - Ten tx(s), each consuming 1M gas, paying 100, 90, ..., 10 gwei
+func syntheticPool() []*MemPoolTx {
+
+	txs := make([]*MemPoolTx, 0, 10)
+	for i := 0; i < 10; i++ {
+		tx := legacyTx(byte(i+1), int64(100-i*10))
+		tx.From = txAddress(i)
+		tx.Gas = 1_000_000
+
+		txs = append(txs, tx)
+	}
+
+	return txs
+
+}
+
+// assertPrices - Fails test unless each recommended price is as many gwei
+func assertPrices(t *testing.T, got []*big.Int, want ...int64) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %d price(s), got %d", len(want), len(got))
+	}
+
+	for i := range want {
+		if got[i].Cmp(BigHexToBigDecimal(gwei(want[i]))) != 0 {
+			t.Fatalf("budget %d : expected %d gwei, got %s wei", i, want[i], got[i])
+		}
+	}
+}
+
+func TestRecommendGasPrice(t *testing.T) {
+
+	tree := NewGasPriceTree()
+	for _, tx := range syntheticPool() {
+		tree.insert(tx)
+	}
+
+	// Budget beyond what pool holds falls back to lowest price paid
+	assertPrices(t, recommendGasPrice(tree, nil, []uint64{1_000_000, 3_000_000, 5_000_000, 20_000_000}), 100, 80, 60, 10)
+	assertPrices(t, recommendGasPrice(NewGasPriceTree(), nil, []uint64{1_000_000}), 0)
+
+}
+
+// With base fee known, tx(s) are walked as per what they'd actually pay,
+// skipping ones which can't pay base fee
+func TestRecommendGasPriceWithBaseFee(t *testing.T) {
+
+	tree := NewGasPriceTree()
+	for _, tx := range syntheticPool() {
+		tree.insert(tx)
+	}
+
+	// Highest fee cap in pool, but pays only 51 gwei at base fee
+	lowTip := dynamicFeeTx(0xff, 500, 1)
+	lowTip.Gas = 1_000_000
+	tree.insert(lowTip)
+
+	baseFee := BigHexToBigDecimal(gwei(50))
+
+	// 100, 90, 80, 70, 60, 51 are able to pay base fee, rest aren't
+	assertPrices(t, recommendGasPrice(tree, baseFee, []uint64{1_000_000, 6_000_000, 7_000_000}), 100, 51, 50)
+
+}
+
+func TestPendingPoolRecommendGasPrice(t *testing.T) {
+
+	pool := newTestPools(t)
+	fillPending(t, pool, syntheticPool())
+
+	assertPrices(t, pool.Pending.RecommendGasPrice(nil, []uint64{2_000_000, 10_000_000}), 90, 10)
+
+	if !pool.Pending.Add(context.Background(), legacyTx(0xee, 95)) {
+		t.Fatal("expected tx to be admitted")
+	}
+
+	// Tx without gas limit doesn't consume any budget
+	assertPrices(t, pool.Pending.RecommendGasPrice(nil, []uint64{2_000_000}), 90)
+
+}
@@ -0,0 +1,75 @@
+package data
+
+import (
+	"math/big"
+	"time"
+)
+
+// GasPriceStats - Distribution of gas price paid by tx(s) living in pool,
+// as seen at `ComputedAt`
+//
+// @note All prices are in wei & nil when pool is empty
+type GasPriceStats struct {
+	Count      uint64
+	Min        *big.Int
+	Max        *big.Int
+	Mean       *big.Int
+	P10        *big.Int
+	P25        *big.Int
+	P50        *big.Int
+	P75        *big.Int
+	P90        *big.Int
+	P99        *big.Int
+	ComputedAt time.Time
+}
+
+// percentile - Given tx(s) ordered as per gas price paid, picks gas price
+// at `p`th percentile, using nearest rank method
+func percentile(txs *GasPriceTree, p uint64) *big.Int {
+
+	rank := (p*uint64(txs.len()) + 99) / 100
+	if rank == 0 {
+		rank = 1
+	}
+
+	return txs.priceOf(txs.at(int(rank - 1)))
+
+}
+
+// computeGasPriceStats - Given tx(s) ordered as per gas price paid,
+// computes distribution of gas price
+//
+// @note Supposed to be invoked from pool's own life cycle manager go
+// routine, so that it's computed over consistent snapshot
+func computeGasPriceStats(txs *GasPriceTree) GasPriceStats {
+
+	n := txs.len()
+
+	stats := GasPriceStats{
+		Count:      uint64(n),
+		ComputedAt: time.Now().UTC(),
+	}
+
+	if n == 0 {
+		return stats
+	}
+
+	sum := big.NewInt(0)
+	txs.ascend(0, func(tx *MemPoolTx) bool {
+		sum.Add(sum, txs.priceOf(tx))
+		return true
+	})
+
+	stats.Min = txs.priceOf(txs.at(0))
+	stats.Max = txs.priceOf(txs.at(n - 1))
+	stats.Mean = sum.Div(sum, big.NewInt(int64(n)))
+	stats.P10 = percentile(txs, 10)
+	stats.P25 = percentile(txs, 25)
+	stats.P50 = percentile(txs, 50)
+	stats.P75 = percentile(txs, 75)
+	stats.P90 = percentile(txs, 90)
+	stats.P99 = percentile(txs, 99)
+
+	return stats
+
+}
@@ -0,0 +1,643 @@
+package data
+
+import (
+	"bytes"
+	"errors"
+	"hash/maphash"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrUnknownCursor - Tx pointed to by pagination cursor is neither in pool
+// nor among recently removed ones, so position to resume from is unknown
+var ErrUnknownCursor = errors.New("unknown cursor")
+
+// recentlyRemoved - Ordering keys of these many tx(s), which most recently
+// left tree, are remembered, so that pagination cursors pointing to them
+// can still be resolved to nearest position
+const recentlyRemoved = 4096
+
+// gasPriceNode - One tx living in gas price ordered tree, along with
+// effective gas price it pays, cached so that it's not recomputed during
+// each comparison
+type gasPriceNode struct {
+	tx       *MemPoolTx
+	price    *big.Int
+	priority uint64
+	size     int
+	left     *gasPriceNode
+	right    *gasPriceNode
+}
+
+// GasPriceTree - Tx(s) living in pool, ordered as per effective gas price
+// they're paying, where ties are broken using tx hash, so that ordering
+// stays stable
+//
+// Dynamic fee tx(s) are keyed on what they'd pay at base fee of latest
+// block, so that they interleave with legacy tx(s) as they'd be picked
+// for next block. Whenever new block is seen, those are re-keyed, while
+// legacy tx(s) keep their key. Until first block is seen, fee cap is
+// considered as key
+//
+// It's a treap, where each node also keeps size of subtree rooted at it,
+// so that insertion, removal & looking up tx at some rank are all
+// logarithmic, while same structure serves both ascending & descending
+// ordered views
+//
+// @note Not concurrent safe, supposed to be used only from pool's own
+// life cycle manager go routine
+type GasPriceTree struct {
+	root *gasPriceNode
+	// Priorities are drawn from tx hash, mixed with seed of its own,
+	// so that they don't follow tie breaking order of same priced tx(s)
+	priorities maphash.Hash
+	// Gas price paid by recently removed tx(s), where oldest entry is
+	// forgotten first, once there're `recentlyRemoved` of them
+	removed      map[common.Hash]*big.Int
+	removedOrder []common.Hash
+	next         int
+	// Base fee of latest block, dynamic fee tx(s) are keyed on
+	baseFee *big.Int
+}
+
+// NewGasPriceTree - Creates empty gas price ordered tree
+func NewGasPriceTree() *GasPriceTree {
+	return &GasPriceTree{removed: make(map[common.Hash]*big.Int)}
+}
+
+// sizeOf - #-of tx(s) in subtree rooted at given node
+func sizeOf(n *gasPriceNode) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.size
+}
+
+// resize - Recomputes size of subtree, after its children are updated
+func (n *gasPriceNode) resize() {
+	n.size = 1 + sizeOf(n.left) + sizeOf(n.right)
+}
+
+// less - Checks whether this node is to be placed before tx paying
+// given price, with given hash
+func (n *gasPriceNode) less(price *big.Int, hash []byte) bool {
+
+	if c := n.price.Cmp(price); c != 0 {
+		return c < 0
+	}
+
+	return bytes.Compare(n.tx.Hash.Bytes(), hash) < 0
+
+}
+
+// split - Splits subtree into two, where left one holds all tx(s) ordered
+// before given key & right one holds rest
+func split(n *gasPriceNode, price *big.Int, hash []byte) (*gasPriceNode, *gasPriceNode) {
+
+	if n == nil {
+		return nil, nil
+	}
+
+	if n.less(price, hash) {
+
+		l, r := split(n.right, price, hash)
+		n.right = l
+		n.resize()
+
+		return n, r
+
+	}
+
+	l, r := split(n.left, price, hash)
+	n.left = r
+	n.resize()
+
+	return l, n
+
+}
+
+// merge - Merges two subtrees, where all tx(s) of left one are
+// ordered before all tx(s) of right one
+func merge(l *gasPriceNode, r *gasPriceNode) *gasPriceNode {
+
+	if l == nil {
+		return r
+	}
+
+	if r == nil {
+		return l
+	}
+
+	if l.priority > r.priority {
+
+		l.right = merge(l.right, r)
+		l.resize()
+
+		return l
+
+	}
+
+	r.left = merge(l, r.left)
+	r.resize()
+
+	return r
+
+}
+
+// len - #-of tx(s) present in tree
+func (g *GasPriceTree) len() int {
+	return sizeOf(g.root)
+}
+
+// insert - Puts tx in tree, keeping it ordered
+//
+// Priority of node must not be correlated with its key. Tx(s) paying same
+// gas price are ordered by hash, so taking priority straight from hash
+// would turn each price level into a linked list, when gas price is flat.
+// Hash is rather mixed with randomly seeded one, keeping tree balanced in
+// expectation
+func (g *GasPriceTree) insert(tx *MemPoolTx) {
+
+	node := &gasPriceNode{
+		tx:       tx,
+		price:    g.priceOf(tx),
+		priority: g.priorityOf(tx),
+		size:     1,
+	}
+
+	l, r := split(g.root, node.price, tx.Hash.Bytes())
+	g.root = merge(merge(l, node), r)
+
+}
+
+// priceOf - Effective gas price paid by tx, which it's keyed on, at base fee
+// tree is keyed at
+func (g *GasPriceTree) priceOf(tx *MemPoolTx) *big.Int {
+
+	return tx.EffectiveGasPrice(g.baseFee)
+
+}
+
+// keyedOn - Whether tx(s) are keyed on effective gas price at given base fee
+func (g *GasPriceTree) keyedOn(baseFee *big.Int) bool {
+
+	if g.baseFee == nil || baseFee == nil {
+		return g.baseFee == nil && baseFee == nil
+	}
+
+	return g.baseFee.Cmp(baseFee) == 0
+
+}
+
+// rekey - Re-keys dynamic fee tx(s) on what they'd pay at given base fee,
+// while legacy tx(s) keep their key & relative order
+//
+// Re-keyed tx(s) are sorted on their own & merged with legacy ones, after
+// which tree is rebuilt from sorted nodes in linear time, keeping their
+// priorities, so that it's balanced as before
+func (g *GasPriceTree) rekey(baseFee *big.Int) {
+
+	if baseFee == nil || g.keyedOn(baseFee) {
+		return
+	}
+
+	g.baseFee = new(big.Int).Set(baseFee)
+
+	fixed := make([]*gasPriceNode, 0, g.len())
+	moving := make([]*gasPriceNode, 0)
+
+	walkNodes(g.root, func(n *gasPriceNode) {
+
+		if !n.tx.IsDynamicFee() {
+			fixed = append(fixed, n)
+			return
+		}
+
+		n.price = g.priceOf(n.tx)
+		moving = append(moving, n)
+
+	})
+
+	sort.Slice(moving, func(i, j int) bool {
+		return moving[i].less(moving[j].price, moving[j].tx.Hash.Bytes())
+	})
+
+	sorted := make([]*gasPriceNode, 0, len(fixed)+len(moving))
+	i, j := 0, 0
+
+	for i < len(fixed) && j < len(moving) {
+
+		if fixed[i].less(moving[j].price, moving[j].tx.Hash.Bytes()) {
+			sorted = append(sorted, fixed[i])
+			i++
+			continue
+		}
+
+		sorted = append(sorted, moving[j])
+		j++
+
+	}
+
+	sorted = append(sorted, fixed[i:]...)
+	sorted = append(sorted, moving[j:]...)
+
+	g.root = buildFromSorted(sorted)
+
+}
+
+// walkNodes - Visits all nodes of subtree, in order
+func walkNodes(n *gasPriceNode, visit func(*gasPriceNode)) {
+
+	if n == nil {
+		return
+	}
+
+	walkNodes(n.left, visit)
+	visit(n)
+	walkNodes(n.right, visit)
+
+}
+
+// buildFromSorted - Builds treap out of nodes, already ordered as per their
+// key, where node with higher priority is placed above, using stack holding
+// right spine of tree built so far
+func buildFromSorted(nodes []*gasPriceNode) *gasPriceNode {
+
+	spine := make([]*gasPriceNode, 0)
+
+	for _, n := range nodes {
+
+		n.left, n.right = nil, nil
+
+		var last *gasPriceNode
+		for len(spine) != 0 && spine[len(spine)-1].priority < n.priority {
+			last = spine[len(spine)-1]
+			spine = spine[:len(spine)-1]
+		}
+
+		n.left = last
+		if len(spine) != 0 {
+			spine[len(spine)-1].right = n
+		}
+
+		spine = append(spine, n)
+
+	}
+
+	if len(spine) == 0 {
+		return nil
+	}
+
+	resizeAll(spine[0])
+	return spine[0]
+
+}
+
+// resizeAll - Recomputes size of each subtree, children first
+func resizeAll(n *gasPriceNode) {
+
+	if n == nil {
+		return
+	}
+
+	resizeAll(n.left)
+	resizeAll(n.right)
+	n.resize()
+
+}
+
+// priorityOf - Heap priority of node holding given tx
+func (g *GasPriceTree) priorityOf(tx *MemPoolTx) uint64 {
+
+	g.priorities.Reset()
+	g.priorities.Write(tx.Hash[:])
+
+	return g.priorities.Sum64()
+
+}
+
+// remove - Removes tx from tree, returning whether it was present
+func (g *GasPriceTree) remove(tx *MemPoolTx) bool {
+
+	price := g.priceOf(tx)
+
+	var removed bool
+	g.root = removeNode(g.root, price, tx.Hash.Bytes(), &removed)
+
+	if removed {
+		g.remember(tx.Hash, price)
+	}
+
+	return removed
+
+}
+
+// remember - Keeps gas price paid by tx, which just left tree, forgetting
+// oldest remembered one, when there's no more room
+func (g *GasPriceTree) remember(hash common.Hash, price *big.Int) {
+
+	if g.removed == nil {
+		g.removed = make(map[common.Hash]*big.Int)
+	}
+
+	if _, ok := g.removed[hash]; ok {
+		g.removed[hash] = price
+		return
+	}
+
+	if len(g.removedOrder) < recentlyRemoved {
+
+		g.removedOrder = append(g.removedOrder, hash)
+		g.removed[hash] = price
+		return
+
+	}
+
+	delete(g.removed, g.removedOrder[g.next])
+
+	g.removedOrder[g.next] = hash
+	g.removed[hash] = price
+	g.next = (g.next + 1) % recentlyRemoved
+
+}
+
+// removeNode - Finds node holding tx with given key in subtree & replaces it
+// with merged children, while updating sizes along the path
+func removeNode(n *gasPriceNode, price *big.Int, hash []byte, removed *bool) *gasPriceNode {
+
+	if n == nil {
+		return nil
+	}
+
+	if n.price.Cmp(price) == 0 && bytes.Equal(n.tx.Hash.Bytes(), hash) {
+
+		*removed = true
+		return merge(n.left, n.right)
+
+	}
+
+	if n.less(price, hash) {
+		n.right = removeNode(n.right, price, hash, removed)
+	} else {
+		n.left = removeNode(n.left, price, hash, removed)
+	}
+
+	n.resize()
+	return n
+
+}
+
+// at - Returns tx at given rank, in ascending order of gas price paid
+func (g *GasPriceTree) at(rank int) *MemPoolTx {
+
+	n := g.root
+
+	for n != nil {
+
+		l := sizeOf(n.left)
+
+		switch {
+
+		case rank < l:
+			n = n.left
+		case rank == l:
+			return n.tx
+		default:
+			rank -= l + 1
+			n = n.right
+
+		}
+
+	}
+
+	return nil
+
+}
+
+// countBelow - #-of tx(s) paying gas price lower than given price, or
+// lower than/ equal to it, when inclusive
+func (g *GasPriceTree) countBelow(price *big.Int, inclusive bool) int {
+
+	count := 0
+	n := g.root
+
+	for n != nil {
+
+		c := n.price.Cmp(price)
+
+		if c < 0 || (inclusive && c == 0) {
+			count += sizeOf(n.left) + 1
+			n = n.right
+			continue
+		}
+
+		n = n.left
+
+	}
+
+	return count
+
+}
+
+// countKeysBelow - #-of tx(s) ordered before given key, or before/ at it,
+// when inclusive
+func (g *GasPriceTree) countKeysBelow(price *big.Int, hash []byte, inclusive bool) int {
+
+	count := 0
+	n := g.root
+
+	for n != nil {
+
+		if n.less(price, hash) || (inclusive && n.price.Cmp(price) == 0 && bytes.Equal(n.tx.Hash.Bytes(), hash)) {
+			count += sizeOf(n.left) + 1
+			n = n.right
+			continue
+		}
+
+		n = n.left
+
+	}
+
+	return count
+
+}
+
+// offsetAfter - Position right after tx with given hash, in requested order,
+// where `live` is that tx, if it's still in pool. Otherwise gas price it was
+// paying is looked up among recently removed tx(s), so that position it'd
+// have been at is returned
+func (g *GasPriceTree) offsetAfter(order int, hash common.Hash, live *MemPoolTx) (uint64, error) {
+
+	var price *big.Int
+
+	if live != nil {
+		price = g.priceOf(live)
+	} else if remembered, ok := g.removed[hash]; ok {
+		price = remembered
+	} else {
+		return 0, ErrUnknownCursor
+	}
+
+	if order == DESC {
+		return uint64(g.len() - g.countKeysBelow(price, hash.Bytes(), false)), nil
+	}
+
+	return uint64(g.countKeysBelow(price, hash.Bytes(), true)), nil
+
+}
+
+// page - Answers listing request, resolving cursor to offset first, if any,
+// where `live` holds all tx(s) of pool, keyed by hash
+func (g *GasPriceTree) page(req ListRequest, live map[common.Hash]*MemPoolTx) TxPage {
+
+	offset := req.Offset
+
+	if req.After != nil {
+
+		var err error
+
+		offset, err = g.offsetAfter(req.Order, *req.After, live[*req.After])
+		if err != nil {
+			return TxPage{Total: uint64(g.len()), err: err}
+		}
+
+	}
+
+	return TxPage{
+		Txs:    CloneAll(g.collect(req.Order, offset, req.Limit)),
+		Total:  uint64(g.len()),
+		Offset: offset,
+	}
+
+}
+
+// ascend - Visits tx(s) in ascending order of gas price paid, starting at
+// given rank, until visitor asks to stop
+func (g *GasPriceTree) ascend(from int, visit func(*MemPoolTx) bool) {
+	ascendNode(g.root, from, visit)
+}
+
+// ascendNode - In-order traversal of subtree, skipping first `from` tx(s),
+// returns false when visitor asked to stop
+func ascendNode(n *gasPriceNode, from int, visit func(*MemPoolTx) bool) bool {
+
+	if n == nil {
+		return true
+	}
+
+	l := sizeOf(n.left)
+
+	if from < l {
+		if !ascendNode(n.left, from, visit) {
+			return false
+		}
+	}
+
+	if from <= l {
+		if !visit(n.tx) {
+			return false
+		}
+	}
+
+	skip := from - l - 1
+	if skip < 0 {
+		skip = 0
+	}
+
+	return ascendNode(n.right, skip, visit)
+
+}
+
+// descend - Visits tx(s) in descending order of gas price paid, starting
+// at given rank ( counted from highest ), until visitor asks to stop
+func (g *GasPriceTree) descend(from int, visit func(*MemPoolTx) bool) {
+	descendNode(g.root, from, visit)
+}
+
+// descendNode - Reverse in-order traversal of subtree, skipping first `from`
+// tx(s), returns false when visitor asked to stop
+func descendNode(n *gasPriceNode, from int, visit func(*MemPoolTx) bool) bool {
+
+	if n == nil {
+		return true
+	}
+
+	r := sizeOf(n.right)
+
+	if from < r {
+		if !descendNode(n.right, from, visit) {
+			return false
+		}
+	}
+
+	if from <= r {
+		if !visit(n.tx) {
+			return false
+		}
+	}
+
+	skip := from - r - 1
+	if skip < 0 {
+		skip = 0
+	}
+
+	return descendNode(n.left, skip, visit)
+
+}
+
+// collect - Copies window of at max `limit` tx(s), starting at `offset`, in
+// requested order, where zero `limit` denotes all remaining tx(s)
+//
+// Only visited portion of tree is touched, so cost is proportional to
+// window size, not to pool size
+func (g *GasPriceTree) collect(order int, offset uint64, limit uint64) []*MemPoolTx {
+
+	n := uint64(g.len())
+	if offset >= n {
+		return []*MemPoolTx{}
+	}
+
+	if limit == 0 || offset+limit > n {
+		limit = n - offset
+	}
+
+	txs := make([]*MemPoolTx, 0, limit)
+	visit := func(tx *MemPoolTx) bool {
+		txs = append(txs, tx)
+		return uint64(len(txs)) < limit
+	}
+
+	if order == DESC {
+		g.descend(int(offset), visit)
+	} else {
+		g.ascend(int(offset), visit)
+	}
+
+	return txs
+
+}
+
+// between - Returns tx(s) paying gas price within [low, high], ascending
+// ordered, where absent bound denotes open-ended range
+func (g *GasPriceTree) between(low *big.Int, high *big.Int) []*MemPoolTx {
+
+	start := 0
+	if low != nil {
+		start = g.countBelow(low, false)
+	}
+
+	end := g.len()
+	if high != nil {
+		end = g.countBelow(high, true)
+	}
+
+	if start >= end {
+		return []*MemPoolTx{}
+	}
+
+	return g.collect(ASC, uint64(start), uint64(end-start))
+
+}
@@ -0,0 +1,395 @@
+package data
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// gwei - Given #-of gwei as hex encoded big number
+func gwei(n int64) *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(0).Mul(big.NewInt(n), big.NewInt(1_000_000_000)))
+}
+
+// legacyTx - Legacy tx with given hash byte & gas price in gwei
+func legacyTx(b byte, gasPrice int64) *MemPoolTx {
+	return &MemPoolTx{Hash: common.Hash{b}, GasPrice: gwei(gasPrice)}
+}
+
+// dynamicFeeTx - EIP-1559 tx with given hash byte, fee cap & tip cap in gwei
+func dynamicFeeTx(b byte, feeCap int64, tip int64) *MemPoolTx {
+	return &MemPoolTx{
+		Hash:                 common.Hash{b},
+		Type:                 2,
+		GasPrice:             gwei(feeCap),
+		MaxFeePerGas:         gwei(feeCap),
+		MaxPriorityFeePerGas: gwei(tip),
+	}
+}
+
+func TestEffectiveGasPrice(t *testing.T) {
+
+	baseFee := BigHexToBigDecimal(gwei(50))
+
+	cases := []struct {
+		name    string
+		tx      *MemPoolTx
+		baseFee *big.Int
+		want    *hexutil.Big
+	}{
+		{"legacy without base fee", legacyTx(1, 60), nil, gwei(60)},
+		{"legacy with base fee", legacyTx(1, 60), baseFee, gwei(60)},
+		{"legacy without gas price", &MemPoolTx{}, baseFee, gwei(0)},
+		{"dynamic without base fee", dynamicFeeTx(1, 200, 1), nil, gwei(200)},
+		{"dynamic pays tip", dynamicFeeTx(1, 200, 1), baseFee, gwei(51)},
+		{"dynamic capped by fee cap", dynamicFeeTx(1, 52, 10), baseFee, gwei(52)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+
+			if got := c.tx.EffectiveGasPrice(c.baseFee); got.Cmp(BigHexToBigDecimal(c.want)) != 0 {
+				t.Fatalf("expected %s, got %s", BigHexToBigDecimal(c.want), got)
+			}
+
+		})
+	}
+
+}
+
+// Sorted views key dynamic fee tx(s) on their fee cap only until some base
+// fee is seen, after which tx with high fee cap & low tip moves below legacy
+// tx which pays more at that base fee
+func TestGasPriceTreeKeyedOnBaseFee(t *testing.T) {
+
+	baseFee := BigHexToBigDecimal(gwei(50))
+
+	lowTip := dynamicFeeTx(1, 200, 1)
+	legacy := legacyTx(2, 60)
+	cheap := legacyTx(3, 10)
+
+	tree := NewGasPriceTree()
+	for _, tx := range []*MemPoolTx{legacy, lowTip, cheap} {
+		tree.insert(tx)
+	}
+
+	assertOrder := func(want ...*MemPoolTx) {
+		t.Helper()
+
+		got := tree.collect(DESC, 0, 0)
+		if len(got) != len(want) {
+			t.Fatalf("expected %d tx(s), got %d", len(want), len(got))
+		}
+
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("rank %d : expected %s, got %s", i, want[i].Hash, got[i].Hash)
+			}
+		}
+	}
+
+	assertOrder(lowTip, legacy, cheap)
+
+	tree.rekey(baseFee)
+	assertOrder(legacy, lowTip, cheap)
+
+	if between := tree.between(BigHexToBigDecimal(gwei(100)), nil); len(between) != 0 {
+		t.Fatalf("expected no tx within [100, ∞) gwei, got %d tx(s)", len(between))
+	}
+
+	if between := tree.between(BigHexToBigDecimal(gwei(51)), BigHexToBigDecimal(gwei(51))); len(between) != 1 || between[0] != lowTip {
+		t.Fatalf("expected only dynamic fee tx within [51, 51] gwei, got %d tx(s)", len(between))
+	}
+
+	if !tree.remove(lowTip) || tree.len() != 2 {
+		t.Fatal("expected re-keyed tx to be removed")
+	}
+
+	assertOrder(legacy, cheap)
+
+}
+
+// Legacy & dynamic fee tx(s) interleave differently at each base fee, while
+// tree keeps agreeing with sorting, its sizes stay right & tx(s) can still be
+// removed after being re-keyed
+func TestGasPriceTreeRekeyMatchesSort(t *testing.T) {
+
+	rng := rand.New(rand.NewSource(1))
+
+	txs := makeTxs(2000, 200)
+	for i, tx := range txs {
+
+		if i%2 == 0 {
+			continue
+		}
+
+		tx.Type = 2
+		tx.MaxFeePerGas = gwei(1 + rng.Int63n(500))
+		tx.MaxPriorityFeePerGas = gwei(1 + rng.Int63n(20))
+		tx.GasPrice = tx.MaxFeePerGas
+
+	}
+
+	tree := NewGasPriceTree()
+	for _, tx := range txs {
+		tree.insert(tx)
+	}
+
+	var checkSizes func(n *gasPriceNode) int
+	checkSizes = func(n *gasPriceNode) int {
+
+		if n == nil {
+			return 0
+		}
+
+		size := checkSizes(n.left) + checkSizes(n.right) + 1
+		if n.size != size {
+			t.Fatalf("expected subtree size %d, got %d", size, n.size)
+		}
+
+		if n.left != nil && n.left.priority > n.priority || n.right != nil && n.right.priority > n.priority {
+			t.Fatal("expected parent to have higher priority than its children")
+		}
+
+		return size
+
+	}
+
+	for _, fee := range []int64{100, 20, 300, 20, 0} {
+
+		baseFee := BigHexToBigDecimal(gwei(fee))
+		tree.rekey(baseFee)
+
+		if !tree.keyedOn(baseFee) {
+			t.Fatalf("expected tree to be keyed on %s", baseFee)
+		}
+
+		sorted := make([]*MemPoolTx, len(txs))
+		copy(sorted, txs)
+		sort.Slice(sorted, func(i, j int) bool {
+
+			if c := sorted[i].EffectiveGasPrice(baseFee).Cmp(sorted[j].EffectiveGasPrice(baseFee)); c != 0 {
+				return c < 0
+			}
+
+			return bytes.Compare(sorted[i].Hash.Bytes(), sorted[j].Hash.Bytes()) < 0
+
+		})
+
+		got := tree.collect(ASC, 0, 0)
+		if len(got) != len(sorted) || checkSizes(tree.root) != len(sorted) {
+			t.Fatalf("base fee %d : expected %d tx(s), got %d", fee, len(sorted), len(got))
+		}
+
+		for i := range sorted {
+			if got[i] != sorted[i] {
+				t.Fatalf("base fee %d, rank %d : expected %s, got %s", fee, i, sorted[i].Hash, got[i].Hash)
+			}
+		}
+
+		for _, tx := range txs[:100] {
+			if !tree.remove(tx) {
+				t.Fatalf("base fee %d : expected %s to be removed", fee, tx.Hash)
+			}
+		}
+
+		txs = txs[100:]
+
+	}
+
+}
+
+// depthOf - Length of longest path from given node to some leaf
+func depthOf(n *gasPriceNode) int {
+
+	if n == nil {
+		return 0
+	}
+
+	l, r := depthOf(n.left), depthOf(n.right)
+	if l > r {
+		return l + 1
+	}
+
+	return r + 1
+
+}
+
+// flatTxs - `n` distinct legacy tx(s), all paying same gas price, as seen
+// during periods when gas price is flat
+func flatTxs(n int) []*MemPoolTx {
+
+	txs := makeTxs(n, n/10+1)
+	for _, tx := range txs {
+		tx.GasPrice = gwei(100)
+	}
+
+	return txs
+
+}
+
+// Tx(s) paying same gas price are ordered by hash, which must not also
+// decide heap priority, otherwise tree degenerates into linked list
+func TestGasPriceTreeDepthFlatPrice(t *testing.T) {
+
+	const n = 20_000
+
+	tree := NewGasPriceTree()
+	for _, tx := range flatTxs(n) {
+		tree.insert(tx)
+	}
+
+	if tree.len() != n {
+		t.Fatalf("expected %d tx(s) in tree, got %d", n, tree.len())
+	}
+
+	// Expected depth of treap is ~3 * log2(n), i.e. ~43 for 20k nodes
+	if depth := depthOf(tree.root); depth > 100 {
+		t.Fatalf("expected logarithmic depth for %d same priced tx(s), got %d", n, depth)
+	}
+
+	got := tree.collect(ASC, 0, 0)
+	for i := 1; i < len(got); i++ {
+		if bytes.Compare(got[i-1].Hash.Bytes(), got[i].Hash.Bytes()) >= 0 {
+			t.Fatalf("rank %d : expected same priced tx(s) to be ordered by hash", i)
+		}
+	}
+
+}
+
+// benchSizes - Pool sizes tree is compared against sorted slice at
+var benchSizes = []int{10_000, 100_000, 500_000}
+
+// filledTree - Tree & descending sorted slice holding same `n` tx(s)
+func filledTree(n int) (*GasPriceTree, MemPoolTxsDesc, []*MemPoolTx) {
+
+	txs := makeTxs(n, n/10)
+
+	tree := NewGasPriceTree()
+	for _, tx := range txs {
+		tree.insert(tx)
+	}
+
+	sorted := make(MemPoolTxsDesc, len(txs), len(txs)+1)
+	copy(sorted, txs)
+	SortByGasPriceDesc(sorted)
+
+	return tree, sorted, txs
+
+}
+
+func TestGasPriceTreeMatchesSort(t *testing.T) {
+
+	tree, sorted, txs := filledTree(5000)
+
+	got := tree.collect(DESC, 0, 0)
+	for i := range sorted {
+		if got[i].EffectiveGasPrice(nil).Cmp(sorted[i].EffectiveGasPrice(nil)) != 0 {
+			t.Fatalf("rank %d : expected gas price %s, got %s", i, sorted[i].EffectiveGasPrice(nil), got[i].EffectiveGasPrice(nil))
+		}
+	}
+
+	for _, tx := range txs[:2500] {
+		if !tree.remove(tx) {
+			t.Fatalf("expected %s to be removed", tx.Hash)
+		}
+	}
+
+	if tree.len() != 2500 || tree.remove(txs[0]) {
+		t.Fatalf("expected 2500 tx(s) to be left, got %d", tree.len())
+	}
+
+}
+
+// Re-inserting tx right after removing it keeps pool size steady
+func BenchmarkGasPriceInsertRemove(b *testing.B) {
+
+	for _, n := range benchSizes {
+
+		tree, sorted, txs := filledTree(n)
+
+		b.Run("tree/"+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tx := txs[i%n]
+
+				tree.remove(tx)
+				tree.insert(tx)
+			}
+		})
+
+		var list TxList = sorted
+		b.Run("slice/"+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tx := txs[i%n]
+
+				list = Remove(list, tx)
+				list = Insert(list, tx)
+			}
+		})
+
+	}
+
+}
+
+// Window of 100 tx(s) from middle of pool, where sorted slice used to be
+// built by sorting whole pool for each listing
+func BenchmarkGasPricePage(b *testing.B) {
+
+	for _, n := range benchSizes {
+
+		tree, _, txs := filledTree(n)
+		offset := uint64(n / 2)
+
+		b.Run("tree/"+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tree.collect(DESC, offset, 100)
+			}
+		})
+
+		b.Run("sort/"+strconv.Itoa(n), func(b *testing.B) {
+			buffer := make([]*MemPoolTx, n)
+
+			for i := 0; i < b.N; i++ {
+				copy(buffer, txs)
+				sort.SliceStable(buffer, func(i, j int) bool {
+					return buffer[i].EffectiveGasPrice(nil).Cmp(buffer[j].EffectiveGasPrice(nil)) > 0
+				})
+
+				_ = buffer[offset : offset+100]
+			}
+		})
+
+	}
+
+}
+
+// Every tx pays same gas price, which used to make tree as deep as it's large
+func BenchmarkGasPriceInsertRemoveFlat(b *testing.B) {
+
+	for _, n := range benchSizes {
+
+		txs := flatTxs(n)
+
+		tree := NewGasPriceTree()
+		for _, tx := range txs {
+			tree.insert(tx)
+		}
+
+		b.Run("tree/"+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tx := txs[i%n]
+
+				tree.remove(tx)
+				tree.insert(tx)
+			}
+		})
+
+	}
+
+}
@@ -0,0 +1,89 @@
+package data
+
+import (
+	"log"
+	"sync"
+)
+
+// Hook - Invoked with copy of tx, when it joins/ leaves pool, so that
+// services embedding pool can react to tx life cycle events in-process
+type Hook func(*MemPoolTx)
+
+// hookSet - Hooks registered on pool, to be invoked after tx gets
+// added to/ removed from pool
+//
+// @note Registration can happen from any go routine, while hooks
+// are invoked from pool's own life cycle manager go routine
+type hookSet struct {
+	lock     sync.RWMutex
+	onAdd    []Hook
+	onRemove []Hook
+}
+
+// registerAdd - Appends hook to be invoked after tx gets added
+func (h *hookSet) registerAdd(hook Hook) {
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.onAdd = append(h.onAdd, hook)
+
+}
+
+// registerRemove - Appends hook to be invoked after tx gets removed
+func (h *hookSet) registerRemove(hook Hook) {
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.onRemove = append(h.onRemove, hook)
+
+}
+
+// added - Invokes all hooks registered for tx being added
+func (h *hookSet) added(tx *MemPoolTx) {
+
+	h.lock.RLock()
+	hooks := h.onAdd
+	h.lock.RUnlock()
+
+	invokeHooks(hooks, tx)
+
+}
+
+// removed - Invokes all hooks registered for tx being removed
+func (h *hookSet) removed(tx *MemPoolTx) {
+
+	h.lock.RLock()
+	hooks := h.onRemove
+	h.lock.RUnlock()
+
+	invokeHooks(hooks, tx)
+
+}
+
+// invokeHooks - Invokes each hook, in order of registration, with its own
+// copy of tx, so that no hook can mutate pool state or affect others
+func invokeHooks(hooks []Hook, tx *MemPoolTx) {
+
+	for _, hook := range hooks {
+		invokeHook(hook, tx.Clone())
+	}
+
+}
+
+// invokeHook - Invokes hook, recovering from panic, if any, so that
+// misbehaving hook can't take pool down
+func invokeHook(hook Hook, tx *MemPoolTx) {
+
+	defer func() {
+
+		if r := recover(); r != nil {
+			log.Printf("[❗️] Recovered from panic in pool hook : %v | %s\n", r, tx.Hash.Hex())
+		}
+
+	}()
+
+	hook(tx)
+
+}
@@ -1,9 +1,12 @@
 package data
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/itzmeanjan/harmony/app/listen"
 )
 
 // Sorting direction representation
@@ -26,6 +29,13 @@ type AddRequest struct {
 	ResponseChan chan bool
 }
 
+// AddBatchRequest - Adding multiple tx(s) into pool, in one go, where
+// response denotes how many of them were actually added
+type AddBatchRequest struct {
+	Txs          []*MemPoolTx
+	ResponseChan chan uint64
+}
+
 // RemoveRequest - For removing existing tx into pool
 type RemoveRequest struct {
 	TxStat       *TxStatus
@@ -34,8 +44,12 @@ type RemoveRequest struct {
 
 // RemovedUnstuckTx - Remove unstuck tx from queued pool, request to be
 // sent in this form
+//
+// When status is `MANUALLY_EVICTED`, tx is evicted on operator's request,
+// otherwise it's considered to be unstuck
 type RemovedUnstuckTx struct {
 	Hash         common.Hash
+	Status       int
 	ResponseChan chan *MemPoolTx
 }
 
@@ -67,15 +81,116 @@ type GetRequest struct {
 	ResponseChan chan *MemPoolTx
 }
 
+// FindTxsRequest - Looking up which of given tx(s) are present in pool,
+// where copies of found ones are sent back, keyed by their hash
+type FindTxsRequest struct {
+	Txs          []common.Hash
+	ResponseChan chan map[common.Hash]*MemPoolTx
+}
+
+// DuplicateTxsRequest - Finding tx(s) with same sender address & nonce
+// as of given one
+type DuplicateTxsRequest struct {
+	Tx           common.Hash
+	ResponseChan chan []*MemPoolTx
+}
+
+// GasPriceRangeRequest - Listing tx(s) paying gas price within given
+// range, where absent bound denotes open-ended range
+type GasPriceRangeRequest struct {
+	Low          *big.Int
+	High         *big.Int
+	ResponseChan chan []*MemPoolTx
+}
+
+// GasPriceRecommendRequest - Asking for gas price to be paid for getting
+// included within each of given gas budgets, considering given base fee
+type GasPriceRecommendRequest struct {
+	BaseFee      *big.Int
+	Budgets      []uint64
+	ResponseChan chan []*big.Int
+}
+
+// StuckRequest - Flagging pending tx(s), which can't pay given base fee
+// & have been pending for at least `After` duration, where response holds
+// those flagged for very first time
+type StuckRequest struct {
+	BaseFee      *big.Int
+	After        time.Duration
+	ResponseChan chan []*MemPoolTx
+}
+
+// SenderNonce - Account nonce of sender, as of latest block
+type SenderNonce struct {
+	From  common.Address
+	Nonce hexutil.Uint64
+}
+
+// AgeWalkRequest - Copying tx(s) living in pool for more/ less than
+// or equals to `Age`, at max `Limit` of them, unless it's 0, which are
+// then visited by caller
+type AgeWalkRequest struct {
+	Age          time.Duration
+	Older        bool
+	Limit        uint64
+	ResponseChan chan []*MemPoolTx
+}
+
+// PruneSetRequest - Finding out which pending tx(s) are to be pruned,
+// after seeing `Txs` mined in some block
+type PruneSetRequest struct {
+	Txs          listen.CaughtTxs
+	ResponseChan chan PruneSet
+}
+
+// SameNonceRequest - Finding tx(s) with same sender address & nonce as
+// given tx, which may be living in other pool, except that tx itself
+type SameNonceRequest struct {
+	Tx           *MemPoolTx
+	ResponseChan chan []*MemPoolTx
+}
+
+// CountFromRequest - Getting #-of txs sent by address `A`, present in pool
+type CountFromRequest struct {
+	From         common.Address
+	ResponseChan chan uint64
+}
+
+// TopSendersRequest - Getting at max `N` senders, having most
+// txs present in pool
+type TopSendersRequest struct {
+	N            uint64
+	ResponseChan chan []SenderCount
+}
+
 // CountRequest - Getting #-of txs present in pool
 type CountRequest struct {
 	ResponseChan chan uint64
 }
 
-// ListRequest - Listing all txs in pool
+// ListRequest - Listing txs in pool, in requested order
+//
+// Only window of `Limit` tx(s), starting at `Offset`, is copied
+// & sent back. Zero `Limit` denotes all tx(s) from `Offset`.
+//
+// When `After` is set, window starts right after that tx, in requested
+// order, ignoring `Offset`
 type ListRequest struct {
 	Order        int
-	ResponseChan chan []*MemPoolTx
+	Offset       uint64
+	Limit        uint64
+	After        *common.Hash
+	ResponseChan chan TxPage
+}
+
+// TxPage - Window of tx(s) from pool, along with total #-of tx(s)
+// present in pool & where window starts, so that clients can paginate
+// deterministically
+type TxPage struct {
+	Txs    []*MemPoolTx
+	Total  uint64
+	Offset uint64
+	err    error
 }
 
 // TxsFromARequest - When requesting for txs living in pool
@@ -0,0 +1,150 @@
+package data
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// LatencySample - How long one tx, paying `GasPrice`, waited in pending
+// pool before getting confirmed
+type LatencySample struct {
+	Hash       common.Hash
+	GasPrice   *big.Int
+	PendingFor time.Duration
+}
+
+// LatencyBucket - Distribution of pending duration of confirmed tx(s),
+// paying gas price within [`MinGasPrice`, `MaxGasPrice`]
+type LatencyBucket struct {
+	Decile      uint64
+	MinGasPrice *big.Int
+	MaxGasPrice *big.Int
+	Count       uint64
+	P50         time.Duration
+	P90         time.Duration
+	P99         time.Duration
+}
+
+// ConfirmationLatencyStats - Pending duration distribution of recently
+// confirmed tx(s), bucketed by gas price decile, in ascending order
+type ConfirmationLatencyStats struct {
+	Samples    uint64
+	Buckets    []LatencyBucket
+	ComputedAt time.Time
+}
+
+// latencyRing - Bounded ring buffer, keeping only last `N` samples,
+// where oldest one gets overwritten when it's full
+//
+// @note Not concurrent safe, supposed to be used only from pool's own
+// life cycle manager go routine
+type latencyRing struct {
+	samples []LatencySample
+	next    int
+	full    bool
+}
+
+// newLatencyRing - Creates ring buffer for keeping at max `size` samples
+func newLatencyRing(size uint64) *latencyRing {
+	return &latencyRing{samples: make([]LatencySample, size)}
+}
+
+// add - Puts sample in ring, overwriting oldest one if full
+func (r *latencyRing) add(sample LatencySample) {
+
+	if len(r.samples) == 0 {
+		return
+	}
+
+	r.samples[r.next] = sample
+	r.next = (r.next + 1) % len(r.samples)
+
+	if r.next == 0 {
+		r.full = true
+	}
+
+}
+
+// all - Copy of samples present in ring, oldest first
+func (r *latencyRing) all() []LatencySample {
+
+	if !r.full {
+
+		samples := make([]LatencySample, r.next)
+		copy(samples, r.samples[:r.next])
+		return samples
+
+	}
+
+	samples := make([]LatencySample, 0, len(r.samples))
+	samples = append(samples, r.samples[r.next:]...)
+	samples = append(samples, r.samples[:r.next]...)
+
+	return samples
+
+}
+
+// durationPercentile - Given ascending ordered durations, picks `p`th
+// percentile, using nearest rank method
+func durationPercentile(durations []time.Duration, p int) time.Duration {
+
+	rank := (p*len(durations) + 99) / 100
+	if rank == 0 {
+		rank = 1
+	}
+
+	return durations[rank-1]
+
+}
+
+// computeLatencyStats - Splits samples into ( at max ) ten equal sized
+// buckets, as per gas price paid & computes pending duration distribution
+// of each bucket
+func computeLatencyStats(samples []LatencySample) ConfirmationLatencyStats {
+
+	stats := ConfirmationLatencyStats{
+		Samples:    uint64(len(samples)),
+		Buckets:    make([]LatencyBucket, 0, 10),
+		ComputedAt: time.Now().UTC(),
+	}
+
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].GasPrice.Cmp(samples[j].GasPrice) < 0
+	})
+
+	for d := 0; d < 10; d++ {
+
+		start := d * len(samples) / 10
+		end := (d + 1) * len(samples) / 10
+
+		if start == end {
+			continue
+		}
+
+		durations := make([]time.Duration, 0, end-start)
+		for _, s := range samples[start:end] {
+			durations = append(durations, s.PendingFor)
+		}
+
+		sort.Slice(durations, func(i, j int) bool {
+			return durations[i] < durations[j]
+		})
+
+		stats.Buckets = append(stats.Buckets, LatencyBucket{
+			Decile:      uint64(d + 1),
+			MinGasPrice: samples[start].GasPrice,
+			MaxGasPrice: samples[end-1].GasPrice,
+			Count:       uint64(end - start),
+			P50:         durationPercentile(durations, 50),
+			P90:         durationPercentile(durations, 90),
+			P99:         durationPercentile(durations, 99),
+		})
+
+	}
+
+	return stats
+
+}
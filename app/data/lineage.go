@@ -0,0 +1,109 @@
+package data
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/itzmeanjan/harmony/app/listen"
+)
+
+// TxLineage - Tx along with other tx(s) it's related to, i.e. ones sharing
+// same sender & nonce, ones to be pruned alongside it, when it gets mined &
+// ones replacing/ replaced by it
+type TxLineage struct {
+	Tx *MemPoolTx
+	// Other tx(s) with same sender & nonce, descending ordered as per
+	// gas price paid
+	Duplicates []*MemPoolTx
+	// Other pending tx(s) to be pruned, if this one gets mined
+	Prunables []*MemPoolTx
+	// Tx which has replaced this one, if still known
+	ReplacedBy *MemPoolTx
+	// Duplicate tx(s), which are replaced by this one
+	Replaces []*MemPoolTx
+}
+
+// Lineage - Given txHash, finds tx, which is either living in pending/ queued
+// pool or has recently left pending pool, along with its lineage. Returns
+// nil, if tx isn't known
+func (m *MemPool) Lineage(hash common.Hash) *TxLineage {
+
+	v, _ := m.LineageWithContext(context.Background(), hash)
+	return v
+
+}
+
+// LineageWithContext - Context aware `Lineage`
+func (m *MemPool) LineageWithContext(ctx context.Context, hash common.Hash) (*TxLineage, error) {
+
+	tx, err := m.knownTx(ctx, hash)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+
+	lineage := &TxLineage{
+		Tx:         tx,
+		Duplicates: make([]*MemPoolTx, 0),
+		Prunables:  make([]*MemPoolTx, 0),
+		Replaces:   make([]*MemPoolTx, 0),
+	}
+
+	duplicates, err := m.sameNonceTxs(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	lineage.Duplicates = append(lineage.Duplicates, duplicates...)
+
+	// Only pending tx can get mined, so pruning is looked at as if this
+	// one was seen in next block
+	if tx.Pool == "pending" {
+
+		set, err := m.Pending.PruneSetOfWithContext(ctx, listen.CaughtTxs{&listen.CaughtTx{Hash: tx.Hash, Nonce: uint64(tx.Nonce)}})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range set.Prunables {
+
+			if v.Hash != tx.Hash {
+				lineage.Prunables = append(lineage.Prunables, v)
+			}
+
+		}
+
+	}
+
+	if tx.IsReplaced() {
+
+		lineage.ReplacedBy, err = m.knownTx(ctx, tx.ReplacedBy)
+		if err != nil {
+			return nil, err
+		}
+
+	}
+
+	for _, v := range lineage.Duplicates {
+
+		if v.ReplacedBy == tx.Hash {
+			lineage.Replaces = append(lineage.Replaces, v)
+		}
+
+	}
+
+	return lineage, nil
+
+}
+
+// knownTx - Tx living in either of pools or recently left pending pool,
+// nil if it isn't known
+func (m *MemPool) knownTx(ctx context.Context, hash common.Hash) (*MemPoolTx, error) {
+
+	tx, err := m.GetWithContext(ctx, hash)
+	if err != nil || tx != nil {
+		return tx, err
+	}
+
+	return m.Pending.RecentWithContext(ctx, hash)
+
+}
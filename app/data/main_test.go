@@ -0,0 +1,75 @@
+package data
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/itzmeanjan/harmony/app/config"
+)
+
+// testConfig - Config keys tests are run with, on top of defaults, where
+// publishing is turned off, because there's no Pub/Sub hub to talk to
+var testConfig = map[string]string{
+	"RPCUrl":                    "http://localhost:8545",
+	"WSUrl":                     "ws://localhost:8546",
+	"Pub0SubHost":               "127.0.0.1",
+	"Pub0SubPort":               "13000",
+	"PublishPendingEntry":       "false",
+	"PublishPendingExit":        "false",
+	"PublishQueuedEntry":        "false",
+	"PublishQueuedExit":         "false",
+	"PublishPendingReplacement": "false",
+	"PublishPendingStuck":       "false",
+	"PublishPendingReorged":     "false",
+	"PublishQueuedToPending":    "false",
+}
+
+// readTestConfig - Reads config from overridden keys only, config file
+// is never present
+func readTestConfig() error {
+	return config.Read(filepath.Join(os.TempDir(), "harmony-test-absent.env"))
+}
+
+func TestMain(m *testing.M) {
+
+	for k, v := range testConfig {
+		config.Override(k, v)
+	}
+
+	if err := readTestConfig(); err != nil {
+		log.Fatalf("[❗️] Failed to read test config : %s\n", err.Error())
+	}
+
+	os.Exit(m.Run())
+
+}
+
+// withConfig - Runs test with given config keys overridden, which are
+// brought back to what they were, once test is done
+func withConfig(tb testing.TB, kv map[string]string) {
+	tb.Helper()
+
+	previous := make(map[string]string, len(kv))
+	for k, v := range kv {
+		previous[k] = config.Get(k)
+		config.Override(k, v)
+	}
+
+	if err := readTestConfig(); err != nil {
+		tb.Fatal(err)
+	}
+
+	tb.Cleanup(func() {
+
+		for k, v := range previous {
+			config.Override(k, v)
+		}
+
+		if err := readTestConfig(); err != nil {
+			tb.Fatal(err)
+		}
+
+	})
+}
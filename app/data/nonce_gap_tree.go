@@ -0,0 +1,206 @@
+package data
+
+import (
+	"bytes"
+	"hash/maphash"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// nonceGapNode - Tx of some sender farthest from becoming executable, along
+// with its nonce gap & effective gas price, cached so that they're not
+// recomputed during each comparison
+type nonceGapNode struct {
+	tx       *MemPoolTx
+	gap      hexutil.Uint64
+	price    *big.Int
+	priority uint64
+	left     *nonceGapNode
+	right    *nonceGapNode
+}
+
+// NonceGapTree - One tx per sender having tx(s) in queued pool, one which is
+// farthest from becoming executable, ordered as per nonce gap, largest first,
+// where ties are broken by picking one paying lower gas price, then by hash
+//
+// Same as `GasPriceTree`, it's a treap, so that sender's entry can be updated
+// in logarithmic time, whenever its tx(s) or account nonce change, while tx
+// to be evicted is found by walking down left spine, instead of scanning all
+// senders
+//
+// @note Not concurrent safe, supposed to be used only from pool's own
+// life cycle manager go routine
+type NonceGapTree struct {
+	root       *nonceGapNode
+	priorities maphash.Hash
+	bySender   map[common.Address]*nonceGapNode
+}
+
+// NewNonceGapTree - Creates empty nonce gap ordered tree
+func NewNonceGapTree() *NonceGapTree {
+	return &NonceGapTree{bySender: make(map[common.Address]*nonceGapNode)}
+}
+
+// before - Checks whether this node is to be placed before given one
+func (n *nonceGapNode) before(o *nonceGapNode) bool {
+
+	if n.gap != o.gap {
+		return n.gap > o.gap
+	}
+
+	if c := n.price.Cmp(o.price); c != 0 {
+		return c < 0
+	}
+
+	return bytes.Compare(n.tx.Hash.Bytes(), o.tx.Hash.Bytes()) < 0
+
+}
+
+// splitNonceGap - Splits subtree into two, where left one holds all nodes
+// ordered before given one & right one holds rest
+func splitNonceGap(n *nonceGapNode, key *nonceGapNode) (*nonceGapNode, *nonceGapNode) {
+
+	if n == nil {
+		return nil, nil
+	}
+
+	if n.before(key) {
+
+		l, r := splitNonceGap(n.right, key)
+		n.right = l
+
+		return n, r
+
+	}
+
+	l, r := splitNonceGap(n.left, key)
+	n.left = r
+
+	return l, n
+
+}
+
+// mergeNonceGap - Merges two subtrees, where all nodes of left one are
+// ordered before all nodes of right one
+func mergeNonceGap(l *nonceGapNode, r *nonceGapNode) *nonceGapNode {
+
+	if l == nil {
+		return r
+	}
+
+	if r == nil {
+		return l
+	}
+
+	if l.priority > r.priority {
+
+		l.right = mergeNonceGap(l.right, r)
+		return l
+
+	}
+
+	r.left = mergeNonceGap(l, r.left)
+	return r
+
+}
+
+// removeNonceGapNode - Finds given node in subtree & replaces it with merged
+// children
+func removeNonceGapNode(n *nonceGapNode, key *nonceGapNode) *nonceGapNode {
+
+	if n == nil {
+		return nil
+	}
+
+	if n == key {
+		return mergeNonceGap(n.left, n.right)
+	}
+
+	if n.before(key) {
+		n.right = removeNonceGapNode(n.right, key)
+	} else {
+		n.left = removeNonceGapNode(n.left, key)
+	}
+
+	return n
+
+}
+
+// len - #-of senders present in tree
+func (g *NonceGapTree) len() int {
+	return len(g.bySender)
+}
+
+// set - Replaces entry of sender, with its tx farthest from becoming
+// executable, given sender's tx(s) ascending ordered as per nonce & its
+// account nonce, if known. Sender's entry is dropped when it has no tx(s)
+//
+// If sender's account nonce isn't known yet, its lowest queued nonce is
+// considered in place of that
+func (g *NonceGapTree) set(from common.Address, txs []*MemPoolTx, nonce hexutil.Uint64, known bool) {
+
+	g.unset(from)
+
+	if len(txs) == 0 {
+		return
+	}
+
+	if !known {
+		nonce = txs[0].Nonce
+	}
+
+	// Last one is farthest for this sender
+	last := txs[len(txs)-1]
+
+	var gap hexutil.Uint64
+	if last.Nonce > nonce {
+		gap = last.Nonce - nonce
+	}
+
+	g.priorities.Reset()
+	g.priorities.Write(last.Hash[:])
+
+	node := &nonceGapNode{
+		tx:       last,
+		gap:      gap,
+		price:    last.EffectiveGasPrice(nil),
+		priority: g.priorities.Sum64(),
+	}
+
+	l, r := splitNonceGap(g.root, node)
+	g.root = mergeNonceGap(mergeNonceGap(l, node), r)
+	g.bySender[from] = node
+
+}
+
+// unset - Drops entry of sender, if present
+func (g *NonceGapTree) unset(from common.Address) {
+
+	node, ok := g.bySender[from]
+	if !ok {
+		return
+	}
+
+	g.root = removeNonceGapNode(g.root, node)
+	delete(g.bySender, from)
+
+}
+
+// farthest - Tx farthest from becoming executable, among all senders, nil
+// when tree is empty
+func (g *NonceGapTree) farthest() *MemPoolTx {
+
+	n := g.root
+	if n == nil {
+		return nil
+	}
+
+	for n.left != nil {
+		n = n.left
+	}
+
+	return n.tx
+
+}
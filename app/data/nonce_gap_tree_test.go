@@ -0,0 +1,159 @@
+package data
+
+import (
+	"bytes"
+	"math/rand"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// farthestByScan - Tx farthest from becoming executable, found by visiting
+// every sender, which is what tree must agree with
+func farthestByScan(index map[common.Address][]*MemPoolTx, nonces map[common.Address]hexutil.Uint64) *MemPoolTx {
+
+	var picked *MemPoolTx
+	var pickedGap hexutil.Uint64
+
+	for addr, txs := range index {
+
+		if len(txs) == 0 {
+			continue
+		}
+
+		base, ok := nonces[addr]
+		if !ok {
+			base = txs[0].Nonce
+		}
+
+		last := txs[len(txs)-1]
+
+		var gap hexutil.Uint64
+		if last.Nonce > base {
+			gap = last.Nonce - base
+		}
+
+		if picked != nil && gap < pickedGap {
+			continue
+		}
+
+		if picked != nil && gap == pickedGap {
+
+			c := last.EffectiveGasPrice(nil).Cmp(picked.EffectiveGasPrice(nil))
+			if c > 0 || (c == 0 && bytes.Compare(last.Hash.Bytes(), picked.Hash.Bytes()) > 0) {
+				continue
+			}
+
+		}
+
+		picked, pickedGap = last, gap
+
+	}
+
+	return picked
+
+}
+
+// Senders' tx(s) & account nonces keep changing, while tree keeps agreeing
+// with full scan on which tx is farthest from becoming executable
+func TestNonceGapTreeFarthest(t *testing.T) {
+
+	rng := rand.New(rand.NewSource(1))
+	now := time.Now().UTC()
+
+	tree := NewNonceGapTree()
+	index := make(map[common.Address][]*MemPoolTx)
+	nonces := make(map[common.Address]hexutil.Uint64)
+
+	set := func(from common.Address) {
+		nonce, known := nonces[from]
+		tree.set(from, index[from], nonce, known)
+	}
+
+	for i := 0; i < 5000; i++ {
+
+		sender := rng.Intn(50)
+		from := txAddress(sender)
+
+		switch op := rng.Intn(4); {
+
+		case op < 2:
+
+			// Sender's tx(s) are kept ascending ordered as per nonce
+			var nonce uint64
+			if txs := index[from]; len(txs) != 0 {
+				nonce = uint64(txs[len(txs)-1].Nonce)
+			}
+
+			tx := queuedTx(0, sender, nonce+uint64(1+rng.Intn(3)), int64(1+rng.Intn(5)), now)
+			tx.Hash = txHash(i)
+			index[from] = append(index[from], tx)
+
+		case op == 2:
+
+			if txs := index[from]; len(txs) != 0 {
+				index[from] = txs[1:]
+			}
+
+			if len(index[from]) == 0 {
+				delete(index, from)
+				delete(nonces, from)
+			}
+
+		default:
+
+			if _, ok := index[from]; ok {
+				nonces[from] = hexutil.Uint64(rng.Intn(20))
+			}
+
+		}
+
+		set(from)
+
+		if tree.len() != len(index) {
+			t.Fatalf("step %d : expected %d sender(s) in tree, got %d", i, len(index), tree.len())
+		}
+
+		if want, got := farthestByScan(index, nonces), tree.farthest(); want != got {
+			t.Fatalf("step %d : expected farthest tx %v, got %v", i, want, got)
+		}
+
+	}
+
+}
+
+// Pool is full & every new tx evicts one farthest from becoming executable,
+// which used to scan all senders per eviction
+func BenchmarkNonceGapEviction(b *testing.B) {
+
+	for _, n := range benchSizes {
+
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+
+			tree := NewNonceGapTree()
+			now := time.Now().UTC()
+
+			txs := make([][]*MemPoolTx, n)
+			for i := range txs {
+				tx := queuedTx(0, i, uint64(i%64), int64(1+i%7), now)
+				tx.Hash = txHash(i)
+				txs[i] = []*MemPoolTx{tx}
+				tree.set(tx.From, txs[i], 0, true)
+			}
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				evicted := tree.farthest()
+				tree.set(evicted.From, nil, 0, false)
+				tree.set(evicted.From, []*MemPoolTx{evicted}, 0, true)
+			}
+
+		})
+
+	}
+
+}
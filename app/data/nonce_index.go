@@ -0,0 +1,107 @@
+package data
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// NonceIndex - Secondary index of tx(s) living in pool, keyed by sender
+// address & nonce, so that duplicate/ replacement tx(s) can be looked up
+// without scanning whole pool
+//
+// @note Not concurrent safe, supposed to be used only from pool's own
+// life cycle manager go routine
+type NonceIndex map[common.Address]map[hexutil.Uint64][]common.Hash
+
+// add - Indexes newly added tx
+func (n NonceIndex) add(tx *MemPoolTx) {
+
+	nonces, ok := n[tx.From]
+	if !ok {
+		nonces = make(map[hexutil.Uint64][]common.Hash)
+		n[tx.From] = nonces
+	}
+
+	nonces[tx.Nonce] = append(nonces[tx.Nonce], tx.Hash)
+
+}
+
+// remove - Drops index entry of tx leaving pool, while cleaning up
+// empty entries
+func (n NonceIndex) remove(tx *MemPoolTx) {
+
+	nonces, ok := n[tx.From]
+	if !ok {
+		return
+	}
+
+	hashes := nonces[tx.Nonce]
+	for i := 0; i < len(hashes); i++ {
+
+		if hashes[i] == tx.Hash {
+			hashes = append(hashes[:i], hashes[i+1:]...)
+			break
+		}
+
+	}
+
+	if len(hashes) != 0 {
+		nonces[tx.Nonce] = hashes
+		return
+	}
+
+	delete(nonces, tx.Nonce)
+	if len(nonces) == 0 {
+		delete(n, tx.From)
+	}
+
+}
+
+// get - Hashes of all tx(s) sent from address with given nonce
+func (n NonceIndex) get(from common.Address, nonce hexutil.Uint64) []common.Hash {
+
+	return n[from][nonce]
+
+}
+
+// duplicatesOf - Given txHash, finds tx(s) with same sender address & nonce,
+// from pool's tx(s), descending ordered as per gas price paid
+//
+// Returned tx(s) are deep copies, safe to be handed out
+func (n NonceIndex) duplicatesOf(txs map[common.Hash]*MemPoolTx, hash common.Hash) []*MemPoolTx {
+
+	target, ok := txs[hash]
+	if !ok {
+		return nil
+	}
+
+	return n.sameNonceAs(txs, target.From, target.Nonce, hash)
+
+}
+
+// sameNonceAs - Finds tx(s) from pool's tx(s), sent from given address with
+// given nonce, except one with given hash, descending ordered as per gas
+// price paid, where that tx doesn't need to be living in this pool
+//
+// Returned tx(s) are deep copies, safe to be handed out
+func (n NonceIndex) sameNonceAs(txs map[common.Hash]*MemPoolTx, from common.Address, nonce hexutil.Uint64, except common.Hash) []*MemPoolTx {
+
+	hashes := n.get(from, nonce)
+	result := make([]*MemPoolTx, 0, len(hashes))
+
+	for _, h := range hashes {
+
+		if h == except {
+			continue
+		}
+
+		if tx, ok := txs[h]; ok {
+			result = append(result, tx.Clone())
+		}
+
+	}
+
+	SortByGasPriceDesc(result)
+	return result
+
+}
@@ -0,0 +1,98 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// indexedTxs - `n` tx(s), where every 10th one is re-submitted with same
+// sender & nonce, along with secondary index of them
+func indexedTxs(n int) (map[common.Hash]*MemPoolTx, NonceIndex, []*MemPoolTx) {
+
+	txs := makeTxs(n, n/10)
+	for i := 0; i < len(txs); i += 10 {
+		txs[i].Nonce = txs[i+1].Nonce
+		txs[i].From = txs[i+1].From
+	}
+
+	pool := make(map[common.Hash]*MemPoolTx, n)
+	index := make(NonceIndex)
+	for _, tx := range txs {
+		pool[tx.Hash] = tx
+		index.add(tx)
+	}
+
+	return pool, index, txs
+
+}
+
+// scanDuplicatesOf - How duplicate tx(s) were found before index was kept,
+// by looking at every tx in pool
+func scanDuplicatesOf(txs map[common.Hash]*MemPoolTx, hash common.Hash) []*MemPoolTx {
+
+	target, ok := txs[hash]
+	if !ok {
+		return nil
+	}
+
+	result := make([]*MemPoolTx, 0)
+	for _, tx := range txs {
+		if tx.IsDuplicateOf(target) {
+			result = append(result, tx.Clone())
+		}
+	}
+
+	SortByGasPriceDesc(result)
+	return result
+
+}
+
+func TestNonceIndexMatchesScan(t *testing.T) {
+
+	pool, index, txs := indexedTxs(1000)
+
+	for _, tx := range txs {
+
+		indexed := index.duplicatesOf(pool, tx.Hash)
+		scanned := scanDuplicatesOf(pool, tx.Hash)
+
+		if len(indexed) != len(scanned) {
+			t.Fatalf("%s : expected %d duplicate(s), got %d", tx.Hash, len(scanned), len(indexed))
+		}
+
+		for i := range indexed {
+			if indexed[i].Hash != scanned[i].Hash {
+				t.Fatalf("%s : expected duplicate %s, got %s", tx.Hash, scanned[i].Hash, indexed[i].Hash)
+			}
+		}
+
+	}
+
+	for _, tx := range txs {
+		index.remove(tx)
+	}
+
+	if len(index) != 0 {
+		t.Fatalf("expected index to be empty, found %d sender(s)", len(index))
+	}
+
+}
+
+func BenchmarkDuplicateLookup(b *testing.B) {
+
+	pool, index, txs := indexedTxs(100_000)
+
+	b.Run("index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			index.duplicatesOf(pool, txs[i%len(txs)].Hash)
+		}
+	})
+
+	b.Run("scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			scanDuplicatesOf(pool, txs[i%len(txs)].Hash)
+		}
+	})
+
+}
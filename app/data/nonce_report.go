@@ -0,0 +1,148 @@
+package data
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/itzmeanjan/harmony/app/upstream"
+)
+
+// NonceGap - Range of consecutive nonces [From, To], for which no tx
+// from sender is present in mempool
+type NonceGap struct {
+	From hexutil.Uint64
+	To   hexutil.Uint64
+}
+
+// NonceReport - Nonce level view of all tx(s) sent by some address, living
+// in mempool, helpful while debugging why some tx(s) are stuck
+type NonceReport struct {
+	Address common.Address
+	// Next nonce to be used by sender, as seen by upstream node
+	// i.e. #-of tx(s) already confirmed
+	ConfirmedNonce hexutil.Uint64
+	Pending        []hexutil.Uint64
+	Queued         []hexutil.Uint64
+	// Nonces missing in mempool, in between confirmed nonce &
+	// highest nonce seen in any of the pools
+	Missing []NonceGap
+	// Queued tx(s) which can't be picked up until missing
+	// nonces show up
+	Blocked []*MemPoolTx
+}
+
+// nonceGaps - Given confirmed nonce & ascending ordered nonces present in
+// mempool, finds out ranges of nonces missing in between
+func nonceGaps(confirmed hexutil.Uint64, nonces []hexutil.Uint64) []NonceGap {
+
+	gaps := make([]NonceGap, 0)
+	next := confirmed
+
+	for _, nonce := range nonces {
+
+		// Already confirmed or same nonce tx(s), nothing missing
+		if nonce < next {
+			continue
+		}
+
+		if nonce > next {
+			gaps = append(gaps, NonceGap{From: next, To: nonce - 1})
+		}
+
+		next = nonce + 1
+
+	}
+
+	return gaps
+
+}
+
+// mergeNonces - Merges two ascending ordered lists of tx(s) into one ascending
+// ordered list of unique nonces
+func mergeNonces(a []*MemPoolTx, b []*MemPoolTx) []hexutil.Uint64 {
+
+	merged := make([]hexutil.Uint64, 0, len(a)+len(b))
+
+	push := func(nonce hexutil.Uint64) {
+		if len(merged) != 0 && merged[len(merged)-1] == nonce {
+			return
+		}
+
+		merged = append(merged, nonce)
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+
+		if j >= len(b) || (i < len(a) && a[i].Nonce <= b[j].Nonce) {
+			push(a[i].Nonce)
+			i++
+			continue
+		}
+
+		push(b[j].Nonce)
+		j++
+
+	}
+
+	return merged
+
+}
+
+// uniqueNonces - Extracts nonces of ascending ordered tx(s), while skipping
+// same nonce tx(s)
+func uniqueNonces(txs []*MemPoolTx) []hexutil.Uint64 {
+	return mergeNonces(txs, nil)
+}
+
+// NonceReport - Combines sender's tx(s) living in both pending & queued pool
+// with its confirmed nonce, as seen by upstream node, for finding out where
+// nonce gaps are & which queued tx(s) are blocked by them
+func (m *MemPool) NonceReport(ctx context.Context, addr common.Address) (*NonceReport, error) {
+
+	var confirmed hexutil.Uint64
+
+	if err := upstream.Call(ctx, m.Pending.RPC, &confirmed, "eth_getTransactionCount", addr.Hex(), "latest"); err != nil {
+		return nil, err
+	}
+
+	// Both are ascending ordered as per nonce
+	pending, err := m.Pending.TxsFromAWithContext(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	queued, err := m.Queued.TxsFromAWithContext(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	gaps := nonceGaps(confirmed, mergeNonces(pending, queued))
+
+	blocked := make([]*MemPoolTx, 0, len(queued))
+	if len(gaps) != 0 {
+
+		for _, tx := range queued {
+			if tx.Nonce > gaps[0].From {
+				blocked = append(blocked, tx)
+			}
+		}
+
+	}
+
+	return &NonceReport{
+		Address:        addr,
+		ConfirmedNonce: confirmed,
+		Pending:        uniqueNonces(pending),
+		Queued:         uniqueNonces(queued),
+		Missing:        gaps,
+		Blocked:        blocked,
+	}, nil
+
+}
+
+// QueuedGapReport - Why tx(s) are sitting in queued pool, per sender
+func (m *MemPool) QueuedGapReport(ctx context.Context) ([]*SenderGap, error) {
+	return m.Queued.GapReport(ctx)
+}
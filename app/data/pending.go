@@ -3,46 +3,101 @@ package data
 import (
 	"context"
 	"log"
-	"runtime"
+	"math/big"
+	"sort"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/gammazero/workerpool"
 	"github.com/itzmeanjan/harmony/app/config"
 	"github.com/itzmeanjan/harmony/app/listen"
-	"github.com/itzmeanjan/pub0sub/ops"
 	"github.com/itzmeanjan/pub0sub/publisher"
 )
 
 // PendingPool - Currently present pending tx(s) i.e. which are ready to
 // be mined in next block
+//
+// All state of pool is owned by its life cycle manager go routine, no
+// lock is guarding it, so it must be accessed only by sending request
+// on respective channel
 type PendingPool struct {
 	Transactions             map[common.Hash]*MemPoolTx
 	TxsFromAddress           map[common.Address]TxList
+	TxsByNonce               NonceIndex
 	DroppedTxs               map[common.Hash]time.Time
 	RemovedTxs               map[common.Hash]time.Time
-	AscTxsByGasPrice         TxList
-	DescTxsByGasPrice        TxList
+	TxsByGasPrice            *GasPriceTree
+	TxsByAge                 *AgeTree
+	ContractCreationTxs      TxList
 	Done                     uint64
 	LastSeenBlock            uint64
 	LastSeenAt               time.Time
 	AddTxChan                chan AddRequest
+	AddBatchChan             chan AddBatchRequest
 	AddFromQueuedPoolChan    chan AddRequest
 	RemoveTxChan             chan RemoveRequest
 	AlreadyInPendingPoolChan chan *MemPoolTx
 	InPendingPoolChan        chan<- *MemPoolTx
 	TxExistsChan             chan ExistsRequest
 	GetTxChan                chan GetRequest
+	FindTxsChan              chan FindTxsRequest
+	DuplicateTxsChan         chan DuplicateTxsRequest
+	SameNonceChan            chan SameNonceRequest
+	GasPriceRangeChan        chan GasPriceRangeRequest
 	CountTxsChan             chan CountRequest
 	ListTxsChan              chan ListRequest
 	TxsFromAChan             chan TxsFromARequest
+	CountFromChan            chan CountFromRequest
+	TopSendersChan           chan TopSendersRequest
+	ContractCreationsChan    chan chan []*MemPoolTx
 	DoneChan                 chan chan uint64
-	SetLastSeenBlockChan     chan uint64
+	GasPriceStatsChan        chan chan GasPriceStats
+	AggregatesChan           chan chan PoolAggregates
+	StatsChan                chan chan PoolStats
+	LatencySamplesChan       chan chan []LatencySample
+	RecentTxChan             chan GetRequest
+	ResizedChan              chan struct{}
+	EvaluateStuckChan        chan StuckRequest
+	StuckTxsChan             chan chan []*MemPoolTx
+	PruneSetChan             chan PruneSetRequest
+	RecommendChan            chan GasPriceRecommendRequest
+	SetLastSeenBlockChan     chan listen.Head
 	LastSeenBlockChan        chan chan LastSeenBlock
+	NewBlockChan             chan struct{}
+	ReorgedChan              chan []common.Hash
+	AgeWalkChan              chan AgeWalkRequest
+	StoppedChan              chan struct{}
+	Codec                    Codec
+	AddedBatch               *TxBatch
+	RemovedBatch             *TxBatch
 	PubSub                   *publisher.Publisher
 	RPC                      *rpc.Client
+	Workers                  *workerpool.WorkerPool
+	QueuedPool               *QueuedPool
+	hooks                    hookSet
+	totals                   aggregates
+	latencies                *latencyRing
+	recent                   *recentTxs
+	stuckTxs                 map[common.Hash]bool
+	// #-of messages published on entry/ exit topics, to be accessed
+	// atomically
+	publishedAdded    uint64
+	publishedRemoved  uint64
+	publishedReplaced uint64
+	publishedStuck    uint64
+	publishedReorged  uint64
+	publishedPromoted uint64
+	// Pruned tx(s), which are allowed to be re-admitted, because block
+	// they were pruned after got replaced, keyed by when it was seen
+	reorged map[common.Hash]time.Time
+	// Tx(s) admitted into pool, on their way to queued pool & not found
+	// tx tracker, so that life cycle manager never waits on either
+	toQueued  relay[*MemPoolTx]
+	toTracker relay[*MemPoolTx]
+	// Tx(s) disappeared from mempool content, on their way to pruner, so
+	// that poller never waits on it
+	candidates relay[[]common.Hash]
 }
 
 // HasBeenAllocatedFor - Given an address from which tx was sent
@@ -78,27 +133,138 @@ func (p *PendingPool) allocateFor(addr common.Address) TxList {
 
 // Start - This method is supposed to be run as an independent
 // go routine, maintaining pending pool state, through out its life time
+//
+// If life cycle manager panics, it's respawned, so that one malformed
+// tx can't take whole pool down
 func (p *PendingPool) Start(ctx context.Context) {
 
+	// Publishing to pubsub topics is just another hook, invoked
+	// along with ones registered by embedding service
+	p.RegisterAddHook(func(tx *MemPoolTx) {
+		p.PublishAdded(ctx, tx)
+	})
+	p.RegisterRemoveHook(func(tx *MemPoolTx) {
+		p.PublishRemoved(ctx, tx)
+	})
+
+	// Queued pool may be waiting on this pool, while it's being notified,
+	// which is why notifications are handed off to their own go routines
+	go p.toQueued.forward(ctx, p.AlreadyInPendingPoolChan)
+	go p.toTracker.forward(ctx, p.InPendingPoolChan)
+
+	supervise(ctx, "pending pool", p.run)
+
+	// Life cycle manager is gone for good, so that accessors waiting
+	// on it can give up, instead of blocking forever
+	close(p.StoppedChan)
+	p.drainRequests()
+
+}
+
+// drainRequests - Discards requests, which got queued up just as life cycle
+// manager was exiting, their senders give up with `ErrPoolStopped`
+func (p *PendingPool) drainRequests() {
+
+	drain(p.AddTxChan)
+	drain(p.AddBatchChan)
+	drain(p.AddFromQueuedPoolChan)
+	drain(p.RemoveTxChan)
+	drain(p.TxExistsChan)
+	drain(p.GetTxChan)
+	drain(p.FindTxsChan)
+	drain(p.DuplicateTxsChan)
+	drain(p.SameNonceChan)
+	drain(p.GasPriceRangeChan)
+	drain(p.CountTxsChan)
+	drain(p.ListTxsChan)
+	drain(p.TxsFromAChan)
+	drain(p.CountFromChan)
+	drain(p.TopSendersChan)
+	drain(p.ContractCreationsChan)
+	drain(p.DoneChan)
+	drain(p.GasPriceStatsChan)
+	drain(p.AggregatesChan)
+	drain(p.StatsChan)
+	drain(p.LatencySamplesChan)
+	drain(p.RecentTxChan)
+	drain(p.EvaluateStuckChan)
+	drain(p.StuckTxsChan)
+	drain(p.PruneSetChan)
+	drain(p.RecommendChan)
+	drain(p.LastSeenBlockChan)
+	drain(p.AgeWalkChan)
+
+}
+
+// Alive - Checks whether pending pool's life cycle manager is still running
+func (p *PendingPool) Alive() bool {
+
+	select {
+	case <-p.StoppedChan:
+		return false
+	default:
+		return true
+	}
+
+}
+
+// Resized - Lets pending pool's life cycle manager know size limit might
+// have changed, so that it can evict tx(s) beyond it
+func (p *PendingPool) Resized() {
+
+	select {
+	case <-p.StoppedChan:
+	case p.ResizedChan <- struct{}{}:
+	}
+
+}
+
+// run - Pending pool's life cycle manager loop
+func (p *PendingPool) run(ctx context.Context) {
+
+	// Tx(s) joining/ leaving pool are accumulated in these batches,
+	// when batching is enabled, which are to be flushed periodically
+	p.AddedBatch = NewTxBatch(p.Codec, config.GetPendingTxEntryPublishTopic, &p.publishedAdded)
+	p.RemovedBatch = NewTxBatch(p.Codec, config.GetPendingTxExitPublishTopic, &p.publishedRemoved)
+
+	flushTicker := time.NewTicker(time.Duration(config.GetPublishBatchPeriod()) * time.Millisecond)
+	defer flushTicker.Stop()
+
 	// Closure for checking whether adding new tx triggers
 	// condition for dropping some other tx
 	//
-	// Selecting which tx to be dropped
+	// Selecting which tx to be dropped, as per configured policy
 	//
 	// - Tx with lowest gas price paid ✅
-	// - Oldest tx living in mempool ❌
-	// - Oldest tx with lowest gas price paid ❌
-	//
-	// ✅ : Implemented
-	// ❌ : Not yet
+	// - Oldest tx living in mempool ✅
+	// - Oldest tx with lowest gas price paid ✅
 	//
 	// @note Don't accept tx which are already dropped
 	needToDropTxs := func() bool {
-		return uint64(p.AscTxsByGasPrice.len())+1 > config.GetPendingPoolSize()
+		return uint64(p.TxsByGasPrice.len())+1 > config.GetPendingPoolSize()
+	}
+
+	policy := config.GetEvictionPolicy()
+
+	// Pending duration of recently confirmed tx(s), it survives
+	// respawning of life cycle manager, just like pool state
+	if p.latencies == nil {
+		p.latencies = newLatencyRing(config.GetConfirmationLatencySamples())
 	}
 
-	pickTxWithLowestGasPrice := func() *MemPoolTx {
-		return p.AscTxsByGasPrice.get()[0]
+	// Tx(s) which have recently left pool, so that they can still be
+	// looked up for a while, after being confirmed/ dropped
+	if p.recent == nil {
+		p.recent = newRecentTxs(config.GetRecentTxsBuffer())
+	}
+
+	// Gas price statistics are cached for configured period, so that
+	// repeated queries don't rescan whole pool
+	var gasPriceStats GasPriceStats
+	gasPriceStatsPeriod := time.Duration(config.GetGasPriceStatsPeriod()) * time.Millisecond
+
+	pickTxToEvict := func() *MemPoolTx {
+		return pickEvictable(policy, p.TxsByGasPrice, p.TxsByAge)
 	}
 
 	// Plain simple safe tx adding into pool, logic, invoke it from other section
@@ -106,21 +272,50 @@ func (p *PendingPool) Start(ctx context.Context) {
 	// Don't rewrite this logic again
 	addTx := func(tx *MemPoolTx) {
 
-		p.AscTxsByGasPrice = Insert(p.AscTxsByGasPrice, tx)
-		p.DescTxsByGasPrice = Insert(p.DescTxsByGasPrice, tx)
+		p.TxsByGasPrice.insert(tx)
 		p.TxsFromAddress[tx.From] = Insert(p.allocateFor(tx.From), tx)
 		p.Transactions[tx.Hash] = tx
+		p.TxsByNonce.add(tx)
+		p.TxsByAge.insert(tx)
+
+		p.totals.added(tx, p.TxsFromAddress[tx.From].len())
+
+		if tx.IsContractCreation() {
+			p.ContractCreationTxs = Insert(p.ContractCreationTxs, tx)
+		}
 
 	}
 
 	// Plain simple remove tx logic, use it everywhere else
 	removeTx := func(tx *MemPoolTx) {
 
+		// Running totals are updated only when tx was living in pool
+		_, present := p.Transactions[tx.Hash]
+
 		// Remove from sorted tx list, keep it sorted
-		p.AscTxsByGasPrice = Remove(p.AscTxsByGasPrice, tx)
-		p.DescTxsByGasPrice = Remove(p.DescTxsByGasPrice, tx)
-		p.TxsFromAddress[tx.From] = Remove(p.TxsFromAddress[tx.From], tx)
+		p.TxsByGasPrice.remove(tx)
+		if p.hasBeenAllocatedFor(tx.From) {
+			p.TxsFromAddress[tx.From] = Remove(p.TxsFromAddress[tx.From], tx)
+		}
 		delete(p.Transactions, tx.Hash)
+		p.TxsByNonce.remove(tx)
+		p.TxsByAge.remove(tx)
+
+		if present {
+			p.totals.removed(tx, int(countFrom(p.TxsFromAddress, tx.From)))
+		}
+
+		// Sender has no more tx(s) in pool, so its entry is reclaimed,
+		// to be allocated again lazily, when it sends next tx
+		if p.hasBeenAllocatedFor(tx.From) && p.TxsFromAddress[tx.From].len() == 0 {
+			delete(p.TxsFromAddress, tx.From)
+		}
+
+		delete(p.stuckTxs, tx.Hash)
+
+		if tx.IsContractCreation() {
+			p.ContractCreationTxs = Remove(p.ContractCreationTxs, tx)
+		}
 
 	}
 
@@ -129,17 +324,23 @@ func (p *PendingPool) Start(ctx context.Context) {
 	// set up by user
 	dropTx := func(tx *MemPoolTx) {
 
+		tx.DroppedAt = time.Now().UTC()
+		tx.Pool = "dropped"
+		tx.EvictionReason = policy
+
 		removeTx(tx)
-		// 👇 op not being done while holding lock
-		// is due to the fact, no other competing
-		// worker attempting to read from/ write to
-		// this one, now
+		p.hooks.removed(tx)
+		log.Printf("[➖] Evicted tx from pending pool, following `%s` policy : %s\n", policy, tx.Hash.Hex())
+
+		// Marking that tx has been dropped, so that
+		// it won't get picked up next time
 		p.DroppedTxs[tx.Hash] = time.Now().UTC()
 
 	}
 
-	// Closure for safely adding new tx into pool
-	txAdder := func(tx *MemPoolTx) bool {
+	// Closure for safely admitting new tx into pool, only updates
+	// state, publishing is done separately, by `txAnnouncer`
+	txAdmitter := func(tx *MemPoolTx) bool {
 
 		if _, ok := p.Transactions[tx.Hash]; ok {
 			return false
@@ -155,17 +356,144 @@ func (p *PendingPool) Start(ctx context.Context) {
 			return false
 		}
 
+		// Sender has already reached its cap, so it needs to make room
+		// for this tx, by giving up its own lowest gas price tx, unless
+		// this one is paying even lower
+		if limit := config.GetMaxTxsPerAddress(); limit != 0 && p.hasBeenAllocatedFor(tx.From) && uint64(p.TxsFromAddress[tx.From].len()) >= limit {
+
+			cheapest := cheapestOf(p.TxsFromAddress[tx.From].get(), p.TxsByGasPrice.baseFee)
+			if p.TxsByGasPrice.priceOf(cheapest).Cmp(p.TxsByGasPrice.priceOf(tx)) > 0 {
+				p.DroppedTxs[tx.Hash] = time.Now().UTC()
+				return false
+			}
+
+			cheapest.Pool = "dropped"
+			cheapest.DroppedAt = time.Now().UTC()
+			cheapest.EvictionReason = SenderCap
+
+			removeTx(cheapest)
+			p.DroppedTxs[cheapest.Hash] = time.Now().UTC()
+			p.hooks.removed(cheapest)
+
+			log.Printf("[➖] Evicted tx from pending pool, sender reached cap of %d tx(s) : %s\n", limit, cheapest.Hash.Hex())
+
+		}
+
 		if needToDropTxs() {
-			dropTx(pickTxWithLowestGasPrice())
+			dropTx(pickTxToEvict())
 		}
 
-		// Marking we found this tx in mempool now
-		tx.PendingFrom = time.Now().UTC()
+		// Marking we found this tx in mempool now, unless some peer
+		// has already seen it earlier
+		if now := time.Now().UTC(); tx.PendingFrom.IsZero() || tx.PendingFrom.After(now) {
+			tx.PendingFrom = now
+		}
 		tx.Pool = "pending"
 
 		addTx(tx)
-		p.PublishAdded(ctx, tx)
 
+		// Sender might have re-submitted same nonce tx, with bumped
+		// fee, linking older one(s) with this replacement
+		for _, hash := range p.TxsByNonce.get(tx.From, tx.Nonce) {
+
+			old, ok := p.Transactions[hash]
+			if !ok || old.IsReplaced() || !tx.IsFeeBumpOf(old) {
+				continue
+			}
+
+			old.ReplacedBy = tx.Hash
+
+		}
+
+		return true
+
+	}
+
+	// Publishes newly admitted tx, along with older same nonce
+	// tx(s), which are replaced by it
+	txAnnouncer := func(tx *MemPoolTx) {
+
+		p.hooks.added(tx)
+
+		if _, ok := p.reorged[tx.Hash]; ok {
+			delete(p.reorged, tx.Hash)
+			p.PublishReorged(ctx, tx)
+		}
+
+		for _, hash := range p.TxsByNonce.get(tx.From, tx.Nonce) {
+
+			if old, ok := p.Transactions[hash]; ok && old.ReplacedBy == tx.Hash {
+				p.PublishReplaced(ctx, old)
+			}
+
+		}
+
+		if tx.Promoted {
+			p.PublishPromoted(ctx, tx)
+		}
+
+	}
+
+	// Finds which of given tx(s) are still living in queued pool, in one
+	// go, so that ones getting promoted can be told apart, even when
+	// they arrive here before queued pool could hand them over
+	//
+	// @note Queued pool never waits on pending pool, so asking it from
+	// here can't deadlock
+	queuedAmong := func(txs ...*MemPoolTx) map[common.Hash]*MemPoolTx {
+
+		if p.QueuedPool == nil {
+			return nil
+		}
+
+		hashes := make([]common.Hash, 0, len(txs))
+		for _, tx := range txs {
+			if _, ok := p.Transactions[tx.Hash]; !ok {
+				hashes = append(hashes, tx.Hash)
+			}
+		}
+
+		if len(hashes) == 0 {
+			return nil
+		}
+
+		found, err := p.QueuedPool.FindWithContext(ctx, hashes)
+		if err != nil {
+			return nil
+		}
+
+		return found
+
+	}
+
+	// Marks admitted tx as promoted, carrying over when it joined queued
+	// pool, so that time spent there can be computed
+	promote := func(tx *MemPoolTx, queued *MemPoolTx) {
+
+		tx.Promoted = true
+
+		if tx.QueuedAt.IsZero() {
+			tx.QueuedAt = queued.QueuedAt
+		}
+		if tx.UnstuckAt.IsZero() {
+			tx.UnstuckAt = time.Now().UTC()
+		}
+
+	}
+
+	// Closure for safely adding new tx into pool, where non-nil `queued`
+	// denotes it's being promoted from queued pool
+	txAdder := func(tx *MemPoolTx, queued *MemPoolTx) bool {
+
+		if !txAdmitter(tx) {
+			return false
+		}
+
+		if queued != nil {
+			promote(tx, queued)
+		}
+
+		txAnnouncer(tx)
 		return true
 
 	}
@@ -191,10 +519,32 @@ func (p *PendingPool) Start(ctx context.Context) {
 		if txStat.Status == CONFIRMED {
 			tx.Pool = "confirmed"
 			tx.ConfirmedAt = time.Now().UTC()
+
+			if !tx.PendingFrom.IsZero() && tx.ConfirmedAt.After(tx.PendingFrom) {
+				p.latencies.add(LatencySample{
+					Hash:       tx.Hash,
+					GasPrice:   tx.EffectiveGasPrice(nil),
+					PendingFor: tx.ConfirmedAt.Sub(tx.PendingFrom),
+				})
+			}
+		}
+
+		// Some other tx with same sender & nonce got mined
+		if txStat.Status == REPLACED {
+			tx.Pool = "replaced"
+			tx.DroppedAt = time.Now().UTC()
+		}
+
+		// Operator asked to get rid of it, because it's phantom i.e.
+		// dropped upstream, in a way pruner couldn't catch
+		if txStat.Status == MANUALLY_EVICTED {
+			tx.Pool = "dropped"
+			tx.DroppedAt = time.Now().UTC()
 		}
 
 		removeTx(tx)
-		p.PublishRemoved(ctx, tx)
+		p.recent.add(tx.Clone())
+		p.hooks.removed(tx)
 
 		return true
 
@@ -207,9 +557,14 @@ func (p *PendingPool) Start(ctx context.Context) {
 		case <-ctx.Done():
 			return
 
+		case <-flushTicker.C:
+
+			p.AddedBatch.Flush(p.PubSub)
+			p.RemovedBatch.Flush(p.PubSub)
+
 		case req := <-p.AddTxChan:
 
-			added := txAdder(req.Tx)
+			added := txAdder(req.Tx, queuedAmong(req.Tx)[req.Tx.Hash])
 			req.ResponseChan <- added
 
 			// @note Only if added successfully
@@ -217,14 +572,49 @@ func (p *PendingPool) Start(ctx context.Context) {
 				// Letting queued pool know, this tx is already added
 				// in pending pool, so it can be removed from queued pool
 				// if it's living there too
-				p.AlreadyInPendingPoolChan <- req.Tx
-				p.InPendingPoolChan <- req.Tx
+				p.toQueued.push(req.Tx)
+				p.toTracker.push(req.Tx)
+			}
+
+		case req := <-p.AddBatchChan:
+
+			// Whole batch is admitted in one go, so that readers never
+			// see half updated pool, publishing is done afterwards
+			added := make([]*MemPoolTx, 0, len(req.Txs))
+			queued := queuedAmong(req.Txs...)
+
+			for _, tx := range req.Txs {
+
+				if !txAdmitter(tx) {
+					continue
+				}
+
+				if q, ok := queued[tx.Hash]; ok {
+					promote(tx, q)
+				}
+
+				added = append(added, tx)
+
+			}
+
+			req.ResponseChan <- uint64(len(added))
+
+			for _, tx := range added {
+
+				txAnnouncer(tx)
+
+				p.toQueued.push(tx)
+				p.toTracker.push(tx)
+
 			}
 
 		case req := <-p.AddFromQueuedPoolChan:
 
-			req.ResponseChan <- txAdder(req.Tx)
-			p.InPendingPoolChan <- req.Tx
+			// Marked only once admitted, but before announcing, so that
+			// pending pool entry event can be told apart from unrelated
+			// new tx
+			req.ResponseChan <- txAdder(req.Tx, req.Tx)
+			p.toTracker.push(req.Tx)
 
 		case req := <-p.RemoveTxChan:
 
@@ -236,6 +626,35 @@ func (p *PendingPool) Start(ctx context.Context) {
 				// it won't get picked up next time
 				p.RemovedTxs[req.TxStat.Hash] = time.Now().UTC()
 				p.Done++
+
+				// Manually evicted tx must not reappear from next poll,
+				// even if upstream node still reports it
+				if req.TxStat.Status == MANUALLY_EVICTED {
+					p.DroppedTxs[req.TxStat.Hash] = time.Now().UTC()
+				}
+			}
+
+		case hashes := <-p.ReorgedChan:
+
+			if p.reorged == nil {
+				p.reorged = make(map[common.Hash]time.Time)
+			}
+
+			for _, hash := range hashes {
+
+				// Only tx(s) removed by pruner get another chance, not
+				// those evicted as per policy/ on operator's request
+				if _, ok := p.DroppedTxs[hash]; ok {
+					continue
+				}
+
+				if _, ok := p.RemovedTxs[hash]; !ok {
+					continue
+				}
+
+				delete(p.RemovedTxs, hash)
+				p.reorged[hash] = time.Now().UTC()
+
 			}
 
 		case req := <-p.TxExistsChan:
@@ -246,48 +665,54 @@ func (p *PendingPool) Start(ctx context.Context) {
 		case req := <-p.GetTxChan:
 
 			if tx, ok := p.Transactions[req.Tx]; ok {
-				req.ResponseChan <- tx
+				req.ResponseChan <- tx.Clone()
 				break
 			}
 
 			req.ResponseChan <- nil
 
-		case req := <-p.CountTxsChan:
+		case req := <-p.FindTxsChan:
 
-			req.ResponseChan <- uint64(p.AscTxsByGasPrice.len())
+			found := make(map[common.Hash]*MemPoolTx)
+			for _, hash := range req.Txs {
+				if tx, ok := p.Transactions[hash]; ok {
+					found[hash] = tx.Clone()
+				}
+			}
 
-		case req := <-p.ListTxsChan:
+			req.ResponseChan <- found
 
-			if req.Order == ASC {
+		case req := <-p.DuplicateTxsChan:
 
-				// If empty, just return nil
-				if p.AscTxsByGasPrice.len() == 0 {
-					req.ResponseChan <- nil
-					break
-				}
+			req.ResponseChan <- p.TxsByNonce.duplicatesOf(p.Transactions, req.Tx)
 
-				copied := make([]*MemPoolTx, p.AscTxsByGasPrice.len())
-				copy(copied, p.AscTxsByGasPrice.get())
+		case req := <-p.SameNonceChan:
 
-				req.ResponseChan <- copied
-				break
+			req.ResponseChan <- p.TxsByNonce.sameNonceAs(p.Transactions, req.Tx.From, req.Tx.Nonce, req.Tx.Hash)
 
-			}
+		case req := <-p.GasPriceRangeChan:
 
-			if req.Order == DESC {
+			req.ResponseChan <- CloneAll(p.TxsByGasPrice.between(req.Low, req.High))
 
-				// If empty, just return nil
-				if p.DescTxsByGasPrice.len() == 0 {
-					req.ResponseChan <- nil
-					break
-				}
+		case req := <-p.CountTxsChan:
 
-				copied := make([]*MemPoolTx, p.DescTxsByGasPrice.len())
-				copy(copied, p.DescTxsByGasPrice.get())
+			req.ResponseChan <- uint64(p.TxsByGasPrice.len())
 
-				req.ResponseChan <- copied
+		case req := <-p.ListTxsChan:
 
-			}
+			req.ResponseChan <- p.TxsByGasPrice.page(req, p.Transactions)
+
+		case req := <-p.CountFromChan:
+
+			req.ResponseChan <- countFrom(p.TxsFromAddress, req.From)
+
+		case req := <-p.TopSendersChan:
+
+			req.ResponseChan <- topSenders(p.TxsFromAddress, req.N)
+
+		case req := <-p.AgeWalkChan:
+
+			req.ResponseChan <- snapshotByAge(p.TxsByAge, req.Age, req.Older, req.Limit)
 
 		case req := <-p.TxsFromAChan:
 			// Return only those txs, which were sent by specific address `A`
@@ -299,16 +724,72 @@ func (p *PendingPool) Start(ctx context.Context) {
 					break
 				}
 
-				copied := make([]*MemPoolTx, txs.len())
-				copy(copied, txs.get())
-
-				req.ResponseChan <- copied
+				req.ResponseChan <- CloneAll(txs.get())
 				break
 
 			}
 
 			req.ResponseChan <- nil
 
+		case req := <-p.ContractCreationsChan:
+			// Return only contract creation tx(s), descending ordered
+			// as per gas price paid
+
+			if p.ContractCreationTxs.len() == 0 {
+				req <- nil
+				break
+			}
+
+			req <- CloneAll(p.ContractCreationTxs.get())
+
+		case req := <-p.GasPriceStatsChan:
+
+			if time.Now().UTC().Sub(gasPriceStats.ComputedAt) >= gasPriceStatsPeriod {
+				gasPriceStats = computeGasPriceStats(p.TxsByGasPrice)
+			}
+
+			req <- gasPriceStats
+
+		case req := <-p.AggregatesChan:
+
+			req <- p.totals.snapshot(p.TxsByGasPrice.len(), len(p.TxsFromAddress))
+
+		case req := <-p.StatsChan:
+
+			req <- poolStats(&p.totals, p.TxsByGasPrice, p.TxsByAge)
+
+		case req := <-p.LatencySamplesChan:
+
+			req <- p.latencies.all()
+
+		case <-p.ResizedChan:
+
+			// Limit might have been shrunk, by reloading config, so
+			// tx(s) beyond it are evicted right away
+			for uint64(p.TxsByGasPrice.len()) > config.GetPendingPoolSize() {
+				dropTx(pickTxToEvict())
+			}
+
+		case req := <-p.RecentTxChan:
+
+			req.ResponseChan <- p.recent.get(req.Tx)
+
+		case req := <-p.EvaluateStuckChan:
+
+			req.ResponseChan <- p.evaluateStuck(req.BaseFee, req.After)
+
+		case req := <-p.StuckTxsChan:
+
+			req <- p.flaggedStuck()
+
+		case req := <-p.PruneSetChan:
+
+			req.ResponseChan <- p.pruneSetOf(req.Txs)
+
+		case req := <-p.RecommendChan:
+
+			req.ResponseChan <- recommendGasPrice(p.TxsByGasPrice, req.BaseFee, req.Budgets)
+
 		case req := <-p.DoneChan:
 
 			// How many tx(s) are seen to be
@@ -319,16 +800,38 @@ func (p *PendingPool) Start(ctx context.Context) {
 			// Nothing but count of `dropped` & `confirmed` tx(s)
 			req <- p.Done
 
-		case num := <-p.SetLastSeenBlockChan:
+		case head := <-p.SetLastSeenBlockChan:
 
 			// Only keep moving forward
-			if p.LastSeenBlock > num {
+			if p.LastSeenBlock > head.Number {
 				break
 			}
 
-			p.LastSeenBlock = num
+			advanced := p.LastSeenBlock < head.Number
+
+			p.LastSeenBlock = head.Number
 			p.LastSeenAt = time.Now().UTC()
 
+			// Gas price ordered views are keyed on what tx(s) pay at
+			// latest base fee, queued pool is told without waiting
+			if head.BaseFee != nil {
+
+				p.TxsByGasPrice.rekey(head.BaseFee)
+				if p.QueuedPool != nil {
+					p.QueuedPool.setBaseFee(head.BaseFee)
+				}
+
+			}
+
+			// Letting mempool poller know, without blocking, in
+			// case it's not yet done with previous one
+			if advanced {
+				select {
+				case p.NewBlockChan <- struct{}{}:
+				default:
+				}
+			}
+
 		case req := <-p.LastSeenBlockChan:
 
 			req <- LastSeenBlock{Number: p.LastSeenBlock, At: p.LastSeenAt}
@@ -363,6 +866,15 @@ func (p *PendingPool) Start(ctx context.Context) {
 
 			}
 
+			// Reorged tx(s), which didn't reappear in mempool
+			for k := range p.reorged {
+
+				if time.Now().UTC().Sub(p.reorged[k]) > time.Duration(1)*time.Hour {
+					delete(p.reorged, k)
+				}
+
+			}
+
 		}
 
 	}
@@ -371,8 +883,16 @@ func (p *PendingPool) Start(ctx context.Context) {
 
 // Prune - Remove confirmed/ dropped txs from pending pool
 //
+// By default what happened to each prunable tx is decided from content of
+// mined block itself, checking receipt of each of them is done only when
+// asked to, in configuration
+//
+// Tx(s) pruned after seeing each of last few blocks are remembered, so that
+// when some of those blocks get replaced, due to chain reorganization, they
+// can be re-admitted, if they reappear in mempool
+//
 // @note This method is supposed to be run as independent go routine
-func (p *PendingPool) Prune(ctx context.Context, caughtTxsChan <-chan listen.CaughtTxs, confirmedTxsChan chan<- ConfirmedTx, notFoundTxsChan chan<- listen.CaughtTxs) {
+func (p *PendingPool) Prune(ctx context.Context, caughtTxsChan <-chan listen.CaughtTxs, reorgChan <-chan listen.Reorg, confirmedTxsChan chan<- ConfirmedTx, notFoundTxsChan chan<- listen.CaughtTxs) {
 
 	// Creating worker pool, where jobs to be submitted
 	// for concurrently checking whether tx was dropped or not
@@ -381,6 +901,9 @@ func (p *PendingPool) Prune(ctx context.Context, caughtTxsChan <-chan listen.Cau
 
 	internalChan := make(chan *TxStatus, 4096)
 	var droppedOrConfirmed uint64
+	byReceipt := config.GetPruneByReceipt()
+	pruned := newPrunedByBlock(config.GetReorgDepth())
+	disappeared := newDisappearedTxs(config.GetDisappearedTxProbeBlocks())
 
 	for {
 
@@ -389,90 +912,81 @@ func (p *PendingPool) Prune(ctx context.Context, caughtTxsChan <-chan listen.Cau
 		case <-ctx.Done():
 			return
 
-		case txs := <-caughtTxsChan:
-
-			var prunables []*MemPoolTx = make([]*MemPoolTx, 0, len(txs))
-			var notFoundTxs []*listen.CaughtTx = make([]*listen.CaughtTx, 0, len(txs))
+		case <-p.candidates.ready():
 
-			// How & which prunable tx(s) are kept in linear memory slot `prunables`
-			// i.e. starting from where & how many of those
-			//
-			// If we find one higher nonce value is processed already, we're skipping
-			// it because it **must** have included all lower nonce txs living in pool
-			// from same address
-			//
-			// But otherwise, we'll remove what already we've included for some lower
-			// nonce tx & include all for some higher nonce tx
-			type metadata struct {
-				nonce hexutil.Uint64
-				from  int
-				count int
+			// Disappeared from mempool content, but block showing them mined
+			// isn't seen yet, so they're left to be pruned when it's seen.
+			// Only ones still living in pool, few blocks later, are checked
+			// with node
+			for _, hashes := range p.candidates.take() {
+				disappeared.add(hashes)
 			}
 
-			var alreadyAddedFromA map[common.Address]*metadata = make(map[common.Address]*metadata)
-
-			for i := 0; i < len(txs); i++ {
+		case reorg := <-reorgChan:
 
-				tx := p.Get(txs[i].Hash)
-				if tx == nil {
-					// well, couldn't find tx in pool, keeping track of
-					// it in another worker, which will let us know about it
-					// when need to
-					notFoundTxs = append(notFoundTxs, txs[i])
-					continue
-				}
-
-				if meta, ok := alreadyAddedFromA[tx.From]; ok {
-
-					if meta.nonce > tx.Nonce {
-						// well, we've already added tx into `prunables`, because we're already
-						// done with processing some tx with higher nonce & this tx was included there
-						continue
-					}
-
-					// We've already added prunables for some smaller nonce value
-					// which is why we're going to remove those from memory to avoid
-					// processing duplicates
-					copy(prunables[meta.from:], prunables[meta.from+meta.count:])
-					for j := 0; j < meta.count; j++ {
-						prunables[len(prunables)-meta.count+j] = nil
-					}
-					prunables = prunables[:len(prunables)-meta.count]
-					alreadyAddedFromA[tx.From] = nil
-
-				}
-
-				_prunableLocal := p.Prunables(tx)
-				_prubableLocalC := len(_prunableLocal)
-				_metadata := metadata{nonce: tx.Nonce, from: len(prunables), count: _prubableLocalC}
+			if hashes := pruned.forget(reorg.From, reorg.To); len(hashes) != 0 {
+				p.ReorgedChan <- hashes
+				log.Printf("[🔀] %d pruned tx(s) can be re-admitted, after reorganization of block(s) %d..%d\n", len(hashes), reorg.From, reorg.To)
+			}
 
-				prunables = append(prunables, _prunableLocal...)
-				alreadyAddedFromA[tx.From] = &_metadata
+		case txs := <-caughtTxsChan:
 
-				// Can be GC-ed
-				CleanSlice(_prunableLocal)
+			if due := disappeared.mined(txs); len(due) != 0 {
+				wp.Submit(func() {
+					p.probeDisappeared(ctx, due, internalChan)
+				})
+			}
 
+			// Mined tx(s) grouped by sender, with one lookup in per sender
+			// index, computed by pool's life cycle manager in one go
+			set, err := p.PruneSetOfWithContext(ctx, txs)
+			if err != nil {
+				return
 			}
 
+			pruned.record(txs, set.Prunables)
+
 			// In current iteration, if we've found some mined txs
 			// not to be present in mempool, we're keeping track of it
 			// in different worker & let us know about it in future date
-			if len(notFoundTxs) != 0 {
-				notFoundTxsChan <- notFoundTxs
+			if len(set.NotFound) != 0 {
+				notFoundTxsChan <- set.NotFound
 			}
 
 			// Letting queued pool pruning worker know txs from
 			// these addresses with this nonce got mined in this block
-			for addr := range alreadyAddedFromA {
-				confirmedTxsChan <- ConfirmedTx{From: addr, Nonce: alreadyAddedFromA[addr].nonce}
+			for addr, nonce := range set.HighestNonce {
+				confirmedTxsChan <- ConfirmedTx{From: addr, Nonce: nonce}
 			}
 
-			// not required anymore, can be GC-ed
-			alreadyAddedFromA = nil
+			prunables := set.Prunables
+
+			if !byReceipt {
+
+				for i := 0; i < len(prunables); i++ {
+
+					if p.Remove(ctx, &TxStatus{Hash: prunables[i].Hash, Status: set.StatusOf(prunables[i])}) {
+						droppedOrConfirmed++
+					}
+
+				}
+
+				if len(prunables) != 0 {
+					log.Printf("[➖] Pruned pending tx pool, removed %d tx(s) so far\n", droppedOrConfirmed)
+				}
+
+				CleanSlice(prunables)
+				break
+
+			}
+
+			minedNonces := set.MinedNonces
 
 			for i := 0; i < len(prunables); i++ {
 
-				func(tx *MemPoolTx) {
+				_, replaced := minedNonces[prunables[i].From][prunables[i].Nonce]
+
+				func(tx *MemPoolTx, replaced bool) {
 
 					wp.Submit(func() {
 
@@ -481,6 +995,12 @@ func (p *PendingPool) Prune(ctx context.Context, caughtTxsChan <-chan listen.Cau
 						dropped, _ := tx.IsDropped(ctx, p.RPC)
 						if dropped {
 
+							// Different tx with same sender & nonce got mined
+							if replaced {
+								internalChan <- &TxStatus{Hash: tx.Hash, Status: REPLACED}
+								return
+							}
+
 							internalChan <- &TxStatus{Hash: tx.Hash, Status: DROPPED}
 							return
 
@@ -490,15 +1010,18 @@ func (p *PendingPool) Prune(ctx context.Context, caughtTxsChan <-chan listen.Cau
 
 					})
 
-				}(prunables[i])
+				}(prunables[i], replaced)
 
 			}
 
+			// not required anymore, can be GC-ed
+			minedNonces = nil
+
 			CleanSlice(prunables)
 
 		case tx := <-internalChan:
 
-			if tx.Status == CONFIRMED || tx.Status == DROPPED {
+			if tx.Status == CONFIRMED || tx.Status == DROPPED || tx.Status == REPLACED {
 
 				// Keep pruning as soon as we determined it can be pruned, rather than wait
 				// for all to come & then doing it
@@ -518,38 +1041,213 @@ func (p *PendingPool) Prune(ctx context.Context, caughtTxsChan <-chan listen.Cau
 
 }
 
+// RegisterAddHook - Registers hook to be invoked, with copy of tx, after
+// it's added to pending pool
+//
+// Hooks are invoked from pool's life cycle manager go routine, so they're
+// supposed to return quickly, panics are recovered & logged
+func (p *PendingPool) RegisterAddHook(hook Hook) {
+	p.hooks.registerAdd(hook)
+}
+
+// RegisterRemoveHook - Registers hook to be invoked, with copy of tx, after
+// it's removed from pending pool
+//
+// Hooks are invoked from pool's life cycle manager go routine, so they're
+// supposed to return quickly, panics are recovered & logged
+func (p *PendingPool) RegisterRemoveHook(hook Hook) {
+	p.hooks.registerRemove(hook)
+}
+
 // Get - Given tx hash, attempts to find out tx in pending pool, if any
 //
 // Returns nil, if found nothing
 func (p *PendingPool) Get(hash common.Hash) *MemPoolTx {
 
-	respChan := make(chan *MemPoolTx)
+	v, _ := p.GetWithContext(context.Background(), hash)
+	return v
 
-	p.GetTxChan <- GetRequest{Tx: hash, ResponseChan: respChan}
+}
+
+// GetWithContext - Context aware `Get`
+func (p *PendingPool) GetWithContext(ctx context.Context, hash common.Hash) (*MemPoolTx, error) {
 
-	return <-respChan
+	return request(ctx, p.StoppedChan, p.GetTxChan, func(respChan chan *MemPoolTx) GetRequest {
+		return GetRequest{Tx: hash, ResponseChan: respChan}
+	})
 
 }
 
 // Exists - Checks whether tx of given hash exists on pending pool or not
 func (p *PendingPool) Exists(hash common.Hash) bool {
 
-	respChan := make(chan bool)
+	v, _ := p.ExistsWithContext(context.Background(), hash)
+	return v
+
+}
 
-	p.TxExistsChan <- ExistsRequest{Tx: hash, ResponseChan: respChan}
+// ExistsWithContext - Context aware `Exists`
+func (p *PendingPool) ExistsWithContext(ctx context.Context, hash common.Hash) (bool, error) {
 
-	return <-respChan
+	return request(ctx, p.StoppedChan, p.TxExistsChan, func(respChan chan bool) ExistsRequest {
+		return ExistsRequest{Tx: hash, ResponseChan: respChan}
+	})
+
+}
+
+// Find - Given tx hashes, returns copies of those present in pending pool,
+// keyed by their hash
+func (p *PendingPool) Find(hashes []common.Hash) map[common.Hash]*MemPoolTx {
+
+	v, _ := p.FindWithContext(context.Background(), hashes)
+	return v
+
+}
+
+// FindWithContext - Context aware `Find`
+func (p *PendingPool) FindWithContext(ctx context.Context, hashes []common.Hash) (map[common.Hash]*MemPoolTx, error) {
+
+	return request(ctx, p.StoppedChan, p.FindTxsChan, func(respChan chan map[common.Hash]*MemPoolTx) FindTxsRequest {
+		return FindTxsRequest{Txs: hashes, ResponseChan: respChan}
+	})
 
 }
 
 // Count - How many tx(s) currently present in pending pool
 func (p *PendingPool) Count() uint64 {
 
-	respChan := make(chan uint64)
+	v, _ := p.CountWithContext(context.Background())
+	return v
+
+}
+
+// CountWithContext - Context aware `Count`
+func (p *PendingPool) CountWithContext(ctx context.Context) (uint64, error) {
+
+	return request(ctx, p.StoppedChan, p.CountTxsChan, func(respChan chan uint64) CountRequest {
+		return CountRequest{ResponseChan: respChan}
+	})
+
+}
+
+// GasPriceStats - Returns distribution of gas price paid by tx(s) living
+// in pending pool, computed over consistent snapshot of pool
+//
+// @note It's recomputed at max once in configured period, otherwise
+// cached copy is returned
+func (p *PendingPool) GasPriceStats() GasPriceStats {
+
+	v, _ := p.GasPriceStatsWithContext(context.Background())
+	return v
+
+}
 
-	p.CountTxsChan <- CountRequest{ResponseChan: respChan}
+// GasPriceStatsWithContext - Context aware `GasPriceStats`
+func (p *PendingPool) GasPriceStatsWithContext(ctx context.Context) (GasPriceStats, error) {
 
-	return <-respChan
+	return request(ctx, p.StoppedChan, p.GasPriceStatsChan, func(respChan chan GasPriceStats) chan GasPriceStats {
+		return respChan
+	})
+
+}
+
+// Aggregates - Returns totals over all tx(s) living in pending pool,
+// which are kept up-to-date as tx(s) join/ leave pool
+func (p *PendingPool) Aggregates() PoolAggregates {
+
+	v, _ := p.AggregatesWithContext(context.Background())
+	return v
+
+}
+
+// AggregatesWithContext - Context aware `Aggregates`
+func (p *PendingPool) AggregatesWithContext(ctx context.Context) (PoolAggregates, error) {
+
+	return request(ctx, p.StoppedChan, p.AggregatesChan, func(respChan chan PoolAggregates) chan PoolAggregates {
+		return respChan
+	})
+
+}
+
+// Stats - Returns composition of pending pool, without scanning it, where
+// limit is left to be filled in by caller
+func (p *PendingPool) Stats() PoolStats {
+
+	v, _ := p.StatsWithContext(context.Background())
+	return v
+
+}
+
+// StatsWithContext - Context aware `Stats`
+func (p *PendingPool) StatsWithContext(ctx context.Context) (PoolStats, error) {
+
+	return request(ctx, p.StoppedChan, p.StatsChan, func(respChan chan PoolStats) chan PoolStats {
+		return respChan
+	})
+
+}
+
+// ConfirmationLatencyStats - Pending duration distribution of recently
+// confirmed tx(s), bucketed by gas price decile, so that it can be told
+// how long tx paying some gas price is likely to wait
+//
+// Only samples are copied by life cycle manager, distribution is
+// computed on caller's go routine
+func (p *PendingPool) ConfirmationLatencyStats() ConfirmationLatencyStats {
+
+	v, _ := p.ConfirmationLatencyStatsWithContext(context.Background())
+	return v
+
+}
+
+// ConfirmationLatencyStatsWithContext - Context aware `ConfirmationLatencyStats`
+func (p *PendingPool) ConfirmationLatencyStatsWithContext(ctx context.Context) (ConfirmationLatencyStats, error) {
+
+	v, err := request(ctx, p.StoppedChan, p.LatencySamplesChan, func(respChan chan []LatencySample) chan []LatencySample {
+		return respChan
+	})
+	if err != nil {
+		return ConfirmationLatencyStats{}, err
+	}
+
+	return computeLatencyStats(v), nil
+
+}
+
+// Recent - Given txHash, looks up tx, which has recently left pending pool,
+// being confirmed/ dropped/ replaced, while it's still remembered
+func (p *PendingPool) Recent(hash common.Hash) *MemPoolTx {
+
+	v, _ := p.RecentWithContext(context.Background(), hash)
+	return v
+
+}
+
+// RecentWithContext - Context aware `Recent`
+func (p *PendingPool) RecentWithContext(ctx context.Context, hash common.Hash) (*MemPoolTx, error) {
+
+	return request(ctx, p.StoppedChan, p.RecentTxChan, func(respChan chan *MemPoolTx) GetRequest {
+		return GetRequest{Tx: hash, ResponseChan: respChan}
+	})
+
+}
+
+// RecommendGasPrice - Walks down pending pool, descending ordered as per gas
+// price paid, for finding out price to be paid for getting included within
+// each of given gas budgets
+func (p *PendingPool) RecommendGasPrice(baseFee *big.Int, budgets []uint64) []*big.Int {
+
+	v, _ := p.RecommendGasPriceWithContext(context.Background(), baseFee, budgets)
+	return v
+
+}
+
+// RecommendGasPriceWithContext - Context aware `RecommendGasPrice`
+func (p *PendingPool) RecommendGasPriceWithContext(ctx context.Context, baseFee *big.Int, budgets []uint64) ([]*big.Int, error) {
+
+	return request(ctx, p.StoppedChan, p.RecommendChan, func(respChan chan []*big.Int) GasPriceRecommendRequest {
+		return GasPriceRecommendRequest{BaseFee: baseFee, Budgets: budgets, ResponseChan: respChan}
+	})
 
 }
 
@@ -558,89 +1256,87 @@ func (p *PendingPool) Count() uint64 {
 //
 // This is nothing but count of `dropped` & `confirmed` tx(s)
 func (p *PendingPool) Processed() uint64 {
-	respChan := make(chan uint64)
 
-	p.DoneChan <- respChan
+	v, _ := p.ProcessedWithContext(context.Background())
+	return v
 
-	return <-respChan
 }
 
-// GetLastSeenBlock - Get last seen block & time, as reported
-// by block header listener
-func (p *PendingPool) GetLastSeenBlock() LastSeenBlock {
-	respChan := make(chan LastSeenBlock)
+// ProcessedWithContext - Context aware `Processed`
+func (p *PendingPool) ProcessedWithContext(ctx context.Context) (uint64, error) {
 
-	p.LastSeenBlockChan <- respChan
-	return <-respChan
-}
+	return request(ctx, p.StoppedChan, p.DoneChan, func(respChan chan uint64) chan uint64 {
+		return respChan
+	})
 
-// Prunables - Given tx, we're attempting to find out all txs which are living
-// in pending pool now & having same sender address & same/ lower nonce, so that
-// pruner can update state while removing mined txs from mempool
-func (p *PendingPool) Prunables(targetTx *MemPoolTx) []*MemPoolTx {
+}
 
-	txs := p.TxsFromA(targetTx.From)
-	if txs == nil {
-		return nil
-	}
+// GetLastSeenBlock - Get last seen block & time, as reported
+// by block header listener
+func (p *PendingPool) GetLastSeenBlock() LastSeenBlock {
 
-	txCount := uint64(len(txs))
-	commChan := make(chan *MemPoolTx, txCount)
-	result := make([]*MemPoolTx, 0, txCount)
-	// This is the tx which got mined
-	result = append(result, targetTx)
+	v, _ := p.GetLastSeenBlockWithContext(context.Background())
+	return v
 
-	var wp *workerpool.WorkerPool
+}
 
-	if txCount > uint64(runtime.NumCPU()) {
-		wp = workerpool.New(runtime.NumCPU())
-	} else {
-		wp = workerpool.New(int(txCount))
-	}
+// GetLastSeenBlockWithContext - Context aware `GetLastSeenBlock`
+func (p *PendingPool) GetLastSeenBlockWithContext(ctx context.Context) (LastSeenBlock, error) {
 
-	for i := 0; i < len(txs); i++ {
+	return request(ctx, p.StoppedChan, p.LastSeenBlockChan, func(respChan chan LastSeenBlock) chan LastSeenBlock {
+		return respChan
+	})
 
-		func(tx *MemPoolTx) {
+}
 
-			wp.Submit(func() {
+// ReplacementsOf - Given txHash, returns chain of tx(s) living in pending pool,
+// which have replaced it, one after another, by bumping fee
+//
+// @note Last one is latest replacement
+func (p *PendingPool) ReplacementsOf(hash common.Hash) []*MemPoolTx {
 
-				if tx.Hash != targetTx.Hash && tx.Nonce <= targetTx.Nonce {
-					commChan <- tx
-					return
-				}
+	v, _ := p.ReplacementsOfWithContext(context.Background(), hash)
+	return v
 
-				commChan <- nil
+}
 
-			})
+// ReplacementsOfWithContext - Context aware `ReplacementsOf`
+func (p *PendingPool) ReplacementsOfWithContext(ctx context.Context, hash common.Hash) ([]*MemPoolTx, error) {
 
-		}(txs[i])
+	tx, err := p.GetWithContext(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
 
+	if tx == nil {
+		return nil, nil
 	}
 
-	var received uint64
+	result := make([]*MemPoolTx, 0, 1)
+	// Seen tx(s), so that we don't end up looping forever
+	seen := map[common.Hash]struct{}{hash: {}}
+
+	for tx.IsReplaced() {
 
-	// Waiting for all go routines to finish
-	for v := range commChan {
+		if _, ok := seen[tx.ReplacedBy]; ok {
+			break
+		}
+		seen[tx.ReplacedBy] = struct{}{}
 
-		if v != nil {
-			result = append(result, v)
+		tx, err = p.GetWithContext(ctx, tx.ReplacedBy)
+		if err != nil {
+			return nil, err
 		}
 
-		received++
-		if received >= txCount {
+		if tx == nil {
 			break
 		}
 
-	}
+		result = append(result, tx)
 
-	// This call is irrelevant here, probably, but still being made
-	//
-	// Because all workers have exited, otherwise we could have never
-	// reached this point
-	wp.Stop()
-	CleanSlice(txs)
+	}
 
-	return result
+	return result, nil
 
 }
 
@@ -652,94 +1348,155 @@ func (p *PendingPool) Prunables(targetTx *MemPoolTx) []*MemPoolTx {
 //
 // Considering one tx duplicate of given one, if this tx has same
 // nonce & sender address, as of given ones
+//
+// Duplicates are ordered by gas price paid, so first one is
+// currently winning, if it's paying more than given tx
 func (p *PendingPool) DuplicateTxs(hash common.Hash) []*MemPoolTx {
 
-	targetTx := p.Get(hash)
-	if targetTx == nil {
-		return nil
-	}
+	v, _ := p.DuplicateTxsWithContext(context.Background(), hash)
+	return v
 
-	txs := p.TxsFromA(targetTx.From)
-	if txs == nil {
-		return nil
-	}
+}
 
-	txCount := uint64(len(txs))
-	commChan := make(chan *MemPoolTx, txCount)
-	result := make([]*MemPoolTx, 0, txCount)
+// DuplicateTxsWithContext - Context aware `DuplicateTxs`
+func (p *PendingPool) DuplicateTxsWithContext(ctx context.Context, hash common.Hash) ([]*MemPoolTx, error) {
 
-	var wp *workerpool.WorkerPool
+	return request(ctx, p.StoppedChan, p.DuplicateTxsChan, func(respChan chan []*MemPoolTx) DuplicateTxsRequest {
+		return DuplicateTxsRequest{Tx: hash, ResponseChan: respChan}
+	})
 
-	if txCount > uint64(runtime.NumCPU()) {
-		wp = workerpool.New(runtime.NumCPU())
-	} else {
-		wp = workerpool.New(int(txCount))
-	}
+}
 
-	for i := 0; i < len(txs); i++ {
+// SameNonceTxs - Given tx, which may be living in any pool, finds tx(s) living
+// in pending pool with same sender address & nonce, descending ordered as per
+// gas price paid
+func (p *PendingPool) SameNonceTxs(tx *MemPoolTx) []*MemPoolTx {
 
-		func(tx *MemPoolTx) {
+	v, _ := p.SameNonceTxsWithContext(context.Background(), tx)
+	return v
 
-			wp.Submit(func() {
+}
 
-				if tx.IsDuplicateOf(targetTx) {
-					commChan <- tx
-					return
-				}
+// SameNonceTxsWithContext - Context aware `SameNonceTxs`
+func (p *PendingPool) SameNonceTxsWithContext(ctx context.Context, tx *MemPoolTx) ([]*MemPoolTx, error) {
+
+	return request(ctx, p.StoppedChan, p.SameNonceChan, func(respChan chan []*MemPoolTx) SameNonceRequest {
+		return SameNonceRequest{Tx: tx, ResponseChan: respChan}
+	})
+
+}
 
-				commChan <- nil
+// AscListTxs - Returns all tx(s) present in pending pool, as slice, ascending ordered as per gas price paid
+func (p *PendingPool) AscListTxs() []*MemPoolTx {
 
-			})
+	v, _ := p.AscListTxsWithContext(context.Background())
+	return v
 
-		}(txs[i])
+}
 
-	}
+// AscListTxsWithContext - Context aware `AscListTxs`
+func (p *PendingPool) AscListTxsWithContext(ctx context.Context) ([]*MemPoolTx, error) {
 
-	var received uint64
+	page, err := p.list(ctx, ListRequest{Order: ASC})
+	return page.Txs, err
 
-	for v := range commChan {
+}
 
-		if v != nil {
-			result = append(result, v)
-		}
+// DescListTxs - Returns all tx(s) present in pending pool, as slice, descending ordered as per gas price paid
+func (p *PendingPool) DescListTxs() []*MemPoolTx {
 
-		received++
-		if received >= txCount {
-			break
-		}
+	v, _ := p.DescListTxsWithContext(context.Background())
+	return v
 
-	}
+}
 
-	// This call is irrelevant here, probably, but still being made
-	//
-	// Because all workers have exited, otherwise we could have never
-	// reached this point
-	wp.Stop()
-	CleanSlice(txs)
+// DescListTxsWithContext - Context aware `DescListTxs`
+func (p *PendingPool) DescListTxsWithContext(ctx context.Context) ([]*MemPoolTx, error) {
 
-	return result
+	page, err := p.list(ctx, ListRequest{Order: DESC})
+	return page.Txs, err
 
 }
 
-// AscListTxs - Returns all tx(s) present in pending pool, as slice, ascending ordered as per gas price paid
-func (p *PendingPool) AscListTxs() []*MemPoolTx {
+// ListPage - Returns window of at max `limit` tx(s), starting at `offset`, from
+// pending pool, ordered as per gas price paid, along with total #-of tx(s) in pool
+//
+// Only requested window gets copied, zero `limit` denotes all tx(s) from `offset`
+func (p *PendingPool) ListPage(order int, offset uint64, limit uint64) TxPage {
 
-	respChan := make(chan []*MemPoolTx)
+	v, _ := p.ListPageWithContext(context.Background(), order, offset, limit)
+	return v
 
-	p.ListTxsChan <- ListRequest{ResponseChan: respChan, Order: ASC}
+}
 
-	return <-respChan
+// ListPageWithContext - Context aware `ListPage`
+func (p *PendingPool) ListPageWithContext(ctx context.Context, order int, offset uint64, limit uint64) (TxPage, error) {
+
+	return p.list(ctx, ListRequest{Order: order, Offset: offset, Limit: limit})
 
 }
 
-// DescListTxs - Returns all tx(s) present in pending pool, as slice, descending ordered as per gas price paid
-func (p *PendingPool) DescListTxs() []*MemPoolTx {
+// ListAfterWithContext - Returns window of at max `limit` tx(s), starting right
+// after tx with given hash ( or from very beginning, when it's nil ), from pending pool, ordered as per gas price paid, along
+// with total #-of tx(s) in pool
+//
+// If that tx has already left pool, window starts where it'd have been,
+// as long as it's among recently removed ones
+func (p *PendingPool) ListAfterWithContext(ctx context.Context, order int, after *common.Hash, limit uint64) (TxPage, error) {
 
-	respChan := make(chan []*MemPoolTx)
+	return p.list(ctx, ListRequest{Order: order, After: after, Limit: limit})
 
-	p.ListTxsChan <- ListRequest{ResponseChan: respChan, Order: DESC}
+}
 
-	return <-respChan
+// list - Sends listing request to pool's life cycle manager & waits for
+// response, giving up as soon as `ctx` is done or pool has stopped
+func (p *PendingPool) list(ctx context.Context, req ListRequest) (TxPage, error) {
+
+	v, err := request(ctx, p.StoppedChan, p.ListTxsChan, func(respChan chan TxPage) ListRequest {
+		req.ResponseChan = respChan
+		return req
+	})
+	if err != nil {
+		return TxPage{}, err
+	}
+
+	return v, v.err
+
+}
+
+// CountFrom - #-of tx(s) sent by address `A`, living in pending pool,
+// answered from per sender index, without copying tx(s)
+func (p *PendingPool) CountFrom(addr common.Address) uint64 {
+
+	v, _ := p.CountFromWithContext(context.Background(), addr)
+	return v
+
+}
+
+// CountFromWithContext - Context aware `CountFrom`
+func (p *PendingPool) CountFromWithContext(ctx context.Context, addr common.Address) (uint64, error) {
+
+	return request(ctx, p.StoppedChan, p.CountFromChan, func(respChan chan uint64) CountFromRequest {
+		return CountFromRequest{ResponseChan: respChan, From: addr}
+	})
+
+}
+
+// TopSenders - Returns at max `n` senders, having most tx(s) living
+// in pending pool, descending ordered as per #-of tx(s)
+func (p *PendingPool) TopSenders(n uint64) []SenderCount {
+
+	v, _ := p.TopSendersWithContext(context.Background(), n)
+	return v
+
+}
+
+// TopSendersWithContext - Context aware `TopSenders`
+func (p *PendingPool) TopSendersWithContext(ctx context.Context, n uint64) ([]SenderCount, error) {
+
+	return request(ctx, p.StoppedChan, p.TopSendersChan, func(respChan chan []SenderCount) TopSendersRequest {
+		return TopSendersRequest{ResponseChan: respChan, N: n}
+	})
 
 }
 
@@ -747,11 +1504,35 @@ func (p *PendingPool) DescListTxs() []*MemPoolTx {
 // by address `A`
 func (p *PendingPool) TxsFromA(addr common.Address) []*MemPoolTx {
 
-	respChan := make(chan []*MemPoolTx)
+	v, _ := p.TxsFromAWithContext(context.Background(), addr)
+	return v
 
-	p.TxsFromAChan <- TxsFromARequest{ResponseChan: respChan, From: addr}
+}
 
-	return <-respChan
+// TxsFromAWithContext - Context aware `TxsFromA`
+func (p *PendingPool) TxsFromAWithContext(ctx context.Context, addr common.Address) ([]*MemPoolTx, error) {
+
+	return request(ctx, p.StoppedChan, p.TxsFromAChan, func(respChan chan []*MemPoolTx) TxsFromARequest {
+		return TxsFromARequest{ResponseChan: respChan, From: addr}
+	})
+
+}
+
+// ContractCreations - Returns all contract creation tx(s) present in pending pool,
+// descending ordered as per gas price paid
+func (p *PendingPool) ContractCreations() []*MemPoolTx {
+
+	v, _ := p.ContractCreationsWithContext(context.Background())
+	return v
+
+}
+
+// ContractCreationsWithContext - Context aware `ContractCreations`
+func (p *PendingPool) ContractCreationsWithContext(ctx context.Context) ([]*MemPoolTx, error) {
+
+	return request(ctx, p.StoppedChan, p.ContractCreationsChan, func(respChan chan []*MemPoolTx) chan []*MemPoolTx {
+		return respChan
+	})
 
 }
 
@@ -759,228 +1540,349 @@ func (p *PendingPool) TxsFromA(addr common.Address) []*MemPoolTx {
 // where being top is determined by how much gas price paid by tx sender
 func (p *PendingPool) TopXWithHighGasPrice(x uint64) []*MemPoolTx {
 
-	txs := p.DescListTxs()
-	if uint64(len(txs)) <= x {
-		return txs
+	v, _ := p.TopXWithHighGasPriceWithContext(context.Background(), x)
+	return v
+
+}
+
+// TopXWithHighGasPriceWithContext - Context aware `TopXWithHighGasPrice`
+func (p *PendingPool) TopXWithHighGasPriceWithContext(ctx context.Context, x uint64) ([]*MemPoolTx, error) {
+
+	// Zero limit would denote whole pool
+	if x == 0 {
+		return []*MemPoolTx{}, nil
 	}
 
-	CleanSlice(txs[x:])
-	return txs[:x]
+	page, err := p.ListPageWithContext(ctx, DESC, 0, x)
+	return page.Txs, err
 
 }
 
-// TopXWithLowGasPrice - Returns only top `X` tx(s) present in pending mempool,
-// where being top is determined by how low gas price paid by tx sender
-func (p *PendingPool) TopXWithLowGasPrice(x uint64) []*MemPoolTx {
+// TopXByCost - Returns only top `X` tx(s) present in pending mempool,
+// where being top is determined by total cost of tx i.e. `gasPrice * gasLimit + value`
+func (p *PendingPool) TopXByCost(x uint64) []*MemPoolTx {
+
+	v, _ := p.TopXByCostWithContext(context.Background(), x)
+	return v
+
+}
+
+// TopXByCostWithContext - Context aware `TopXByCost`
+func (p *PendingPool) TopXByCostWithContext(ctx context.Context, x uint64) ([]*MemPoolTx, error) {
+
+	txs, err := p.DescListTxsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(txs) == 0 {
+		return nil, nil
+	}
+
+	// Computing cost of each tx only once, before sorting
+	costs := make(map[common.Hash]*big.Int, len(txs))
+	for i := 0; i < len(txs); i++ {
+		costs[txs[i].Hash] = txs[i].Cost()
+	}
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		return costs[txs[i].Hash].Cmp(costs[txs[j].Hash]) > 0
+	})
 
-	txs := p.AscListTxs()
 	if uint64(len(txs)) <= x {
-		return txs
+		return txs, nil
 	}
 
 	CleanSlice(txs[x:])
-	return txs[:x]
+	return txs[:x], nil
 
 }
 
-// SentFrom - Returns a list of pending tx(s) sent from
-// specified address
-func (p *PendingPool) SentFrom(address common.Address) []*MemPoolTx {
-	return p.TxsFromA(address)
+// ValueGTE - Returns tx(s) present in pending mempool, transferring value
+// >= given threshold, descending ordered as per gas price paid
+//
+// @note Tx(s) without value are considered to be transferring nothing
+func (p *PendingPool) ValueGTE(threshold *big.Int) []*MemPoolTx {
+
+	v, _ := p.ValueGTEWithContext(context.Background(), threshold)
+	return v
+
 }
 
-// SentTo - Returns a list of pending tx(s) sent to
-// specified address
-func (p *PendingPool) SentTo(address common.Address) []*MemPoolTx {
+// ValueGTEWithContext - Context aware `ValueGTE`
+func (p *PendingPool) ValueGTEWithContext(ctx context.Context, threshold *big.Int) ([]*MemPoolTx, error) {
 
-	txs := p.DescListTxs()
-	if txs == nil {
-		return nil
+	txs, err := p.DescListTxsWithContext(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	txCount := uint64(len(txs))
-	commChan := make(chan *MemPoolTx, txCount)
-	result := make([]*MemPoolTx, 0, txCount)
+	if len(txs) == 0 {
+		return nil, nil
+	}
 
-	wp := workerpool.New(runtime.NumCPU())
+	result := make([]*MemPoolTx, 0, len(txs))
 
 	for i := 0; i < len(txs); i++ {
 
-		func(tx *MemPoolTx) {
+		if bigOrZero(txs[i].Value).Cmp(threshold) >= 0 {
+			result = append(result, txs[i])
+		}
+
+	}
 
-			wp.Submit(func() {
+	CleanSlice(txs)
+	return result, nil
 
-				if tx.IsSentTo(address) {
-					commChan <- tx
-					return
-				}
+}
 
-				commChan <- nil
+// GasPriceBetween - Returns tx(s) present in pending mempool, paying gas price
+// within [low, high], ascending ordered as per gas price paid
+//
+// Boundaries are found using binary search, so only relevant portion of
+// pool is copied. Absent bound denotes open-ended range.
+func (p *PendingPool) GasPriceBetween(low *big.Int, high *big.Int) []*MemPoolTx {
 
-			})
+	v, _ := p.GasPriceBetweenWithContext(context.Background(), low, high)
+	return v
 
-		}(txs[i])
+}
 
-	}
+// GasPriceBetweenWithContext - Context aware `GasPriceBetween`
+func (p *PendingPool) GasPriceBetweenWithContext(ctx context.Context, low *big.Int, high *big.Int) ([]*MemPoolTx, error) {
 
-	var received uint64
-	mustReceive := txCount
+	return request(ctx, p.StoppedChan, p.GasPriceRangeChan, func(respChan chan []*MemPoolTx) GasPriceRangeRequest {
+		return GasPriceRangeRequest{Low: low, High: high, ResponseChan: respChan}
+	})
 
-	// Waiting for all go routines to finish
-	for v := range commChan {
+}
 
-		if v != nil {
-			result = append(result, v)
-		}
+// TopXWithLowGasPrice - Returns only top `X` tx(s) present in pending mempool,
+// where being top is determined by how low gas price paid by tx sender
+func (p *PendingPool) TopXWithLowGasPrice(x uint64) []*MemPoolTx {
 
-		received++
-		if received >= mustReceive {
-			break
-		}
+	v, _ := p.TopXWithLowGasPriceWithContext(context.Background(), x)
+	return v
+
+}
+
+// TopXWithLowGasPriceWithContext - Context aware `TopXWithLowGasPrice`
+func (p *PendingPool) TopXWithLowGasPriceWithContext(ctx context.Context, x uint64) ([]*MemPoolTx, error) {
 
+	// Zero limit would denote whole pool
+	if x == 0 {
+		return []*MemPoolTx{}, nil
 	}
 
-	// This call is irrelevant here, probably, but still being made
-	//
-	// Because all workers have exited, otherwise we could have never
-	// reached this point
-	wp.Stop()
-	CleanSlice(txs)
+	page, err := p.ListPageWithContext(ctx, ASC, 0, x)
+	return page.Txs, err
 
-	return result
+}
 
+// SentFrom - Returns a list of pending tx(s) sent from
+// specified address
+func (p *PendingPool) SentFrom(address common.Address) []*MemPoolTx {
+	return p.TxsFromA(address)
 }
 
-// OlderThanX - Returns a list of all pending tx(s), which are
-// living in mempool for more than or equals to `X` time unit
-func (p *PendingPool) OlderThanX(x time.Duration) []*MemPoolTx {
+// ByType - Returns a list of pending tx(s) of given type, where legacy
+// tx(s) are of type `0`
+func (p *PendingPool) ByType(t uint64) []*MemPoolTx {
+
+	v, _ := p.ByTypeWithContext(context.Background(), t)
+	return v
+
+}
 
-	txs := p.DescListTxs()
-	if txs == nil {
-		return nil
+// ByTypeWithContext - Context aware `ByType`
+func (p *PendingPool) ByTypeWithContext(ctx context.Context, t uint64) ([]*MemPoolTx, error) {
+
+	txs, err := p.DescListTxsWithContext(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	txCount := uint64(len(txs))
-	commChan := make(chan *MemPoolTx, txCount)
-	result := make([]*MemPoolTx, 0, txCount)
+	if len(txs) == 0 {
+		return nil, nil
+	}
 
-	wp := workerpool.New(runtime.NumCPU())
+	result := filterTxs(p.Workers, txs, func(tx *MemPoolTx) bool {
+		return tx.IsOfType(t)
+	})
 
-	for i := 0; i < len(txs); i++ {
+	CleanSlice(txs)
+	return result, nil
 
-		func(tx *MemPoolTx) {
+}
 
-			wp.Submit(func() {
+// SentTo - Returns a list of pending tx(s) sent to
+// specified address
+func (p *PendingPool) SentTo(address common.Address) []*MemPoolTx {
 
-				if tx.IsPendingForGTE(x) {
-					commChan <- tx
-					return
-				}
+	v, _ := p.SentToWithContext(context.Background(), address)
+	return v
 
-				commChan <- nil
+}
 
-			})
+// SentToWithContext - Context aware `SentTo`
+func (p *PendingPool) SentToWithContext(ctx context.Context, address common.Address) ([]*MemPoolTx, error) {
 
-		}(txs[i])
+	txs, err := p.DescListTxsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 
+	if len(txs) == 0 {
+		return nil, nil
 	}
 
-	var received uint64
-	mustReceive := txCount
+	result := filterTxs(p.Workers, txs, func(tx *MemPoolTx) bool {
+		return tx.IsSentTo(address)
+	})
 
-	// Waiting for all go routines to finish
-	for v := range commChan {
+	CleanSlice(txs)
+	return result, nil
 
-		if v != nil {
-			result = append(result, v)
-		}
+}
 
-		received++
-		if received >= mustReceive {
-			break
-		}
+// SentToWithMethod - Returns a list of pending tx(s) sent to specified
+// address, invoking contract method identified by given 4-byte selector
+func (p *PendingPool) SentToWithMethod(address common.Address, selector [4]byte) []*MemPoolTx {
+
+	v, _ := p.SentToWithMethodWithContext(context.Background(), address, selector)
+	return v
+
+}
 
+// SentToWithMethodWithContext - Context aware `SentToWithMethod`
+func (p *PendingPool) SentToWithMethodWithContext(ctx context.Context, address common.Address, selector [4]byte) ([]*MemPoolTx, error) {
+
+	txs, err := p.DescListTxsWithContext(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// This call is irrelevant here, probably, but still being made
-	//
-	// Because all workers have exited, otherwise we could have never
-	// reached this point
-	wp.Stop()
+	if len(txs) == 0 {
+		return nil, nil
+	}
+
+	result := filterTxs(p.Workers, txs, func(tx *MemPoolTx) bool {
+		return tx.IsSentToWithMethod(address, selector)
+	})
+
 	CleanSlice(txs)
+	return result, nil
+
+}
 
-	return result
+// snapshotByAge - Asks pool for copies of tx(s) living in it for more/ less
+// than or equals to `X` time unit, at max `limit` of them, unless it's 0
+func (p *PendingPool) snapshotByAge(ctx context.Context, x time.Duration, older bool, limit uint64) ([]*MemPoolTx, error) {
+
+	return request(ctx, p.StoppedChan, p.AgeWalkChan, func(respChan chan []*MemPoolTx) AgeWalkRequest {
+		return AgeWalkRequest{Age: x, Older: older, Limit: limit, ResponseChan: respChan}
+	})
 
 }
 
-// FresherThanX - Returns a list of all pending tx(s), which are
-// living in mempool for less than or equals to `X` time unit
-func (p *PendingPool) FresherThanX(x time.Duration) []*MemPoolTx {
+// ForEachOlderThan - Visits copy of each pending tx, living in mempool for
+// more than or equals to `X` time unit, oldest first, until `visit` returns false
+//
+// Matching tx(s) are copied inside pool's life cycle manager, while `visit`
+// is invoked on caller's go routine, so it can't hold up pool
+func (p *PendingPool) ForEachOlderThan(x time.Duration, visit Visitor) {
 
-	txs := p.DescListTxs()
-	if txs == nil {
-		return nil
-	}
+	p.ForEachOlderThanWithContext(context.Background(), x, visit)
 
-	txCount := uint64(len(txs))
-	commChan := make(chan *MemPoolTx, txCount)
-	result := make([]*MemPoolTx, 0, txCount)
+}
 
-	wp := workerpool.New(runtime.NumCPU())
+// ForEachOlderThanWithContext - Context aware `ForEachOlderThan`, which stops
+// visiting as soon as `ctx` is done
+func (p *PendingPool) ForEachOlderThanWithContext(ctx context.Context, x time.Duration, visit Visitor) error {
 
-	for i := 0; i < len(txs); i++ {
+	txs, err := p.snapshotByAge(ctx, x, true, 0)
+	if err != nil {
+		return err
+	}
 
-		func(tx *MemPoolTx) {
+	return visitAll(ctx, txs, visit)
 
-			wp.Submit(func() {
+}
 
-				if tx.IsPendingForLTE(x) {
-					commChan <- tx
-					return
-				}
+// ForEachFresherThan - Visits copy of each pending tx, living in mempool for
+// less than or equals to `X` time unit, freshest first, until `visit` returns false
+//
+// Matching tx(s) are copied inside pool's life cycle manager, while `visit`
+// is invoked on caller's go routine, so it can't hold up pool
+func (p *PendingPool) ForEachFresherThan(x time.Duration, visit Visitor) {
 
-				commChan <- nil
+	p.ForEachFresherThanWithContext(context.Background(), x, visit)
 
-			})
+}
 
-		}(txs[i])
+// ForEachFresherThanWithContext - Context aware `ForEachFresherThan`, which stops
+// visiting as soon as `ctx` is done
+func (p *PendingPool) ForEachFresherThanWithContext(ctx context.Context, x time.Duration, visit Visitor) error {
 
+	txs, err := p.snapshotByAge(ctx, x, false, 0)
+	if err != nil {
+		return err
 	}
 
-	var received uint64
-	mustReceive := txCount
+	return visitAll(ctx, txs, visit)
 
-	// Waiting for all go routines to finish
-	for v := range commChan {
+}
 
-		if v != nil {
-			result = append(result, v)
-		}
+// OlderThanX - Returns a list of all pending tx(s), which are
+// living in mempool for more than or equals to `X` time unit, oldest first
+func (p *PendingPool) OlderThanX(x time.Duration) []*MemPoolTx {
 
-		received++
-		if received >= mustReceive {
-			break
-		}
+	v, _ := p.OlderThanXWithContext(context.Background(), x)
+	return v
 
-	}
+}
 
-	// This call is irrelevant here, probably, but still being made
-	//
-	// Because all workers have exited, otherwise we could have never
-	// reached this point
-	wp.Stop()
-	CleanSlice(txs)
+// OlderThanXWithContext - Context aware `OlderThanX`
+func (p *PendingPool) OlderThanXWithContext(ctx context.Context, x time.Duration) ([]*MemPoolTx, error) {
 
-	return result
+	return p.snapshotByAge(ctx, x, true, 0)
+
+}
+
+// FresherThanX - Returns a list of all pending tx(s), which are
+// living in mempool for less than or equals to `X` time unit, freshest first
+func (p *PendingPool) FresherThanX(x time.Duration) []*MemPoolTx {
+
+	v, _ := p.FresherThanXWithContext(context.Background(), x)
+	return v
+
+}
+
+// FresherThanXWithContext - Context aware `FresherThanX`
+func (p *PendingPool) FresherThanXWithContext(ctx context.Context, x time.Duration) ([]*MemPoolTx, error) {
+
+	return p.snapshotByAge(ctx, x, false, 0)
 
 }
 
 // HigherThanX - Returns a list of pending txs which are paid with
 // gas price >= `X`
 func (p *PendingPool) HigherThanX(x float64) []*MemPoolTx {
-	txs := p.DescListTxs()
-	if txs == nil {
-		return nil
+
+	v, _ := p.HigherThanXWithContext(context.Background(), x)
+	return v
+
+}
+
+// HigherThanXWithContext - Context aware `HigherThanX`
+func (p *PendingPool) HigherThanXWithContext(ctx context.Context, x float64) ([]*MemPoolTx, error) {
+	txs, err := p.DescListTxsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(txs) == 0 {
+		return nil, nil
 	}
 
 	txCount := uint64(len(txs))
@@ -998,15 +1900,27 @@ func (p *PendingPool) HigherThanX(x float64) []*MemPoolTx {
 	}
 
 	CleanSlice(txs)
-	return result
+	return result, nil
 }
 
 // LowerThanX - Returns a list of pending txs which are paid with
 // gas price <= `X`
 func (p *PendingPool) LowerThanX(x float64) []*MemPoolTx {
-	txs := p.AscListTxs()
-	if txs == nil {
-		return nil
+
+	v, _ := p.LowerThanXWithContext(context.Background(), x)
+	return v
+
+}
+
+// LowerThanXWithContext - Context aware `LowerThanX`
+func (p *PendingPool) LowerThanXWithContext(ctx context.Context, x float64) ([]*MemPoolTx, error) {
+	txs, err := p.AscListTxsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(txs) == 0 {
+		return nil, nil
 	}
 
 	txCount := uint64(len(txs))
@@ -1024,7 +1938,7 @@ func (p *PendingPool) LowerThanX(x float64) []*MemPoolTx {
 	}
 
 	CleanSlice(txs)
-	return result
+	return result, nil
 }
 
 // Add - Attempts to add new tx found in pending pool into
@@ -1032,13 +1946,15 @@ func (p *PendingPool) LowerThanX(x float64) []*MemPoolTx {
 //
 // If it returns `true`, it denotes, it's success, otherwise it's failure
 // because this tx is already present in pending pool
+//
+// Gives up as soon as `ctx` is done or pool has stopped, when outcome isn't
+// known, so it's reported as not added, even if pool went on to add it
 func (p *PendingPool) Add(ctx context.Context, tx *MemPoolTx) bool {
 
-	respChan := make(chan bool)
-
-	p.AddTxChan <- AddRequest{Tx: tx, ResponseChan: respChan}
-
-	return <-respChan
+	v, _ := request(ctx, p.StoppedChan, p.AddTxChan, func(respChan chan bool) AddRequest {
+		return AddRequest{Tx: tx, ResponseChan: respChan}
+	})
+	return v
 
 }
 
@@ -1047,11 +1963,10 @@ func (p *PendingPool) Add(ctx context.Context, tx *MemPoolTx) bool {
 // back to self for so
 func (p *PendingPool) AddUnstuck(ctx context.Context, tx *MemPoolTx) bool {
 
-	respChan := make(chan bool)
-
-	p.AddFromQueuedPoolChan <- AddRequest{Tx: tx, ResponseChan: respChan}
-
-	return <-respChan
+	v, _ := request(ctx, p.StoppedChan, p.AddFromQueuedPoolChan, func(respChan chan bool) AddRequest {
+		return AddRequest{Tx: tx, ResponseChan: respChan}
+	})
+	return v
 
 }
 
@@ -1073,72 +1988,138 @@ func (p *PendingPool) VerifiedAdd(ctx context.Context, tx *MemPoolTx) bool {
 
 }
 
-// PublishAdded - Publish new pending tx pool content ( in messagepack serialized format )
-// to pubsub topic
+// PublishAdded - Publish new pending tx pool content ( serialized using configured codec )
+// to pubsub topic, unless publishing on it is disabled
 func (p *PendingPool) PublishAdded(ctx context.Context, msg *MemPoolTx) {
 
-	data, err := msg.ToMessagePack()
-	if err != nil {
-		log.Printf("[❗️] Failed to serialize into messagepack : %s\n", err.Error())
+	if !config.GetPublishPendingEntry() {
 		return
 	}
 
-	if _, err := p.PubSub.Publish(&ops.Msg{
-		Topics: []string{config.GetPendingTxEntryPublishTopic()},
-		Data:   data,
-	}); err != nil {
-		log.Printf("[❗️] Failed to publish tx joining pending pool : %s\n", err.Error())
+	publishTx(p.PubSub, p.Codec, p.AddedBatch, config.GetPendingTxEntryPublishTopic(), &p.publishedAdded, msg, "tx joining pending pool")
+
+}
+
+// PublishReplaced - Publish pending tx, which has been replaced by some
+// fee bumped tx, to pubsub topic, unless publishing on it is disabled
+//
+// These tx(s) are still living in pending pool, but they're unlikely
+// to be mined
+func (p *PendingPool) PublishReplaced(ctx context.Context, msg *MemPoolTx) {
+
+	if !config.GetPublishPendingReplacement() {
+		return
 	}
 
+	publishTx(p.PubSub, p.Codec, nil, config.GetPendingTxReplacementPublishTopic(), &p.publishedReplaced, msg, "replaced pending tx")
+
+}
+
+// PublishReorged - Publish tx, re-admitted into pending pool, because block it
+// was pruned after got replaced due to chain reorganization, ( serialized using
+// configured codec ) to pubsub topic, unless publishing on it is disabled
+func (p *PendingPool) PublishReorged(ctx context.Context, msg *MemPoolTx) {
+
+	if !config.GetPublishPendingReorged() {
+		return
+	}
+
+	publishTx(p.PubSub, p.Codec, nil, config.GetPendingTxReorgedPublishTopic(), &p.publishedReorged, msg, "reorged pending tx")
+
+}
+
+// PublishPromoted - Publish tx, just promoted from queued pool to pending pool,
+// ( serialized using configured codec ) to pubsub topic, so that promotion
+// can be linked with its queued pool exit event, unless publishing on it
+// is disabled
+//
+// Time spent in queued pool is `UnstuckAt - QueuedAt`
+func (p *PendingPool) PublishPromoted(ctx context.Context, msg *MemPoolTx) {
+
+	if !config.GetPublishQueuedToPending() {
+		return
+	}
+
+	publishTx(p.PubSub, p.Codec, nil, config.GetQueuedToPendingPublishTopic(), &p.publishedPromoted, msg, "promoted queued tx")
+
 }
 
 // Remove - Removes already existing tx from pending tx pool
 // denoting it has been mined i.e. confirmed/ dropped ( possible too )
+//
+// False is returned, when `ctx` is done or pool has stopped before answering
 func (p *PendingPool) Remove(ctx context.Context, txStat *TxStatus) bool {
 
-	respChan := make(chan bool)
-	p.RemoveTxChan <- RemoveRequest{TxStat: txStat, ResponseChan: respChan}
+	v, _ := request(ctx, p.StoppedChan, p.RemoveTxChan, func(respChan chan bool) RemoveRequest {
+		return RemoveRequest{TxStat: txStat, ResponseChan: respChan}
+	})
+	return v
+
+}
 
-	return <-respChan
+// ForceRemove - Evicts tx from pending pool, on operator's request, which
+// is also remembered as dropped, so that it doesn't reappear from next poll,
+// returning whether it was actually present
+func (p *PendingPool) ForceRemove(ctx context.Context, txHash common.Hash) bool {
+
+	return p.Remove(ctx, &TxStatus{Hash: txHash, Status: MANUALLY_EVICTED})
 
 }
 
-// PublishRemoved - Publish old pending tx pool content ( in messagepack serialized format )
+// PublishRemoved - Publish old pending tx pool content ( serialized using configured codec )
 // to pubsub topic
 //
-// These tx(s) are leaving pending pool i.e. they're confirmed now
+// These tx(s) are leaving pending pool i.e. they're confirmed now, skipped
+// when publishing on it is disabled
 func (p *PendingPool) PublishRemoved(ctx context.Context, msg *MemPoolTx) {
 
-	data, err := msg.ToMessagePack()
-	if err != nil {
-		log.Printf("[❗️] Failed to serialize into messagepack : %s\n", err.Error())
+	if !config.GetPublishPendingExit() {
 		return
 	}
 
-	if _, err := p.PubSub.Publish(&ops.Msg{
-		Topics: []string{config.GetPendingTxExitPublishTopic()},
-		Data:   data,
-	}); err != nil {
-		log.Printf("[❗️] Failed to publish tx leaving pending pool : %s\n", err.Error())
+	publishTx(p.PubSub, p.Codec, p.RemovedBatch, config.GetPendingTxExitPublishTopic(), &p.publishedRemoved, msg, "tx leaving pending pool")
+
+}
+
+// AddBatch - Adds multiple tx(s) into pending pool, in one go, returning
+// how many of them were actually added
+//
+// Zero is returned, when `ctx` is done or pool has stopped before answering
+func (p *PendingPool) AddBatch(ctx context.Context, txs []*MemPoolTx) uint64 {
+
+	if len(txs) == 0 {
+		return 0
 	}
 
+	v, _ := request(ctx, p.StoppedChan, p.AddBatchChan, func(respChan chan uint64) AddBatchRequest {
+		return AddBatchRequest{Txs: txs, ResponseChan: respChan}
+	})
+	return v
+
+}
+
+// PruneCandidates - Lets pruner know these tx(s) have disappeared from
+// mempool content, so they may have been dropped, without waiting on it
+func (p *PendingPool) PruneCandidates(hashes []common.Hash) {
+
+	p.candidates.push(hashes)
+
 }
 
 // AddPendings - Update latest pending pool state
+//
+// All tx(s) are admitted in single request, so that pool's life cycle
+// manager doesn't get interleaved with readers, while ingesting them
 func (p *PendingPool) AddPendings(ctx context.Context, txs map[string]map[string]*MemPoolTx) uint64 {
 
-	var count uint64
+	batch := make([]*MemPoolTx, 0, len(txs))
 
 	for keyO := range txs {
 		for keyI := range txs[keyO] {
-
-			if p.Add(ctx, txs[keyO][keyI]) {
-				count++
-			}
-
+			batch = append(batch, txs[keyO][keyI])
 		}
 	}
 
-	return count
+	return p.AddBatch(ctx, batch)
 
 }
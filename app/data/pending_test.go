@@ -0,0 +1,666 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/itzmeanjan/harmony/app/listen"
+)
+
+// Tx published by one node & received by another one, over p2p, keeps
+// pending since timestamp of node which saw it first, for every codec
+func TestPendingFromSurvivesPeerHop(t *testing.T) {
+
+	for _, name := range []string{"msgpack", "json", "protobuf"} {
+		t.Run(name, func(t *testing.T) {
+
+			codec := NewCodec(name)
+			origin := newTestPools(t)
+			peer := newTestPools(t)
+
+			tx := dynamicFeeTx(1, 100, 2)
+			tx.From = txAddress(1)
+			tx.ChainID = (*hexutil.Big)(big.NewInt(1))
+			tx.PendingFrom = time.Now().UTC().Add(-time.Minute).Round(0)
+
+			if !origin.Pending.Add(context.Background(), tx) {
+				t.Fatal("expected tx to be admitted by origin")
+			}
+
+			published := origin.Pending.Get(tx.Hash)
+			if published == nil || !published.PendingFrom.Equal(tx.PendingFrom) {
+				t.Fatal("expected origin to keep pending since timestamp")
+			}
+
+			data, err := SerializeMany(codec, []*MemPoolTx{published})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			received, err := DeserializeMany(codec, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ok, err := peer.HandleTxFromPeer(context.Background(), received[0]); err != nil || !ok {
+				t.Fatalf("expected tx to be admitted by peer, got (%v, %v)", ok, err)
+			}
+
+			readded := peer.Pending.Get(tx.Hash)
+			if readded == nil {
+				t.Fatal("expected tx in peer's pending pool")
+			}
+
+			if !readded.PendingFrom.Equal(tx.PendingFrom) {
+				t.Fatalf("expected pending since %s, got %s", tx.PendingFrom, readded.PendingFrom)
+			}
+
+		})
+	}
+
+}
+
+// Peer's clock running ahead must not make tx look younger than it is
+func TestPendingFromInFutureIsClamped(t *testing.T) {
+
+	pool := newTestPools(t)
+
+	tx := legacyTx(1, 10)
+	tx.PendingFrom = time.Now().UTC().Add(time.Hour)
+
+	if !pool.Pending.Add(context.Background(), tx) {
+		t.Fatal("expected tx to be admitted")
+	}
+
+	if got := pool.Pending.Get(tx.Hash); got.PendingFrom.After(time.Now().UTC()) {
+		t.Fatalf("expected pending since timestamp to be clamped, got %s", got.PendingFrom)
+	}
+
+}
+
+func BenchmarkClone(b *testing.B) {
+
+	tx := sampleTx()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		tx.Clone()
+	}
+
+}
+
+// Listing deep copies only requested window, so cost of asking for one
+// page stays flat, while copying whole pool grows with it
+func BenchmarkListPage(b *testing.B) {
+
+	withConfig(b, map[string]string{"PendingPoolSize": "50000"})
+
+	pool := newTestPools(b)
+	fillPending(b, pool, makeTxs(50_000, 5_000))
+
+	for _, limit := range []uint64{100, 0} {
+
+		name := "window=" + strconv.FormatUint(limit, 10)
+		if limit == 0 {
+			name = "window=all"
+		}
+
+		b.Run(name, func(b *testing.B) {
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if page := pool.Pending.ListPage(DESC, 0, limit); len(page.Txs) == 0 {
+					b.Fatal("expected non-empty page")
+				}
+			}
+
+		})
+
+	}
+
+}
+
+// Tx evicted from full pending pool leaves it same way as from queued pool,
+// marked dropped along with policy followed, with exit event emitted
+func TestPendingEvictionMarksDropped(t *testing.T) {
+
+	withConfig(t, map[string]string{"PendingPoolSize": "2", "PendingPoolEvictionPolicy": LowestGasPrice})
+
+	pool := newTestPools(t)
+
+	left := make(chan *MemPoolTx, 4)
+	pool.Pending.RegisterRemoveHook(func(tx *MemPoolTx) {
+		left <- tx
+	})
+
+	cheap, mid, high := legacyTx(1, 10), legacyTx(2, 20), legacyTx(3, 30)
+	for _, tx := range []*MemPoolTx{cheap, mid, high} {
+		tx.From = txAddress(int(tx.Hash[0]))
+
+		if !pool.Pending.Add(context.Background(), tx) {
+			t.Fatalf("expected %s to be admitted", tx.Hash)
+		}
+	}
+
+	var evicted *MemPoolTx
+	select {
+	case evicted = <-left:
+	case <-time.After(time.Second):
+		t.Fatal("expected remove hook to be invoked for evicted tx")
+	}
+
+	if evicted.Hash != cheap.Hash {
+		t.Fatalf("expected %s to be evicted, got %s", cheap.Hash, evicted.Hash)
+	}
+
+	if evicted.Pool != "dropped" || evicted.EvictionReason != LowestGasPrice || evicted.DroppedAt.IsZero() {
+		t.Fatalf("expected evicted tx to be marked dropped, got pool `%s`, reason `%s`", evicted.Pool, evicted.EvictionReason)
+	}
+
+	if pool.Pending.Exists(cheap.Hash) || pool.Pending.Count() != 2 {
+		t.Fatal("expected evicted tx to be gone from pool")
+	}
+
+	again := legacyTx(1, 10)
+	again.From = cheap.From
+	if pool.Pending.Add(context.Background(), again) {
+		t.Fatal("expected evicted tx not to be re-admitted")
+	}
+
+}
+
+// Sender flooding pool keeps only its best paying tx(s), within its cap
+func TestPerSenderCap(t *testing.T) {
+
+	withConfig(t, map[string]string{"PendingPoolSize": "2000", "MaxTxsPerAddress": "64"})
+
+	pool := newTestPools(t)
+
+	evicted := make(chan *MemPoolTx, 1000)
+	pool.Pending.RegisterRemoveHook(func(tx *MemPoolTx) {
+		evicted <- tx.Clone()
+	})
+
+	// Distinct gas prices, arriving in scrambled order
+	txs := makeTxs(1000, 1)
+	for i, tx := range txs {
+		tx.GasPrice = gwei(int64(1 + (i*7)%1000))
+		pool.Pending.Add(context.Background(), tx)
+	}
+
+	if len(evicted) == 0 {
+		t.Fatal("expected sender's cheaper tx(s) to be evicted")
+	}
+
+	for len(evicted) > 0 {
+		if tx := <-evicted; tx.Pool != "dropped" || tx.EvictionReason != SenderCap || tx.DroppedAt.IsZero() {
+			t.Fatalf("expected evicted tx to be marked dropped, for sender's cap, got pool `%s`, reason `%s`", tx.Pool, tx.EvictionReason)
+		}
+	}
+
+	sender := txAddress(0)
+	if n := pool.Pending.CountFrom(sender); n != 64 {
+		t.Fatalf("expected sender to be capped at 64 tx(s), got %d", n)
+	}
+
+	kept := pool.Pending.TxsFromA(sender)
+	if len(kept) != 64 {
+		t.Fatalf("expected 64 tx(s) from sender, got %d", len(kept))
+	}
+
+	lowest := BigHexToBigDecimal(gwei(1000 - 64 + 1))
+	for _, tx := range kept {
+		if tx.EffectiveGasPrice(nil).Cmp(lowest) < 0 {
+			t.Fatalf("expected only 64 best paying tx(s) to be kept, found one paying %s", tx.EffectiveGasPrice(nil))
+		}
+	}
+
+	// Paying less than anything sender already has, so it's refused
+	cheap := legacyTx(0xff, 1)
+	cheap.From = sender
+	if pool.Pending.Add(context.Background(), cheap) {
+		t.Fatal("expected tx paying less than sender's cheapest to be refused")
+	}
+
+	// Some other sender isn't affected
+	other := legacyTx(0xfe, 1)
+	other.From = txAddress(1)
+	if !pool.Pending.Add(context.Background(), other) {
+		t.Fatal("expected tx from other sender to be admitted")
+	}
+
+}
+
+// Contract creation tx(s) living in pools must be skipped, while looking
+// for tx(s) sent to some address
+func TestSentToSkipsContractCreations(t *testing.T) {
+
+	pool := newTestPools(t)
+
+	target := txAddress(42)
+
+	creation := legacyTx(1, 10)
+	sent := legacyTx(2, 10)
+	sent.To = &target
+	queuedCreation := legacyTx(3, 10)
+	queuedSent := legacyTx(4, 10)
+	queuedSent.To = &target
+
+	fillPending(t, pool, []*MemPoolTx{creation, sent})
+
+	for _, tx := range []*MemPoolTx{queuedCreation, queuedSent} {
+		if !pool.Queued.Add(context.Background(), tx) {
+			t.Fatalf("expected %s to be admitted into queued pool", tx.Hash)
+		}
+	}
+
+	for _, got := range [][]*MemPoolTx{pool.Pending.SentTo(target), pool.Queued.SentTo(target)} {
+		if len(got) != 1 || got[0].To == nil || *got[0].To != target {
+			t.Fatalf("expected only tx sent to %s, got %d tx(s)", target.Hex(), len(got))
+		}
+	}
+
+	if len(pool.Pending.SentTo(common.Address{})) != 0 || len(pool.Queued.SentTo(common.Address{})) != 0 {
+		t.Fatal("expected contract creations not to be reported as sent to zero address")
+	}
+
+	if len(pool.Pending.SentToWithMethod(common.Address{}, [4]byte{})) != 0 {
+		t.Fatal("expected contract creations not to be reported as invoking any method")
+	}
+
+}
+
+// Per sender entries are reclaimed as soon as sender has nothing left in
+// pool, so that churning senders don't leak memory
+func TestSenderEntriesReclaimed(t *testing.T) {
+
+	withConfig(t, map[string]string{"PendingPoolSize": "20000", "QueuedPoolSize": "20000"})
+
+	pool := newTestPools(t)
+
+	pending := makeTxs(10_000, 10_000)
+	fillPending(t, pool, pending)
+
+	queued := makeTxs(10_000, 10_000)
+	for i, tx := range queued {
+		tx.Hash = txHash(10_000 + i)
+		tx.Nonce += 5
+
+		if !pool.Queued.Add(context.Background(), tx) {
+			t.Fatalf("expected %s to be admitted into queued pool", tx.Hash)
+		}
+	}
+
+	if n := pool.Pending.Aggregates().SenderEntries; n != 10_000 {
+		t.Fatalf("expected 10000 sender entries in pending pool, got %d", n)
+	}
+
+	if n := pool.Queued.Aggregates().SenderEntries; n != 10_000 {
+		t.Fatalf("expected 10000 sender entries in queued pool, got %d", n)
+	}
+
+	for _, tx := range pending {
+		if !pool.Pending.Remove(context.Background(), &TxStatus{Hash: tx.Hash, Status: CONFIRMED}) {
+			t.Fatalf("expected %s to be removed from pending pool", tx.Hash)
+		}
+	}
+
+	for _, tx := range queued {
+		if pool.Queued.Remove(context.Background(), tx.Hash) == nil {
+			t.Fatalf("expected %s to be removed from queued pool", tx.Hash)
+		}
+	}
+
+	for name, aggregates := range map[string]PoolAggregates{"pending": pool.Pending.Aggregates(), "queued": pool.Queued.Aggregates()} {
+		if aggregates.Count != 0 || aggregates.UniqueSenders != 0 || aggregates.SenderEntries != 0 {
+			t.Fatalf("expected %s pool to be back to zero, got %d tx(s), %d sender(s), %d sender entries", name, aggregates.Count, aggregates.UniqueSenders, aggregates.SenderEntries)
+		}
+	}
+
+}
+
+// Visitor runs on caller's go routine, so it can query pool it's walking
+// over, which used to dead lock, when it was invoked inside life cycle manager
+func TestForEachOlderThanVisitsOnCaller(t *testing.T) {
+
+	pool := newTestPools(t)
+
+	txs := makeTxs(10, 10)
+	for i, tx := range txs {
+		tx.PendingFrom = time.Now().UTC().Add(-time.Duration(len(txs)-i) * time.Minute)
+	}
+
+	fillPending(t, pool, txs)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	visited := make([]*MemPoolTx, 0, 3)
+	if err := pool.Pending.ForEachOlderThanWithContext(ctx, 5*time.Minute, func(tx *MemPoolTx) bool {
+
+		if _, err := pool.Pending.GetWithContext(ctx, tx.Hash); err != nil {
+			t.Errorf("expected pool to be queried from visitor, got %q", err.Error())
+		}
+
+		visited = append(visited, tx)
+		return len(visited) < 3
+
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(visited) != 3 {
+		t.Fatalf("expected walk to stop after 3 tx(s), got %d", len(visited))
+	}
+
+	for i, tx := range visited {
+		if tx.Hash != txs[i].Hash {
+			t.Fatalf("rank %d : expected %s, got %s", i, txs[i].Hash, tx.Hash)
+		}
+	}
+
+	// Walk is given up on, once caller is no longer interested
+	cancel()
+
+	if err := pool.Pending.ForEachFresherThanWithContext(ctx, time.Hour, func(*MemPoolTx) bool {
+		t.Error("expected no tx to be visited, after walk was given up on")
+		return true
+	}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected walk to be given up on, got %v", err)
+	}
+
+}
+
+// Many go routines add to full pending pool at once, each admission evicting
+// some other tx, while re-adding already seen tx(s) looks up dropped ones,
+// which is supposed to be run with `-race`
+func TestPendingEvictionConcurrentAdds(t *testing.T) {
+
+	const (
+		size    = 64
+		workers = 16
+		n       = 1600
+	)
+
+	withConfig(t, map[string]string{"PendingPoolSize": strconv.Itoa(size), "PendingPoolEvictionPolicy": LowestGasPrice})
+
+	pool := newTestPools(t)
+
+	var lock sync.Mutex
+	added := make(map[common.Hash]int)
+	evicted := make(map[common.Hash]int)
+
+	pool.Pending.RegisterAddHook(func(tx *MemPoolTx) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		added[tx.Hash]++
+	})
+	pool.Pending.RegisterRemoveHook(func(tx *MemPoolTx) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if tx.Pool != "dropped" || tx.EvictionReason != LowestGasPrice || tx.DroppedAt.IsZero() {
+			t.Errorf("expected evicted tx to be marked dropped, got pool `%s`, reason `%s`", tx.Pool, tx.EvictionReason)
+		}
+
+		evicted[tx.Hash]++
+	})
+
+	txs := makeTxs(n, n)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+
+		wg.Add(1)
+		go func(own []*MemPoolTx) {
+			defer wg.Done()
+
+			for _, tx := range own {
+				if !pool.Pending.Add(context.Background(), tx.Clone()) {
+					t.Errorf("expected %s to be admitted, evicting some other tx", tx.Hash)
+				}
+			}
+
+			// Each one is either living in pool or already dropped
+			for _, tx := range own {
+				if pool.Pending.Add(context.Background(), tx.Clone()) {
+					t.Errorf("expected %s not to be admitted again", tx.Hash)
+				}
+			}
+
+		}(txs[w*n/workers : (w+1)*n/workers])
+
+	}
+
+	wg.Wait()
+
+	if count := pool.Pending.Count(); count != size {
+		t.Fatalf("expected pool to stay at capacity of %d, got %d", size, count)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if len(added) != n || len(evicted) != n-size {
+		t.Fatalf("expected %d add & %d remove hook invocations, got %d & %d", n, n-size, len(added), len(evicted))
+	}
+
+	for hash, times := range evicted {
+
+		if times != 1 || added[hash] != 1 {
+			t.Fatalf("expected %s to be added & evicted once, got %d & %d", hash, added[hash], times)
+		}
+
+		if pool.Pending.Exists(hash) {
+			t.Fatalf("expected evicted tx %s to be gone from pool", hash)
+		}
+
+	}
+
+}
+
+// Batch larger than buffer of channel, queued pool is notified on, is
+// admitted, while queued pool moves tx of one of batch senders into pending
+// pool, which used to leave both pools waiting on each other
+func TestBatchLargerThanNotificationBuffer(t *testing.T) {
+
+	const n = 5000
+
+	withConfig(t, map[string]string{"PendingPoolSize": strconv.Itoa(2 * n)})
+
+	_, client := newFakeRPC(t)
+	pool := newPrunedTestPools(t, client)
+
+	txs := makeTxs(n, n)
+
+	// Next nonce of first sender, waiting in queued pool
+	next := legacyTx(0xff, 10)
+	next.From = txs[0].From
+	next.Nonce = txs[0].Nonce + 1
+
+	if !pool.Queued.Add(context.Background(), next) {
+		t.Fatal("expected tx to be queued")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if added := pool.Pending.AddBatch(ctx, txs); added != n {
+		t.Fatalf("expected %d tx(s) to be admitted, got %d", n, added)
+	}
+
+	for {
+
+		exists, err := pool.Pending.ExistsWithContext(ctx, next.Hash)
+		if err != nil {
+			t.Fatalf("expected pending pool to keep serving requests, got %q", err.Error())
+		}
+
+		if exists {
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+
+	}
+
+	if count, err := pool.Pending.CountWithContext(ctx); err != nil || count != n+1 {
+		t.Fatalf("expected %d tx(s) in pending pool, got %d", n+1, count)
+	}
+
+	if pool.Queued.Exists(next.Hash) {
+		t.Fatal("expected tx to have left queued pool")
+	}
+
+}
+
+// Tx living in queued pool, which is added into pending pool directly, as
+// it's seen pending in next poll, before queued pool could hand it over, is
+// still announced as promoted, unlike tx never seen queued
+func TestPromotedOnDirectAdd(t *testing.T) {
+
+	for _, name := range []string{"single", "batch"} {
+		t.Run(name, func(t *testing.T) {
+
+			pool := newTestPools(t)
+
+			queuedAt := time.Now().UTC().Add(-time.Minute).Round(0)
+			promoted := queuedTx(1, 1, 0, 10, queuedAt)
+			fresh := queuedTx(2, 2, 0, 10, time.Time{})
+
+			if !pool.Queued.Add(context.Background(), promoted.Clone()) {
+				t.Fatal("expected tx to be queued")
+			}
+
+			announced := make(chan *MemPoolTx, 2)
+			pool.Pending.RegisterAddHook(func(tx *MemPoolTx) {
+				announced <- tx.Clone()
+			})
+
+			promoted.QueuedAt = time.Time{}
+
+			if name == "single" {
+				if !pool.Pending.Add(context.Background(), promoted) || !pool.Pending.Add(context.Background(), fresh) {
+					t.Fatal("expected both tx(s) to be admitted")
+				}
+			} else {
+				if added := pool.Pending.AddBatch(context.Background(), []*MemPoolTx{promoted, fresh}); added != 2 {
+					t.Fatalf("expected both tx(s) to be admitted, got %d", added)
+				}
+			}
+
+			for i := 0; i < 2; i++ {
+
+				var tx *MemPoolTx
+				select {
+				case tx = <-announced:
+				case <-time.After(time.Second):
+					t.Fatal("expected add hook to be invoked for each tx")
+				}
+
+				if tx.Hash == fresh.Hash {
+					if tx.Promoted {
+						t.Fatal("expected tx never seen queued not to be marked promoted")
+					}
+					continue
+				}
+
+				if !tx.Promoted || !tx.QueuedAt.Equal(queuedAt) || tx.UnstuckAt.IsZero() {
+					t.Fatalf("expected tx to be announced promoted, queued at %s, got %v, %s, %s", queuedAt, tx.Promoted, tx.QueuedAt, tx.UnstuckAt)
+				}
+
+			}
+
+		})
+	}
+
+}
+
+// Tx rejected by pending pool, while being handed over by queued pool, is
+// never marked promoted
+func TestPromotedOnlyOnceAdmitted(t *testing.T) {
+
+	pool := newTestPools(t)
+
+	tx := queuedTx(1, 1, 0, 10, time.Now().UTC())
+	if !pool.Pending.Add(context.Background(), tx.Clone()) {
+		t.Fatal("expected tx to be admitted")
+	}
+
+	again := tx.Clone()
+	if pool.Pending.AddUnstuck(context.Background(), again) {
+		t.Fatal("expected duplicate tx to be rejected")
+	}
+
+	if again.Promoted || pool.Pending.Get(tx.Hash).Promoted {
+		t.Fatal("expected rejected tx not to be marked promoted")
+	}
+
+}
+
+// New head carrying base fee re-keys gas price ordered views of both pools,
+// so that dynamic fee tx with high fee cap & low tip moves below legacy tx,
+// which pays more at that base fee
+func TestNewHeadRekeysGasPriceViews(t *testing.T) {
+
+	pool := newTestPools(t)
+
+	for i, p := range []interface {
+		Add(context.Context, *MemPoolTx) bool
+	}{pool.Pending, pool.Queued} {
+
+		lowTip := dynamicFeeTx(byte(2*i+1), 200, 1)
+		lowTip.From = txAddress(2*i + 1)
+		legacy := legacyTx(byte(2*i+2), 60)
+		legacy.From = txAddress(2*i + 2)
+
+		if !p.Add(context.Background(), lowTip) || !p.Add(context.Background(), legacy) {
+			t.Fatal("expected both tx(s) to be admitted")
+		}
+
+	}
+
+	order := func(page TxPage) []byte {
+
+		hashes := make([]byte, 0, len(page.Txs))
+		for _, tx := range page.Txs {
+			hashes = append(hashes, tx.Hash[0])
+		}
+
+		return hashes
+
+	}
+
+	if got := order(pool.Pending.ListPage(DESC, 0, 0)); !bytes.Equal(got, []byte{1, 2}) {
+		t.Fatalf("expected pending tx(s) ordered by fee cap before any base fee is seen, got %v", got)
+	}
+
+	pool.Pending.SetLastSeenBlockChan <- listen.Head{Number: 1, BaseFee: BigHexToBigDecimal(gwei(50))}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+
+		pending := order(pool.Pending.ListPage(DESC, 0, 0))
+		queued := order(pool.Queued.ListPage(DESC, 0, 0))
+		if bytes.Equal(pending, []byte{2, 1}) && bytes.Equal(queued, []byte{4, 3}) {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("expected both pools to be re-keyed on new base fee, got pending %v & queued %v", pending, queued)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+
+	}
+
+}
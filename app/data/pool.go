@@ -2,12 +2,23 @@ package data
 
 import (
 	"context"
+	"errors"
 	"log"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/itzmeanjan/harmony/app/config"
 )
 
+// ErrPoolStopped - Pool's life cycle manager has exited, so it can't
+// answer any request anymore
+var ErrPoolStopped = errors.New("pool has been stopped")
+
 // MemPool - Current state of mempool, where all pending/ queued tx(s)
 // are present. Among these pending tx(s), any of them can be picked up during next
 // block mining phase, but any tx(s) present in queued pool, can't be picked up
@@ -17,6 +28,11 @@ import (
 type MemPool struct {
 	Pending *PendingPool
 	Queued  *QueuedPool
+	ChainID *big.Int
+	// Hashes seen in previous poll, accessed only by poller
+	last *pollSnapshot
+	// How long last poll took, in nanoseconds, to be accessed atomically
+	lastPollDuration int64
 }
 
 // Get - Given a txhash, attempts to find out tx, if
@@ -45,16 +61,155 @@ func (m *MemPool) Exists(hash common.Hash) bool {
 
 }
 
+// GetWithContext - Context aware `Get`
+func (m *MemPool) GetWithContext(ctx context.Context, hash common.Hash) (*MemPoolTx, error) {
+
+	queued, err := m.Queued.GetWithContext(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if queued != nil {
+		return queued, nil
+	}
+
+	return m.Pending.GetWithContext(ctx, hash)
+
+}
+
+// ExistsWithContext - Context aware `Exists`
+func (m *MemPool) ExistsWithContext(ctx context.Context, hash common.Hash) (bool, error) {
+
+	queued, err := m.Queued.ExistsWithContext(ctx, hash)
+	if err != nil {
+		return false, err
+	}
+
+	if queued {
+		return queued, nil
+	}
+
+	return m.Pending.ExistsWithContext(ctx, hash)
+
+}
+
 // PendingDuplicates - Find duplicate tx(s), given txHash, present
-// in pending mempool
+// in pending mempool, where given tx may be living in any pool
 func (m *MemPool) PendingDuplicates(hash common.Hash) []*MemPoolTx {
-	return m.Pending.DuplicateTxs(hash)
+
+	v, _ := m.PendingDuplicatesWithContext(context.Background(), hash)
+	return v
+
+}
+
+// PendingDuplicatesWithContext - Context aware `PendingDuplicates`
+func (m *MemPool) PendingDuplicatesWithContext(ctx context.Context, hash common.Hash) ([]*MemPoolTx, error) {
+
+	tx, err := m.GetWithContext(ctx, hash)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+
+	return m.Pending.SameNonceTxsWithContext(ctx, tx)
+
+}
+
+// PendingReplacementsOf - Find tx(s), present in pending mempool, which
+// have replaced given tx, by bumping fee
+func (m *MemPool) PendingReplacementsOf(hash common.Hash) []*MemPoolTx {
+	return m.Pending.ReplacementsOf(hash)
+}
+
+// PendingReplacementsOfWithContext - Context aware `PendingReplacementsOf`
+func (m *MemPool) PendingReplacementsOfWithContext(ctx context.Context, hash common.Hash) ([]*MemPoolTx, error) {
+	return m.Pending.ReplacementsOfWithContext(ctx, hash)
 }
 
 // QueuedDuplicates - Find duplicate tx(s), given txHash, present
-// in queued mempool
+// in queued mempool, where given tx may be living in any pool
 func (m *MemPool) QueuedDuplicates(hash common.Hash) []*MemPoolTx {
-	return m.Queued.DuplicateTxs(hash)
+
+	v, _ := m.QueuedDuplicatesWithContext(context.Background(), hash)
+	return v
+
+}
+
+// QueuedDuplicatesWithContext - Context aware `QueuedDuplicates`
+func (m *MemPool) QueuedDuplicatesWithContext(ctx context.Context, hash common.Hash) ([]*MemPoolTx, error) {
+
+	tx, err := m.GetWithContext(ctx, hash)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+
+	return m.Queued.SameNonceTxsWithContext(ctx, tx)
+
+}
+
+// Duplicates - Find duplicate tx(s), given txHash, living in either
+// of pending/ queued pool, descending ordered as per gas price paid
+//
+// Duplicate pair may span pools i.e. one queued, other one pending
+func (m *MemPool) Duplicates(hash common.Hash) []*MemPoolTx {
+
+	v, _ := m.DuplicatesWithContext(context.Background(), hash)
+	return v
+
+}
+
+// DuplicatesWithContext - Context aware `Duplicates`
+func (m *MemPool) DuplicatesWithContext(ctx context.Context, hash common.Hash) ([]*MemPoolTx, error) {
+
+	tx, err := m.GetWithContext(ctx, hash)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+
+	return m.sameNonceTxs(ctx, tx)
+
+}
+
+// sameNonceTxs - Tx(s) with same sender & nonce as given one, from both
+// pools, descending ordered as per gas price paid
+func (m *MemPool) sameNonceTxs(ctx context.Context, tx *MemPoolTx) ([]*MemPoolTx, error) {
+
+	pending, err := m.Pending.SameNonceTxsWithContext(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	queued, err := m.Queued.SameNonceTxsWithContext(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := append(pending, queued...)
+	if len(txs) == 0 {
+		return nil, nil
+	}
+
+	SortByGasPriceDesc(txs)
+	return txs, nil
+
+}
+
+// TxsByNonce - Find tx(s) sent from given address with given nonce, living in
+// either of pending/ queued pool, descending ordered as per gas price paid
+//
+// More than one tx is returned, when original one is being replaced
+func (m *MemPool) TxsByNonce(from common.Address, nonce uint64) []*MemPoolTx {
+
+	v, _ := m.TxsByNonceWithContext(context.Background(), from, nonce)
+	return v
+
+}
+
+// TxsByNonceWithContext - Context aware `TxsByNonce`
+func (m *MemPool) TxsByNonceWithContext(ctx context.Context, from common.Address, nonce uint64) ([]*MemPoolTx, error) {
+
+	// No tx has zero hash, so none of them is excluded
+	return m.sameNonceTxs(ctx, &MemPoolTx{From: from, Nonce: hexutil.Uint64(nonce)})
+
 }
 
 // PendingPoolLength - Returning current pending tx queue length
@@ -67,6 +222,61 @@ func (m *MemPool) QueuedPoolLength() uint64 {
 	return m.Queued.Count()
 }
 
+// Resized - Lets both pools know their size limits might have changed,
+// after config got reloaded
+func (m *MemPool) Resized() {
+
+	m.Pending.Resized()
+	m.Queued.Resized()
+
+}
+
+// PendingPoolLengthWithContext - Context aware `PendingPoolLength`
+func (m *MemPool) PendingPoolLengthWithContext(ctx context.Context) (uint64, error) {
+	return m.Pending.CountWithContext(ctx)
+}
+
+// QueuedPoolLengthWithContext - Context aware `QueuedPoolLength`
+func (m *MemPool) QueuedPoolLengthWithContext(ctx context.Context) (uint64, error) {
+	return m.Queued.CountWithContext(ctx)
+}
+
+// PendingPage - Returns window of pending tx(s), ordered as per gas price
+// paid, along with total #-of pending tx(s), for paginated listing
+func (m *MemPool) PendingPage(order int, offset uint64, limit uint64) TxPage {
+	return m.Pending.ListPage(order, offset, limit)
+}
+
+// QueuedPage - Returns window of queued tx(s), ordered as per gas price
+// paid, along with total #-of queued tx(s), for paginated listing
+func (m *MemPool) QueuedPage(order int, offset uint64, limit uint64) TxPage {
+	return m.Queued.ListPage(order, offset, limit)
+}
+
+// PendingPageWithContext - Context aware `PendingPage`
+func (m *MemPool) PendingPageWithContext(ctx context.Context, order int, offset uint64, limit uint64) (TxPage, error) {
+	return m.Pending.ListPageWithContext(ctx, order, offset, limit)
+}
+
+// QueuedPageWithContext - Context aware `QueuedPage`
+func (m *MemPool) QueuedPageWithContext(ctx context.Context, order int, offset uint64, limit uint64) (TxPage, error) {
+	return m.Queued.ListPageWithContext(ctx, order, offset, limit)
+}
+
+// PendingAfterWithContext - Returns window of pending tx(s), ordered as per
+// gas price paid, starting right after tx with given hash, if any, for cursor
+// based pagination
+func (m *MemPool) PendingAfterWithContext(ctx context.Context, order int, after *common.Hash, limit uint64) (TxPage, error) {
+	return m.Pending.ListAfterWithContext(ctx, order, after, limit)
+}
+
+// QueuedAfterWithContext - Returns window of queued tx(s), ordered as per
+// gas price paid, starting right after tx with given hash, if any, for cursor
+// based pagination
+func (m *MemPool) QueuedAfterWithContext(ctx context.Context, order int, after *common.Hash, limit uint64) (TxPage, error) {
+	return m.Queued.ListAfterWithContext(ctx, order, after, limit)
+}
+
 // DoneTxCount - #-of tx(s) seen to processed during this node's life time
 func (m *MemPool) DoneTxCount() uint64 {
 	return m.Pending.Processed()
@@ -78,50 +288,144 @@ func (m *MemPool) LastSeenBlock() LastSeenBlock {
 	return m.Pending.GetLastSeenBlock()
 }
 
+// DoneTxCountWithContext - Context aware `DoneTxCount`
+func (m *MemPool) DoneTxCountWithContext(ctx context.Context) (uint64, error) {
+	return m.Pending.ProcessedWithContext(ctx)
+}
+
+// LastSeenBlockWithContext - Context aware `LastSeenBlock`
+func (m *MemPool) LastSeenBlockWithContext(ctx context.Context) (LastSeenBlock, error) {
+	return m.Pending.GetLastSeenBlockWithContext(ctx)
+}
+
 // PendingForGTE - Returning list of tx(s), pending for more than
 // x time unit
 func (m *MemPool) PendingForGTE(x time.Duration) []*MemPoolTx {
 	return m.Pending.OlderThanX(x)
 }
 
+// PendingForGTEWithContext - Context aware `PendingForGTE`
+func (m *MemPool) PendingForGTEWithContext(ctx context.Context, x time.Duration) ([]*MemPoolTx, error) {
+	return m.Pending.OlderThanXWithContext(ctx, x)
+}
+
 // PendingForLTE - Returning list of tx(s), pending for less than
 // x time unit
 func (m *MemPool) PendingForLTE(x time.Duration) []*MemPoolTx {
 	return m.Pending.FresherThanX(x)
 }
 
+// PendingForLTEWithContext - Context aware `PendingForLTE`
+func (m *MemPool) PendingForLTEWithContext(ctx context.Context, x time.Duration) ([]*MemPoolTx, error) {
+	return m.Pending.FresherThanXWithContext(ctx, x)
+}
+
 // QueuedForGTE - Returning list of tx(s), queued for more than
 // x time unit
 func (m *MemPool) QueuedForGTE(x time.Duration) []*MemPoolTx {
 	return m.Queued.OlderThanX(x)
 }
 
+// QueuedForGTEWithContext - Context aware `QueuedForGTE`
+func (m *MemPool) QueuedForGTEWithContext(ctx context.Context, x time.Duration) ([]*MemPoolTx, error) {
+	return m.Queued.OlderThanXWithContext(ctx, x)
+}
+
 // QueuedForLTE - Returning list of tx(s), queued for less than
 // x time unit
 func (m *MemPool) QueuedForLTE(x time.Duration) []*MemPoolTx {
 	return m.Queued.FresherThanX(x)
 }
 
+// QueuedForLTEWithContext - Context aware `QueuedForLTE`
+func (m *MemPool) QueuedForLTEWithContext(ctx context.Context, x time.Duration) ([]*MemPoolTx, error) {
+	return m.Queued.FresherThanXWithContext(ctx, x)
+}
+
 // PendingWithGTE - Returns list of tx(s), pending with gas price >= `X`
 func (m *MemPool) PendingWithGTE(x float64) []*MemPoolTx {
 	return m.Pending.HigherThanX(x)
 }
 
+// PendingWithGTEWithContext - Context aware `PendingWithGTE`
+func (m *MemPool) PendingWithGTEWithContext(ctx context.Context, x float64) ([]*MemPoolTx, error) {
+	return m.Pending.HigherThanXWithContext(ctx, x)
+}
+
 // PendingWithLTE - Returns list of tx(s), pending with gas price <= `X`
 func (m *MemPool) PendingWithLTE(x float64) []*MemPoolTx {
 	return m.Pending.LowerThanX(x)
 }
 
+// PendingWithLTEWithContext - Context aware `PendingWithLTE`
+func (m *MemPool) PendingWithLTEWithContext(ctx context.Context, x float64) ([]*MemPoolTx, error) {
+	return m.Pending.LowerThanXWithContext(ctx, x)
+}
+
+// PendingWithValueGTE - Returns list of tx(s), pending with transferred
+// value >= `X` wei
+func (m *MemPool) PendingWithValueGTE(x *big.Int) []*MemPoolTx {
+	return m.Pending.ValueGTE(x)
+}
+
+// PendingWithValueGTEWithContext - Context aware `PendingWithValueGTE`
+func (m *MemPool) PendingWithValueGTEWithContext(ctx context.Context, x *big.Int) ([]*MemPoolTx, error) {
+	return m.Pending.ValueGTEWithContext(ctx, x)
+}
+
+// PendingWithGasPriceBetween - Returns list of tx(s), pending with gas price
+// within [low, high] wei, where absent bound denotes open-ended range
+func (m *MemPool) PendingWithGasPriceBetween(low *big.Int, high *big.Int) []*MemPoolTx {
+	return m.Pending.GasPriceBetween(low, high)
+}
+
+// PendingWithGasPriceBetweenWithContext - Context aware `PendingWithGasPriceBetween`
+func (m *MemPool) PendingWithGasPriceBetweenWithContext(ctx context.Context, low *big.Int, high *big.Int) ([]*MemPoolTx, error) {
+	return m.Pending.GasPriceBetweenWithContext(ctx, low, high)
+}
+
 // QueuedWithGTE - Returns list of tx(s), queued with gas price >= `X`
 func (m *MemPool) QueuedWithGTE(x float64) []*MemPoolTx {
 	return m.Queued.HigherThanX(x)
 }
 
+// QueuedWithGTEWithContext - Context aware `QueuedWithGTE`
+func (m *MemPool) QueuedWithGTEWithContext(ctx context.Context, x float64) ([]*MemPoolTx, error) {
+	return m.Queued.HigherThanXWithContext(ctx, x)
+}
+
 // QueuedWithLTE - Returns list of tx(s), queued with gas price <= `X`
 func (m *MemPool) QueuedWithLTE(x float64) []*MemPoolTx {
 	return m.Queued.LowerThanX(x)
 }
 
+// QueuedWithLTEWithContext - Context aware `QueuedWithLTE`
+func (m *MemPool) QueuedWithLTEWithContext(ctx context.Context, x float64) ([]*MemPoolTx, error) {
+	return m.Queued.LowerThanXWithContext(ctx, x)
+}
+
+// QueuedWithValueGTE - Returns list of tx(s), queued with transferred
+// value >= `X` wei
+func (m *MemPool) QueuedWithValueGTE(x *big.Int) []*MemPoolTx {
+	return m.Queued.ValueGTE(x)
+}
+
+// QueuedWithValueGTEWithContext - Context aware `QueuedWithValueGTE`
+func (m *MemPool) QueuedWithValueGTEWithContext(ctx context.Context, x *big.Int) ([]*MemPoolTx, error) {
+	return m.Queued.ValueGTEWithContext(ctx, x)
+}
+
+// QueuedWithGasPriceBetween - Returns list of tx(s), queued with gas price
+// within [low, high] wei, where absent bound denotes open-ended range
+func (m *MemPool) QueuedWithGasPriceBetween(low *big.Int, high *big.Int) []*MemPoolTx {
+	return m.Queued.GasPriceBetween(low, high)
+}
+
+// QueuedWithGasPriceBetweenWithContext - Context aware `QueuedWithGasPriceBetween`
+func (m *MemPool) QueuedWithGasPriceBetweenWithContext(ctx context.Context, low *big.Int, high *big.Int) ([]*MemPoolTx, error) {
+	return m.Queued.GasPriceBetweenWithContext(ctx, low, high)
+}
+
 // PendingFrom - List of tx(s) pending from address
 //
 // @note These are going to be same nonce tx(s), only one of them will
@@ -130,67 +434,431 @@ func (m *MemPool) PendingFrom(address common.Address) []*MemPoolTx {
 	return m.Pending.SentFrom(address)
 }
 
+// PendingFromWithContext - Context aware `PendingFrom`
+func (m *MemPool) PendingFromWithContext(ctx context.Context, address common.Address) ([]*MemPoolTx, error) {
+	return m.Pending.TxsFromAWithContext(ctx, address)
+}
+
 // PendingTo - List of tx(s) living in pending pool, sent to specified address
 func (m *MemPool) PendingTo(address common.Address) []*MemPoolTx {
 	return m.Pending.SentTo(address)
 }
 
+// PendingToWithContext - Context aware `PendingTo`
+func (m *MemPool) PendingToWithContext(ctx context.Context, address common.Address) ([]*MemPoolTx, error) {
+	return m.Pending.SentToWithContext(ctx, address)
+}
+
+// PendingToWithMethod - List of tx(s) living in pending pool, sent to specified
+// address, for invoking contract method identified by given selector
+func (m *MemPool) PendingToWithMethod(address common.Address, selector [4]byte) []*MemPoolTx {
+	return m.Pending.SentToWithMethod(address, selector)
+}
+
+// PendingToWithMethodWithContext - Context aware `PendingToWithMethod`
+func (m *MemPool) PendingToWithMethodWithContext(ctx context.Context, address common.Address, selector [4]byte) ([]*MemPoolTx, error) {
+	return m.Pending.SentToWithMethodWithContext(ctx, address, selector)
+}
+
+// PendingContractCreations - List of contract creation tx(s) living in pending pool
+func (m *MemPool) PendingContractCreations() []*MemPoolTx {
+	return m.Pending.ContractCreations()
+}
+
+// PendingContractCreationsWithContext - Context aware `PendingContractCreations`
+func (m *MemPool) PendingContractCreationsWithContext(ctx context.Context) ([]*MemPoolTx, error) {
+	return m.Pending.ContractCreationsWithContext(ctx)
+}
+
+// PendingOfType - List of tx(s) of given type, living in pending pool
+func (m *MemPool) PendingOfType(t uint64) []*MemPoolTx {
+	return m.Pending.ByType(t)
+}
+
+// PendingOfTypeWithContext - Context aware `PendingOfType`
+func (m *MemPool) PendingOfTypeWithContext(ctx context.Context, t uint64) ([]*MemPoolTx, error) {
+	return m.Pending.ByTypeWithContext(ctx, t)
+}
+
+// QueuedOfType - List of tx(s) of given type, living in queued pool
+func (m *MemPool) QueuedOfType(t uint64) []*MemPoolTx {
+	return m.Queued.ByType(t)
+}
+
+// QueuedOfTypeWithContext - Context aware `QueuedOfType`
+func (m *MemPool) QueuedOfTypeWithContext(ctx context.Context, t uint64) ([]*MemPoolTx, error) {
+	return m.Queued.ByTypeWithContext(ctx, t)
+}
+
+// AllTxsFrom - List of tx(s) from specified address, living in either of
+// pending/ queued pool, ascending ordered as per nonce, where `Pool` field
+// of each tx tells which pool it's living in
+func (m *MemPool) AllTxsFrom(address common.Address) []*MemPoolTx {
+
+	v, _ := m.AllTxsFromWithContext(context.Background(), address)
+	return v
+
+}
+
+// AllTxsFromWithContext - Context aware `AllTxsFrom`
+func (m *MemPool) AllTxsFromWithContext(ctx context.Context, address common.Address) ([]*MemPoolTx, error) {
+
+	pending, err := m.Pending.TxsFromAWithContext(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	queued, err := m.Queued.TxsFromAWithContext(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*MemPoolTx, 0, len(pending)+len(queued))
+	txs = append(txs, pending...)
+	txs = append(txs, queued...)
+
+	if len(txs) == 0 {
+		return nil, nil
+	}
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].Nonce < txs[j].Nonce
+	})
+
+	return txs, nil
+
+}
+
 // QueuedFrom - List of stuck tx(s) from specified address, due to nonce gap
 func (m *MemPool) QueuedFrom(address common.Address) []*MemPoolTx {
 	return m.Queued.SentFrom(address)
 }
 
+// QueuedFromWithContext - Context aware `QueuedFrom`
+func (m *MemPool) QueuedFromWithContext(ctx context.Context, address common.Address) ([]*MemPoolTx, error) {
+	return m.Queued.TxsFromAWithContext(ctx, address)
+}
+
+// PendingCountFrom - #-of tx(s) sent by address `A`, living in pending pool
+func (m *MemPool) PendingCountFrom(address common.Address) uint64 {
+	return m.Pending.CountFrom(address)
+}
+
+// PendingCountFromWithContext - Context aware `PendingCountFrom`
+func (m *MemPool) PendingCountFromWithContext(ctx context.Context, address common.Address) (uint64, error) {
+	return m.Pending.CountFromWithContext(ctx, address)
+}
+
+// QueuedCountFrom - #-of tx(s) sent by address `A`, living in queued pool
+func (m *MemPool) QueuedCountFrom(address common.Address) uint64 {
+	return m.Queued.CountFrom(address)
+}
+
+// QueuedCountFromWithContext - Context aware `QueuedCountFrom`
+func (m *MemPool) QueuedCountFromWithContext(ctx context.Context, address common.Address) (uint64, error) {
+	return m.Queued.CountFromWithContext(ctx, address)
+}
+
+// TopXPendingSenders - Returns top `X` senders, having most tx(s) living
+// in pending pool
+func (m *MemPool) TopXPendingSenders(x uint64) []SenderCount {
+	return m.Pending.TopSenders(x)
+}
+
+// TopXPendingSendersWithContext - Context aware `TopXPendingSenders`
+func (m *MemPool) TopXPendingSendersWithContext(ctx context.Context, x uint64) ([]SenderCount, error) {
+	return m.Pending.TopSendersWithContext(ctx, x)
+}
+
+// TopXQueuedSenders - Returns top `X` senders, having most tx(s) living
+// in queued pool
+func (m *MemPool) TopXQueuedSenders(x uint64) []SenderCount {
+	return m.Queued.TopSenders(x)
+}
+
+// TopXQueuedSendersWithContext - Context aware `TopXQueuedSenders`
+func (m *MemPool) TopXQueuedSendersWithContext(ctx context.Context, x uint64) ([]SenderCount, error) {
+	return m.Queued.TopSendersWithContext(ctx, x)
+}
+
 // QueuedTo - List of stuck tx(s) present in queued pool, sent to specified
 // address
 func (m *MemPool) QueuedTo(address common.Address) []*MemPoolTx {
 	return m.Queued.SentTo(address)
 }
 
+// QueuedToWithContext - Context aware `QueuedTo`
+func (m *MemPool) QueuedToWithContext(ctx context.Context, address common.Address) ([]*MemPoolTx, error) {
+	return m.Queued.SentToWithContext(ctx, address)
+}
+
 // TopXPendingWithHighGasPrice - Returns a list of top `X` pending tx(s)
 // where high gas price tx(s) are prioritized
 func (m *MemPool) TopXPendingWithHighGasPrice(x uint64) []*MemPoolTx {
 	return m.Pending.TopXWithHighGasPrice(x)
 }
 
+// TopXPendingWithHighGasPriceWithContext - Context aware `TopXPendingWithHighGasPrice`
+func (m *MemPool) TopXPendingWithHighGasPriceWithContext(ctx context.Context, x uint64) ([]*MemPoolTx, error) {
+	return m.Pending.TopXWithHighGasPriceWithContext(ctx, x)
+}
+
 // TopXQueuedWithHighGasPrice - Returns a list of top `X` queued tx(s)
 // where high gas price tx(s) are prioritized
 func (m *MemPool) TopXQueuedWithHighGasPrice(x uint64) []*MemPoolTx {
 	return m.Queued.TopXWithHighGasPrice(x)
 }
 
+// TopXQueuedWithHighGasPriceWithContext - Context aware `TopXQueuedWithHighGasPrice`
+func (m *MemPool) TopXQueuedWithHighGasPriceWithContext(ctx context.Context, x uint64) ([]*MemPoolTx, error) {
+	return m.Queued.TopXWithHighGasPriceWithContext(ctx, x)
+}
+
+// TopXPendingByCost - Returns a list of top `X` pending tx(s)
+// where tx(s) costing more to sender are prioritized
+func (m *MemPool) TopXPendingByCost(x uint64) []*MemPoolTx {
+	return m.Pending.TopXByCost(x)
+}
+
+// TopXPendingByCostWithContext - Context aware `TopXPendingByCost`
+func (m *MemPool) TopXPendingByCostWithContext(ctx context.Context, x uint64) ([]*MemPoolTx, error) {
+	return m.Pending.TopXByCostWithContext(ctx, x)
+}
+
 // TopXPendingWithLowGasPrice - Returns a list of top `X` pending tx(s)
 // where low gas price tx(s) are prioritized
 func (m *MemPool) TopXPendingWithLowGasPrice(x uint64) []*MemPoolTx {
 	return m.Pending.TopXWithLowGasPrice(x)
 }
 
+// TopXPendingWithLowGasPriceWithContext - Context aware `TopXPendingWithLowGasPrice`
+func (m *MemPool) TopXPendingWithLowGasPriceWithContext(ctx context.Context, x uint64) ([]*MemPoolTx, error) {
+	return m.Pending.TopXWithLowGasPriceWithContext(ctx, x)
+}
+
+// TopXLongestQueued - Returns a list of top `X` queued tx(s), which have
+// been living in mempool for longest, oldest first
+func (m *MemPool) TopXLongestQueued(x uint64) []*MemPoolTx {
+	return m.Queued.LongestQueued(x)
+}
+
+// TopXLongestQueuedWithContext - Context aware `TopXLongestQueued`
+func (m *MemPool) TopXLongestQueuedWithContext(ctx context.Context, x uint64) ([]*MemPoolTx, error) {
+	return m.Queued.LongestQueuedWithContext(ctx, x)
+}
+
 // TopXQueuedWithLowGasPrice - Returns a list of top `X` queued tx(s)
 // where low gas price tx(s) are prioritized
 func (m *MemPool) TopXQueuedWithLowGasPrice(x uint64) []*MemPoolTx {
 	return m.Queued.TopXWithLowGasPrice(x)
 }
 
+// TopXQueuedWithLowGasPriceWithContext - Context aware `TopXQueuedWithLowGasPrice`
+func (m *MemPool) TopXQueuedWithLowGasPriceWithContext(ctx context.Context, x uint64) ([]*MemPoolTx, error) {
+	return m.Queued.TopXWithLowGasPriceWithContext(ctx, x)
+}
+
 // Process - Process all current pending & queued tx pool content & populate our in-memory buffer
-func (m *MemPool) Process(ctx context.Context, pending map[string]map[string]*MemPoolTx, queued map[string]map[string]*MemPoolTx) {
+//
+// Only tx(s) not seen in previous poll are pushed into pools, while pending tx(s)
+// which disappeared since then are handed over to pruner, as candidates to be
+// checked. Once in every `MemPoolFullResyncPolls` polls, all tx(s) are pushed,
+// so that tx(s) missed due to some reason get another chance.
+//
+// Both sections touch different pools, each owned by its own life cycle manager,
+// so those are ingested concurrently, returning #-of tx(s) added to pending &
+// queued pool, respectively
+//
+// @note Supposed to be invoked only by mempool poller
+func (m *MemPool) Process(ctx context.Context, pending map[string]map[string]*MemPoolTx, queued map[string]map[string]*MemPoolTx) (uint64, uint64) {
+
+	if m.last == nil {
+		m.last = &pollSnapshot{}
+	}
+
+	currentP := hashesOf(pending)
+	currentQ := hashesOf(queued)
+
+	previousP, previousQ := m.last.pending, m.last.queued
+	if m.last.polls%config.GetMemPoolFullResyncPolls() == 0 {
+		previousP, previousQ = nil, nil
+	}
+
+	if gone := disappeared(m.last.pending, currentP, currentQ); len(gone) != 0 {
+		m.Pending.PruneCandidates(gone)
+	}
+
+	m.last.pending, m.last.queued = currentP, currentQ
+	m.last.polls++
+
+	var addedP, addedQ uint64
+	var wg sync.WaitGroup
+
+	wg.Add(2)
+
+	go func() {
+
+		defer wg.Done()
+
+		start := time.Now().UTC()
+
+		if addedQ = m.Queued.AddAll(ctx, newlySeen(queued, previousQ)); addedQ != 0 {
+			log.Printf("[➕] Added %d tx(s) to queued tx pool, in %s\n", addedQ, time.Now().UTC().Sub(start))
+		}
+
+	}()
+
+	go func() {
+
+		defer wg.Done()
+
+		start := time.Now().UTC()
+
+		if addedP = m.Pending.AddBatch(ctx, newlySeen(pending, previousP)); addedP != 0 {
+			log.Printf("[➕] Added %d tx(s) to pending tx pool, in %s\n", addedP, time.Now().UTC().Sub(start))
+		}
+
+	}()
+
+	wg.Wait()
+
+	return addedP, addedQ
+
+}
+
+// PendingGasPriceStats - Distribution of gas price paid by tx(s) living
+// in pending pool
+func (m *MemPool) PendingGasPriceStats() GasPriceStats {
+	return m.Pending.GasPriceStats()
+}
+
+// PendingGasPriceStatsWithContext - Context aware `PendingGasPriceStats`
+func (m *MemPool) PendingGasPriceStatsWithContext(ctx context.Context) (GasPriceStats, error) {
+	return m.Pending.GasPriceStatsWithContext(ctx)
+}
+
+// ConfirmationLatencyStats - Pending duration distribution of recently
+// confirmed tx(s), bucketed by gas price decile
+func (m *MemPool) ConfirmationLatencyStats() ConfirmationLatencyStats {
+	return m.Pending.ConfirmationLatencyStats()
+}
+
+// ConfirmationLatencyStatsWithContext - Context aware `ConfirmationLatencyStats`
+func (m *MemPool) ConfirmationLatencyStatsWithContext(ctx context.Context) (ConfirmationLatencyStats, error) {
+	return m.Pending.ConfirmationLatencyStatsWithContext(ctx)
+}
+
+// PendingStuck - Returns tx(s) living in pending pool, which are currently
+// flagged as stuck i.e. can't pay latest base fee
+func (m *MemPool) PendingStuck() []*MemPoolTx {
+	return m.Pending.StuckTxs()
+}
+
+// PendingStuckWithContext - Context aware `PendingStuck`
+func (m *MemPool) PendingStuckWithContext(ctx context.Context) ([]*MemPoolTx, error) {
+	return m.Pending.StuckTxsWithContext(ctx)
+}
+
+// PoolAggregates - Totals over tx(s) living in pending & queued pools,
+// read in constant time, as those're kept up-to-date by pools
+func (m *MemPool) PoolAggregates() MemPoolAggregates {
 
-	start := time.Now().UTC()
+	v, _ := m.PoolAggregatesWithContext(context.Background())
+	return v
 
-	if addedQ := m.Queued.AddQueued(ctx, queued); addedQ != 0 {
-		log.Printf("[➕] Added %d tx(s) to queued tx pool, in %s\n", addedQ, time.Now().UTC().Sub(start))
+}
+
+// PoolAggregatesWithContext - Context aware `PoolAggregates`
+func (m *MemPool) PoolAggregatesWithContext(ctx context.Context) (MemPoolAggregates, error) {
+
+	pending, err := m.Pending.AggregatesWithContext(ctx)
+	if err != nil {
+		return MemPoolAggregates{}, err
+	}
+
+	queued, err := m.Queued.AggregatesWithContext(ctx)
+	if err != nil {
+		return MemPoolAggregates{}, err
+	}
+
+	return MemPoolAggregates{Pending: pending, Queued: queued}, nil
+
+}
+
+// Stat - Log current mempool state, skipped when pools can't answer
+// before `ctx` is done
+func (m *MemPool) Stat(ctx context.Context, start time.Time, interval time.Duration, skipped uint64) {
+
+	atomic.StoreInt64(&m.lastPollDuration, int64(time.Now().UTC().Sub(start)))
+
+	aggregates, err := m.PoolAggregatesWithContext(ctx)
+	if err != nil {
+		log.Printf("[❗️] Failed to read pool aggregates : %s\n", err.Error())
+		return
 	}
 
-	start = time.Now().UTC()
+	log.Printf("📊 Pending : %d sender(s), %d gas, %.4f ether, %d contract creation(s) | Queued : %d sender(s), %d gas, %.4f ether, %d contract creation(s)\n",
+		aggregates.Pending.UniqueSenders, aggregates.Pending.TotalGas, NumericValueEther(aggregates.Pending.TotalValue), aggregates.Pending.ContractCreations,
+		aggregates.Queued.UniqueSenders, aggregates.Queued.TotalGas, NumericValueEther(aggregates.Queued.TotalValue), aggregates.Queued.ContractCreations)
+	log.Printf("📊 Pending Tx Types : %s | Queued Tx Types : %s | Sender Index Entries : pending %d, queued %d\n",
+		aggregates.Pending.TypeCounts(), aggregates.Queued.TypeCounts(),
+		aggregates.Pending.SenderEntries, aggregates.Queued.SenderEntries)
+
+	log.Printf("📊 Utilization : pending %d/%d ( %.2f %% ) | queued %d/%d ( %.2f %% )\n",
+		aggregates.Pending.Count, config.GetPendingPoolSize(), utilization(aggregates.Pending.Count, config.GetPendingPoolSize()),
+		aggregates.Queued.Count, config.GetQueuedPoolSize(), utilization(aggregates.Queued.Count, config.GetQueuedPoolSize()))
+
+	stats, err := m.PendingGasPriceStatsWithContext(ctx)
+	if err != nil {
+		log.Printf("[❗️] Failed to read gas price stats : %s\n", err.Error())
+		return
+	}
 
-	if addedP := m.Pending.AddPendings(ctx, pending); addedP != 0 {
-		log.Printf("[➕] Added %d tx(s) to pending tx pool, in %s\n", addedP, time.Now().UTC().Sub(start))
+	if stats.Count == 0 {
+		log.Printf("❇️ Pending Tx(s) : %d | Queued Tx(s) : %d | Polling every %s, skipped %d poll(s), in %s\n", aggregates.Pending.Count, aggregates.Queued.Count, interval, skipped, time.Now().UTC().Sub(start))
+		return
 	}
 
+	gwei := func(v *big.Int) float64 {
+		return NumericGasPriceGwei((*hexutil.Big)(v))
+	}
+
+	log.Printf("❇️ Pending Tx(s) : %d | Queued Tx(s) : %d | Gas Price ( Gwei ) : min %.2f, p50 %.2f, p90 %.2f, max %.2f | Polling every %s, skipped %d poll(s), in %s\n",
+		aggregates.Pending.Count, aggregates.Queued.Count,
+		gwei(stats.Min), gwei(stats.P50), gwei(stats.P90), gwei(stats.Max),
+		interval, skipped, time.Now().UTC().Sub(start))
+
 }
 
-// Stat - Log current mempool state
-func (m *MemPool) Stat(start time.Time) {
+// utilization - How much of pool's capacity is used, in percentage
+func utilization(count uint64, limit uint64) float64 {
+
+	if limit == 0 {
+		return 0
+	}
 
-	log.Printf("❇️ Pending Tx(s) : %d | Queued Tx(s) : %d, in %s\n", m.PendingPoolLength(), m.QueuedPoolLength(), time.Now().UTC().Sub(start))
+	return float64(count) * 100 / float64(limit)
+
+}
+
+// ForceRemove - Evicts phantom tx from whichever pool it's living in, on
+// operator's request, returning whether it was actually present
+//
+// Evicted tx is remembered as dropped, so that it doesn't reappear
+// from next poll, while exit event is published as usual
+func (m *MemPool) ForceRemove(ctx context.Context, hash common.Hash) bool {
+
+	if m.Pending.ForceRemove(ctx, hash) {
+		log.Printf("[➖] Manually evicted tx from pending pool : %s\n", hash.Hex())
+		return true
+	}
+
+	if m.Queued.ForceRemove(ctx, hash) {
+		log.Printf("[➖] Manually evicted tx from queued pool : %s\n", hash.Hex())
+		return true
+	}
+
+	return false
 
 }
 
@@ -198,11 +866,32 @@ func (m *MemPool) Stat(start time.Time) {
 // is received from any `harmony` peer, it will be checked against latest state
 // of local mempool view, to decide whether this tx can be acted upon
 // somehow or not
-func (m *MemPool) HandleTxFromPeer(ctx context.Context, tx *MemPoolTx) bool {
+func (m *MemPool) HandleTxFromPeer(ctx context.Context, tx *MemPoolTx) (bool, error) {
+
+	// Peer might be tracking mempool of some other chain, those
+	// tx(s) must not pollute local pool
+	if err := m.VerifyChainID(tx); err != nil {
+		return false, err
+	}
+
+	// Peer may have sent only signature components, without sender
+	// address, which is why it needs to be derived before admitting
+	// tx into pool, otherwise it'll be indexed under zero address
+	if (tx.Pool == "pending" || tx.Pool == "queued") && tx.From == (common.Address{}) {
+
+		if err := tx.RecoverSender(m.ChainID); err != nil {
+			log.Printf("[❗️] Failed to recover sender of tx from peer : %s | %s\n", err.Error(), tx.Hash.Hex())
+			return false, err
+		}
+
+	}
 
 	// Checking whether we already have this tx included in pool
 	// or not
-	exists := m.Exists(tx.Hash)
+	exists, err := m.ExistsWithContext(ctx, tx.Hash)
+	if err != nil {
+		return false, err
+	}
 
 	var status bool
 
@@ -219,6 +908,15 @@ func (m *MemPool) HandleTxFromPeer(ctx context.Context, tx *MemPoolTx) bool {
 			status = m.Pending.Remove(ctx, &TxStatus{Hash: tx.Hash, Status: DROPPED})
 		}
 
+	case "replaced":
+
+		// If we already have entry for this tx & we just learnt
+		// this tx got replaced, we'll try to update our state
+		// same as our peer did
+		if exists {
+			status = m.Pending.Remove(ctx, &TxStatus{Hash: tx.Hash, Status: REPLACED})
+		}
+
 	case "confirmed":
 
 		// If we already have entry for this tx & we just learnt
@@ -244,6 +942,31 @@ func (m *MemPool) HandleTxFromPeer(ctx context.Context, tx *MemPoolTx) bool {
 
 	}
 
-	return status
+	return status, nil
+
+}
+
+// VerifyChainID - Checks whether tx is signed for chain, this node is
+// tracking mempool of
+//
+// Tx(s) without replay protection are accepted/ rejected as configured
+func (m *MemPool) VerifyChainID(tx *MemPoolTx) error {
+
+	chainID := tx.SignedChainID()
+	if chainID == nil {
+
+		if config.GetAcceptUnprotectedPeerTxs() {
+			return nil
+		}
+
+		return ErrUnprotectedTx
+
+	}
+
+	if m.ChainID != nil && chainID.Cmp(m.ChainID) != 0 {
+		return ErrWrongChainID
+	}
+
+	return nil
 
 }
@@ -0,0 +1,113 @@
+package data
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/itzmeanjan/harmony/app/config"
+)
+
+// PoolStats - Composition of one pool, as seen at time of asking, read
+// without scanning whole pool
+//
+// @note `Added` & `Removed` are cumulative since start up, gas prices are
+// in wei & nil when pool is empty
+type PoolStats struct {
+	Count         uint64
+	Limit         uint64
+	UniqueSenders uint64
+	TotalGas      uint64
+	TotalValue    *big.Int
+	P10           *big.Int
+	P25           *big.Int
+	P50           *big.Int
+	P75           *big.Int
+	P90           *big.Int
+	P99           *big.Int
+	OldestAge     time.Duration
+	Added         uint64
+	Removed       uint64
+}
+
+// MemPoolStats - Composition of pending & queued pools, along with how
+// long last mempool poll took & state of topics pools publish on
+type MemPoolStats struct {
+	Pending          PoolStats
+	Queued           PoolStats
+	Topics           []TopicStats
+	LastPollDuration time.Duration
+	ComputedAt       time.Time
+}
+
+// poolStats - Given running totals, tx(s) ordered as per gas price paid &
+// as per time they joined pool at, takes snapshot of pool composition
+//
+// Percentiles are looked up by rank in gas price tree & oldest tx is found
+// in age ordered tree, so nothing here grows linearly with pool size
+//
+// @note Supposed to be invoked from pool's own life cycle manager go routine
+func poolStats(totals *aggregates, byGasPrice *GasPriceTree, byAge *AgeTree) PoolStats {
+
+	stats := PoolStats{
+		Count:         uint64(byGasPrice.len()),
+		UniqueSenders: totals.senders,
+		TotalGas:      totals.gas,
+		TotalValue:    new(big.Int).Set(&totals.value),
+		Added:         totals.joined,
+		Removed:       totals.left,
+	}
+
+	if byGasPrice.len() != 0 {
+
+		stats.P10 = percentile(byGasPrice, 10)
+		stats.P25 = percentile(byGasPrice, 25)
+		stats.P50 = percentile(byGasPrice, 50)
+		stats.P75 = percentile(byGasPrice, 75)
+		stats.P90 = percentile(byGasPrice, 90)
+		stats.P99 = percentile(byGasPrice, 99)
+
+	}
+
+	if oldest := byAge.oldest(); oldest != nil {
+		stats.OldestAge = time.Now().UTC().Sub(byAge.joinedAt(oldest))
+	}
+
+	return stats
+
+}
+
+// Stats - Composition of pending & queued pools, cheap enough to be
+// asked for frequently, as it's backed by counters kept up-to-date by pools
+func (m *MemPool) Stats() MemPoolStats {
+
+	v, _ := m.StatsWithContext(context.Background())
+	return v
+
+}
+
+// StatsWithContext - Context aware `Stats`
+func (m *MemPool) StatsWithContext(ctx context.Context) (MemPoolStats, error) {
+
+	pending, err := m.Pending.StatsWithContext(ctx)
+	if err != nil {
+		return MemPoolStats{}, err
+	}
+	pending.Limit = config.GetPendingPoolSize()
+
+	queued, err := m.Queued.StatsWithContext(ctx)
+	if err != nil {
+		return MemPoolStats{}, err
+	}
+	queued.Limit = config.GetQueuedPoolSize()
+
+	return MemPoolStats{
+		Pending:          pending,
+		Queued:           queued,
+		Topics:           append(m.Pending.Topics(), m.Queued.Topics()...),
+		LastPollDuration: time.Duration(atomic.LoadInt64(&m.lastPollDuration)),
+		ComputedAt:       time.Now().UTC(),
+	}, nil
+
+}
@@ -0,0 +1,737 @@
+package data
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gammazero/workerpool"
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/harmony/app/listen"
+	"github.com/itzmeanjan/pub0sub/publisher"
+)
+
+// newTestPools - Pending & queued pools, wired same way as during bootup,
+// with their life cycle managers running, until test is done
+//
+// Publisher is never connected, so publishing attempts fail fast
+func newTestPools(tb testing.TB) *MemPool {
+	tb.Helper()
+
+	pool, _ := newStoppableTestPools(tb)
+	return pool
+}
+
+// newStoppableTestPools - Same as `newTestPools`, but also returns function
+// for stopping both pools' life cycle managers before test is done, which
+// returns only after both of them have exited
+func newStoppableTestPools(tb testing.TB) (*MemPool, func()) {
+	tb.Helper()
+
+	return startTestPools(tb, nil)
+}
+
+// newPrunedTestPools - Same as `newTestPools`, but talking to given node,
+// while queued pool's pruner reacts to tx(s) joining pending pool, same
+// way as it does after bootup
+func newPrunedTestPools(tb testing.TB, client *rpc.Client) *MemPool {
+	tb.Helper()
+
+	pool, _ := startTestPools(tb, client)
+	return pool
+}
+
+// startTestPools - Wires & starts both pools, where queued pool's pruner is
+// run only when node to talk to is given
+func startTestPools(tb testing.TB, client *rpc.Client) (*MemPool, func()) {
+	tb.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	alreadyInPendingPoolChan := make(chan *MemPoolTx, 4096)
+	inPendingPoolChan := make(chan *MemPoolTx, 4096)
+	workers := workerpool.New(config.GetConcurrencyFactor())
+	codec := NewCodec(config.GetPublishCodec())
+
+	pending := &PendingPool{
+		Transactions:             make(map[common.Hash]*MemPoolTx),
+		TxsFromAddress:           make(map[common.Address]TxList),
+		TxsByNonce:               make(NonceIndex),
+		DroppedTxs:               make(map[common.Hash]time.Time),
+		RemovedTxs:               make(map[common.Hash]time.Time),
+		TxsByGasPrice:            NewGasPriceTree(),
+		TxsByAge:                 NewPendingAgeTree(),
+		ContractCreationTxs:      make(MemPoolTxsDesc, 0, 1024),
+		LastSeenAt:               time.Now().UTC(),
+		AddTxChan:                make(chan AddRequest, 1),
+		AddBatchChan:             make(chan AddBatchRequest, 1),
+		AddFromQueuedPoolChan:    make(chan AddRequest, 1),
+		RemoveTxChan:             make(chan RemoveRequest, 1),
+		AlreadyInPendingPoolChan: alreadyInPendingPoolChan,
+		InPendingPoolChan:        inPendingPoolChan,
+		TxExistsChan:             make(chan ExistsRequest, 1),
+		GetTxChan:                make(chan GetRequest, 1),
+		FindTxsChan:              make(chan FindTxsRequest, 1),
+		DuplicateTxsChan:         make(chan DuplicateTxsRequest, 1),
+		SameNonceChan:            make(chan SameNonceRequest, 1),
+		GasPriceRangeChan:        make(chan GasPriceRangeRequest, 1),
+		CountTxsChan:             make(chan CountRequest, 1),
+		ListTxsChan:              make(chan ListRequest, 1),
+		TxsFromAChan:             make(chan TxsFromARequest, 1),
+		CountFromChan:            make(chan CountFromRequest, 1),
+		TopSendersChan:           make(chan TopSendersRequest, 1),
+		ContractCreationsChan:    make(chan chan []*MemPoolTx, 1),
+		DoneChan:                 make(chan chan uint64, 1),
+		GasPriceStatsChan:        make(chan chan GasPriceStats, 1),
+		AggregatesChan:           make(chan chan PoolAggregates, 1),
+		StatsChan:                make(chan chan PoolStats, 1),
+		LatencySamplesChan:       make(chan chan []LatencySample, 1),
+		RecentTxChan:             make(chan GetRequest, 1),
+		EvaluateStuckChan:        make(chan StuckRequest, 1),
+		StuckTxsChan:             make(chan chan []*MemPoolTx, 1),
+		PruneSetChan:             make(chan PruneSetRequest, 1),
+		RecommendChan:            make(chan GasPriceRecommendRequest, 1),
+		SetLastSeenBlockChan:     make(chan listen.Head, 16),
+		LastSeenBlockChan:        make(chan chan LastSeenBlock, 1),
+		NewBlockChan:             make(chan struct{}, 1),
+		ReorgedChan:              make(chan []common.Hash, 1),
+		AgeWalkChan:              make(chan AgeWalkRequest, 1),
+		ResizedChan:              make(chan struct{}, 1),
+		StoppedChan:              make(chan struct{}),
+		Codec:                    codec,
+		PubSub:                   &publisher.Publisher{},
+		RPC:                      client,
+		Workers:                  workers,
+	}
+
+	queued := &QueuedPool{
+		Transactions:       make(map[common.Hash]*MemPoolTx),
+		TxsFromAddress:     make(map[common.Address]TxList),
+		TxsByNonce:         make(NonceIndex),
+		DroppedTxs:         make(map[common.Hash]time.Time),
+		RemovedTxs:         make(map[common.Hash]time.Time),
+		TxsByGasPrice:      NewGasPriceTree(),
+		TxsByAge:           NewQueuedAgeTree(),
+		TxsByNonceGap:      NewNonceGapTree(),
+		AddTxChan:          make(chan AddRequest, 1),
+		RemoveTxChan:       make(chan RemovedUnstuckTx, 1),
+		TxExistsChan:       make(chan ExistsRequest, 1),
+		GetTxChan:          make(chan GetRequest, 1),
+		FindTxsChan:        make(chan FindTxsRequest, 1),
+		DuplicateTxsChan:   make(chan DuplicateTxsRequest, 1),
+		SameNonceChan:      make(chan SameNonceRequest, 1),
+		GasPriceRangeChan:  make(chan GasPriceRangeRequest, 1),
+		CountTxsChan:       make(chan CountRequest, 1),
+		ListTxsChan:        make(chan ListRequest, 1),
+		TxsFromAChan:       make(chan TxsFromARequest, 1),
+		CountFromChan:      make(chan CountFromRequest, 1),
+		TopSendersChan:     make(chan TopSendersRequest, 1),
+		AggregatesChan:     make(chan chan PoolAggregates, 1),
+		StatsChan:          make(chan chan PoolStats, 1),
+		SetSenderNonceChan: make(chan SenderNonce, 1),
+		BaseFeeChan:        make(chan *big.Int, 1),
+		GapReportChan:      make(chan chan []*SenderGap, 1),
+		SendersChan:        make(chan chan []common.Address, 1),
+		AgeWalkChan:        make(chan AgeWalkRequest, 1),
+		ResizedChan:        make(chan struct{}, 1),
+		StoppedChan:        make(chan struct{}),
+		Codec:              codec,
+		PubSub:             &publisher.Publisher{},
+		RPC:                client,
+		Workers:            workers,
+		PendingPool:        pending,
+	}
+	pending.QueuedPool = queued
+
+	// Nobody else is listening for tx(s) joining pending pool
+	drain := func(c <-chan *MemPoolTx) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-c:
+			}
+		}
+	}
+
+	if client != nil {
+		go queued.Prune(ctx, make(chan ConfirmedTx), alreadyInPendingPoolChan)
+	} else {
+		go drain(alreadyInPendingPoolChan)
+	}
+	go drain(inPendingPoolChan)
+	go pending.Start(ctx)
+	go queued.Start(ctx)
+
+	stop := func() {
+		cancel()
+
+		<-pending.StoppedChan
+		<-queued.StoppedChan
+	}
+
+	tb.Cleanup(func() {
+		stop()
+		workers.Stop()
+	})
+
+	return &MemPool{Pending: pending, Queued: queued, ChainID: big.NewInt(1)}, stop
+}
+
+// txAddress - Deterministic address for `i`-th sender
+func txAddress(i int) common.Address {
+	var addr common.Address
+	binary.BigEndian.PutUint64(addr[12:], uint64(i)+1)
+
+	return addr
+}
+
+// txHash - Deterministic, uniformly spread hash for `i`-th tx
+func txHash(i int) common.Hash {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(i))
+
+	return crypto.Keccak256Hash(b[:])
+}
+
+// makeTxs - `n` distinct legacy tx(s) spread over `senders` senders, with
+// consecutive nonces per sender & pseudo random gas price, same for each
+// invocation
+func makeTxs(n int, senders int) []*MemPoolTx {
+	rnd := rand.New(rand.NewSource(int64(n)))
+	to := txAddress(1 << 30)
+
+	txs := make([]*MemPoolTx, 0, n)
+	for i := 0; i < n; i++ {
+		txs = append(txs, &MemPoolTx{
+			Hash:     txHash(i),
+			From:     txAddress(i % senders),
+			To:       &to,
+			Nonce:    hexutil.Uint64(i / senders),
+			Gas:      21000,
+			GasPrice: gwei(1 + rnd.Int63n(500)),
+			Value:    (*hexutil.Big)(big.NewInt(0)),
+			Input:    hexutil.Bytes{},
+		})
+	}
+
+	return txs
+}
+
+// fillPending - Adds tx(s) to pending pool in one batch, failing unless
+// all of them got admitted
+func fillPending(tb testing.TB, pool *MemPool, txs []*MemPoolTx) {
+	tb.Helper()
+
+	if added := pool.Pending.AddBatch(context.Background(), txs); added != uint64(len(txs)) {
+		tb.Fatalf("expected %d tx(s) to be admitted, got %d", len(txs), added)
+	}
+}
+
+// Pools are hammered from many go routines at once, adding, removing &
+// listing, which is supposed to be run with `-race`, for catching any state
+// being touched outside of life cycle manager
+func TestPoolsHammer(t *testing.T) {
+
+	withConfig(t, map[string]string{"PendingPoolSize": "10000", "QueuedPoolSize": "10000"})
+
+	pool := newTestPools(t)
+
+	const workers = 8
+	const perWorker = 250
+
+	txs := makeTxs(2*workers*perWorker, 500)
+	pending, queued := txs[:workers*perWorker], txs[workers*perWorker:]
+
+	ctx := context.Background()
+	done := make(chan struct{})
+
+	var writers sync.WaitGroup
+	for w := 0; w < workers; w++ {
+
+		writers.Add(1)
+
+		go func(own []*MemPoolTx, ownQueued []*MemPoolTx) {
+			defer writers.Done()
+
+			for i, tx := range own {
+
+				pool.Pending.Add(ctx, tx)
+				pool.Queued.Add(ctx, ownQueued[i])
+
+				// Every other tx leaves right after joining
+				if i%2 == 0 {
+					pool.Pending.Remove(ctx, &TxStatus{Hash: tx.Hash, Status: CONFIRMED})
+					pool.Queued.Remove(ctx, ownQueued[i].Hash)
+				}
+
+			}
+		}(pending[w*perWorker:(w+1)*perWorker], queued[w*perWorker:(w+1)*perWorker])
+
+	}
+
+	var readers sync.WaitGroup
+	for r := 0; r < workers; r++ {
+
+		readers.Add(1)
+
+		go func(r int) {
+			defer readers.Done()
+
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				for _, page := range []TxPage{pool.Pending.ListPage(DESC, 0, 50), pool.Queued.ListPage(ASC, 0, 50)} {
+					for _, tx := range page.Txs {
+						// Copies handed out are caller's own
+						tx.Pool = "mutated"
+					}
+				}
+
+				pool.Pending.Get(pending[r].Hash)
+				pool.Queued.TxsFromA(queued[r].From)
+				pool.PoolAggregates()
+				pool.Pending.GasPriceStats()
+			}
+		}(r)
+
+	}
+
+	writers.Wait()
+	close(done)
+	readers.Wait()
+
+	if n := pool.Pending.Count(); n != workers*perWorker/2 {
+		t.Fatalf("expected %d tx(s) in pending pool, got %d", workers*perWorker/2, n)
+	}
+
+	if n := pool.Queued.Count(); n != workers*perWorker/2 {
+		t.Fatalf("expected %d tx(s) in queued pool, got %d", workers*perWorker/2, n)
+	}
+
+	for _, tx := range pool.Pending.ListPage(ASC, 0, 0).Txs {
+		if tx.Pool != "pending" {
+			t.Fatalf("expected pool state not to be mutated by readers, found `%s`", tx.Pool)
+		}
+	}
+
+}
+
+// pollOf - Tx(s) laid out same way as section of `txpool_content`
+// response, keyed by sender & nonce
+func pollOf(txs []*MemPoolTx) map[string]map[string]*MemPoolTx {
+
+	poll := make(map[string]map[string]*MemPoolTx)
+
+	for _, tx := range txs {
+
+		if _, ok := poll[tx.From.Hex()]; !ok {
+			poll[tx.From.Hex()] = make(map[string]*MemPoolTx)
+		}
+
+		poll[tx.From.Hex()][strconv.FormatUint(uint64(tx.Nonce), 10)] = tx
+
+	}
+
+	return poll
+
+}
+
+// Poll is ingested into both pools at once, while peers gossip both fresh
+// tx(s) & ones also found in poll, and some are added directly, which is
+// supposed to be run with `-race`
+//
+// Every tx must be admitted exactly once, into pool it was seen in
+func TestProcessWithPeersHammer(t *testing.T) {
+
+	withConfig(t, map[string]string{"PendingPoolSize": "100000", "QueuedPoolSize": "100000", "AcceptUnprotectedPeerTxs": "true"})
+
+	pool := newTestPools(t)
+
+	const (
+		polled  = 3000
+		gossip  = 1000
+		overlap = 500
+		workers = 8
+	)
+
+	txs := makeTxs(2*polled+2*gossip, 800)
+	polledP, polledQ := txs[:polled], txs[polled:2*polled]
+	gossipP, gossipQ := txs[2*polled:2*polled+gossip], txs[2*polled+gossip:]
+
+	// Peers send their own copies, marked with pool they saw tx in
+	fromPeers := make([]*MemPoolTx, 0, 2*gossip+2*overlap)
+	for _, section := range []struct {
+		txs  []*MemPoolTx
+		pool string
+	}{
+		{gossipP, "pending"}, {gossipQ, "queued"}, {polledP[:overlap], "pending"}, {polledQ[:overlap], "queued"},
+	} {
+		for _, tx := range section.txs {
+			copied := tx.Clone()
+			copied.Pool = section.pool
+
+			fromPeers = append(fromPeers, copied)
+		}
+	}
+
+	directly := make([]*MemPoolTx, 0, overlap)
+	for _, tx := range polledP[overlap : 2*overlap] {
+		directly = append(directly, tx.Clone())
+	}
+
+	ctx := context.Background()
+	start := make(chan struct{})
+
+	var admittedP, admittedQ uint64
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-start
+
+		p, q := pool.Process(ctx, pollOf(polledP), pollOf(polledQ))
+		atomic.AddUint64(&admittedP, p)
+		atomic.AddUint64(&admittedQ, q)
+	}()
+
+	for w := 0; w < workers; w++ {
+
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			<-start
+
+			for i := w; i < len(fromPeers); i += workers {
+
+				ok, err := pool.HandleTxFromPeer(ctx, fromPeers[i])
+				if err != nil {
+					t.Errorf("expected tx from peer to be handled, got %q", err.Error())
+					return
+				}
+
+				if !ok {
+					continue
+				}
+
+				if fromPeers[i].Pool == "queued" {
+					atomic.AddUint64(&admittedQ, 1)
+				} else {
+					atomic.AddUint64(&admittedP, 1)
+				}
+
+			}
+
+			for i := w; i < len(directly); i += workers {
+				if pool.Pending.Add(ctx, directly[i]) {
+					atomic.AddUint64(&admittedP, 1)
+				}
+			}
+		}(w)
+
+	}
+
+	close(start)
+	wg.Wait()
+
+	if admittedP != polled+gossip || admittedQ != polled+gossip {
+		t.Fatalf("expected %d tx(s) to be admitted into each pool, got %d pending & %d queued", polled+gossip, admittedP, admittedQ)
+	}
+
+	if p, q := pool.Pending.Count(), pool.Queued.Count(); p != polled+gossip || q != polled+gossip {
+		t.Fatalf("expected %d tx(s) in each pool, got %d pending & %d queued", polled+gossip, p, q)
+	}
+
+	for _, section := range [][]*MemPoolTx{polledP, gossipP} {
+		for _, tx := range section {
+			if pool.Queued.Exists(tx.Hash) {
+				t.Fatalf("expected pending tx %s not to leak into queued pool", tx.Hash)
+			}
+		}
+	}
+
+	for _, section := range [][]*MemPoolTx{polledQ, gossipQ} {
+		for _, tx := range section {
+			if pool.Pending.Exists(tx.Hash) {
+				t.Fatalf("expected queued tx %s not to leak into pending pool", tx.Hash)
+			}
+		}
+	}
+
+	// Same poll again brings nothing new
+	if p, q := pool.Process(ctx, pollOf(polledP), pollOf(polledQ)); p != 0 || q != 0 {
+		t.Fatalf("expected nothing to be added from repeated poll, got %d pending & %d queued", p, q)
+	}
+
+}
+
+// Once life cycle managers have exited, accessors are supposed to give up
+// with sentinel error, instead of blocking forever on request channels
+func TestAccessorsAfterStop(t *testing.T) {
+
+	pool, stop := newStoppableTestPools(t)
+
+	txs := makeTxs(16, 4)
+	fillPending(t, pool, txs)
+
+	stop()
+
+	ctx := context.Background()
+	hash, from := txs[0].Hash, txs[0].From
+
+	accessors := map[string]func() error{
+		"Pending.Get": func() error {
+			_, err := pool.Pending.GetWithContext(ctx, hash)
+			return err
+		},
+		"Pending.Exists": func() error {
+			_, err := pool.Pending.ExistsWithContext(ctx, hash)
+			return err
+		},
+		"Pending.Count": func() error {
+			_, err := pool.Pending.CountWithContext(ctx)
+			return err
+		},
+		"Pending.AscListTxs": func() error {
+			_, err := pool.Pending.AscListTxsWithContext(ctx)
+			return err
+		},
+		"Pending.TxsFromA": func() error {
+			_, err := pool.Pending.TxsFromAWithContext(ctx, from)
+			return err
+		},
+		"Pending.Stats": func() error {
+			_, err := pool.Pending.StatsWithContext(ctx)
+			return err
+		},
+		"Pending.RecommendGasPrice": func() error {
+			_, err := pool.Pending.RecommendGasPriceWithContext(ctx, gwei(1).ToInt(), []uint64{21000})
+			return err
+		},
+		"Pending.OlderThanX": func() error {
+			_, err := pool.Pending.OlderThanXWithContext(ctx, 0)
+			return err
+		},
+		"Pending.StuckTxs": func() error {
+			_, err := pool.Pending.StuckTxsWithContext(ctx)
+			return err
+		},
+		"Queued.Get": func() error {
+			_, err := pool.Queued.GetWithContext(ctx, hash)
+			return err
+		},
+		"Queued.Count": func() error {
+			_, err := pool.Queued.CountWithContext(ctx)
+			return err
+		},
+		"Queued.ListPage": func() error {
+			_, err := pool.Queued.ListPageWithContext(ctx, DESC, 0, 10)
+			return err
+		},
+		"Queued.Senders": func() error {
+			_, err := pool.Queued.SendersWithContext(ctx)
+			return err
+		},
+		"Queued.OlderThanX": func() error {
+			_, err := pool.Queued.OlderThanXWithContext(ctx, 0)
+			return err
+		},
+		"Get": func() error {
+			_, err := pool.GetWithContext(ctx, hash)
+			return err
+		},
+		"Duplicates": func() error {
+			_, err := pool.DuplicatesWithContext(ctx, hash)
+			return err
+		},
+		"Stats": func() error {
+			_, err := pool.StatsWithContext(ctx)
+			return err
+		},
+	}
+
+	for name, accessor := range accessors {
+
+		errChan := make(chan error, 1)
+		go func(accessor func() error) {
+			errChan <- accessor()
+		}(accessor)
+
+		select {
+		case err := <-errChan:
+			if !errors.Is(err, ErrPoolStopped) {
+				t.Errorf("%s : expected `%v`, got `%v`", name, ErrPoolStopped, err)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("%s : still blocked after pool stopped", name)
+		}
+
+	}
+
+	// Blocking variants fall back to zero values
+	if tx := pool.Pending.Get(hash); tx != nil {
+		t.Errorf("expected no tx from stopped pool, got %s", tx.Hash)
+	}
+
+	if n := pool.Queued.Count(); n != 0 {
+		t.Errorf("expected zero count from stopped pool, got %d", n)
+	}
+
+	// Mutators report nothing done, instead of blocking forever
+	fresh := makeTxs(2, 1)
+	mutators := map[string]func() bool{
+		"Pending.Add":        func() bool { return pool.Pending.Add(ctx, fresh[0]) },
+		"Pending.AddUnstuck": func() bool { return pool.Pending.AddUnstuck(ctx, fresh[0]) },
+		"Pending.AddBatch":   func() bool { return pool.Pending.AddBatch(ctx, fresh) != 0 },
+		"Pending.Remove":     func() bool { return pool.Pending.Remove(ctx, &TxStatus{Hash: hash, Status: CONFIRMED}) },
+		"Queued.Add":         func() bool { return pool.Queued.Add(ctx, fresh[1]) },
+		"Queued.Remove":      func() bool { return pool.Queued.Remove(ctx, hash) != nil },
+	}
+
+	for name, mutator := range mutators {
+
+		doneChan := make(chan bool, 1)
+		go func(mutator func() bool) {
+			doneChan <- mutator()
+		}(mutator)
+
+		select {
+		case done := <-doneChan:
+			if done {
+				t.Errorf("%s : expected nothing to be done by stopped pool", name)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("%s : still blocked after pool stopped", name)
+		}
+
+	}
+
+}
+
+func TestRequest(t *testing.T) {
+
+	answer := func(ch chan chan uint64) {
+		respChan := <-ch
+		respChan <- 42
+	}
+
+	cases := []struct {
+		name    string
+		stopped bool
+		cancel  bool
+		serve   func(ch chan chan uint64, stopped chan struct{})
+		want    uint64
+		wantErr error
+	}{
+		{
+			name: "answered",
+			serve: func(ch chan chan uint64, stopped chan struct{}) {
+				answer(ch)
+			},
+			want: 42,
+		},
+		{
+			name:    "stopped before sending",
+			stopped: true,
+			wantErr: ErrPoolStopped,
+		},
+		{
+			name:    "cancelled before sending",
+			cancel:  true,
+			wantErr: context.Canceled,
+		},
+		{
+			name: "stopped while waiting",
+			serve: func(ch chan chan uint64, stopped chan struct{}) {
+				<-ch
+				close(stopped)
+			},
+			wantErr: ErrPoolStopped,
+		},
+		{
+			name: "answered just before stopping",
+			serve: func(ch chan chan uint64, stopped chan struct{}) {
+				answer(ch)
+				close(stopped)
+			},
+			want: 42,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+
+			// Unbuffered, so that request is sent only when it's taken
+			ch := make(chan chan uint64)
+			stopped := make(chan struct{})
+			if c.stopped {
+				close(stopped)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if c.cancel {
+				cancel()
+			}
+
+			if c.serve != nil {
+				go c.serve(ch, stopped)
+			}
+
+			got, err := request(ctx, stopped, ch, func(respChan chan uint64) chan uint64 {
+				return respChan
+			})
+			if !errors.Is(err, c.wantErr) {
+				t.Fatalf("expected `%v`, got `%v`", c.wantErr, err)
+			}
+
+			if got != c.want {
+				t.Fatalf("expected %d, got %d", c.want, got)
+			}
+
+		})
+	}
+
+}
+
+// Request taken, but never answered, is given up on once `ctx` is done
+func TestRequestCancelledWhileWaiting(t *testing.T) {
+
+	ch := make(chan chan uint64, 1)
+	stopped := make(chan struct{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := request(ctx, stopped, ch, func(respChan chan uint64) chan uint64 {
+		return respChan
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected `%v`, got `%v`", context.DeadlineExceeded, err)
+	}
+
+	// Request left behind is discarded, once pool stops
+	close(stopped)
+	drain(ch)
+
+	if len(ch) != 0 {
+		t.Fatalf("expected request channel to be drained, %d left", len(ch))
+	}
+
+}
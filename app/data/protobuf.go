@@ -0,0 +1,339 @@
+package data
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers, as declared in `tx.proto`
+const (
+	pbBlockHash protowire.Number = iota + 1
+	pbBlockNumber
+	pbFrom
+	pbGas
+	pbGasPrice
+	pbMaxFeePerGas
+	pbMaxPriorityFeePerGas
+	pbHash
+	pbInput
+	pbNonce
+	pbTo
+	pbTransactionIndex
+	pbValue
+	pbType
+	pbChainID
+	pbV
+	pbR
+	pbS
+	pbQueuedAt
+	pbUnstuckAt
+	pbPendingFrom
+	pbConfirmedAt
+	pbDroppedAt
+	pbPool
+	pbReceivedFrom
+	pbAccessList
+	pbReplacedBy
+	pbEvictionReason
+	pbPromoted
+)
+
+// Field numbers of `AccessTuple` message
+const (
+	pbAccessAddress protowire.Number = iota + 1
+	pbAccessStorageKeys
+)
+
+// appendBytes - Appends length delimited field to protobuf encoded buffer
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+// appendVarint - Appends varint field to protobuf encoded buffer
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+// appendBig - Appends big number, if present, as big-endian bytes
+func appendBig(b []byte, num protowire.Number, v *hexutil.Big) []byte {
+	if v == nil {
+		return b
+	}
+
+	return appendBytes(b, num, (*big.Int)(v).Bytes())
+}
+
+// appendTime - Appends timestamp, if set, as unix nanoseconds
+func appendTime(b []byte, num protowire.Number, v time.Time) []byte {
+	if v.IsZero() {
+		return b
+	}
+
+	return appendVarint(b, num, uint64(v.UnixNano()))
+}
+
+// ToProtobuf - Serialize to protocol buffer encoded byte array format,
+// following schema defined in `tx.proto`
+func (m *MemPoolTx) ToProtobuf() ([]byte, error) {
+
+	b := make([]byte, 0, 256+len(m.Input))
+
+	if m.BlockHash != nil {
+		b = appendBytes(b, pbBlockHash, m.BlockHash.Bytes())
+	}
+
+	b = appendBig(b, pbBlockNumber, m.BlockNumber)
+	b = appendBytes(b, pbFrom, m.From.Bytes())
+	b = appendVarint(b, pbGas, uint64(m.Gas))
+	b = appendBig(b, pbGasPrice, m.GasPrice)
+	b = appendBig(b, pbMaxFeePerGas, m.MaxFeePerGas)
+	b = appendBig(b, pbMaxPriorityFeePerGas, m.MaxPriorityFeePerGas)
+	b = appendBytes(b, pbHash, m.Hash.Bytes())
+	b = appendBytes(b, pbInput, m.Input)
+	b = appendVarint(b, pbNonce, uint64(m.Nonce))
+
+	if m.To != nil {
+		b = appendBytes(b, pbTo, m.To.Bytes())
+	}
+
+	if m.TransactionIndex != nil {
+		b = appendVarint(b, pbTransactionIndex, uint64(*m.TransactionIndex))
+	}
+
+	b = appendBig(b, pbValue, m.Value)
+	b = appendVarint(b, pbType, uint64(m.Type))
+	b = appendBig(b, pbChainID, m.ChainID)
+	b = appendBig(b, pbV, m.V)
+	b = appendBig(b, pbR, m.R)
+	b = appendBig(b, pbS, m.S)
+	b = appendTime(b, pbQueuedAt, m.QueuedAt)
+	b = appendTime(b, pbUnstuckAt, m.UnstuckAt)
+	b = appendTime(b, pbPendingFrom, m.PendingFrom)
+	b = appendTime(b, pbConfirmedAt, m.ConfirmedAt)
+	b = appendTime(b, pbDroppedAt, m.DroppedAt)
+	b = appendBytes(b, pbPool, []byte(m.Pool))
+	b = appendBytes(b, pbReceivedFrom, []byte(m.ReceivedFrom))
+
+	if m.AccessList != nil {
+
+		for _, tuple := range *m.AccessList {
+
+			_b := appendBytes(nil, pbAccessAddress, tuple.Address.Bytes())
+			for _, key := range tuple.StorageKeys {
+				_b = appendBytes(_b, pbAccessStorageKeys, key.Bytes())
+			}
+
+			b = appendBytes(b, pbAccessList, _b)
+
+		}
+
+	}
+
+	if m.IsReplaced() {
+		b = appendBytes(b, pbReplacedBy, m.ReplacedBy.Bytes())
+	}
+
+	if len(m.EvictionReason) != 0 {
+		b = appendBytes(b, pbEvictionReason, []byte(m.EvictionReason))
+	}
+
+	if m.Promoted {
+		b = appendVarint(b, pbPromoted, 1)
+	}
+
+	return b, nil
+
+}
+
+// consumeField - Reads next field from protobuf encoded buffer, returning
+// field number, wire type, raw value & #-of bytes consumed
+func consumeField(b []byte) (protowire.Number, protowire.Type, []byte, uint64, int, error) {
+
+	num, typ, n := protowire.ConsumeTag(b)
+	if n < 0 {
+		return 0, 0, nil, 0, 0, protowire.ParseError(n)
+	}
+
+	switch typ {
+
+	case protowire.BytesType:
+
+		v, m := protowire.ConsumeBytes(b[n:])
+		if m < 0 {
+			return 0, 0, nil, 0, 0, protowire.ParseError(m)
+		}
+
+		return num, typ, v, 0, n + m, nil
+
+	case protowire.VarintType:
+
+		v, m := protowire.ConsumeVarint(b[n:])
+		if m < 0 {
+			return 0, 0, nil, 0, 0, protowire.ParseError(m)
+		}
+
+		return num, typ, nil, v, n + m, nil
+
+	default:
+
+		// Unknown fields of other wire types are skipped
+		m := protowire.ConsumeFieldValue(num, typ, b[n:])
+		if m < 0 {
+			return 0, 0, nil, 0, 0, protowire.ParseError(m)
+		}
+
+		return num, typ, nil, 0, n + m, nil
+
+	}
+
+}
+
+// decodeAccessTuple - Decodes one access list entry
+func decodeAccessTuple(b []byte) (types.AccessTuple, error) {
+
+	var tuple types.AccessTuple
+
+	for len(b) > 0 {
+
+		num, _, v, _, n, err := consumeField(b)
+		if err != nil {
+			return tuple, err
+		}
+
+		switch num {
+
+		case pbAccessAddress:
+			tuple.Address = common.BytesToAddress(v)
+		case pbAccessStorageKeys:
+			tuple.StorageKeys = append(tuple.StorageKeys, common.BytesToHash(v))
+
+		}
+
+		b = b[n:]
+
+	}
+
+	return tuple, nil
+
+}
+
+// FromProtobuf - Given protocol buffer encoded byte array, attempts to
+// deserialize into structured tx format
+func FromProtobuf(data []byte) (*MemPoolTx, error) {
+
+	var tx MemPoolTx
+
+	toBig := func(v []byte) *hexutil.Big {
+		return (*hexutil.Big)(new(big.Int).SetBytes(v))
+	}
+
+	toTime := func(v uint64) time.Time {
+		return time.Unix(0, int64(v)).UTC()
+	}
+
+	for len(data) > 0 {
+
+		num, typ, v, u, n, err := consumeField(data)
+		if err != nil {
+			return nil, err
+		}
+
+		data = data[n:]
+
+		if typ != protowire.BytesType && typ != protowire.VarintType {
+			continue
+		}
+
+		switch num {
+
+		case pbBlockHash:
+			hash := common.BytesToHash(v)
+			tx.BlockHash = &hash
+		case pbBlockNumber:
+			tx.BlockNumber = toBig(v)
+		case pbFrom:
+			tx.From = common.BytesToAddress(v)
+		case pbGas:
+			tx.Gas = hexutil.Uint64(u)
+		case pbGasPrice:
+			tx.GasPrice = toBig(v)
+		case pbMaxFeePerGas:
+			tx.MaxFeePerGas = toBig(v)
+		case pbMaxPriorityFeePerGas:
+			tx.MaxPriorityFeePerGas = toBig(v)
+		case pbHash:
+			tx.Hash = common.BytesToHash(v)
+		case pbInput:
+			tx.Input = append(hexutil.Bytes{}, v...)
+		case pbNonce:
+			tx.Nonce = hexutil.Uint64(u)
+		case pbTo:
+			to := common.BytesToAddress(v)
+			tx.To = &to
+		case pbTransactionIndex:
+			idx := hexutil.Uint64(u)
+			tx.TransactionIndex = &idx
+		case pbValue:
+			tx.Value = toBig(v)
+		case pbType:
+			tx.Type = hexutil.Uint64(u)
+		case pbChainID:
+			tx.ChainID = toBig(v)
+		case pbV:
+			tx.V = toBig(v)
+		case pbR:
+			tx.R = toBig(v)
+		case pbS:
+			tx.S = toBig(v)
+		case pbQueuedAt:
+			tx.QueuedAt = toTime(u)
+		case pbUnstuckAt:
+			tx.UnstuckAt = toTime(u)
+		case pbPendingFrom:
+			tx.PendingFrom = toTime(u)
+		case pbConfirmedAt:
+			tx.ConfirmedAt = toTime(u)
+		case pbDroppedAt:
+			tx.DroppedAt = toTime(u)
+		case pbPool:
+			tx.Pool = string(v)
+		case pbReceivedFrom:
+			tx.ReceivedFrom = string(v)
+		case pbReplacedBy:
+			tx.ReplacedBy = common.BytesToHash(v)
+		case pbEvictionReason:
+			tx.EvictionReason = string(v)
+		case pbPromoted:
+			tx.Promoted = u != 0
+		case pbAccessList:
+
+			tuple, err := decodeAccessTuple(v)
+			if err != nil {
+				return nil, err
+			}
+
+			if tx.AccessList == nil {
+				tx.AccessList = &types.AccessList{}
+			}
+
+			*tx.AccessList = append(*tx.AccessList, tuple)
+
+		}
+
+	}
+
+	if tx.Hash == (common.Hash{}) {
+		return nil, errors.New("tx hash missing in protobuf message")
+	}
+
+	return &tx, nil
+
+}
@@ -0,0 +1,307 @@
+package data
+
+import (
+	"context"
+	"log"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/itzmeanjan/harmony/app/listen"
+)
+
+// PruneSet - Tx(s) living in pending pool, which need to be pruned, after
+// seeing tx(s) mined in some block
+type PruneSet struct {
+	// Tx(s) sent by senders of mined tx(s), with same/ lower nonce,
+	// including mined ones
+	Prunables []*MemPoolTx
+	// Mined tx(s), which are not present in pending pool
+	NotFound listen.CaughtTxs
+	// Highest mined nonce of each sender
+	HighestNonce map[common.Address]hexutil.Uint64
+	// All mined nonces of each sender, so that other tx(s) with same
+	// sender & nonce can be marked as replaced
+	MinedNonces map[common.Address]map[hexutil.Uint64]struct{}
+	// Hashes of mined tx(s), found in pool
+	Mined map[common.Hash]struct{}
+}
+
+// StatusOf - Given prunable tx, decides what happened to it, only looking at
+// content of mined block i.e. without any RPC call
+//
+// Tx present in block is confirmed, another tx with same sender & nonce
+// being present makes it replaced, otherwise it's dropped, because
+// a higher nonce from same sender got mined
+func (s *PruneSet) StatusOf(tx *MemPoolTx) int {
+
+	if _, ok := s.Mined[tx.Hash]; ok {
+		return CONFIRMED
+	}
+
+	if _, ok := s.MinedNonces[tx.From][tx.Nonce]; ok {
+		return REPLACED
+	}
+
+	return DROPPED
+
+}
+
+// pruneSetOf - Given tx(s) mined in one block, groups those found in pool by
+// sender, for finding out highest mined nonce of each sender & then collects
+// all prunable tx(s) of each sender, with single lookup in per sender index
+//
+// Cost is proportional to #-of mined tx(s) & #-of tx(s) sent by their
+// senders, not to pool size
+//
+// @note Supposed to be invoked from pool's own life cycle manager go routine
+func (p *PendingPool) pruneSetOf(txs listen.CaughtTxs) PruneSet {
+
+	set := PruneSet{
+		Prunables:    make([]*MemPoolTx, 0, len(txs)),
+		NotFound:     make(listen.CaughtTxs, 0),
+		HighestNonce: make(map[common.Address]hexutil.Uint64),
+		MinedNonces:  make(map[common.Address]map[hexutil.Uint64]struct{}),
+		Mined:        make(map[common.Hash]struct{}),
+	}
+
+	for _, caught := range txs {
+
+		tx, ok := p.Transactions[caught.Hash]
+		if !ok {
+			// well, couldn't find tx in pool, keeping track of
+			// it in another worker, which will let us know about it
+			// when need to
+			set.NotFound = append(set.NotFound, caught)
+			continue
+		}
+
+		set.Mined[tx.Hash] = struct{}{}
+
+		if _, ok := set.MinedNonces[tx.From]; !ok {
+			set.MinedNonces[tx.From] = make(map[hexutil.Uint64]struct{})
+		}
+		set.MinedNonces[tx.From][tx.Nonce] = struct{}{}
+
+		if highest, ok := set.HighestNonce[tx.From]; !ok || highest < tx.Nonce {
+			set.HighestNonce[tx.From] = tx.Nonce
+		}
+
+	}
+
+	for from, highest := range set.HighestNonce {
+
+		list, ok := p.TxsFromAddress[from]
+		if !ok {
+			continue
+		}
+
+		// Sender's tx(s) are kept ascending ordered as per nonce, so
+		// walking stops as soon as nonce goes beyond highest mined one
+		for _, tx := range list.get() {
+
+			if tx.Nonce > highest {
+				break
+			}
+
+			set.Prunables = append(set.Prunables, tx.Clone())
+
+		}
+
+	}
+
+	return set
+
+}
+
+// PruneSetOf - Given tx(s) mined in one block, finds out which tx(s) living
+// in pending pool need to be pruned, computed over consistent snapshot of pool
+func (p *PendingPool) PruneSetOf(txs listen.CaughtTxs) PruneSet {
+
+	v, _ := p.PruneSetOfWithContext(context.Background(), txs)
+	return v
+
+}
+
+// PruneSetOfWithContext - Context aware `PruneSetOf`
+func (p *PendingPool) PruneSetOfWithContext(ctx context.Context, txs listen.CaughtTxs) (PruneSet, error) {
+
+	return request(ctx, p.StoppedChan, p.PruneSetChan, func(respChan chan PruneSet) PruneSetRequest {
+		return PruneSetRequest{Txs: txs, ResponseChan: respChan}
+	})
+
+}
+
+// prunedByBlock - Hashes of tx(s) pruned after seeing each of last few
+// blocks, kept by pruner, so that they can be given another chance when
+// those blocks get replaced
+type prunedByBlock struct {
+	hashes map[uint64][]common.Hash
+	depth  uint64
+}
+
+// newPrunedByBlock - Remembers tx(s) pruned for at max `depth` recent blocks
+func newPrunedByBlock(depth uint64) *prunedByBlock {
+
+	return &prunedByBlock{
+		hashes: make(map[uint64][]common.Hash),
+		depth:  depth,
+	}
+
+}
+
+// record - Given mined tx(s) of one block & tx(s) being pruned after seeing
+// those, remembers them against block number, while forgetting those
+// remembered for blocks, which are too old to be reorganized now
+func (p *prunedByBlock) record(mined listen.CaughtTxs, prunables []*MemPoolTx) {
+
+	var block uint64
+
+	for _, tx := range mined {
+		if tx.Block > block {
+			block = tx.Block
+		}
+	}
+
+	for _, tx := range prunables {
+		p.hashes[block] = append(p.hashes[block], tx.Hash)
+	}
+
+	for num := range p.hashes {
+		if num+p.depth <= block {
+			delete(p.hashes, num)
+		}
+	}
+
+}
+
+// forget - Returns hashes of tx(s) pruned after seeing blocks in given
+// range, while forgetting them, as those blocks are replaced now
+func (p *prunedByBlock) forget(from uint64, to uint64) []common.Hash {
+
+	hashes := make([]common.Hash, 0)
+
+	for num := from; num <= to; num++ {
+
+		hashes = append(hashes, p.hashes[num]...)
+		delete(p.hashes, num)
+
+	}
+
+	return hashes
+
+}
+
+// probeBatchSize - At max these many disappeared tx(s) are looked up with
+// node, in one batched request
+const probeBatchSize = 256
+
+// disappearedTxs - Pending tx(s) which disappeared from mempool content, kept
+// by pruner, against block it had seen last when they disappeared, so that
+// ones not mined in next few blocks can be checked with node
+type disappearedTxs struct {
+	since map[common.Hash]uint64
+	head  uint64
+	after uint64
+}
+
+// newDisappearedTxs - Disappeared tx(s) are checked with node, once they've
+// been waited on for `after` blocks
+func newDisappearedTxs(after uint64) *disappearedTxs {
+
+	return &disappearedTxs{
+		since: make(map[common.Hash]uint64),
+		after: after,
+	}
+
+}
+
+// add - Starts waiting on given tx(s), unless already waiting on them
+func (d *disappearedTxs) add(hashes []common.Hash) {
+
+	for _, hash := range hashes {
+		if _, ok := d.since[hash]; !ok {
+			d.since[hash] = d.head
+		}
+	}
+
+}
+
+// mined - Given tx(s) of newly seen block, stops waiting on them, as they'll
+// be pruned anyway, returning those which are waited on for long enough now
+//
+// Tx(s) which disappeared before any block was seen, start waiting from this
+// one
+func (d *disappearedTxs) mined(txs listen.CaughtTxs) []common.Hash {
+
+	for _, tx := range txs {
+
+		delete(d.since, tx.Hash)
+		if tx.Block > d.head {
+			d.head = tx.Block
+		}
+
+	}
+
+	due := make([]common.Hash, 0)
+
+	for hash, since := range d.since {
+
+		if since == 0 {
+			d.since[hash] = d.head
+			continue
+		}
+
+		if since+d.after <= d.head {
+			due = append(due, hash)
+			delete(d.since, hash)
+		}
+
+	}
+
+	return due
+
+}
+
+// probeDisappeared - Among given disappeared tx(s), ones still living in pool
+// are checked with node, in batches, where those it doesn't know about anymore
+// are sent to pruner as dropped
+func (p *PendingPool) probeDisappeared(ctx context.Context, hashes []common.Hash, statusChan chan<- *TxStatus) {
+
+	present, err := p.FindWithContext(ctx, hashes)
+	if err != nil || len(present) == 0 {
+		return
+	}
+
+	living := make([]common.Hash, 0, len(present))
+	for hash := range present {
+		living = append(living, hash)
+	}
+
+	for start := 0; start < len(living); start += probeBatchSize {
+
+		end := start + probeBatchSize
+		if end > len(living) {
+			end = len(living)
+		}
+
+		unknown, err := UnknownAmong(ctx, p.RPC, living[start:end])
+		if err != nil {
+
+			log.Printf("[❗️] Failed to check whether disappeared tx(s) are dropped : %s\n", err.Error())
+			return
+
+		}
+
+		for _, hash := range unknown {
+
+			select {
+			case <-ctx.Done():
+				return
+			case statusChan <- &TxStatus{Hash: hash, Status: DROPPED}:
+			}
+
+		}
+
+	}
+
+}
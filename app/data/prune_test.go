@@ -0,0 +1,193 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/itzmeanjan/harmony/app/listen"
+)
+
+// prunablePool - Pending pool state, without life cycle manager running,
+// holding `n` tx(s) from `n/10` senders, along with block mining first
+// `mined` of them
+func prunablePool(n int, mined int) (*PendingPool, listen.CaughtTxs) {
+
+	p := &PendingPool{
+		Transactions:   make(map[common.Hash]*MemPoolTx, n),
+		TxsFromAddress: make(map[common.Address]TxList),
+	}
+
+	txs := makeTxs(n, n/10)
+	for _, tx := range txs {
+		p.Transactions[tx.Hash] = tx
+		p.TxsFromAddress[tx.From] = Insert(p.allocateFor(tx.From), tx)
+	}
+
+	block := make(listen.CaughtTxs, 0, mined)
+	for _, tx := range txs[:mined] {
+		block = append(block, &listen.CaughtTx{Hash: tx.Hash, Nonce: uint64(tx.Nonce)})
+	}
+
+	return p, block
+
+}
+
+// scanPruneSetOf - How prunable tx(s) were found before per sender index
+// was used, by looking at every tx in pool, for each mined tx
+func scanPruneSetOf(p *PendingPool, txs listen.CaughtTxs) []*MemPoolTx {
+
+	highest := make(map[common.Address]hexutil.Uint64)
+	for _, caught := range txs {
+
+		tx, ok := p.Transactions[caught.Hash]
+		if !ok {
+			continue
+		}
+
+		if nonce, ok := highest[tx.From]; !ok || nonce < tx.Nonce {
+			highest[tx.From] = tx.Nonce
+		}
+
+	}
+
+	prunables := make([]*MemPoolTx, 0, len(txs))
+	for _, tx := range p.Transactions {
+
+		if nonce, ok := highest[tx.From]; ok && tx.Nonce <= nonce {
+			prunables = append(prunables, tx.Clone())
+		}
+
+	}
+
+	return prunables
+
+}
+
+func TestPruneSetOf(t *testing.T) {
+
+	p, block := prunablePool(1000, 30)
+
+	// Not living in pool
+	block = append(block, &listen.CaughtTx{Hash: txHash(1 << 20)})
+
+	set := p.pruneSetOf(block)
+
+	if len(set.NotFound) != 1 || len(set.Mined) != 30 {
+		t.Fatalf("expected 30 mined & 1 unknown tx, got %d & %d", len(set.Mined), len(set.NotFound))
+	}
+
+	if want := scanPruneSetOf(p, block); len(set.Prunables) != len(want) {
+		t.Fatalf("expected %d prunable tx(s), got %d", len(want), len(set.Prunables))
+	}
+
+	for _, tx := range set.Prunables {
+		if status := set.StatusOf(tx); status != CONFIRMED {
+			t.Fatalf("expected only mined tx(s) to be prunable, %s is %d", tx.Hash, status)
+		}
+	}
+
+}
+
+// Block carrying 300 tx(s), pruned against pool of 100k tx(s)
+func BenchmarkPruneSetOf(b *testing.B) {
+
+	p, block := prunablePool(100_000, 300)
+
+	b.Run("index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.pruneSetOf(block)
+		}
+	})
+
+	b.Run("scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			scanPruneSetOf(p, block)
+		}
+	})
+
+}
+
+// Tx(s) disappeared from mempool content are left alone, until few blocks
+// later, when ones still living in pool are checked with node, where only
+// those node doesn't know about are pruned as dropped
+func TestDisappearedProbedAfterFewBlocks(t *testing.T) {
+
+	withConfig(t, map[string]string{"DisappearedTxProbeBlocks": "2"})
+
+	eth, client := newFakeRPC(t)
+	pool := newPrunedTestPools(t, client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	caughtTxsChan := make(chan listen.CaughtTxs)
+	notFoundTxsChan := make(chan listen.CaughtTxs, 16)
+	go pool.Pending.Prune(ctx, caughtTxsChan, make(chan listen.Reorg), make(chan ConfirmedTx, 16), notFoundTxsChan)
+
+	mined, known, dropped := legacyTx(1, 10), legacyTx(2, 10), legacyTx(3, 10)
+	for i, tx := range []*MemPoolTx{mined, known, dropped} {
+
+		tx.From = txAddress(i)
+		if !pool.Pending.Add(ctx, tx) {
+			t.Fatalf("expected %s to be admitted", tx.Hash)
+		}
+
+	}
+
+	eth.lock.Lock()
+	eth.txs[known.Hash] = &rpcTx{Hash: known.Hash}
+	eth.lock.Unlock()
+
+	pool.Pending.PruneCandidates([]common.Hash{mined.Hash, known.Hash, dropped.Hash})
+
+	// Each block also carries tx not in pool, which pruner reports back,
+	// once it's done with that block
+	block := func(number uint64, txs ...*MemPoolTx) {
+
+		caught := listen.CaughtTxs{{Hash: txHash(int(number)), Block: number}}
+		for _, tx := range txs {
+			caught = append(caught, &listen.CaughtTx{Hash: tx.Hash, Nonce: uint64(tx.Nonce), Block: number})
+		}
+
+		caughtTxsChan <- caught
+
+		select {
+		case <-notFoundTxsChan:
+		case <-time.After(time.Second):
+			t.Fatalf("expected pruner to be done with block %d", number)
+		}
+
+	}
+
+	block(100, mined)
+	block(101)
+
+	if pool.Pending.Exists(mined.Hash) {
+		t.Fatal("expected mined tx to be pruned, when its block is seen")
+	}
+
+	if !pool.Pending.Exists(dropped.Hash) {
+		t.Fatal("expected disappeared tx not to be checked, before few blocks are seen")
+	}
+
+	block(102)
+
+	deadline := time.After(time.Second)
+	for pool.Pending.Exists(dropped.Hash) {
+
+		select {
+		case <-deadline:
+			t.Fatal("expected tx unknown to node to be pruned as dropped")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+	}
+
+	if !pool.Pending.Exists(known.Hash) {
+		t.Fatal("expected tx still known to node to be kept")
+	}
+
+}
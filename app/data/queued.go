@@ -3,14 +3,14 @@ package data
 import (
 	"context"
 	"log"
-	"runtime"
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/gammazero/workerpool"
 	"github.com/itzmeanjan/harmony/app/config"
-	"github.com/itzmeanjan/pub0sub/ops"
 	"github.com/itzmeanjan/pub0sub/publisher"
 )
 
@@ -20,23 +20,56 @@ import (
 // What it essentially denotes is, these tx(s) are not ready to be picked up
 // when next block is going to be picked, when these tx(s) are going to be
 // moved to pending pool, only they can be considered before mining
+//
+// All state of pool is owned by its life cycle manager go routine, no
+// lock is guarding it, so it must be accessed only by sending request
+// on respective channel
 type QueuedPool struct {
-	Transactions      map[common.Hash]*MemPoolTx
-	TxsFromAddress    map[common.Address]TxList
-	DroppedTxs        map[common.Hash]time.Time
-	RemovedTxs        map[common.Hash]time.Time
-	AscTxsByGasPrice  TxList
-	DescTxsByGasPrice TxList
-	AddTxChan         chan AddRequest
-	RemoveTxChan      chan RemovedUnstuckTx
-	TxExistsChan      chan ExistsRequest
-	GetTxChan         chan GetRequest
-	CountTxsChan      chan CountRequest
-	ListTxsChan       chan ListRequest
-	TxsFromAChan      chan TxsFromARequest
-	PubSub            *publisher.Publisher
-	RPC               *rpc.Client
-	PendingPool       *PendingPool
+	Transactions       map[common.Hash]*MemPoolTx
+	TxsFromAddress     map[common.Address]TxList
+	TxsByNonce         NonceIndex
+	DroppedTxs         map[common.Hash]time.Time
+	RemovedTxs         map[common.Hash]time.Time
+	TxsByGasPrice      *GasPriceTree
+	TxsByAge           *AgeTree
+	TxsByNonceGap      *NonceGapTree
+	AddTxChan          chan AddRequest
+	RemoveTxChan       chan RemovedUnstuckTx
+	TxExistsChan       chan ExistsRequest
+	GetTxChan          chan GetRequest
+	FindTxsChan        chan FindTxsRequest
+	DuplicateTxsChan   chan DuplicateTxsRequest
+	SameNonceChan      chan SameNonceRequest
+	GasPriceRangeChan  chan GasPriceRangeRequest
+	CountTxsChan       chan CountRequest
+	ListTxsChan        chan ListRequest
+	TxsFromAChan       chan TxsFromARequest
+	CountFromChan      chan CountFromRequest
+	TopSendersChan     chan TopSendersRequest
+	AggregatesChan     chan chan PoolAggregates
+	StatsChan          chan chan PoolStats
+	SetSenderNonceChan chan SenderNonce
+	BaseFeeChan        chan *big.Int
+	GapReportChan      chan chan []*SenderGap
+	SendersChan        chan chan []common.Address
+	AgeWalkChan        chan AgeWalkRequest
+	ResizedChan        chan struct{}
+	StoppedChan        chan struct{}
+	Codec              Codec
+	AddedBatch         *TxBatch
+	RemovedBatch       *TxBatch
+	PubSub             *publisher.Publisher
+	RPC                *rpc.Client
+	Workers            *workerpool.WorkerPool
+	PendingPool        *PendingPool
+	hooks              hookSet
+	totals             aggregates
+	// #-of messages published on entry/ exit topics, to be accessed
+	// atomically
+	publishedAdded   uint64
+	publishedRemoved uint64
+	// Account nonce of senders, as last seen by unstuck checker
+	senderNonces map[common.Address]hexutil.Uint64
 }
 
 // hasBeenAllocatedFor - Checking whether memory has been allocated
@@ -65,37 +98,146 @@ func (q *QueuedPool) allocateFor(addr common.Address) TxList {
 // Start - This method is supposed to be started as a
 // seperate go routine which will manage queued pool ops
 // through out its life
+//
+// If life cycle manager panics, it's respawned, so that one malformed
+// tx can't take whole pool down
 func (q *QueuedPool) Start(ctx context.Context) {
 
+	// Publishing to pubsub topics is just another hook, invoked
+	// along with ones registered by embedding service
+	q.RegisterAddHook(func(tx *MemPoolTx) {
+		q.PublishAdded(ctx, tx)
+	})
+	q.RegisterRemoveHook(func(tx *MemPoolTx) {
+		q.PublishRemoved(ctx, tx)
+	})
+
+	supervise(ctx, "queued pool", q.run)
+
+	// Life cycle manager is gone for good, so that accessors waiting
+	// on it can give up, instead of blocking forever
+	close(q.StoppedChan)
+	q.drainRequests()
+
+}
+
+// drainRequests - Discards requests, which got queued up just as life cycle
+// manager was exiting, their senders give up with `ErrPoolStopped`
+func (q *QueuedPool) drainRequests() {
+
+	drain(q.AddTxChan)
+	drain(q.RemoveTxChan)
+	drain(q.TxExistsChan)
+	drain(q.GetTxChan)
+	drain(q.FindTxsChan)
+	drain(q.DuplicateTxsChan)
+	drain(q.SameNonceChan)
+	drain(q.GasPriceRangeChan)
+	drain(q.CountTxsChan)
+	drain(q.ListTxsChan)
+	drain(q.TxsFromAChan)
+	drain(q.CountFromChan)
+	drain(q.TopSendersChan)
+	drain(q.AggregatesChan)
+	drain(q.StatsChan)
+	drain(q.GapReportChan)
+	drain(q.SendersChan)
+	drain(q.AgeWalkChan)
+
+}
+
+// Alive - Checks whether queued pool's life cycle manager is still running
+func (q *QueuedPool) Alive() bool {
+
+	select {
+	case <-q.StoppedChan:
+		return false
+	default:
+		return true
+	}
+
+}
+
+// Resized - Lets queued pool's life cycle manager know size limit might
+// have changed, so that it can evict tx(s) beyond it
+func (q *QueuedPool) Resized() {
+
+	select {
+	case <-q.StoppedChan:
+	case q.ResizedChan <- struct{}{}:
+	}
+
+}
+
+// run - Queued pool's life cycle manager loop
+func (q *QueuedPool) run(ctx context.Context) {
+
+	// Tx(s) joining/ leaving pool are accumulated in these batches,
+	// when batching is enabled, which are to be flushed periodically
+	q.AddedBatch = NewTxBatch(q.Codec, config.GetQueuedTxEntryPublishTopic, &q.publishedAdded)
+	q.RemovedBatch = NewTxBatch(q.Codec, config.GetQueuedTxExitPublishTopic, &q.publishedRemoved)
+
+	flushTicker := time.NewTicker(time.Duration(config.GetPublishBatchPeriod()) * time.Millisecond)
+	defer flushTicker.Stop()
+
 	// Closure for checking whether adding new tx triggers
 	// condition for dropping some other tx
 	//
-	// Selecting which tx to be dropped
+	// Selecting which tx to be dropped, as per configured policy
 	//
 	// - Tx with lowest gas price paid ✅
-	// - Oldest tx living in mempool ❌
-	// - Oldest tx with lowest gas price paid ❌
-	//
-	// ✅ : Implemented
-	// ❌ : Not yet
+	// - Oldest tx living in mempool ✅
+	// - Oldest tx with lowest gas price paid ✅
+	// - Tx farthest from becoming executable ✅
 	//
 	// @note Don't accept tx which are already dropped
 	needToDropTxs := func() bool {
-		return uint64(q.AscTxsByGasPrice.len())+1 > config.GetQueuedPoolSize()
+		return uint64(q.TxsByGasPrice.len())+1 > config.GetQueuedPoolSize()
+	}
+
+	policy := config.GetQueuedEvictionPolicy()
+
+	if q.senderNonces == nil {
+		q.senderNonces = make(map[common.Address]hexutil.Uint64)
+	}
+
+	pickTxToEvict := func() *MemPoolTx {
+
+		if policy == LargestNonceGap {
+			return q.TxsByNonceGap.farthest()
+		}
+
+		return pickEvictable(policy, q.TxsByGasPrice, q.TxsByAge)
+
 	}
 
-	pickTxWithLowestGasPrice := func() *MemPoolTx {
-		return q.AscTxsByGasPrice.get()[0]
+	// Sender's entry in nonce gap ordered tree is refreshed, whenever its
+	// tx(s) or account nonce change, so that tx farthest from becoming
+	// executable is known without scanning all senders
+	regap := func(from common.Address) {
+
+		if !q.hasBeenAllocatedFor(from) {
+			q.TxsByNonceGap.unset(from)
+			return
+		}
+
+		nonce, known := q.senderNonces[from]
+		q.TxsByNonceGap.set(from, q.TxsFromAddress[from].get(), nonce, known)
+
 	}
 
 	// For adding new tx into queued pool, always
 	// invoke this closure
 	addTx := func(tx *MemPoolTx) {
 
-		q.AscTxsByGasPrice = Insert(q.AscTxsByGasPrice, tx)
-		q.DescTxsByGasPrice = Insert(q.DescTxsByGasPrice, tx)
+		q.TxsByGasPrice.insert(tx)
 		q.TxsFromAddress[tx.From] = Insert(q.allocateFor(tx.From), tx)
 		q.Transactions[tx.Hash] = tx
+		q.TxsByNonce.add(tx)
+		q.TxsByAge.insert(tx)
+		regap(tx.From)
+
+		q.totals.added(tx, q.TxsFromAddress[tx.From].len())
 
 	}
 
@@ -103,20 +245,46 @@ func (q *QueuedPool) Start(ctx context.Context) {
 	// same logic in multiple places, consider using this one
 	removeTx := func(tx *MemPoolTx) {
 
+		// Running totals are updated only when tx was living in pool
+		_, present := q.Transactions[tx.Hash]
+
 		// Remove from sorted tx list, keep it sorted
-		q.AscTxsByGasPrice = Remove(q.AscTxsByGasPrice, tx)
-		q.DescTxsByGasPrice = Remove(q.DescTxsByGasPrice, tx)
-		q.TxsFromAddress[tx.From] = Remove(q.TxsFromAddress[tx.From], tx)
+		q.TxsByGasPrice.remove(tx)
+		if q.hasBeenAllocatedFor(tx.From) {
+			q.TxsFromAddress[tx.From] = Remove(q.TxsFromAddress[tx.From], tx)
+		}
 		delete(q.Transactions, tx.Hash)
+		q.TxsByNonce.remove(tx)
+		q.TxsByAge.remove(tx)
+
+		if present {
+			q.totals.removed(tx, int(countFrom(q.TxsFromAddress, tx.From)))
+		}
+
+		// Sender has no more tx(s) in pool, so its entry is reclaimed,
+		// to be allocated again lazily, when it sends next tx
+		if q.hasBeenAllocatedFor(tx.From) && q.TxsFromAddress[tx.From].len() == 0 {
+			delete(q.TxsFromAddress, tx.From)
+			delete(q.senderNonces, tx.From)
+		}
+
+		regap(tx.From)
 
 	}
 
-	// Silently drop some tx, before adding
-	// new one, so that we don't exceed limit
-	// set up by user
+	// Drop some tx, before adding new one, so that we don't
+	// exceed limit set up by user, while letting subscribers
+	// know why it left pool
 	dropTx := func(tx *MemPoolTx) {
 
+		tx.DroppedAt = time.Now().UTC()
+		tx.Pool = "dropped"
+		tx.EvictionReason = policy
+
 		removeTx(tx)
+		q.hooks.removed(tx)
+		log.Printf("[➖] Evicted tx from queued pool, following `%s` policy : %s\n", policy, tx.Hash.Hex())
+
 		// Marking that tx has been dropped, so that
 		// it won't get picked up next time
 		q.DroppedTxs[tx.Hash] = time.Now().UTC()
@@ -140,31 +308,54 @@ func (q *QueuedPool) Start(ctx context.Context) {
 		}
 
 		if needToDropTxs() {
-			dropTx(pickTxWithLowestGasPrice())
+			dropTx(pickTxToEvict())
 		}
 
-		// Marking we found this tx in mempool now
-		tx.QueuedAt = time.Now().UTC()
+		// Marking we found this tx in mempool now, unless some peer
+		// has already seen it earlier
+		if now := time.Now().UTC(); tx.QueuedAt.IsZero() || tx.QueuedAt.After(now) {
+			tx.QueuedAt = now
+		}
 		tx.Pool = "queued"
 
 		addTx(tx)
-		q.PublishAdded(ctx, tx)
+		q.hooks.added(tx)
 
 		return true
 
 	}
 
-	txRemover := func(txHash common.Hash) *MemPoolTx {
+	txRemover := func(txHash common.Hash, status int) *MemPoolTx {
 
 		tx, ok := q.Transactions[txHash]
 		if !ok {
 			return nil
 		}
 
-		tx.UnstuckAt = time.Now().UTC()
+		switch status {
+
+		case MANUALLY_EVICTED:
+
+			tx.DroppedAt = time.Now().UTC()
+			tx.Pool = "dropped"
+
+		case REPLACED:
+
+			// Some other tx with same sender & nonce got mined
+			tx.DroppedAt = time.Now().UTC()
+			tx.Pool = "replaced"
+
+		default:
+
+			// Marking it's leaving queued pool, because it's not stuck
+			// anymore, so that subscribers can tell it apart
+			tx.UnstuckAt = time.Now().UTC()
+			tx.Pool = "unstuck"
+
+		}
 
 		removeTx(tx)
-		q.PublishRemoved(ctx, tx)
+		q.hooks.removed(tx)
 
 		return tx
 
@@ -176,6 +367,12 @@ func (q *QueuedPool) Start(ctx context.Context) {
 
 		case <-ctx.Done():
 			return
+
+		case <-flushTicker.C:
+
+			q.AddedBatch.Flush(q.PubSub)
+			q.RemovedBatch.Flush(q.PubSub)
+
 		case req := <-q.AddTxChan:
 
 			req.ResponseChan <- txAdder(req.Tx)
@@ -183,13 +380,17 @@ func (q *QueuedPool) Start(ctx context.Context) {
 		case req := <-q.RemoveTxChan:
 
 			// if removed will return non-nil reference to removed tx
-			removed := txRemover(req.Hash)
+			removed := txRemover(req.Hash, req.Status)
 			req.ResponseChan <- removed
 
 			if removed != nil {
 				// Marking that tx has been removed, so that
 				// it won't get picked up next time
 				q.RemovedTxs[req.Hash] = time.Now().UTC()
+
+				if req.Status == MANUALLY_EVICTED {
+					q.DroppedTxs[req.Hash] = time.Now().UTC()
+				}
 			}
 
 		case req := <-q.TxExistsChan:
@@ -200,49 +401,91 @@ func (q *QueuedPool) Start(ctx context.Context) {
 		case req := <-q.GetTxChan:
 
 			if tx, ok := q.Transactions[req.Tx]; ok {
-				req.ResponseChan <- tx
+				req.ResponseChan <- tx.Clone()
 				break
 			}
 
 			req.ResponseChan <- nil
 
+		case req := <-q.FindTxsChan:
+
+			found := make(map[common.Hash]*MemPoolTx)
+			for _, hash := range req.Txs {
+				if tx, ok := q.Transactions[hash]; ok {
+					found[hash] = tx.Clone()
+				}
+			}
+
+			req.ResponseChan <- found
+
+		case req := <-q.DuplicateTxsChan:
+
+			req.ResponseChan <- q.TxsByNonce.duplicatesOf(q.Transactions, req.Tx)
+
+		case req := <-q.SameNonceChan:
+
+			req.ResponseChan <- q.TxsByNonce.sameNonceAs(q.Transactions, req.Tx.From, req.Tx.Nonce, req.Tx.Hash)
+
+		case req := <-q.GasPriceRangeChan:
+
+			req.ResponseChan <- CloneAll(q.TxsByGasPrice.between(req.Low, req.High))
+
 		case req := <-q.CountTxsChan:
 
-			req.ResponseChan <- uint64(q.AscTxsByGasPrice.len())
+			req.ResponseChan <- uint64(q.TxsByGasPrice.len())
 
 		case req := <-q.ListTxsChan:
 
-			if req.Order == ASC {
+			req.ResponseChan <- q.TxsByGasPrice.page(req, q.Transactions)
 
-				// If empty, just return nil
-				if q.AscTxsByGasPrice.len() == 0 {
-					req.ResponseChan <- nil
-					break
-				}
+		case req := <-q.AggregatesChan:
 
-				copied := make([]*MemPoolTx, q.AscTxsByGasPrice.len())
-				copy(copied, q.AscTxsByGasPrice.get())
+			req <- q.totals.snapshot(q.TxsByGasPrice.len(), len(q.TxsFromAddress))
 
-				req.ResponseChan <- copied
-				break
+		case req := <-q.StatsChan:
+
+			req <- poolStats(&q.totals, q.TxsByGasPrice, q.TxsByAge)
+
+		case <-q.ResizedChan:
 
+			// Limit might have been shrunk, by reloading config, so
+			// tx(s) beyond it are evicted right away
+			for uint64(q.TxsByGasPrice.len()) > config.GetQueuedPoolSize() {
+				dropTx(pickTxToEvict())
 			}
 
-			if req.Order == DESC {
+		case req := <-q.CountFromChan:
 
-				// If empty, just return nil
-				if q.DescTxsByGasPrice.len() == 0 {
-					req.ResponseChan <- nil
-					break
-				}
+			req.ResponseChan <- countFrom(q.TxsFromAddress, req.From)
 
-				copied := make([]*MemPoolTx, q.DescTxsByGasPrice.len())
-				copy(copied, q.DescTxsByGasPrice.get())
+		case req := <-q.TopSendersChan:
 
-				req.ResponseChan <- copied
+			req.ResponseChan <- topSenders(q.TxsFromAddress, req.N)
 
+		case req := <-q.SetSenderNonceChan:
+
+			// Only kept as long as sender has tx(s) in pool
+			if q.hasBeenAllocatedFor(req.From) {
+				q.senderNonces[req.From] = req.Nonce
+				regap(req.From)
 			}
 
+		case baseFee := <-q.BaseFeeChan:
+
+			q.TxsByGasPrice.rekey(baseFee)
+
+		case req := <-q.GapReportChan:
+
+			req <- gapsOf(q.TxsFromAddress, q.senderNonces)
+
+		case req := <-q.SendersChan:
+
+			req <- sendersOf(q.TxsFromAddress)
+
+		case req := <-q.AgeWalkChan:
+
+			req.ResponseChan <- snapshotByAge(q.TxsByAge, req.Age, req.Older, req.Limit)
+
 		case req := <-q.TxsFromAChan:
 
 			if txs, ok := q.TxsFromAddress[req.From]; ok {
@@ -252,10 +495,7 @@ func (q *QueuedPool) Start(ctx context.Context) {
 					break
 				}
 
-				copied := make([]*MemPoolTx, txs.len())
-				copy(copied, txs.get())
-
-				req.ResponseChan <- copied
+				req.ResponseChan <- CloneAll(txs.get())
 				break
 
 			}
@@ -330,7 +570,7 @@ func (q *QueuedPool) Prune(ctx context.Context, confirmedTxsChan chan ConfirmedT
 			// unstuck or not, if yes we're going to attempt to mark it as
 			// unstuck
 			txs := q.TxsFromA(mined.From)
-			if txs == nil {
+			if len(txs) == 0 {
 				break
 			}
 
@@ -349,7 +589,7 @@ func (q *QueuedPool) Prune(ctx context.Context, confirmedTxsChan chan ConfirmedT
 			// we can remove all nonce gapless txs, sent from this user
 
 			txs := q.TxsFromA(pending.From)
-			if txs == nil {
+			if len(txs) == 0 {
 				break
 			}
 
@@ -392,38 +632,128 @@ func (q *QueuedPool) Prune(ctx context.Context, confirmedTxsChan chan ConfirmedT
 
 }
 
+// RegisterAddHook - Registers hook to be invoked, with copy of tx, after
+// it's added to queued pool
+//
+// Hooks are invoked from pool's life cycle manager go routine, so they're
+// supposed to return quickly, panics are recovered & logged
+func (q *QueuedPool) RegisterAddHook(hook Hook) {
+	q.hooks.registerAdd(hook)
+}
+
+// RegisterRemoveHook - Registers hook to be invoked, with copy of tx, after
+// it's removed from queued pool
+//
+// Hooks are invoked from pool's life cycle manager go routine, so they're
+// supposed to return quickly, panics are recovered & logged
+func (q *QueuedPool) RegisterRemoveHook(hook Hook) {
+	q.hooks.registerRemove(hook)
+}
+
 // Get - Given tx hash, attempts to find out tx in queued pool, if any
 //
 // Returns nil, if found nothing
 func (q *QueuedPool) Get(hash common.Hash) *MemPoolTx {
 
-	respChan := make(chan *MemPoolTx)
+	v, _ := q.GetWithContext(context.Background(), hash)
+	return v
+
+}
 
-	q.GetTxChan <- GetRequest{Tx: hash, ResponseChan: respChan}
+// GetWithContext - Context aware `Get`
+func (q *QueuedPool) GetWithContext(ctx context.Context, hash common.Hash) (*MemPoolTx, error) {
 
-	return <-respChan
+	return request(ctx, q.StoppedChan, q.GetTxChan, func(respChan chan *MemPoolTx) GetRequest {
+		return GetRequest{Tx: hash, ResponseChan: respChan}
+	})
 
 }
 
 // Exists - Checks whether tx of given hash exists on queued pool or not
 func (q *QueuedPool) Exists(hash common.Hash) bool {
 
-	respChan := make(chan bool)
+	v, _ := q.ExistsWithContext(context.Background(), hash)
+	return v
+
+}
 
-	q.TxExistsChan <- ExistsRequest{Tx: hash, ResponseChan: respChan}
+// ExistsWithContext - Context aware `Exists`
+func (q *QueuedPool) ExistsWithContext(ctx context.Context, hash common.Hash) (bool, error) {
 
-	return <-respChan
+	return request(ctx, q.StoppedChan, q.TxExistsChan, func(respChan chan bool) ExistsRequest {
+		return ExistsRequest{Tx: hash, ResponseChan: respChan}
+	})
+
+}
+
+// Find - Given tx hashes, returns copies of those present in queued pool,
+// keyed by their hash
+func (q *QueuedPool) Find(hashes []common.Hash) map[common.Hash]*MemPoolTx {
+
+	v, _ := q.FindWithContext(context.Background(), hashes)
+	return v
+
+}
+
+// FindWithContext - Context aware `Find`
+func (q *QueuedPool) FindWithContext(ctx context.Context, hashes []common.Hash) (map[common.Hash]*MemPoolTx, error) {
+
+	return request(ctx, q.StoppedChan, q.FindTxsChan, func(respChan chan map[common.Hash]*MemPoolTx) FindTxsRequest {
+		return FindTxsRequest{Txs: hashes, ResponseChan: respChan}
+	})
 
 }
 
 // Count - How many tx(s) currently present in pending pool
 func (q *QueuedPool) Count() uint64 {
 
-	respChan := make(chan uint64)
+	v, _ := q.CountWithContext(context.Background())
+	return v
+
+}
+
+// CountWithContext - Context aware `Count`
+func (q *QueuedPool) CountWithContext(ctx context.Context) (uint64, error) {
+
+	return request(ctx, q.StoppedChan, q.CountTxsChan, func(respChan chan uint64) CountRequest {
+		return CountRequest{ResponseChan: respChan}
+	})
+
+}
+
+// Aggregates - Returns totals over all tx(s) living in queued pool,
+// which are kept up-to-date as tx(s) join/ leave pool
+func (q *QueuedPool) Aggregates() PoolAggregates {
+
+	v, _ := q.AggregatesWithContext(context.Background())
+	return v
 
-	q.CountTxsChan <- CountRequest{ResponseChan: respChan}
+}
+
+// AggregatesWithContext - Context aware `Aggregates`
+func (q *QueuedPool) AggregatesWithContext(ctx context.Context) (PoolAggregates, error) {
+
+	return request(ctx, q.StoppedChan, q.AggregatesChan, func(respChan chan PoolAggregates) chan PoolAggregates {
+		return respChan
+	})
+
+}
+
+// Stats - Returns composition of queued pool, without scanning it, where
+// limit is left to be filled in by caller
+func (q *QueuedPool) Stats() PoolStats {
 
-	return <-respChan
+	v, _ := q.StatsWithContext(context.Background())
+	return v
+
+}
+
+// StatsWithContext - Context aware `Stats`
+func (q *QueuedPool) StatsWithContext(ctx context.Context) (PoolStats, error) {
+
+	return request(ctx, q.StoppedChan, q.StatsChan, func(respChan chan PoolStats) chan PoolStats {
+		return respChan
+	})
 
 }
 
@@ -435,94 +765,155 @@ func (q *QueuedPool) Count() uint64 {
 //
 // Considering one tx duplicate of given one, if this tx has same
 // nonce & sender address, as of given ones
+//
+// Duplicates are ordered by gas price paid, so first one is
+// currently winning, if it's paying more than given tx
 func (q *QueuedPool) DuplicateTxs(hash common.Hash) []*MemPoolTx {
 
-	targetTx := q.Get(hash)
-	if targetTx == nil {
-		return nil
-	}
+	v, _ := q.DuplicateTxsWithContext(context.Background(), hash)
+	return v
 
-	txs := q.TxsFromA(targetTx.From)
-	if txs == nil {
-		return nil
-	}
+}
 
-	txCount := uint64(len(txs))
-	commChan := make(chan *MemPoolTx, txCount)
-	result := make([]*MemPoolTx, 0, txCount)
+// DuplicateTxsWithContext - Context aware `DuplicateTxs`
+func (q *QueuedPool) DuplicateTxsWithContext(ctx context.Context, hash common.Hash) ([]*MemPoolTx, error) {
 
-	var wp *workerpool.WorkerPool
+	return request(ctx, q.StoppedChan, q.DuplicateTxsChan, func(respChan chan []*MemPoolTx) DuplicateTxsRequest {
+		return DuplicateTxsRequest{Tx: hash, ResponseChan: respChan}
+	})
 
-	if txCount > uint64(runtime.NumCPU()) {
-		wp = workerpool.New(runtime.NumCPU())
-	} else {
-		wp = workerpool.New(int(txCount))
-	}
+}
 
-	for i := 0; i < len(txs); i++ {
+// SameNonceTxs - Given tx, which may be living in any pool, finds tx(s) living
+// in queued pool with same sender address & nonce, descending ordered as per
+// gas price paid
+func (q *QueuedPool) SameNonceTxs(tx *MemPoolTx) []*MemPoolTx {
 
-		func(tx *MemPoolTx) {
+	v, _ := q.SameNonceTxsWithContext(context.Background(), tx)
+	return v
 
-			wp.Submit(func() {
+}
 
-				if tx.IsDuplicateOf(targetTx) {
-					commChan <- tx
-					return
-				}
+// SameNonceTxsWithContext - Context aware `SameNonceTxs`
+func (q *QueuedPool) SameNonceTxsWithContext(ctx context.Context, tx *MemPoolTx) ([]*MemPoolTx, error) {
 
-				commChan <- nil
+	return request(ctx, q.StoppedChan, q.SameNonceChan, func(respChan chan []*MemPoolTx) SameNonceRequest {
+		return SameNonceRequest{Tx: tx, ResponseChan: respChan}
+	})
 
-			})
+}
 
-		}(txs[i])
+// AscListTxs - Returns all tx(s) present in queued pool, as slice, ascending ordered as per gas price paid
+func (q *QueuedPool) AscListTxs() []*MemPoolTx {
 
-	}
+	v, _ := q.AscListTxsWithContext(context.Background())
+	return v
 
-	var received uint64
+}
 
-	for v := range commChan {
+// AscListTxsWithContext - Context aware `AscListTxs`
+func (q *QueuedPool) AscListTxsWithContext(ctx context.Context) ([]*MemPoolTx, error) {
 
-		if v != nil {
-			result = append(result, v)
-		}
+	page, err := q.list(ctx, ListRequest{Order: ASC})
+	return page.Txs, err
 
-		received++
-		if received >= txCount {
-			break
-		}
+}
 
-	}
+// DescListTxs - Returns all tx(s) present in queued pool, as slice, descending ordered as per gas price paid
+func (q *QueuedPool) DescListTxs() []*MemPoolTx {
 
-	// This call is irrelevant here, probably, but still being made
-	//
-	// Because all workers have exited, otherwise we could have never
-	// reached this point
-	wp.Stop()
-	CleanSlice(txs)
+	v, _ := q.DescListTxsWithContext(context.Background())
+	return v
+
+}
+
+// DescListTxsWithContext - Context aware `DescListTxs`
+func (q *QueuedPool) DescListTxsWithContext(ctx context.Context) ([]*MemPoolTx, error) {
 
-	return result
+	page, err := q.list(ctx, ListRequest{Order: DESC})
+	return page.Txs, err
 
 }
 
-// AscListTxs - Returns all tx(s) present in queued pool, as slice, ascending ordered as per gas price paid
-func (q *QueuedPool) AscListTxs() []*MemPoolTx {
+// ListPage - Returns window of at max `limit` tx(s), starting at `offset`, from
+// queued pool, ordered as per gas price paid, along with total #-of tx(s) in pool
+//
+// Only requested window gets copied, zero `limit` denotes all tx(s) from `offset`
+func (q *QueuedPool) ListPage(order int, offset uint64, limit uint64) TxPage {
 
-	respChan := make(chan []*MemPoolTx)
+	v, _ := q.ListPageWithContext(context.Background(), order, offset, limit)
+	return v
 
-	q.ListTxsChan <- ListRequest{ResponseChan: respChan, Order: ASC}
+}
 
-	return <-respChan
+// ListPageWithContext - Context aware `ListPage`
+func (q *QueuedPool) ListPageWithContext(ctx context.Context, order int, offset uint64, limit uint64) (TxPage, error) {
+
+	return q.list(ctx, ListRequest{Order: order, Offset: offset, Limit: limit})
 
 }
 
-// DescListTxs - Returns all tx(s) present in queued pool, as slice, descending ordered as per gas price paid
-func (q *QueuedPool) DescListTxs() []*MemPoolTx {
+// ListAfterWithContext - Returns window of at max `limit` tx(s), starting right
+// after tx with given hash ( or from very beginning, when it's nil ), from queued pool, ordered as per gas price paid, along
+// with total #-of tx(s) in pool
+//
+// If that tx has already left pool, window starts where it'd have been,
+// as long as it's among recently removed ones
+func (q *QueuedPool) ListAfterWithContext(ctx context.Context, order int, after *common.Hash, limit uint64) (TxPage, error) {
+
+	return q.list(ctx, ListRequest{Order: order, After: after, Limit: limit})
+
+}
+
+// list - Sends listing request to pool's life cycle manager & waits for
+// response, giving up as soon as `ctx` is done or pool has stopped
+func (q *QueuedPool) list(ctx context.Context, req ListRequest) (TxPage, error) {
 
-	respChan := make(chan []*MemPoolTx)
+	v, err := request(ctx, q.StoppedChan, q.ListTxsChan, func(respChan chan TxPage) ListRequest {
+		req.ResponseChan = respChan
+		return req
+	})
+	if err != nil {
+		return TxPage{}, err
+	}
+
+	return v, v.err
+
+}
 
-	q.ListTxsChan <- ListRequest{ResponseChan: respChan, Order: DESC}
+// CountFrom - #-of tx(s) sent by address `A`, living in queued pool,
+// answered from per sender index, without copying tx(s)
+func (q *QueuedPool) CountFrom(addr common.Address) uint64 {
 
-	return <-respChan
+	v, _ := q.CountFromWithContext(context.Background(), addr)
+	return v
+
+}
+
+// CountFromWithContext - Context aware `CountFrom`
+func (q *QueuedPool) CountFromWithContext(ctx context.Context, addr common.Address) (uint64, error) {
+
+	return request(ctx, q.StoppedChan, q.CountFromChan, func(respChan chan uint64) CountFromRequest {
+		return CountFromRequest{ResponseChan: respChan, From: addr}
+	})
+
+}
+
+// TopSenders - Returns at max `n` senders, having most tx(s) living
+// in queued pool, descending ordered as per #-of tx(s)
+func (q *QueuedPool) TopSenders(n uint64) []SenderCount {
+
+	v, _ := q.TopSendersWithContext(context.Background(), n)
+	return v
+
+}
+
+// TopSendersWithContext - Context aware `TopSenders`
+func (q *QueuedPool) TopSendersWithContext(ctx context.Context, n uint64) ([]SenderCount, error) {
+
+	return request(ctx, q.StoppedChan, q.TopSendersChan, func(respChan chan []SenderCount) TopSendersRequest {
+		return TopSendersRequest{ResponseChan: respChan, N: n}
+	})
 
 }
 
@@ -530,11 +921,17 @@ func (q *QueuedPool) DescListTxs() []*MemPoolTx {
 // by address `A`
 func (q *QueuedPool) TxsFromA(addr common.Address) []*MemPoolTx {
 
-	respChan := make(chan []*MemPoolTx)
+	v, _ := q.TxsFromAWithContext(context.Background(), addr)
+	return v
 
-	q.TxsFromAChan <- TxsFromARequest{ResponseChan: respChan, From: addr}
+}
+
+// TxsFromAWithContext - Context aware `TxsFromA`
+func (q *QueuedPool) TxsFromAWithContext(ctx context.Context, addr common.Address) ([]*MemPoolTx, error) {
 
-	return <-respChan
+	return request(ctx, q.StoppedChan, q.TxsFromAChan, func(respChan chan []*MemPoolTx) TxsFromARequest {
+		return TxsFromARequest{ResponseChan: respChan, From: addr}
+	})
 
 }
 
@@ -542,228 +939,298 @@ func (q *QueuedPool) TxsFromA(addr common.Address) []*MemPoolTx {
 // where being top is determined by how much gas price paid by tx sender
 func (q *QueuedPool) TopXWithHighGasPrice(x uint64) []*MemPoolTx {
 
-	txs := q.DescListTxs()
-	if uint64(len(txs)) <= x {
-		return txs
-	}
-
-	CleanSlice(txs[x:])
-	return txs[:x]
+	v, _ := q.TopXWithHighGasPriceWithContext(context.Background(), x)
+	return v
 
 }
 
-// TopXWithLowGasPrice - Returns only top `X` tx(s) present in queued mempool,
-// where being top is determined by how low gas price paid by tx sender
-func (q *QueuedPool) TopXWithLowGasPrice(x uint64) []*MemPoolTx {
+// TopXWithHighGasPriceWithContext - Context aware `TopXWithHighGasPrice`
+func (q *QueuedPool) TopXWithHighGasPriceWithContext(ctx context.Context, x uint64) ([]*MemPoolTx, error) {
 
-	txs := q.AscListTxs()
-	if uint64(len(txs)) <= x {
-		return txs
+	// Zero limit would denote whole pool
+	if x == 0 {
+		return []*MemPoolTx{}, nil
 	}
 
-	CleanSlice(txs[x:])
-	return txs[:x]
+	page, err := q.ListPageWithContext(ctx, DESC, 0, x)
+	return page.Txs, err
 
 }
 
-// SentFrom - Returns a list of queued tx(s) sent from
-// specified address
-func (q *QueuedPool) SentFrom(address common.Address) []*MemPoolTx {
-	return q.TxsFromA(address)
+// ValueGTE - Returns tx(s) present in queued mempool, transferring value
+// >= given threshold, descending ordered as per gas price paid
+//
+// @note Tx(s) without value are considered to be transferring nothing
+func (q *QueuedPool) ValueGTE(threshold *big.Int) []*MemPoolTx {
+
+	v, _ := q.ValueGTEWithContext(context.Background(), threshold)
+	return v
+
 }
 
-// SentTo - Returns a list of queued tx(s) sent to
-// specified address
-func (q *QueuedPool) SentTo(address common.Address) []*MemPoolTx {
+// ValueGTEWithContext - Context aware `ValueGTE`
+func (q *QueuedPool) ValueGTEWithContext(ctx context.Context, threshold *big.Int) ([]*MemPoolTx, error) {
 
-	txs := q.DescListTxs()
-	if txs == nil {
-		return nil
+	txs, err := q.DescListTxsWithContext(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	txCount := uint64(len(txs))
-	commChan := make(chan *MemPoolTx, txCount)
-	result := make([]*MemPoolTx, 0, txCount)
+	if len(txs) == 0 {
+		return nil, nil
+	}
 
-	wp := workerpool.New(runtime.NumCPU())
+	result := make([]*MemPoolTx, 0, len(txs))
 
 	for i := 0; i < len(txs); i++ {
 
-		func(tx *MemPoolTx) {
+		if bigOrZero(txs[i].Value).Cmp(threshold) >= 0 {
+			result = append(result, txs[i])
+		}
 
-			wp.Submit(func() {
+	}
 
-				if tx.IsSentTo(address) {
-					commChan <- tx
-					return
-				}
+	CleanSlice(txs)
+	return result, nil
 
-				commChan <- nil
+}
 
-			})
+// GasPriceBetween - Returns tx(s) present in queued mempool, paying gas price
+// within [low, high] wei, ascending ordered, where absent bound denotes open-ended range
+func (q *QueuedPool) GasPriceBetween(low *big.Int, high *big.Int) []*MemPoolTx {
 
-		}(txs[i])
+	v, _ := q.GasPriceBetweenWithContext(context.Background(), low, high)
+	return v
 
-	}
+}
 
-	var received uint64
-	mustReceive := txCount
+// GasPriceBetweenWithContext - Context aware `GasPriceBetween`
+func (q *QueuedPool) GasPriceBetweenWithContext(ctx context.Context, low *big.Int, high *big.Int) ([]*MemPoolTx, error) {
 
-	// Waiting for all go routines to finish
-	for v := range commChan {
+	return request(ctx, q.StoppedChan, q.GasPriceRangeChan, func(respChan chan []*MemPoolTx) GasPriceRangeRequest {
+		return GasPriceRangeRequest{Low: low, High: high, ResponseChan: respChan}
+	})
 
-		if v != nil {
-			result = append(result, v)
-		}
+}
 
-		received++
-		if received >= mustReceive {
-			break
-		}
+// TopXWithLowGasPrice - Returns only top `X` tx(s) present in queued mempool,
+// where being top is determined by how low gas price paid by tx sender
+func (q *QueuedPool) TopXWithLowGasPrice(x uint64) []*MemPoolTx {
+
+	v, _ := q.TopXWithLowGasPriceWithContext(context.Background(), x)
+	return v
+
+}
+
+// TopXWithLowGasPriceWithContext - Context aware `TopXWithLowGasPrice`
+func (q *QueuedPool) TopXWithLowGasPriceWithContext(ctx context.Context, x uint64) ([]*MemPoolTx, error) {
 
+	// Zero limit would denote whole pool
+	if x == 0 {
+		return []*MemPoolTx{}, nil
 	}
 
-	// This call is irrelevant here, probably, but still being made
-	//
-	// Because all workers have exited, otherwise we could have never
-	// reached this point
-	wp.Stop()
-	CleanSlice(txs)
+	page, err := q.ListPageWithContext(ctx, ASC, 0, x)
+	return page.Txs, err
+
+}
+
+// SentFrom - Returns a list of queued tx(s) sent from
+// specified address
+func (q *QueuedPool) SentFrom(address common.Address) []*MemPoolTx {
+	return q.TxsFromA(address)
+}
+
+// ByType - Returns a list of queued tx(s) of given type, where legacy
+// tx(s) are of type `0`
+func (q *QueuedPool) ByType(t uint64) []*MemPoolTx {
 
-	return result
+	v, _ := q.ByTypeWithContext(context.Background(), t)
+	return v
 
 }
 
-// OlderThanX - Returns a list of all queued tx(s), which are
-// living in mempool for more than or equals to `X` time unit
-func (q *QueuedPool) OlderThanX(x time.Duration) []*MemPoolTx {
+// ByTypeWithContext - Context aware `ByType`
+func (q *QueuedPool) ByTypeWithContext(ctx context.Context, t uint64) ([]*MemPoolTx, error) {
 
-	txs := q.DescListTxs()
-	if txs == nil {
-		return nil
+	txs, err := q.DescListTxsWithContext(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	txCount := uint64(len(txs))
-	commChan := make(chan *MemPoolTx, txCount)
-	result := make([]*MemPoolTx, 0, txCount)
+	if len(txs) == 0 {
+		return nil, nil
+	}
 
-	wp := workerpool.New(runtime.NumCPU())
+	result := filterTxs(q.Workers, txs, func(tx *MemPoolTx) bool {
+		return tx.IsOfType(t)
+	})
 
-	for i := 0; i < len(txs); i++ {
+	CleanSlice(txs)
+	return result, nil
 
-		func(tx *MemPoolTx) {
+}
 
-			wp.Submit(func() {
+// SentTo - Returns a list of queued tx(s) sent to
+// specified address
+func (q *QueuedPool) SentTo(address common.Address) []*MemPoolTx {
 
-				if tx.IsQueuedForGTE(x) {
-					commChan <- tx
-					return
-				}
+	v, _ := q.SentToWithContext(context.Background(), address)
+	return v
 
-				commChan <- nil
+}
 
-			})
+// SentToWithContext - Context aware `SentTo`
+func (q *QueuedPool) SentToWithContext(ctx context.Context, address common.Address) ([]*MemPoolTx, error) {
 
-		}(txs[i])
+	txs, err := q.DescListTxsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 
+	if len(txs) == 0 {
+		return nil, nil
 	}
 
-	var received uint64
-	mustReceive := txCount
+	result := filterTxs(q.Workers, txs, func(tx *MemPoolTx) bool {
+		return tx.IsSentTo(address)
+	})
 
-	// Waiting for all go routines to finish
-	for v := range commChan {
+	CleanSlice(txs)
+	return result, nil
 
-		if v != nil {
-			result = append(result, v)
-		}
+}
 
-		received++
-		if received >= mustReceive {
-			break
-		}
+// snapshotByAge - Asks pool for copies of tx(s) living in it for more/ less
+// than or equals to `X` time unit, at max `limit` of them, unless it's 0
+func (q *QueuedPool) snapshotByAge(ctx context.Context, x time.Duration, older bool, limit uint64) ([]*MemPoolTx, error) {
+
+	return request(ctx, q.StoppedChan, q.AgeWalkChan, func(respChan chan []*MemPoolTx) AgeWalkRequest {
+		return AgeWalkRequest{Age: x, Older: older, Limit: limit, ResponseChan: respChan}
+	})
+
+}
+
+// ForEachOlderThan - Visits copy of each queued tx, living in mempool for
+// more than or equals to `X` time unit, oldest first, until `visit` returns false
+//
+// Matching tx(s) are copied inside pool's life cycle manager, while `visit`
+// is invoked on caller's go routine, so it can't hold up pool
+func (q *QueuedPool) ForEachOlderThan(x time.Duration, visit Visitor) {
+
+	q.ForEachOlderThanWithContext(context.Background(), x, visit)
 
+}
+
+// ForEachOlderThanWithContext - Context aware `ForEachOlderThan`, which stops
+// visiting as soon as `ctx` is done
+func (q *QueuedPool) ForEachOlderThanWithContext(ctx context.Context, x time.Duration, visit Visitor) error {
+
+	txs, err := q.snapshotByAge(ctx, x, true, 0)
+	if err != nil {
+		return err
 	}
 
-	// This call is irrelevant here, probably, but still being made
-	//
-	// Because all workers have exited, otherwise we could have never
-	// reached this point
-	wp.Stop()
-	CleanSlice(txs)
+	return visitAll(ctx, txs, visit)
 
-	return result
+}
+
+// ForEachFresherThan - Visits copy of each queued tx, living in mempool for
+// less than or equals to `X` time unit, freshest first, until `visit` returns false
+//
+// Matching tx(s) are copied inside pool's life cycle manager, while `visit`
+// is invoked on caller's go routine, so it can't hold up pool
+func (q *QueuedPool) ForEachFresherThan(x time.Duration, visit Visitor) {
+
+	q.ForEachFresherThanWithContext(context.Background(), x, visit)
 
 }
 
-// FresherThanX - Returns a list of all queued tx(s), which are
-// living in mempool for less than or equals to `X` time unit
-func (q *QueuedPool) FresherThanX(x time.Duration) []*MemPoolTx {
+// ForEachFresherThanWithContext - Context aware `ForEachFresherThan`, which stops
+// visiting as soon as `ctx` is done
+func (q *QueuedPool) ForEachFresherThanWithContext(ctx context.Context, x time.Duration, visit Visitor) error {
 
-	txs := q.DescListTxs()
-	if txs == nil {
-		return nil
+	txs, err := q.snapshotByAge(ctx, x, false, 0)
+	if err != nil {
+		return err
 	}
 
-	txCount := uint64(len(txs))
-	commChan := make(chan *MemPoolTx, txCount)
-	result := make([]*MemPoolTx, 0, txCount)
+	return visitAll(ctx, txs, visit)
 
-	wp := workerpool.New(runtime.NumCPU())
+}
 
-	for i := 0; i < len(txs); i++ {
+// OlderThanX - Returns a list of all queued tx(s), which are
+// living in mempool for more than or equals to `X` time unit, oldest first
+func (q *QueuedPool) OlderThanX(x time.Duration) []*MemPoolTx {
 
-		func(tx *MemPoolTx) {
+	v, _ := q.OlderThanXWithContext(context.Background(), x)
+	return v
 
-			wp.Submit(func() {
+}
 
-				if tx.IsQueuedForLTE(x) {
-					commChan <- tx
-					return
-				}
+// OlderThanXWithContext - Context aware `OlderThanX`
+func (q *QueuedPool) OlderThanXWithContext(ctx context.Context, x time.Duration) ([]*MemPoolTx, error) {
+
+	return q.snapshotByAge(ctx, x, true, 0)
 
-				commChan <- nil
+}
 
-			})
+// FresherThanX - Returns a list of all queued tx(s), which are
+// living in mempool for less than or equals to `X` time unit, freshest first
+func (q *QueuedPool) FresherThanX(x time.Duration) []*MemPoolTx {
 
-		}(txs[i])
+	v, _ := q.FresherThanXWithContext(context.Background(), x)
+	return v
 
-	}
+}
 
-	var received uint64
-	mustReceive := txCount
+// FresherThanXWithContext - Context aware `FresherThanX`
+func (q *QueuedPool) FresherThanXWithContext(ctx context.Context, x time.Duration) ([]*MemPoolTx, error) {
 
-	// Waiting for all go routines to finish
-	for v := range commChan {
+	return q.snapshotByAge(ctx, x, false, 0)
 
-		if v != nil {
-			result = append(result, v)
-		}
+}
 
-		received++
-		if received >= mustReceive {
-			break
-		}
+// LongestQueued - Returns at max `x` queued tx(s), which have been living in
+// mempool for longest, oldest first
+//
+// Tx(s) gapped for hours are usually abandoned by their senders
+func (q *QueuedPool) LongestQueued(x uint64) []*MemPoolTx {
 
-	}
+	v, _ := q.LongestQueuedWithContext(context.Background(), x)
+	return v
 
-	// This call is irrelevant here, probably, but still being made
-	//
-	// Because all workers have exited, otherwise we could have never
-	// reached this point
-	wp.Stop()
-	CleanSlice(txs)
+}
+
+// LongestQueuedWithContext - Context aware `LongestQueued`
+func (q *QueuedPool) LongestQueuedWithContext(ctx context.Context, x uint64) ([]*MemPoolTx, error) {
+
+	if x == 0 {
+		return nil, nil
+	}
 
-	return result
+	return q.snapshotByAge(ctx, 0, true, x)
 
 }
 
 // HigherThanX - Returns a list of queued txs which are paid with
 // gas price >= `X`
 func (q *QueuedPool) HigherThanX(x float64) []*MemPoolTx {
-	txs := q.DescListTxs()
-	if txs == nil {
-		return nil
+
+	v, _ := q.HigherThanXWithContext(context.Background(), x)
+	return v
+
+}
+
+// HigherThanXWithContext - Context aware `HigherThanX`
+func (q *QueuedPool) HigherThanXWithContext(ctx context.Context, x float64) ([]*MemPoolTx, error) {
+	txs, err := q.DescListTxsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(txs) == 0 {
+		return nil, nil
 	}
 
 	txCount := uint64(len(txs))
@@ -781,15 +1248,27 @@ func (q *QueuedPool) HigherThanX(x float64) []*MemPoolTx {
 	}
 
 	CleanSlice(txs)
-	return result
+	return result, nil
 }
 
 // LowerThanX - Returns a list of queued txs which are paid with
 // gas price <= `X`
 func (q *QueuedPool) LowerThanX(x float64) []*MemPoolTx {
-	txs := q.AscListTxs()
-	if txs == nil {
-		return nil
+
+	v, _ := q.LowerThanXWithContext(context.Background(), x)
+	return v
+
+}
+
+// LowerThanXWithContext - Context aware `LowerThanX`
+func (q *QueuedPool) LowerThanXWithContext(ctx context.Context, x float64) ([]*MemPoolTx, error) {
+	txs, err := q.AscListTxsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(txs) == 0 {
+		return nil, nil
 	}
 
 	txCount := uint64(len(txs))
@@ -807,7 +1286,7 @@ func (q *QueuedPool) LowerThanX(x float64) []*MemPoolTx {
 	}
 
 	CleanSlice(txs)
-	return result
+	return result, nil
 }
 
 // Add - Attempts to add new tx found in pending pool into
@@ -815,82 +1294,117 @@ func (q *QueuedPool) LowerThanX(x float64) []*MemPoolTx {
 //
 // If it returns `true`, it denotes, it's success, otherwise it's failure
 // because this tx is already present in pending pool
+//
+// Gives up as soon as `ctx` is done or pool has stopped, when outcome isn't
+// known, so it's reported as not added, even if pool went on to add it
 func (q *QueuedPool) Add(ctx context.Context, tx *MemPoolTx) bool {
 
-	respChan := make(chan bool)
-
-	q.AddTxChan <- AddRequest{Tx: tx, ResponseChan: respChan}
-
-	return <-respChan
+	v, _ := request(ctx, q.StoppedChan, q.AddTxChan, func(respChan chan bool) AddRequest {
+		return AddRequest{Tx: tx, ResponseChan: respChan}
+	})
+	return v
 
 }
 
-// PublishAdded - Publish new tx, entered queued pool, ( in messagepack serialized format )
-// to pubsub topic
+// PublishAdded - Publish new tx, entered queued pool, ( serialized using configured codec )
+// to pubsub topic, unless publishing on it is disabled
 func (q *QueuedPool) PublishAdded(ctx context.Context, msg *MemPoolTx) {
 
-	data, err := msg.ToMessagePack()
-	if err != nil {
-		log.Printf("[❗️] Failed to serialize into messagepack : %s\n", err.Error())
+	if !config.GetPublishQueuedEntry() {
 		return
 	}
 
-	if _, err := q.PubSub.Publish(&ops.Msg{
-		Topics: []string{config.GetQueuedTxEntryPublishTopic()},
-		Data:   data,
-	}); err != nil {
-		log.Printf("[❗️] Failed to publish tx joining queued pool : %s\n", err.Error())
-	}
+	publishTx(q.PubSub, q.Codec, q.AddedBatch, config.GetQueuedTxEntryPublishTopic(), &q.publishedAdded, msg, "tx joining queued pool")
 
 }
 
 // Remove - Removes unstuck tx from queued pool
 func (q *QueuedPool) Remove(ctx context.Context, txHash common.Hash) *MemPoolTx {
 
-	respChan := make(chan *MemPoolTx)
+	v, _ := request(ctx, q.StoppedChan, q.RemoveTxChan, func(respChan chan *MemPoolTx) RemovedUnstuckTx {
+		return RemovedUnstuckTx{Hash: txHash, ResponseChan: respChan}
+	})
+	return v
+
+}
+
+// ForceRemove - Evicts tx from queued pool, on operator's request, which
+// is also remembered as dropped, so that it doesn't reappear from next poll,
+// returning whether it was actually present
+func (q *QueuedPool) ForceRemove(ctx context.Context, txHash common.Hash) bool {
+
+	v, _ := request(ctx, q.StoppedChan, q.RemoveTxChan, func(respChan chan *MemPoolTx) RemovedUnstuckTx {
+		return RemovedUnstuckTx{Hash: txHash, Status: MANUALLY_EVICTED, ResponseChan: respChan}
+	})
+	return v != nil
+
+}
+
+// setBaseFee - Lets queued pool know of latest base fee, so that its gas price
+// ordered view gets keyed on it, never blocks. Any older base fee not yet
+// picked up is replaced, only latest one matters
+func (q *QueuedPool) setBaseFee(baseFee *big.Int) {
 
-	q.RemoveTxChan <- RemovedUnstuckTx{Hash: txHash, ResponseChan: respChan}
+	for {
 
-	return <-respChan
+		select {
+		case q.BaseFeeChan <- baseFee:
+			return
+		default:
+		}
+
+		select {
+		case <-q.BaseFeeChan:
+		default:
+		}
+
+	}
 
 }
 
-// PublishRemoved - Publish unstuck tx, leaving queued pool ( in messagepack serialized format )
+// PublishRemoved - Publish unstuck tx, leaving queued pool ( serialized using configured codec )
 // to pubsub topic
 //
 // These tx(s) are leaving queued pool i.e. they're ( probably ) going to
 // sit in pending pool now, unless they're already mined & harmony
-// failed to keep track of it
+// failed to keep track of it. Skipped when publishing on it is disabled
 func (q *QueuedPool) PublishRemoved(ctx context.Context, msg *MemPoolTx) {
 
-	data, err := msg.ToMessagePack()
-	if err != nil {
-		log.Printf("[❗️] Failed to serialize into messagepack : %s\n", err.Error())
+	if !config.GetPublishQueuedExit() {
 		return
 	}
 
-	if _, err := q.PubSub.Publish(&ops.Msg{
-		Topics: []string{config.GetQueuedTxExitPublishTopic()},
-		Data:   data,
-	}); err != nil {
-		log.Printf("[❗️] Failed to publish tx leaving queued pool : %s\n", err.Error())
-	}
+	publishTx(q.PubSub, q.Codec, q.RemovedBatch, config.GetQueuedTxExitPublishTopic(), &q.publishedRemoved, msg, "tx leaving queued pool")
 
 }
 
 // AddQueued - Update latest queued pool state
 func (q *QueuedPool) AddQueued(ctx context.Context, txs map[string]map[string]*MemPoolTx) uint64 {
 
-	var count uint64
+	batch := make([]*MemPoolTx, 0, len(txs))
 
 	for keyO := range txs {
 		for keyI := range txs[keyO] {
+			batch = append(batch, txs[keyO][keyI])
+		}
+	}
 
-			if q.Add(ctx, txs[keyO][keyI]) {
-				count++
-			}
+	return q.AddAll(ctx, batch)
+
+}
 
+// AddAll - Adds given tx(s) into queued pool, one after another, returning
+// how many of them were actually added
+func (q *QueuedPool) AddAll(ctx context.Context, txs []*MemPoolTx) uint64 {
+
+	var count uint64
+
+	for _, tx := range txs {
+
+		if q.Add(ctx, tx) {
+			count++
 		}
+
 	}
 
 	return count
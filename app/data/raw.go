@@ -0,0 +1,124 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	// ErrInvalidRLP - Raw tx couldn't be decoded
+	ErrInvalidRLP = errors.New("invalid rlp encoded tx")
+	// ErrWrongChainID - Raw tx is signed for some other chain
+	ErrWrongChainID = errors.New("tx signed for different chain")
+	// ErrUnprotectedTx - Tx isn't replay protected & those are not accepted
+	ErrUnprotectedTx = errors.New("tx not replay protected")
+	// ErrUnknownChainID - Chain ID of tracked network isn't known, so no
+	// raw tx can be verified against it
+	ErrUnknownChainID = errors.New("chain id not known")
+	// ErrInvalidSender - Sender couldn't be recovered from tx signature
+	ErrInvalidSender = errors.New("invalid sender")
+	// ErrAlreadyKnown - Raw tx is already present in mempool
+	ErrAlreadyKnown = errors.New("tx already known")
+)
+
+// RawTx - Raw signed tx, submitted by client for being tracked
+// in mempool, in hex encoded form
+type RawTx struct {
+	Data hexutil.Bytes `json:"data"`
+}
+
+// FromRawTx - Given raw signed tx, same as it's passed to `eth_sendRawTransaction`,
+// attempts to decode it into mempool tx, while recovering its sender, as
+// signed for given chain
+//
+// Tx(s) without replay protection, signed for other chain or with signature
+// sender can't be recovered from, are rejected
+func FromRawTx(data []byte, chainID *big.Int) (*MemPoolTx, error) {
+
+	if chainID == nil {
+		return nil, ErrUnknownChainID
+	}
+
+	var _tx types.Transaction
+	if err := _tx.UnmarshalBinary(data); err != nil {
+		return nil, ErrInvalidRLP
+	}
+
+	if !_tx.Protected() {
+		return nil, ErrUnprotectedTx
+	}
+
+	if _tx.ChainId().Cmp(chainID) != 0 {
+		return nil, ErrWrongChainID
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), &_tx)
+	if err != nil {
+		return nil, fmt.Errorf("%w : %s", ErrInvalidSender, err.Error())
+	}
+
+	v, r, s := _tx.RawSignatureValues()
+
+	tx := &MemPoolTx{
+		Hash:     _tx.Hash(),
+		From:     sender,
+		Gas:      hexutil.Uint64(_tx.Gas()),
+		GasPrice: (*hexutil.Big)(_tx.GasPrice()),
+		Input:    _tx.Data(),
+		Nonce:    hexutil.Uint64(_tx.Nonce()),
+		To:       _tx.To(),
+		Value:    (*hexutil.Big)(_tx.Value()),
+		Type:     hexutil.Uint64(_tx.Type()),
+		ChainID:  (*hexutil.Big)(_tx.ChainId()),
+		V:        (*hexutil.Big)(v),
+		R:        (*hexutil.Big)(r),
+		S:        (*hexutil.Big)(s),
+	}
+
+	if _tx.Type() != types.LegacyTxType {
+		accessList := _tx.AccessList()
+		tx.AccessList = &accessList
+	}
+
+	if _tx.Type() >= types.DynamicFeeTxType {
+		tx.MaxFeePerGas = (*hexutil.Big)(_tx.GasFeeCap())
+		tx.MaxPriorityFeePerGas = (*hexutil.Big)(_tx.GasTipCap())
+	}
+
+	return tx, nil
+
+}
+
+// AddRawTx - Decodes raw signed tx & attempts to add it into pending pool,
+// before upstream node even sees it
+//
+// Tx is always verified against chain ID of tracked network, when that's
+// not known, every tx is rejected
+func (m *MemPool) AddRawTx(ctx context.Context, data []byte) (*MemPoolTx, error) {
+
+	tx, err := FromRawTx(data, m.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := m.ExistsWithContext(ctx, tx.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		return nil, ErrAlreadyKnown
+	}
+
+	if !m.Pending.Add(ctx, tx) {
+		return nil, ErrAlreadyKnown
+	}
+
+	return tx, nil
+
+}
@@ -0,0 +1,134 @@
+package data
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// rawTx - Signs given tx with freshly generated key, using given signer,
+// returning it in form as it's passed to `eth_sendRawTransaction`, along
+// with expected sender
+func rawTx(t *testing.T, signer types.Signer, inner types.TxData) ([]byte, common.Address) {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := types.SignNewTx(key, signer, inner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data, crypto.PubkeyToAddress(key.PublicKey)
+
+}
+
+func TestFromRawTx(t *testing.T) {
+
+	mainnet := big.NewInt(1)
+	to := txAddress(7)
+
+	cases := []struct {
+		name  string
+		inner types.TxData
+	}{
+		{"legacy", &types.LegacyTx{Nonce: 1, GasPrice: gwei(10).ToInt(), Gas: 21000, To: &to, Value: big.NewInt(1)}},
+		{"access list", &types.AccessListTx{ChainID: mainnet, Nonce: 2, GasPrice: gwei(10).ToInt(), Gas: 30000, To: &to, AccessList: types.AccessList{{Address: to, StorageKeys: []common.Hash{{1}}}}}},
+		{"dynamic fee", &types.DynamicFeeTx{ChainID: mainnet, Nonce: 3, GasTipCap: gwei(2).ToInt(), GasFeeCap: gwei(100).ToInt(), Gas: 21000, To: &to, Value: big.NewInt(1)}},
+		{"dynamic fee contract creation", &types.DynamicFeeTx{ChainID: mainnet, Nonce: 4, GasTipCap: gwei(2).ToInt(), GasFeeCap: gwei(100).ToInt(), Gas: 100000, Data: []byte{0x60, 0x80}}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+
+			data, want := rawTx(t, types.LatestSignerForChainID(mainnet), c.inner)
+
+			tx, err := FromRawTx(data, mainnet)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tx.From != want {
+				t.Fatalf("expected sender %s, got %s", want.Hex(), tx.From.Hex())
+			}
+
+			if tx.Hash != crypto.Keccak256Hash(data) {
+				t.Fatalf("expected hash %s, got %s", crypto.Keccak256Hash(data).Hex(), tx.Hash.Hex())
+			}
+
+			if BigHexToBigDecimal(tx.ChainID).Cmp(mainnet) != 0 {
+				t.Fatalf("expected chain ID %s, got %s", mainnet, BigHexToBigDecimal(tx.ChainID))
+			}
+
+			if tx.IsDynamicFee() != (uint64(tx.Type) == types.DynamicFeeTxType) {
+				t.Fatal("expected only dynamic fee tx to carry fee caps")
+			}
+
+		})
+	}
+
+}
+
+func TestFromRawTxRejects(t *testing.T) {
+
+	mainnet, goerli := big.NewInt(1), big.NewInt(5)
+	to := txAddress(7)
+
+	legacy := &types.LegacyTx{Nonce: 1, GasPrice: gwei(10).ToInt(), Gas: 21000, To: &to}
+	dynamic := &types.DynamicFeeTx{ChainID: mainnet, Nonce: 2, GasTipCap: gwei(2).ToInt(), GasFeeCap: gwei(100).ToInt(), Gas: 21000, To: &to}
+
+	valid, _ := rawTx(t, types.LatestSignerForChainID(mainnet), dynamic)
+	unprotected, _ := rawTx(t, types.HomesteadSigner{}, legacy)
+	legacyOtherChain, _ := rawTx(t, types.LatestSignerForChainID(goerli), legacy)
+	dynamicOtherChain, _ := rawTx(t, types.LatestSignerForChainID(goerli), &types.DynamicFeeTx{ChainID: goerli, Nonce: 3, GasTipCap: gwei(2).ToInt(), GasFeeCap: gwei(100).ToInt(), Gas: 21000, To: &to})
+
+	// Signature values no key could have produced
+	badSignature, err := types.NewTx(&types.DynamicFeeTx{ChainID: mainnet, Nonce: 4, GasTipCap: gwei(2).ToInt(), GasFeeCap: gwei(100).ToInt(), Gas: 21000, To: &to, V: big.NewInt(0), R: big.NewInt(0), S: big.NewInt(0)}).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		data    []byte
+		chainID *big.Int
+		want    error
+	}{
+		{"chain not known", valid, nil, ErrUnknownChainID},
+		{"empty", nil, mainnet, ErrInvalidRLP},
+		{"truncated", valid[:len(valid)/2], mainnet, ErrInvalidRLP},
+		{"unknown tx type", append([]byte{0x7f}, valid[1:]...), mainnet, ErrInvalidRLP},
+		{"unprotected", unprotected, mainnet, ErrUnprotectedTx},
+		{"legacy for other chain", legacyOtherChain, mainnet, ErrWrongChainID},
+		{"dynamic fee for other chain", dynamicOtherChain, mainnet, ErrWrongChainID},
+		{"bad signature", badSignature, mainnet, ErrInvalidSender},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+
+			tx, err := FromRawTx(c.data, c.chainID)
+			if err == nil {
+				t.Fatalf("expected tx to be rejected, got %s", tx.Hash.Hex())
+			}
+
+			if !errors.Is(err, c.want) {
+				t.Fatalf("expected %q, got %q", c.want.Error(), err.Error())
+			}
+
+		})
+	}
+
+}
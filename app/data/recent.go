@@ -0,0 +1,62 @@
+package data
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// recentTxs - Bounded buffer of tx(s), which have recently left pending
+// pool, keeping only last `N` of those, where oldest one gets forgotten
+// when it's full
+//
+// @note Not concurrent safe, supposed to be used only from pool's own
+// life cycle manager go routine
+type recentTxs struct {
+	txs   map[common.Hash]*MemPoolTx
+	order []common.Hash
+	next  int
+}
+
+// newRecentTxs - Creates buffer for keeping at max `size` tx(s)
+func newRecentTxs(size uint64) *recentTxs {
+
+	return &recentTxs{
+		txs:   make(map[common.Hash]*MemPoolTx, size),
+		order: make([]common.Hash, size),
+	}
+
+}
+
+// add - Remembers tx, which has just left pool, forgetting oldest one
+// if full. Tx leaving again, after coming back, only has its content
+// updated, without being moved ahead
+func (r *recentTxs) add(tx *MemPoolTx) {
+
+	if len(r.order) == 0 {
+		return
+	}
+
+	if _, ok := r.txs[tx.Hash]; ok {
+		r.txs[tx.Hash] = tx
+		return
+	}
+
+	if oldest := r.order[r.next]; oldest != (common.Hash{}) {
+		delete(r.txs, oldest)
+	}
+
+	r.order[r.next] = tx.Hash
+	r.txs[tx.Hash] = tx
+	r.next = (r.next + 1) % len(r.order)
+
+}
+
+// get - Copy of remembered tx, if it's still there
+func (r *recentTxs) get(hash common.Hash) *MemPoolTx {
+
+	if tx, ok := r.txs[hash]; ok {
+		return tx.Clone()
+	}
+
+	return nil
+
+}
@@ -0,0 +1,87 @@
+package data
+
+import (
+	"context"
+	"sync"
+)
+
+// relay - Unbounded queue, whose entries are taken out by consumer on its
+// own go routine, so that producer never waits on consumer, which may itself
+// be waiting on producer
+//
+// Zero value is ready to be used
+type relay[T any] struct {
+	lock   sync.Mutex
+	queue  []T
+	signal chan struct{}
+}
+
+// ready - Gets signalled after entries are pushed, so that consumer can
+// take them out
+func (r *relay[T]) ready() chan struct{} {
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.signal == nil {
+		r.signal = make(chan struct{}, 1)
+	}
+
+	return r.signal
+
+}
+
+// push - Queues entry to be taken out by consumer, never blocks
+func (r *relay[T]) push(v T) {
+
+	r.lock.Lock()
+	r.queue = append(r.queue, v)
+	r.lock.Unlock()
+
+	select {
+	case r.ready() <- struct{}{}:
+	default:
+	}
+
+}
+
+// take - Takes out all queued entries, in order they were pushed
+func (r *relay[T]) take() []T {
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	queued := r.queue
+	r.queue = nil
+
+	return queued
+
+}
+
+// forward - Sends queued entries to `out`, in order they were pushed, until
+// `ctx` is done
+//
+// @note This method is supposed to be run as independent go routine
+func (r *relay[T]) forward(ctx context.Context, out chan<- T) {
+
+	for {
+
+		for _, v := range r.take() {
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- v:
+			}
+
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.ready():
+		}
+
+	}
+
+}
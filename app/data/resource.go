@@ -1,6 +1,7 @@
 package data
 
 import (
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -16,6 +17,70 @@ type Resource struct {
 	Pool      *MemPool
 	StartedAt time.Time
 	NetworkID uint64
+	Codec     Codec
+	// Pool state persisted during last run, to be reconciled against
+	// first mempool content fetched, nil when not being restored
+	RestoredState *PoolState
+	// Effective mempool polling interval, as adapted by poller, in
+	// nanoseconds, to be accessed atomically
+	pollingInterval int64
+	// #-of polls, where full mempool content fetch was skipped, to
+	// be accessed atomically
+	skippedPolls uint64
+	// When last poll went fine, as unix nanoseconds, to be accessed atomically
+	lastPolled int64
+}
+
+// SetPollingInterval - Mempool poller lets others know about effective
+// polling interval, after adapting it
+func (r *Resource) SetPollingInterval(interval time.Duration) {
+
+	atomic.StoreInt64(&r.pollingInterval, int64(interval))
+
+}
+
+// PollingInterval - Effective mempool polling interval, as last
+// adapted by poller
+func (r *Resource) PollingInterval() time.Duration {
+
+	return time.Duration(atomic.LoadInt64(&r.pollingInterval))
+
+}
+
+// SkippedPoll - Mempool poller skipped fetching full mempool content,
+// because nothing seemed to have changed
+func (r *Resource) SkippedPoll() uint64 {
+
+	return atomic.AddUint64(&r.skippedPolls, 1)
+
+}
+
+// SkippedPolls - #-of times mempool poller skipped fetching full
+// mempool content, since start up
+func (r *Resource) SkippedPolls() uint64 {
+
+	return atomic.LoadUint64(&r.skippedPolls)
+
+}
+
+// Polled - Mempool poller lets others know last poll went fine
+func (r *Resource) Polled() {
+
+	atomic.StoreInt64(&r.lastPolled, time.Now().UTC().UnixNano())
+
+}
+
+// LastPolled - When mempool was last polled successfully, zero time
+// if it's never been
+func (r *Resource) LastPolled() time.Time {
+
+	v := atomic.LoadInt64(&r.lastPolled)
+	if v == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, v).UTC()
+
 }
 
 // Release - To be called when application will receive shut down request
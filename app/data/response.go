@@ -1,5 +1,7 @@
 package data
 
+import "time"
+
 // Stat - Response to client queries for current mempool state
 // to be sent in this form
 type Stat struct {
@@ -10,6 +12,47 @@ type Stat struct {
 	LatestBlock     uint64 `json:"latestBlock"`
 	SeenAgo         string `json:"latestSeenAgo"`
 	NetworkID       uint64 `json:"networkID"`
+	PollingInterval string `json:"pollingInterval"`
+	SkippedPolls    uint64 `json:"skippedPolls"`
+	TimedOutCalls   uint64 `json:"timedOutRPCCalls"`
+	InboundPeers    uint64 `json:"inboundPeers"`
+	OutboundPeers   uint64 `json:"outboundPeers"`
+	RelayedPeers    uint64 `json:"relayedPeers"`
+	RejectedPeers   uint64 `json:"rejectedPeers"`
+}
+
+// PeerScore - Misbehaviour score of peer, where crossing 1 gets it banned,
+// along with until when it's banned, if it's
+type PeerScore struct {
+	Peer        string     `json:"peer"`
+	Score       float64    `json:"score"`
+	BannedUntil *time.Time `json:"bannedUntil,omitempty"`
+}
+
+// NodeIdentity - Identity of this node in P2P network, with everything
+// needed for configuring other nodes of cluster to connect with it
+type NodeIdentity struct {
+	PeerID       string   `json:"peerId"`
+	Addrs        []string `json:"addrs"`
+	Rendezvous   string   `json:"rendezvous"`
+	Protocols    []string `json:"protocols"`
+	Transport    string   `json:"transport"`
+	Reachability string   `json:"reachability"`
+}
+
+// PeerTraffic - Traffic & tx contribution of peer, accumulated over all
+// connections with it, in this session
+type PeerTraffic struct {
+	Peer        string `json:"peer"`
+	Connected   bool   `json:"connected"`
+	Relayed     bool   `json:"relayed"`
+	BytesIn     uint64 `json:"bytesIn"`
+	BytesOut    uint64 `json:"bytesOut"`
+	FramesIn    uint64 `json:"framesIn"`
+	FramesOut   uint64 `json:"framesOut"`
+	TxsAccepted uint64 `json:"txsAccepted"`
+	TxsRejected uint64 `json:"txsRejected"`
+	Uptime      string `json:"uptime"`
 }
 
 // Msg - Response message sent to client
@@ -17,3 +60,80 @@ type Msg struct {
 	Code    uint8  `json:"code,omitempty"`
 	Message string `json:"message"`
 }
+
+// TxPageResponse - Window of tx(s) from pool, ordered as per gas price paid, where
+// `endCursor` is to be passed as `after` for fetching next window
+type TxPageResponse struct {
+	Txs         []*MemPoolTx `json:"txs"`
+	TotalCount  uint64       `json:"totalCount"`
+	EndCursor   string       `json:"endCursor,omitempty"`
+	HasNextPage bool         `json:"hasNextPage"`
+}
+
+// PoolStatsResponse - Composition of one pool, where gas prices & value
+// are decimal encoded wei, zero when pool is empty
+type PoolStatsResponse struct {
+	Count         uint64 `json:"count"`
+	Limit         uint64 `json:"limit"`
+	UniqueSenders uint64 `json:"uniqueSenders"`
+	TotalGas      uint64 `json:"totalGas"`
+	TotalValue    string `json:"totalValue"`
+	P10           string `json:"p10"`
+	P25           string `json:"p25"`
+	P50           string `json:"p50"`
+	P75           string `json:"p75"`
+	P90           string `json:"p90"`
+	P99           string `json:"p99"`
+	OldestAge     string `json:"oldestAge"`
+	Added         uint64 `json:"added"`
+	Removed       uint64 `json:"removed"`
+}
+
+// TopicStatsResponse - Pub/Sub topic, whether publishing on it is enabled
+// & #-of messages published on it
+type TopicStatsResponse struct {
+	Topic     string `json:"topic"`
+	Enabled   bool   `json:"enabled"`
+	Published uint64 `json:"published"`
+}
+
+// MemPoolStatsResponse - Composition of pending & queued pools, along
+// with how long last mempool poll took & state of pub/sub topics
+type MemPoolStatsResponse struct {
+	Pending          PoolStatsResponse    `json:"pending"`
+	Queued           PoolStatsResponse    `json:"queued"`
+	Topics           []TopicStatsResponse `json:"topics"`
+	LastPollDuration string               `json:"lastPollDuration"`
+	ComputedAt       time.Time            `json:"computedAt"`
+}
+
+// Readiness - Whether node is ready to serve, along with components
+// found failing, when it's not
+type Readiness struct {
+	Ready   bool                `json:"ready"`
+	Failing []*FailingComponent `json:"failing,omitempty"`
+}
+
+// FailingComponent - Component failing readiness check & why
+type FailingComponent struct {
+	Component string `json:"component"`
+	Reason    string `json:"reason"`
+}
+
+// RateLimitUsage - Configured per client rate limit, along with how much
+// of it each recently seen client is using
+type RateLimitUsage struct {
+	RequestsPerSecond float64        `json:"requestsPerSecond"`
+	Burst             uint64         `json:"burst"`
+	Clients           []*ClientUsage `json:"clients"`
+}
+
+// ClientUsage - Requests made by client, identified by API key label or
+// IP address, where `tokens` is #-of requests it can make right now
+type ClientUsage struct {
+	Client   string    `json:"client"`
+	Tokens   float64   `json:"tokens"`
+	Allowed  uint64    `json:"allowed"`
+	Rejected uint64    `json:"rejected"`
+	LastSeen time.Time `json:"lastSeen"`
+}
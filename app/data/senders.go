@@ -0,0 +1,86 @@
+package data
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SenderCount - #-of tx(s) sent by `Address`, living in pool
+type SenderCount struct {
+	Address common.Address
+	Count   uint64
+}
+
+// countFrom - #-of tx(s) sent by given address, living in pool, read
+// from per sender index, without copying any tx
+func countFrom(index map[common.Address]TxList, addr common.Address) uint64 {
+
+	txs, ok := index[addr]
+	if !ok {
+		return 0
+	}
+
+	return uint64(txs.len())
+
+}
+
+// topSenders - Given per sender index of pool, returns at max `n` senders
+// having most tx(s) in pool, descending ordered, where ties are broken
+// using address, so that order stays stable
+func topSenders(index map[common.Address]TxList, n uint64) []SenderCount {
+
+	senders := make([]SenderCount, 0, len(index))
+
+	for addr, txs := range index {
+
+		if txs.len() == 0 {
+			continue
+		}
+
+		senders = append(senders, SenderCount{Address: addr, Count: uint64(txs.len())})
+
+	}
+
+	sort.Slice(senders, func(i, j int) bool {
+
+		if senders[i].Count != senders[j].Count {
+			return senders[i].Count > senders[j].Count
+		}
+
+		return bytes.Compare(senders[i].Address.Bytes(), senders[j].Address.Bytes()) < 0
+
+	})
+
+	if uint64(len(senders)) > n {
+		senders = senders[:n]
+	}
+
+	return senders
+
+}
+
+// sendersOf - Given per sender index of pool, returns addresses of all
+// senders having tx(s) in pool, ascending ordered, so that order stays stable
+func sendersOf(index map[common.Address]TxList) []common.Address {
+
+	senders := make([]common.Address, 0, len(index))
+
+	for addr, txs := range index {
+
+		if txs.len() == 0 {
+			continue
+		}
+
+		senders = append(senders, addr)
+
+	}
+
+	sort.Slice(senders, func(i, j int) bool {
+		return bytes.Compare(senders[i].Bytes(), senders[j].Bytes()) < 0
+	})
+
+	return senders
+
+}
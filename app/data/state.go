@@ -0,0 +1,214 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StateVersion - Format version of persisted pool state, to be bumped
+// whenever layout of `PoolState` changes incompatibly
+const StateVersion uint64 = 1
+
+// ErrIncompatibleState - Persisted pool state was written in some
+// other format version, so it can't be restored
+var ErrIncompatibleState = errors.New("incompatible pool state version")
+
+// PoolState - Snapshot of both pools, as persisted on disk, so that
+// tx(s) keep their original timestamps across restarts
+type PoolState struct {
+	Version uint64       `json:"version"`
+	TakenAt time.Time    `json:"takenAt"`
+	Pending []*MemPoolTx `json:"pending"`
+	Queued  []*MemPoolTx `json:"queued"`
+}
+
+// Snapshot - Captures current state of both pools, where each pool's
+// content is copied by its own life cycle manager, in one go
+func (m *MemPool) Snapshot() *PoolState {
+
+	return &PoolState{
+		Version: StateVersion,
+		TakenAt: time.Now().UTC(),
+		Pending: m.Pending.ListPage(ASC, 0, 0).Txs,
+		Queued:  m.Queued.ListPage(ASC, 0, 0).Txs,
+	}
+
+}
+
+// SaveState - Persists current state of both pools into given file
+//
+// State is first written into temporary file, placed in same directory,
+// which is then renamed, so that previous snapshot is never left half
+// overwritten
+func (m *MemPool) SaveState(file string) error {
+
+	state := m.Snapshot()
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+".*")
+	if err != nil {
+		return err
+	}
+
+	if err := json.NewEncoder(tmp).Encode(state); err != nil {
+
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+
+	}
+
+	if err := tmp.Sync(); err != nil {
+
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+
+	}
+
+	if err := tmp.Close(); err != nil {
+
+		os.Remove(tmp.Name())
+		return err
+
+	}
+
+	return os.Rename(tmp.Name(), file)
+
+}
+
+// PersistState - Periodically persists state of both pools into given
+// file, until asked to stop
+//
+// @note Final snapshot is supposed to be taken by caller, before pools'
+// life cycle managers are asked to stop
+func (m *MemPool) PersistState(ctx context.Context, file string, period time.Duration) {
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+
+		select {
+
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+
+			start := time.Now().UTC()
+
+			if err := m.SaveState(file); err != nil {
+				log.Printf("[❗️] Failed to persist pool state : %s\n", err.Error())
+				break
+			}
+
+			log.Printf("[💾] Persisted pool state, in %s\n", time.Now().UTC().Sub(start))
+
+		}
+
+	}
+
+}
+
+// ReadState - Reads pool state persisted in given file
+//
+// Format version is checked before decoding whole state, so that
+// snapshot written by some other version is skipped, with
+// `ErrIncompatibleState`
+func ReadState(file string) (*PoolState, error) {
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var header struct {
+		Version uint64 `json:"version"`
+	}
+
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+
+	if header.Version != StateVersion {
+		return nil, ErrIncompatibleState
+	}
+
+	var state PoolState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+
+}
+
+// Reconcile - Carries timestamps of restored tx(s) over to same tx(s),
+// as found in fresh mempool content, returning how many of them were
+// carried over
+//
+// Restored tx(s) which are no more living in mempool are simply forgotten,
+// while tx(s) which moved from queued to pending pool in between, keep
+// their queued pool timestamps
+func (s *PoolState) Reconcile(pending map[string]map[string]*MemPoolTx, queued map[string]map[string]*MemPoolTx) uint64 {
+
+	restored := make(map[common.Hash]*MemPoolTx, len(s.Pending)+len(s.Queued))
+
+	for _, tx := range s.Queued {
+		restored[tx.Hash] = tx
+	}
+
+	for _, tx := range s.Pending {
+		restored[tx.Hash] = tx
+	}
+
+	var count uint64
+
+	carry := func(txs map[string]map[string]*MemPoolTx) {
+
+		for keyO := range txs {
+			for keyI := range txs[keyO] {
+
+				tx := txs[keyO][keyI]
+
+				old, ok := restored[tx.Hash]
+				if !ok {
+					continue
+				}
+
+				if tx.QueuedAt.IsZero() {
+					tx.QueuedAt = old.QueuedAt
+				}
+
+				if tx.UnstuckAt.IsZero() {
+					tx.UnstuckAt = old.UnstuckAt
+				}
+
+				if tx.PendingFrom.IsZero() {
+					tx.PendingFrom = old.PendingFrom
+				}
+
+				if len(tx.ReceivedFrom) == 0 {
+					tx.ReceivedFrom = old.ReceivedFrom
+				}
+
+				count++
+
+			}
+		}
+
+	}
+
+	carry(queued)
+	carry(pending)
+
+	return count
+
+}
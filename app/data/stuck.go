@@ -0,0 +1,183 @@
+package data
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/itzmeanjan/harmony/app/config"
+)
+
+// evaluateStuck - Flags tx(s) which can't pay given base fee, even with their
+// max fee/ gas price & have been pending for at least `after` duration,
+// returning copy of those flagged for very first time
+//
+// Flag of tx gets cleared as soon as base fee drops to what it's paying, but
+// it's never announced again, so that alerting systems see one event per tx
+//
+// @note Supposed to be invoked from pool's own life cycle manager go routine
+func (p *PendingPool) evaluateStuck(baseFee *big.Int, after time.Duration) []*MemPoolTx {
+
+	if p.stuckTxs == nil {
+		p.stuckTxs = make(map[common.Hash]bool)
+	}
+
+	for hash, flagged := range p.stuckTxs {
+
+		if !flagged {
+			continue
+		}
+
+		if tx, ok := p.Transactions[hash]; ok && tx.EffectiveGasPrice(nil).Cmp(baseFee) < 0 {
+			continue
+		}
+
+		p.stuckTxs[hash] = false
+
+	}
+
+	now := time.Now().UTC()
+	announce := make([]*MemPoolTx, 0)
+
+	// Tree is ordered as per max fee/ gas price, so only tx(s) paying
+	// lower than base fee are visited
+	p.TxsByGasPrice.ascend(0, func(tx *MemPoolTx) bool {
+
+		if tx.EffectiveGasPrice(nil).Cmp(baseFee) >= 0 {
+			return false
+		}
+
+		if now.Sub(tx.PendingFrom) < after {
+			return true
+		}
+
+		announced, seen := p.stuckTxs[tx.Hash]
+		if announced {
+			return true
+		}
+
+		p.stuckTxs[tx.Hash] = true
+
+		if !seen {
+			announce = append(announce, tx.Clone())
+		}
+
+		return true
+
+	})
+
+	return announce
+
+}
+
+// flaggedStuck - Copy of tx(s) which are currently flagged as stuck
+//
+// @note Supposed to be invoked from pool's own life cycle manager go routine
+func (p *PendingPool) flaggedStuck() []*MemPoolTx {
+
+	txs := make([]*MemPoolTx, 0, len(p.stuckTxs))
+
+	for hash, flagged := range p.stuckTxs {
+
+		if !flagged {
+			continue
+		}
+
+		if tx, ok := p.Transactions[hash]; ok {
+			txs = append(txs, tx.Clone())
+		}
+
+	}
+
+	return txs
+
+}
+
+// StuckTxs - Returns tx(s) living in pending pool, which are currently
+// flagged as stuck i.e. can't pay latest base fee
+func (p *PendingPool) StuckTxs() []*MemPoolTx {
+
+	v, _ := p.StuckTxsWithContext(context.Background())
+	return v
+
+}
+
+// StuckTxsWithContext - Context aware `StuckTxs`
+func (p *PendingPool) StuckTxsWithContext(ctx context.Context) ([]*MemPoolTx, error) {
+
+	return request(ctx, p.StoppedChan, p.StuckTxsChan, func(respChan chan []*MemPoolTx) chan []*MemPoolTx {
+		return respChan
+	})
+
+}
+
+// evaluateStuckWithContext - Asks life cycle manager to flag tx(s), which can't
+// pay given base fee, giving up as soon as `ctx` is done or pool has stopped
+func (p *PendingPool) evaluateStuckWithContext(ctx context.Context, baseFee *big.Int, after time.Duration) ([]*MemPoolTx, error) {
+
+	return request(ctx, p.StoppedChan, p.EvaluateStuckChan, func(respChan chan []*MemPoolTx) StuckRequest {
+		return StuckRequest{BaseFee: baseFee, After: after, ResponseChan: respChan}
+	})
+
+}
+
+// DetectStuck - Periodically fetches latest base fee & lets pending pool
+// flag tx(s) which can't pay it, announcing newly flagged ones on pubsub
+// topic, until asked to stop
+//
+// Nothing is done until chain activates EIP-1559
+func (p *PendingPool) DetectStuck(ctx context.Context) {
+
+	ticker := time.NewTicker(time.Duration(config.GetStuckTxCheckPeriod()) * time.Millisecond)
+	defer ticker.Stop()
+
+	after := time.Duration(config.GetStuckTxAfter()) * time.Millisecond
+
+	for {
+
+		select {
+
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+
+			baseFee, err := LatestBaseFee(ctx, p.RPC)
+			if err != nil {
+				log.Printf("[❗️] Failed to fetch base fee, for detecting stuck tx(s) : %s\n", err.Error())
+				break
+			}
+
+			if baseFee == nil {
+				break
+			}
+
+			flagged, err := p.evaluateStuckWithContext(ctx, baseFee, after)
+			if err != nil {
+				break
+			}
+
+			for _, tx := range flagged {
+				p.PublishStuck(ctx, tx)
+			}
+
+		}
+
+	}
+
+}
+
+// PublishStuck - Publish pending tx, which can't pay latest base fee,
+// ( serialized using configured codec ) to pubsub topic, so that
+// alerting systems can notify sender, unless publishing on it is disabled
+func (p *PendingPool) PublishStuck(ctx context.Context, msg *MemPoolTx) {
+
+	if !config.GetPublishPendingStuck() {
+		return
+	}
+
+	publishTx(p.PubSub, p.Codec, nil, config.GetPendingTxStuckPublishTopic(), &p.publishedStuck, msg, "stuck pending tx")
+
+}
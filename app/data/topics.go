@@ -0,0 +1,73 @@
+package data
+
+import (
+	"sync/atomic"
+
+	"github.com/itzmeanjan/harmony/app/config"
+)
+
+// TopicStats - Pub/Sub topic, pool publishes tx(s) joining/ leaving it on,
+// whether publishing on it is enabled & #-of messages published on it
+// since start up, where each batch counts as one message
+type TopicStats struct {
+	Topic     string
+	Enabled   bool
+	Published uint64
+}
+
+// Topics - Entry & exit topics of pending pool, along with those tx(s)
+// changing state while living in it are published on
+func (p *PendingPool) Topics() []TopicStats {
+
+	return []TopicStats{
+		{
+			Topic:     config.GetPendingTxEntryPublishTopic(),
+			Enabled:   config.GetPublishPendingEntry(),
+			Published: atomic.LoadUint64(&p.publishedAdded),
+		},
+		{
+			Topic:     config.GetPendingTxExitPublishTopic(),
+			Enabled:   config.GetPublishPendingExit(),
+			Published: atomic.LoadUint64(&p.publishedRemoved),
+		},
+		{
+			Topic:     config.GetPendingTxReplacementPublishTopic(),
+			Enabled:   config.GetPublishPendingReplacement(),
+			Published: atomic.LoadUint64(&p.publishedReplaced),
+		},
+		{
+			Topic:     config.GetPendingTxStuckPublishTopic(),
+			Enabled:   config.GetPublishPendingStuck(),
+			Published: atomic.LoadUint64(&p.publishedStuck),
+		},
+		{
+			Topic:     config.GetPendingTxReorgedPublishTopic(),
+			Enabled:   config.GetPublishPendingReorged(),
+			Published: atomic.LoadUint64(&p.publishedReorged),
+		},
+		{
+			Topic:     config.GetQueuedToPendingPublishTopic(),
+			Enabled:   config.GetPublishQueuedToPending(),
+			Published: atomic.LoadUint64(&p.publishedPromoted),
+		},
+	}
+
+}
+
+// Topics - Entry & exit topics of queued pool
+func (q *QueuedPool) Topics() []TopicStats {
+
+	return []TopicStats{
+		{
+			Topic:     config.GetQueuedTxEntryPublishTopic(),
+			Enabled:   config.GetPublishQueuedEntry(),
+			Published: atomic.LoadUint64(&q.publishedAdded),
+		},
+		{
+			Topic:     config.GetQueuedTxExitPublishTopic(),
+			Enabled:   config.GetPublishQueuedExit(),
+			Published: atomic.LoadUint64(&q.publishedRemoved),
+		},
+	}
+
+}
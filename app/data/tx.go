@@ -2,13 +2,17 @@ package data
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/itzmeanjan/harmony/app/graph/model"
+	"github.com/itzmeanjan/harmony/app/upstream"
 
 	"github.com/vmihailenco/msgpack/v5"
 )
@@ -17,29 +21,35 @@ import (
 // RPC call for fetching currently pending/ queued tx(s) in mempool
 // it'll be destructured into this format, for further computation
 type MemPoolTx struct {
-	BlockHash        *common.Hash    `json:"blockHash"`
-	BlockNumber      *hexutil.Big    `json:"blockNumber"`
-	From             common.Address  `json:"from"`
-	Gas              hexutil.Uint64  `json:"gas"`
-	GasPrice         *hexutil.Big    `json:"gasPrice"`
-	Hash             common.Hash     `json:"hash"`
-	Input            hexutil.Bytes   `json:"input"`
-	Nonce            hexutil.Uint64  `json:"nonce"`
-	To               *common.Address `json:"to"`
-	TransactionIndex *hexutil.Uint64 `json:"transactionIndex"`
-	Value            *hexutil.Big    `json:"value"`
-	Type             hexutil.Uint64  `json:"type"`
-	ChainID          *hexutil.Big    `json:"chainId,omitempty"`
-	V                *hexutil.Big    `json:"v"`
-	R                *hexutil.Big    `json:"r"`
-	S                *hexutil.Big    `json:"s"`
-	QueuedAt         time.Time
-	UnstuckAt        time.Time
-	PendingFrom      time.Time
-	ConfirmedAt      time.Time
-	DroppedAt        time.Time
-	Pool             string
-	ReceivedFrom     string
+	BlockHash            *common.Hash      `json:"blockHash"`
+	BlockNumber          *hexutil.Big      `json:"blockNumber"`
+	From                 common.Address    `json:"from"`
+	Gas                  hexutil.Uint64    `json:"gas"`
+	GasPrice             *hexutil.Big      `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas,omitempty"`
+	Hash                 common.Hash       `json:"hash"`
+	Input                hexutil.Bytes     `json:"input"`
+	Nonce                hexutil.Uint64    `json:"nonce"`
+	To                   *common.Address   `json:"to"`
+	TransactionIndex     *hexutil.Uint64   `json:"transactionIndex"`
+	Value                *hexutil.Big      `json:"value"`
+	Type                 hexutil.Uint64    `json:"type"`
+	ChainID              *hexutil.Big      `json:"chainId,omitempty"`
+	AccessList           *types.AccessList `json:"accessList,omitempty"`
+	V                    *hexutil.Big      `json:"v"`
+	R                    *hexutil.Big      `json:"r"`
+	S                    *hexutil.Big      `json:"s"`
+	QueuedAt             time.Time         `json:"queuedAt" msgpack:"queuedAt"`
+	UnstuckAt            time.Time         `json:"unstuckAt" msgpack:"unstuckAt"`
+	PendingFrom          time.Time         `json:"pendingFrom" msgpack:"pendingFrom"`
+	ConfirmedAt          time.Time         `json:"confirmedAt" msgpack:"confirmedAt"`
+	DroppedAt            time.Time         `json:"droppedAt" msgpack:"droppedAt"`
+	Pool                 string            `json:"pool" msgpack:"pool"`
+	ReceivedFrom         string            `json:"receivedFrom" msgpack:"receivedFrom"`
+	ReplacedBy           common.Hash       `json:"replacedBy" msgpack:"replacedBy"`
+	EvictionReason       string            `json:"evictionReason,omitempty" msgpack:"evictionReason,omitempty"`
+	Promoted             bool              `json:"promoted,omitempty" msgpack:"promoted,omitempty"`
 }
 
 // IsDuplicateOf - Checks whether one tx is duplicate of another one or not
@@ -52,6 +62,29 @@ func (m *MemPoolTx) IsDuplicateOf(tx *MemPoolTx) bool {
 
 }
 
+// IsFeeBumpOf - Checks whether this tx is replacing `tx`, by paying
+// at least 10% higher gas price, with same sender address & nonce
+func (m *MemPoolTx) IsFeeBumpOf(tx *MemPoolTx) bool {
+
+	if !m.IsDuplicateOf(tx) {
+		return false
+	}
+
+	bumped := big.NewInt(0).Mul(m.EffectiveGasPrice(nil), big.NewInt(100))
+	required := big.NewInt(0).Mul(tx.EffectiveGasPrice(nil), big.NewInt(110))
+
+	return bumped.Cmp(required) >= 0
+
+}
+
+// IsReplaced - Checks whether some other tx has replaced this
+// one, by bumping fee
+func (m *MemPoolTx) IsReplaced() bool {
+
+	return m.ReplacedBy != (common.Hash{})
+
+}
+
 // IsLowerNonce - Objective is to find out whether `m` has same
 // of lower nonce than `tx`
 func (m *MemPoolTx) IsLowerNonce(tx *MemPoolTx) bool {
@@ -68,12 +101,20 @@ func (m *MemPoolTx) IsSentFrom(address common.Address) bool {
 
 }
 
+// IsContractCreation - Checks whether this tx is deploying new contract
+// i.e. it doesn't have any `to` address
+func (m *MemPoolTx) IsContractCreation() bool {
+
+	return m.To == nil
+
+}
+
 // IsSentTo - Checks if this was sent to certain address ( EOA/ Contract )
 //
 // @note If it's a contract creation tx, it'll not have `to` address
 func (m *MemPoolTx) IsSentTo(address common.Address) bool {
 
-	if m.To == nil {
+	if m.IsContractCreation() {
 		return false
 	}
 
@@ -81,6 +122,214 @@ func (m *MemPoolTx) IsSentTo(address common.Address) bool {
 
 }
 
+// IsOfType - Checks whether this is typed tx of given type, where
+// legacy tx(s) are of type `0`
+//
+// @note Types unknown to harmony are matched as is
+func (m *MemPoolTx) IsOfType(t uint64) bool {
+
+	return uint64(m.Type) == t
+
+}
+
+// bigOrZero - Given hex encoded big number, which may be absent,
+// returns big integer representation of it, `0` in case of absence
+func bigOrZero(num *hexutil.Big) *big.Int {
+
+	if num == nil {
+		return big.NewInt(0)
+	}
+
+	return BigHexToBigDecimal(num)
+
+}
+
+// accessList - Access list of this tx, empty if absent
+func (m *MemPoolTx) accessList() types.AccessList {
+
+	if m.AccessList == nil {
+		return types.AccessList{}
+	}
+
+	return *m.AccessList
+
+}
+
+// chainIDOr - Chain ID this tx is signed for, falling back to given one
+// when it's not part of tx itself i.e. legacy tx(s)
+func (m *MemPoolTx) chainIDOr(chainID *big.Int) *big.Int {
+
+	if m.ChainID == nil {
+		return chainID
+	}
+
+	return BigHexToBigDecimal(m.ChainID)
+
+}
+
+// SignedChainID - Chain ID this tx is signed for, for legacy tx(s) it's
+// derived from `V`, as per EIP-155
+//
+// Returns nil, if tx is not replay protected i.e. pre EIP-155
+func (m *MemPoolTx) SignedChainID() *big.Int {
+
+	if m.ChainID != nil {
+		return BigHexToBigDecimal(m.ChainID)
+	}
+
+	if m.Type != types.LegacyTxType || m.V == nil {
+		return nil
+	}
+
+	v := BigHexToBigDecimal(m.V)
+	if v.BitLen() <= 8 && (v.Uint64() == 27 || v.Uint64() == 28) {
+		return nil
+	}
+
+	// V = chainID * 2 + { 35, 36 }
+	chainID := new(big.Int).Sub(v, big.NewInt(35))
+	return chainID.Rsh(chainID, 1)
+
+}
+
+// RecoverSender - Derives sender address of this tx from signature
+// components i.e. V, R, S & sets it as `From` of this tx
+//
+// @note Legacy, access list & dynamic fee tx(s) are supported
+func (m *MemPoolTx) RecoverSender(chainID *big.Int) error {
+
+	if chainID == nil {
+		return errors.New("chain id not known")
+	}
+
+	if m.V == nil || m.R == nil || m.S == nil {
+		return errors.New("missing signature components")
+	}
+
+	tx, err := m.signedTx(chainID)
+	if err != nil {
+		return err
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		return err
+	}
+
+	m.From = sender
+	return nil
+
+}
+
+// signedTx - Builds go-ethereum's representation of this signed tx, so
+// that its signing hash is computed by go-ethereum itself, where typed
+// tx(s) lacking chain ID are assumed to be signed for given chain
+func (m *MemPoolTx) signedTx(chainID *big.Int) (*types.Transaction, error) {
+
+	v := BigHexToBigDecimal(m.V)
+	r := BigHexToBigDecimal(m.R)
+	s := BigHexToBigDecimal(m.S)
+
+	switch m.Type {
+
+	case types.LegacyTxType:
+
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(m.Nonce),
+			GasPrice: bigOrZero(m.GasPrice),
+			Gas:      uint64(m.Gas),
+			To:       m.To,
+			Value:    bigOrZero(m.Value),
+			Data:     m.Input,
+			V:        v,
+			R:        r,
+			S:        s,
+		}), nil
+
+	case types.AccessListTxType:
+
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    m.chainIDOr(chainID),
+			Nonce:      uint64(m.Nonce),
+			GasPrice:   bigOrZero(m.GasPrice),
+			Gas:        uint64(m.Gas),
+			To:         m.To,
+			Value:      bigOrZero(m.Value),
+			Data:       m.Input,
+			AccessList: m.accessList(),
+			V:          v,
+			R:          r,
+			S:          s,
+		}), nil
+
+	case types.DynamicFeeTxType:
+
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    m.chainIDOr(chainID),
+			Nonce:      uint64(m.Nonce),
+			GasTipCap:  bigOrZero(m.MaxPriorityFeePerGas),
+			GasFeeCap:  bigOrZero(m.MaxFeePerGas),
+			Gas:        uint64(m.Gas),
+			To:         m.To,
+			Value:      bigOrZero(m.Value),
+			Data:       m.Input,
+			AccessList: m.accessList(),
+			V:          v,
+			R:          r,
+			S:          s,
+		}), nil
+
+	default:
+
+		return nil, fmt.Errorf("unsupported tx type : %d", m.Type)
+
+	}
+
+}
+
+// MethodID - 4-byte function selector of contract method this tx
+// invokes, derived from first four bytes of input data
+//
+// @note For plain value transfers i.e. `len(input) < 4`, it's
+// going to be empty selector
+func (m *MemPoolTx) MethodID() [4]byte {
+
+	var selector [4]byte
+
+	if len(m.Input) < 4 {
+		return selector
+	}
+
+	copy(selector[:], m.Input[:4])
+	return selector
+
+}
+
+// MethodIDHex - Hex encoded 4-byte function selector, empty
+// string in case of plain value transfers
+func (m *MemPoolTx) MethodIDHex() string {
+
+	if len(m.Input) < 4 {
+		return ""
+	}
+
+	selector := m.MethodID()
+	return hexutil.Encode(selector[:])
+
+}
+
+// IsSentToWithMethod - Checks whether this tx was sent to given address
+// for invoking contract method, identified by 4-byte selector
+func (m *MemPoolTx) IsSentToWithMethod(address common.Address, selector [4]byte) bool {
+
+	if len(m.Input) < 4 {
+		return false
+	}
+
+	return m.IsSentTo(address) && m.MethodID() == selector
+
+}
+
 // IsPendingForGTE - Test if this tx has been in pending pool
 // for more than or equal to `X` time unit
 func (m *MemPoolTx) IsPendingForGTE(x time.Duration) bool {
@@ -137,7 +386,7 @@ func (m *MemPoolTx) IsDropped(ctx context.Context, rpc *rpc.Client) (bool, error
 
 	var result interface{}
 
-	if err := rpc.CallContext(ctx, &result, "eth_getTransactionReceipt", m.Hash.Hex()); err != nil {
+	if err := upstream.Call(ctx, rpc, &result, "eth_getTransactionReceipt", m.Hash.Hex()); err != nil {
 		return true, err
 	}
 
@@ -151,6 +400,87 @@ func (m *MemPoolTx) IsDropped(ctx context.Context, rpc *rpc.Client) (bool, error
 
 }
 
+// IsConfirmed - Attempts to check whether this tx has been mined or not,
+// returning block number, in which it got included, when mined
+//
+// @note Unknown tx & tx still sitting in mempool i.e. without
+// block number, both are considered to be not confirmed
+func (m *MemPoolTx) IsConfirmed(ctx context.Context, rpc *rpc.Client) (bool, uint64, error) {
+
+	var result *struct {
+		BlockNumber *hexutil.Big `json:"blockNumber"`
+	}
+
+	if err := upstream.Call(ctx, rpc, &result, "eth_getTransactionByHash", m.Hash.Hex()); err != nil {
+		return false, 0, err
+	}
+
+	// Node doesn't know about this tx
+	if result == nil {
+		return false, 0, nil
+	}
+
+	// Tx is known, but still pending
+	if result.BlockNumber == nil {
+		return false, 0, nil
+	}
+
+	return true, result.BlockNumber.ToInt().Uint64(), nil
+
+}
+
+// IsUnknown - Checks whether node doesn't know about this tx anymore i.e.
+// it's neither mined nor sitting in its mempool
+func (m *MemPoolTx) IsUnknown(ctx context.Context, rpc *rpc.Client) (bool, error) {
+
+	var result interface{}
+
+	if err := upstream.Call(ctx, rpc, &result, "eth_getTransactionByHash", m.Hash.Hex()); err != nil {
+		return false, err
+	}
+
+	return result == nil, nil
+
+}
+
+// UnknownAmong - Given tx hashes, finds which of them node doesn't know about
+// anymore, looking all of them up in one batched request
+func UnknownAmong(ctx context.Context, client *rpc.Client, hashes []common.Hash) ([]common.Hash, error) {
+
+	results := make([]interface{}, len(hashes))
+	batch := make([]rpc.BatchElem, len(hashes))
+
+	for i, hash := range hashes {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getTransactionByHash",
+			Args:   []interface{}{hash.Hex()},
+			Result: &results[i],
+		}
+	}
+
+	if err := upstream.BatchCall(ctx, client, batch); err != nil {
+		return nil, err
+	}
+
+	unknown := make([]common.Hash, 0)
+
+	for i, elem := range batch {
+
+		// Can't tell, so it's left alone
+		if elem.Error != nil {
+			continue
+		}
+
+		if results[i] == nil {
+			unknown = append(unknown, hashes[i])
+		}
+
+	}
+
+	return unknown, nil
+
+}
+
 // IsNonceExhausted - Multiple tx(s) of same/ different value
 // can be sent to network with same nonce, where one of them
 // which seems most profitable to miner, will be picked up, while mining next block
@@ -163,7 +493,7 @@ func (m *MemPoolTx) IsNonceExhausted(ctx context.Context, rpc *rpc.Client) (bool
 
 	var result hexutil.Uint64
 
-	if err := rpc.CallContext(ctx, &result, "eth_getTransactionCount", m.From.Hex(), "latest"); err != nil {
+	if err := upstream.Call(ctx, rpc, &result, "eth_getTransactionCount", m.From.Hex(), "latest"); err != nil {
 		return false, err
 	}
 
@@ -177,20 +507,100 @@ func (m *MemPoolTx) IsNonceExhausted(ctx context.Context, rpc *rpc.Client) (bool
 // due to nonce gaps
 func (m *MemPoolTx) IsUnstuck(ctx context.Context, rpc *rpc.Client) (bool, error) {
 
+	nonce, err := AccountNonce(ctx, rpc, m.From)
+	if err != nil {
+		return false, err
+	}
+
+	return m.IsUnstuckAt(nonce), nil
+
+}
+
+// IsUnstuckAt - Checking whether this tx is unstuck, given sender's
+// account nonce, as of latest block
+func (m *MemPoolTx) IsUnstuckAt(nonce hexutil.Uint64) bool {
+
+	return m.Nonce <= nonce
+
+}
+
+// AccountNonce - Fetches nonce of given account, as of latest block, which is
+// what next tx from this account must carry
+func AccountNonce(ctx context.Context, rpc *rpc.Client, addr common.Address) (hexutil.Uint64, error) {
+
 	var result hexutil.Uint64
 
-	if err := rpc.CallContext(ctx, &result, "eth_getTransactionCount", m.From.Hex(), "latest"); err != nil {
-		return false, err
+	if err := upstream.Call(ctx, rpc, &result, "eth_getTransactionCount", addr.Hex(), "latest"); err != nil {
+		return 0, err
+	}
+
+	return result, nil
+
+}
+
+// IsDynamicFee - Checks whether this is an EIP-1559 tx, carrying
+// fee cap & tip cap, instead of a meaningful gas price
+func (m *MemPoolTx) IsDynamicFee() bool {
+
+	return m.MaxFeePerGas != nil
+
+}
+
+// EffectiveGasPrice - Gas price this tx ends up paying per unit of gas,
+// when included in a block with given base fee
+//
+// For legacy tx(s) it's same as gas price, while for dynamic fee tx(s)
+// it's `min(maxFeePerGas, baseFee + maxPriorityFeePerGas)`
+//
+// @note If base fee is `nil`, fee cap is considered as effective gas price
+// for dynamic fee tx(s). Sorted views do so only until first block with
+// base fee is seen, after which they're re-keyed on each new block
+func (m *MemPoolTx) EffectiveGasPrice(baseFee *big.Int) *big.Int {
+
+	if !m.IsDynamicFee() {
+
+		if m.GasPrice == nil {
+			return big.NewInt(0)
+		}
+
+		return BigHexToBigDecimal(m.GasPrice)
+
 	}
 
-	return m.Nonce <= result, nil
+	feeCap := BigHexToBigDecimal(m.MaxFeePerGas)
+	if baseFee == nil {
+		return feeCap
+	}
+
+	tip := big.NewInt(0)
+	if m.MaxPriorityFeePerGas != nil {
+		tip = BigHexToBigDecimal(m.MaxPriorityFeePerGas)
+	}
+
+	price := big.NewInt(0).Add(baseFee, tip)
+	if price.Cmp(feeCap) > 0 {
+		return feeCap
+	}
+
+	return price
+
+}
+
+// Cost - Total amount of wei this tx can cost its sender at max, i.e.
+// `gasPrice * gasLimit + value`
+//
+// @note For dynamic fee tx(s), fee cap is considered as gas price
+func (m *MemPoolTx) Cost() *big.Int {
+
+	cost := big.NewInt(0).Mul(m.EffectiveGasPrice(nil), big.NewInt(0).SetUint64(uint64(m.Gas)))
+	return cost.Add(cost, bigOrZero(m.Value))
 
 }
 
-// HasGasPriceMoreThan - Returns true if gas price of this tx
+// HasGasPriceMoreThan - Returns true if effective gas price of this tx
 // is more than or equals to `X`
 func (m *MemPoolTx) HasGasPriceMoreThan(x float64) bool {
-	gp, err := BigHexToBigFloat(m.GasPrice)
+	gp, err := BigIntToBigFloat(m.EffectiveGasPrice(nil))
 	if err != nil {
 		return false
 	}
@@ -199,10 +609,10 @@ func (m *MemPoolTx) HasGasPriceMoreThan(x float64) bool {
 	return gp.Cmp(given) >= 0
 }
 
-// HasGasPriceLessThan - Returns true if gas price of this tx
+// HasGasPriceLessThan - Returns true if effective gas price of this tx
 // is less than or equals to `X`
 func (m *MemPoolTx) HasGasPriceLessThan(x float64) bool {
-	gp, err := BigHexToBigFloat(m.GasPrice)
+	gp, err := BigIntToBigFloat(m.EffectiveGasPrice(nil))
 	if err != nil {
 		return false
 	}
@@ -211,6 +621,68 @@ func (m *MemPoolTx) HasGasPriceLessThan(x float64) bool {
 	return gp.Cmp(given) <= 0
 }
 
+// cloneBig - Deep copies big number, if present
+func cloneBig(v *hexutil.Big) *hexutil.Big {
+	if v == nil {
+		return nil
+	}
+
+	return (*hexutil.Big)(new(big.Int).Set((*big.Int)(v)))
+}
+
+// Clone - Deep copies tx, so that snapshot can be handed out to
+// caller, while pool keeps mutating its own copy
+func (m *MemPoolTx) Clone() *MemPoolTx {
+
+	tx := *m
+
+	if m.BlockHash != nil {
+		hash := *m.BlockHash
+		tx.BlockHash = &hash
+	}
+
+	if m.To != nil {
+		to := *m.To
+		tx.To = &to
+	}
+
+	if m.TransactionIndex != nil {
+		idx := *m.TransactionIndex
+		tx.TransactionIndex = &idx
+	}
+
+	if m.Input != nil {
+		tx.Input = append(hexutil.Bytes{}, m.Input...)
+	}
+
+	if m.AccessList != nil {
+
+		accessList := make(types.AccessList, 0, len(*m.AccessList))
+		for _, tuple := range *m.AccessList {
+			accessList = append(accessList, types.AccessTuple{
+				Address:     tuple.Address,
+				StorageKeys: append([]common.Hash{}, tuple.StorageKeys...),
+			})
+		}
+
+		tx.AccessList = &accessList
+
+	}
+
+	tx.BlockNumber = cloneBig(m.BlockNumber)
+	tx.GasPrice = cloneBig(m.GasPrice)
+	tx.MaxFeePerGas = cloneBig(m.MaxFeePerGas)
+	tx.MaxPriorityFeePerGas = cloneBig(m.MaxPriorityFeePerGas)
+	tx.Value = cloneBig(m.Value)
+	tx.ChainID = cloneBig(m.ChainID)
+	tx.V = cloneBig(m.V)
+	tx.R = cloneBig(m.R)
+	tx.S = cloneBig(m.S)
+
+	return &tx
+
+}
+
 // ToMessagePack - Serialize to message pack encoded byte array format
 func (m *MemPoolTx) ToMessagePack() ([]byte, error) {
 
@@ -220,6 +692,8 @@ func (m *MemPoolTx) ToMessagePack() ([]byte, error) {
 
 // FromMessagePack - Given serialized byte array, attempts to deserialize
 // into structured tx format
+//
+// @note Timestamps are brought back to UTC, same as they're kept in pool
 func FromMessagePack(data []byte) (*MemPoolTx, error) {
 
 	var tx MemPoolTx
@@ -228,10 +702,23 @@ func FromMessagePack(data []byte) (*MemPoolTx, error) {
 		return nil, err
 	}
 
+	tx.toUTC()
 	return &tx, nil
 
 }
 
+// toUTC - Timestamps decoded from message pack are in local time zone,
+// so they're converted to UTC, zero ones are kept as they're
+func (m *MemPoolTx) toUTC() {
+
+	for _, t := range []*time.Time{&m.QueuedAt, &m.UnstuckAt, &m.PendingFrom, &m.ConfirmedAt, &m.DroppedAt} {
+		if !t.IsZero() {
+			*t = t.UTC()
+		}
+	}
+
+}
+
 // ToGraphQL - Convert to graphql compatible type
 func (m *MemPoolTx) ToGraphQL() *model.MemPoolTx {
 
@@ -309,6 +796,46 @@ func (m *MemPoolTx) ToGraphQL() *model.MemPoolTx {
 
 		}
 
+		// Manually evicted from queued pool, it never was pending
+		if m.PendingFrom.Equal(time.Time{}) {
+
+			gqlTx.PendingFor = "0 s"
+			gqlTx.QueuedFor = m.DroppedAt.Sub(m.QueuedAt).String()
+
+		}
+
+	case "replaced":
+
+		gqlTx = &model.MemPoolTx{
+			From:       m.From.Hex(),
+			Gas:        HexToDecimal(m.Gas),
+			Hash:       m.Hash.Hex(),
+			Input:      m.Input.String(),
+			Nonce:      HexToDecimal(m.Nonce),
+			PendingFor: m.DroppedAt.Sub(m.PendingFrom).String(),
+			QueuedFor:  "0 s",
+			Pool:       m.Pool,
+		}
+
+		if !m.QueuedAt.Equal(time.Time{}) && !m.UnstuckAt.Equal(time.Time{}) {
+
+			gqlTx.QueuedFor = m.UnstuckAt.Sub(m.QueuedAt).String()
+
+		}
+
+	case "unstuck":
+
+		gqlTx = &model.MemPoolTx{
+			From:       m.From.Hex(),
+			Gas:        HexToDecimal(m.Gas),
+			Hash:       m.Hash.Hex(),
+			Input:      m.Input.String(),
+			Nonce:      HexToDecimal(m.Nonce),
+			PendingFor: "0 s",
+			QueuedFor:  m.UnstuckAt.Sub(m.QueuedAt).String(),
+			Pool:       m.Pool,
+		}
+
 	default:
 		// handle situation when deserilisation didn't work
 		// properly
@@ -335,6 +862,29 @@ func (m *MemPoolTx) ToGraphQL() *model.MemPoolTx {
 		gqlTx.GasPriceGwei = 0.0
 	}
 
+	if m.MaxFeePerGas != nil {
+		gqlTx.MaxFeePerGas = HumanReadableGasPrice(m.MaxFeePerGas)
+	} else {
+		gqlTx.MaxFeePerGas = "0"
+	}
+
+	if m.MaxPriorityFeePerGas != nil {
+		gqlTx.MaxPriorityFeePerGas = HumanReadableGasPrice(m.MaxPriorityFeePerGas)
+	} else {
+		gqlTx.MaxPriorityFeePerGas = "0"
+	}
+
+	gqlTx.Type = int(m.Type)
+	gqlTx.Method = m.MethodIDHex()
+	gqlTx.Cost = m.Cost().String()
+
+	if m.IsReplaced() {
+		gqlTx.ReplacedBy = m.ReplacedBy.Hex()
+	}
+
+	gqlTx.EvictionReason = m.EvictionReason
+	gqlTx.Promoted = m.Promoted
+
 	if m.Value != nil {
 		gqlTx.Value = BigHexToBigDecimal(m.Value).String()
 	} else {
@@ -369,6 +919,8 @@ const (
 	PENDING
 	CONFIRMED
 	DROPPED
+	REPLACED
+	MANUALLY_EVICTED
 )
 
 // TxStatus - When ever multiple go routines need to
@@ -0,0 +1,345 @@
+package data
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// rpcTx - Subset of fields, node returns for known tx
+type rpcTx struct {
+	Hash        common.Hash  `json:"hash"`
+	BlockNumber *hexutil.Big `json:"blockNumber"`
+}
+
+// fakeEth - In-memory stand in for upstream node's `eth` namespace,
+// knowing only tx(s) it's told about
+type fakeEth struct {
+	lock   sync.RWMutex
+	txs    map[common.Hash]*rpcTx
+	nonces map[common.Address]hexutil.Uint64
+}
+
+// GetTransactionByHash - Known tx, `null` for unknown one
+func (f *fakeEth) GetTransactionByHash(hash common.Hash) (*rpcTx, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.txs[hash], nil
+}
+
+// GetTransactionCount - Nonce of account, as of latest block
+func (f *fakeEth) GetTransactionCount(addr common.Address, block string) (hexutil.Uint64, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.nonces[addr], nil
+}
+
+// newFakeRPC - RPC client talking to in-memory fake node
+func newFakeRPC(t *testing.T) (*fakeEth, *rpc.Client) {
+	t.Helper()
+
+	eth := &fakeEth{txs: make(map[common.Hash]*rpcTx), nonces: make(map[common.Address]hexutil.Uint64)}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", eth); err != nil {
+		t.Fatal(err)
+	}
+
+	client := rpc.DialInProc(server)
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+
+	return eth, client
+}
+
+// ether - Given #-of ether as hex encoded big number
+func ether(n int64) *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(0).Mul(big.NewInt(n), big.NewInt(1_000_000_000_000_000_000)))
+}
+
+func TestCost(t *testing.T) {
+
+	transfer := legacyTx(1, 10)
+	transfer.Gas = 21000
+	transfer.Value = ether(1)
+
+	legacy := legacyTx(2, 100)
+	legacy.Gas = 21000
+
+	dynamic := dynamicFeeTx(3, 200, 1)
+	dynamic.Gas = 21000
+
+	empty := &MemPoolTx{Gas: 21000}
+
+	cases := []struct {
+		name string
+		tx   *MemPoolTx
+		want *big.Int
+	}{
+		{"legacy with value", transfer, big.NewInt(0).Add(big.NewInt(0).Mul(BigHexToBigDecimal(gwei(10)), big.NewInt(21000)), BigHexToBigDecimal(ether(1)))},
+		{"legacy without value", legacy, big.NewInt(0).Mul(BigHexToBigDecimal(gwei(100)), big.NewInt(21000))},
+		{"dynamic fee pays fee cap", dynamic, big.NewInt(0).Mul(BigHexToBigDecimal(gwei(200)), big.NewInt(21000))},
+		{"without gas price & value", empty, big.NewInt(0)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+
+			if got := c.tx.Cost(); got.Cmp(c.want) != 0 {
+				t.Fatalf("expected %s, got %s", c.want, got)
+			}
+
+		})
+	}
+
+	// Mixed tx(s) ordered by cost, same as `TopXByCost` does
+	txs := []*MemPoolTx{legacy, empty, transfer, dynamic}
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].Cost().Cmp(txs[j].Cost()) > 0
+	})
+
+	want := []*MemPoolTx{transfer, dynamic, legacy, empty}
+	for i := range want {
+		if txs[i] != want[i] {
+			t.Fatalf("rank %d : expected %s, got %s", i, want[i].Hash, txs[i].Hash)
+		}
+	}
+
+}
+
+func TestIsConfirmed(t *testing.T) {
+
+	eth, client := newFakeRPC(t)
+
+	mined := legacyTx(1, 10)
+	pending := legacyTx(2, 10)
+	unknown := legacyTx(3, 10)
+
+	eth.txs[mined.Hash] = &rpcTx{Hash: mined.Hash, BlockNumber: (*hexutil.Big)(big.NewInt(12_000_000))}
+	eth.txs[pending.Hash] = &rpcTx{Hash: pending.Hash}
+
+	cases := []struct {
+		name      string
+		tx        *MemPoolTx
+		confirmed bool
+		block     uint64
+	}{
+		{"mined", mined, true, 12_000_000},
+		{"pending", pending, false, 0},
+		{"unknown", unknown, false, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+
+			confirmed, block, err := c.tx.IsConfirmed(context.Background(), client)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if confirmed != c.confirmed || block != c.block {
+				t.Fatalf("expected (%v, %d), got (%v, %d)", c.confirmed, c.block, confirmed, block)
+			}
+
+		})
+	}
+
+}
+
+func TestIsConfirmedRPCFailure(t *testing.T) {
+
+	_, client := newFakeRPC(t)
+	client.Close()
+
+	confirmed, _, err := legacyTx(1, 10).IsConfirmed(context.Background(), client)
+	if err == nil {
+		t.Fatal("expected error when node can't be reached")
+	}
+
+	if confirmed {
+		t.Fatal("expected failed lookup not to be considered confirmation")
+	}
+
+}
+
+// Tx(s) without gas price, i.e. malformed ones, are considered to be
+// paying nothing, instead of taking comparators down
+func TestNilGasPriceComparators(t *testing.T) {
+
+	free := &MemPoolTx{Hash: common.Hash{1}}
+	paying := legacyTx(2, 10)
+
+	if free.EffectiveGasPrice(nil).Sign() != 0 {
+		t.Fatal("expected absent gas price to be zero")
+	}
+
+	if free.HasGasPriceMoreThan(1) || !free.HasGasPriceLessThan(1) {
+		t.Fatal("expected absent gas price to be lower than 1 gwei")
+	}
+
+	if free.IsFeeBumpOf(paying) || free.Cost().Sign() != 0 {
+		t.Fatal("expected tx without gas price to neither bump fee nor cost anything")
+	}
+
+	if BigHexToBigDecimal(nil).Sign() != 0 || NumericGasPriceGwei(nil) != 0 {
+		t.Fatal("expected absent big number to be zero")
+	}
+
+	txs := []*MemPoolTx{free, paying}
+	SortByGasPriceDesc(txs)
+
+	if txs[0] != paying {
+		t.Fatal("expected paying tx to be placed before one without gas price")
+	}
+
+	tree := NewGasPriceTree()
+	tree.insert(paying)
+	tree.insert(free)
+
+	if tree.at(0) != free || !tree.remove(free) || tree.len() != 1 {
+		t.Fatal("expected tx without gas price to be lowest one in tree")
+	}
+
+}
+
+func TestSentToContractCreation(t *testing.T) {
+
+	creation := legacyTx(1, 10)
+	creation.Input = hexutil.Bytes{0xa9, 0x05, 0x9c, 0xbb, 0x00}
+
+	if !creation.IsContractCreation() {
+		t.Fatal("expected tx without `to` to be contract creation")
+	}
+
+	if creation.IsSentTo(common.Address{}) {
+		t.Fatal("expected contract creation not to be sent to zero address")
+	}
+
+	if creation.IsSentToWithMethod(common.Address{}, creation.MethodID()) {
+		t.Fatal("expected contract creation not to be invoking any method")
+	}
+
+}
+
+// signedTx - Signs given tx with freshly generated key, for given chain,
+// returning it as mempool tx, lacking sender, along with expected sender
+func signedTx(t *testing.T, inner types.TxData, chainID *big.Int) (*MemPoolTx, common.Address) {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), inner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, r, s := tx.RawSignatureValues()
+	accessList := tx.AccessList()
+
+	m := &MemPoolTx{
+		Hash:       tx.Hash(),
+		Gas:        hexutil.Uint64(tx.Gas()),
+		GasPrice:   (*hexutil.Big)(tx.GasPrice()),
+		Input:      tx.Data(),
+		Nonce:      hexutil.Uint64(tx.Nonce()),
+		To:         tx.To(),
+		Value:      (*hexutil.Big)(tx.Value()),
+		Type:       hexutil.Uint64(tx.Type()),
+		AccessList: &accessList,
+		V:          (*hexutil.Big)(v),
+		R:          (*hexutil.Big)(r),
+		S:          (*hexutil.Big)(s),
+	}
+
+	if tx.Type() != types.LegacyTxType {
+		m.ChainID = (*hexutil.Big)(tx.ChainId())
+	}
+
+	if tx.Type() == types.DynamicFeeTxType {
+		m.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		m.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	}
+
+	return m, crypto.PubkeyToAddress(key.PublicKey)
+
+}
+
+func TestRecoverSender(t *testing.T) {
+
+	mainnet, goerli := big.NewInt(1), big.NewInt(5)
+	to := txAddress(7)
+
+	cases := []struct {
+		name    string
+		inner   types.TxData
+		signed  *big.Int
+		wantErr bool
+	}{
+		{
+			name:   "legacy",
+			inner:  &types.LegacyTx{Nonce: 1, GasPrice: gwei(10).ToInt(), Gas: 21000, To: &to, Value: big.NewInt(1)},
+			signed: mainnet,
+		},
+		{
+			name:   "access list",
+			inner:  &types.AccessListTx{ChainID: mainnet, Nonce: 2, GasPrice: gwei(10).ToInt(), Gas: 30000, To: &to, Value: big.NewInt(1), AccessList: types.AccessList{{Address: to, StorageKeys: []common.Hash{{1}}}}},
+			signed: mainnet,
+		},
+		{
+			name:   "dynamic fee",
+			inner:  &types.DynamicFeeTx{ChainID: mainnet, Nonce: 3, GasTipCap: gwei(2).ToInt(), GasFeeCap: gwei(100).ToInt(), Gas: 21000, To: &to, Value: big.NewInt(1), Data: []byte{0xa9, 0x05, 0x9c, 0xbb}},
+			signed: mainnet,
+		},
+		{
+			name:   "dynamic fee contract creation",
+			inner:  &types.DynamicFeeTx{ChainID: mainnet, Nonce: 4, GasTipCap: gwei(2).ToInt(), GasFeeCap: gwei(100).ToInt(), Gas: 100000, Data: []byte{0x60, 0x80}},
+			signed: mainnet,
+		},
+		{
+			name:    "dynamic fee for other chain",
+			inner:   &types.DynamicFeeTx{ChainID: goerli, Nonce: 5, GasTipCap: gwei(2).ToInt(), GasFeeCap: gwei(100).ToInt(), Gas: 21000, To: &to},
+			signed:  goerli,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+
+			tx, want := signedTx(t, c.inner, c.signed)
+
+			err := tx.RecoverSender(mainnet)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("expected recovery to fail, got sender %s", tx.From.Hex())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tx.From != want {
+				t.Fatalf("expected sender %s, got %s", want.Hex(), tx.From.Hex())
+			}
+
+		})
+	}
+
+}
@@ -1,6 +1,10 @@
 package data
 
-import "github.com/ethereum/go-ethereum/common/hexutil"
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
 
 type TxList interface {
 	len() int
@@ -29,8 +33,6 @@ func Insert(txs TxList, tx *MemPoolTx) TxList {
 
 		switch txs.(type) {
 
-		case MemPoolTxsAsc:
-			return (MemPoolTxsAsc)(_txs)
 		case MemPoolTxsDesc:
 			return (MemPoolTxsDesc)(_txs)
 		case TxsFromAddressAsc:
@@ -55,8 +57,6 @@ func Insert(txs TxList, tx *MemPoolTx) TxList {
 
 	switch txs.(type) {
 
-	case MemPoolTxsAsc:
-		return (MemPoolTxsAsc)(_txs)
 	case MemPoolTxsDesc:
 		return (MemPoolTxsDesc)(_txs)
 	case TxsFromAddressAsc:
@@ -84,8 +84,6 @@ func Remove(txs TxList, tx *MemPoolTx) TxList {
 
 	switch txs.(type) {
 
-	case MemPoolTxsAsc:
-		return (MemPoolTxsAsc)(_txs)
 	case MemPoolTxsDesc:
 		return (MemPoolTxsDesc)(_txs)
 	case TxsFromAddressAsc:
@@ -154,3 +152,27 @@ func UntilNonceGap(txs []*MemPoolTx, nonce hexutil.Uint64) []*MemPoolTx {
 
 	return result
 }
+
+// SortByGasPriceDesc - Sorts given slice of txs in place, so that tx paying
+// highest effective gas price comes first
+func SortByGasPriceDesc(txs []*MemPoolTx) {
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].EffectiveGasPrice(nil).Cmp(txs[j].EffectiveGasPrice(nil)) > 0
+	})
+
+}
+
+// CloneAll - Deep copies each tx of given slice, so that snapshot can be
+// returned from pool, without sharing any pointer with it
+func CloneAll(txs []*MemPoolTx) []*MemPoolTx {
+
+	cloned := make([]*MemPoolTx, len(txs))
+
+	for i := 0; i < len(txs); i++ {
+		cloned[i] = txs[i].Clone()
+	}
+
+	return cloned
+
+}
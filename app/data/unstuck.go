@@ -0,0 +1,175 @@
+package data
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/itzmeanjan/harmony/app/config"
+)
+
+// Senders - Returns addresses of all senders having tx(s) living in
+// queued pool, in stable order
+func (q *QueuedPool) Senders() []common.Address {
+
+	v, _ := q.SendersWithContext(context.Background())
+	return v
+
+}
+
+// SendersWithContext - Context aware `Senders`
+func (q *QueuedPool) SendersWithContext(ctx context.Context) ([]common.Address, error) {
+
+	return request(ctx, q.StoppedChan, q.SendersChan, func(respChan chan []common.Address) chan []common.Address {
+		return respChan
+	})
+
+}
+
+// CheckUnstuck - Periodically checks whether nonce gap of any sender having
+// tx(s) in queued pool is closed, promoting those tx(s) to pending pool,
+// without waiting for next poll, until asked to stop
+//
+// Account nonce is fetched once per sender, not per tx, while at max
+// configured #-of senders are checked in each round. Senders are visited
+// in round-robin manner, so that all of them get checked eventually
+func (q *QueuedPool) CheckUnstuck(ctx context.Context) {
+
+	ticker := time.NewTicker(time.Duration(config.GetUnstuckCheckPeriod()) * time.Millisecond)
+	defer ticker.Stop()
+
+	budget := config.GetUnstuckCheckRPCBudget()
+	// Where to resume from, in next round
+	var cursor uint64
+
+	for {
+
+		select {
+
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+
+			start := time.Now().UTC()
+
+			senders, err := q.SendersWithContext(ctx)
+			if err != nil || len(senders) == 0 {
+				break
+			}
+
+			count := uint64(len(senders))
+			if cursor >= count {
+				cursor = 0
+			}
+
+			rounds := budget
+			if rounds > count {
+				rounds = count
+			}
+
+			var promoted uint64
+
+			for i := uint64(0); i < rounds; i++ {
+				promoted += q.promoteUnstuckFrom(ctx, senders[(cursor+i)%count])
+			}
+
+			cursor = (cursor + rounds) % count
+
+			if promoted != 0 {
+				log.Printf("[➖] Promoted %d unstuck tx(s) from queued pool, in %s\n", promoted, time.Now().UTC().Sub(start))
+			}
+
+		}
+
+	}
+
+}
+
+// promoteUnstuckFrom - Given sender, fetches its account nonce & moves all its
+// queued tx(s), up to first nonce gap, to pending pool, returning how many
+// of them got added to pending pool
+//
+// Removal from queued pool is idempotent, so tx already promoted by some other
+// worker or seen in pending pool by next poll, is simply skipped
+func (q *QueuedPool) promoteUnstuckFrom(ctx context.Context, from common.Address) uint64 {
+
+	nonce, err := AccountNonce(ctx, q.RPC, from)
+	if err != nil {
+		log.Printf("[❗️] Failed to fetch account nonce, for checking unstuck tx(s) : %s\n", err.Error())
+		return 0
+	}
+
+	// Remembered by pool, for picking tx farthest from becoming
+	// executable, when it needs to evict one
+	q.setSenderNonce(ctx, from, nonce)
+
+	// Tx(s) are ascending ordered as per nonce
+	txs, err := q.TxsFromAWithContext(ctx, from)
+	if err != nil || len(txs) == 0 {
+		return 0
+	}
+
+	var promoted uint64
+	expected := nonce
+
+	for _, tx := range txs {
+
+		if !tx.IsUnstuckAt(expected) {
+			break
+		}
+
+		if tx.Nonce == expected {
+			expected++
+		}
+
+		// Some other tx with same nonce is already mined, so it's
+		// not going to be pending ever, rather it's replaced
+		if tx.Nonce < nonce {
+			q.removeReplaced(ctx, tx.Hash)
+			continue
+		}
+
+		removed := q.Remove(ctx, tx.Hash)
+		if removed == nil {
+			continue
+		}
+
+		// Account nonce is already checked, which is what verified
+		// addition would have done, per tx
+		if q.PendingPool.AddUnstuck(ctx, removed) {
+			promoted++
+		}
+
+	}
+
+	CleanSlice(txs)
+	return promoted
+
+}
+
+// removeReplaced - Removes tx from queued pool, because some other tx from
+// same sender, with same nonce, is already mined, so that it isn't
+// published as unstuck
+func (q *QueuedPool) removeReplaced(ctx context.Context, txHash common.Hash) *MemPoolTx {
+
+	v, _ := request(ctx, q.StoppedChan, q.RemoveTxChan, func(respChan chan *MemPoolTx) RemovedUnstuckTx {
+		return RemovedUnstuckTx{Hash: txHash, Status: REPLACED, ResponseChan: respChan}
+	})
+	return v
+
+}
+
+// setSenderNonce - Lets pool remember account nonce of sender, as of latest
+// block, unless `ctx` is done or pool has stopped meanwhile
+func (q *QueuedPool) setSenderNonce(ctx context.Context, from common.Address, nonce hexutil.Uint64) {
+
+	select {
+	case <-ctx.Done():
+	case <-q.StoppedChan:
+	case q.SetSenderNonceChan <- SenderNonce{From: from, Nonce: nonce}:
+	}
+
+}
@@ -0,0 +1,57 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// Sender's queued tx(s) up to first nonce gap leave queued pool, where one
+// whose nonce is already used by some mined tx is marked replaced, only
+// executable one is marked unstuck & promoted
+func TestPromoteUnstuckFrom(t *testing.T) {
+
+	eth, client := newFakeRPC(t)
+	pool := newPrunedTestPools(t, client)
+
+	now := time.Now().UTC()
+	replaced := queuedTx(1, 1, 1, 10, now)
+	executable := queuedTx(2, 1, 2, 10, now)
+	gapped := queuedTx(3, 1, 4, 10, now)
+
+	for _, tx := range []*MemPoolTx{replaced, executable, gapped} {
+		if !pool.Queued.Add(context.Background(), tx) {
+			t.Fatalf("expected %s to be queued", tx.Hash)
+		}
+	}
+
+	eth.lock.Lock()
+	eth.nonces[txAddress(1)] = 2
+	eth.lock.Unlock()
+
+	left := make(map[byte]*MemPoolTx)
+	pool.Queued.RegisterRemoveHook(func(tx *MemPoolTx) {
+		left[tx.Hash[0]] = tx.Clone()
+	})
+
+	if promoted := pool.Queued.promoteUnstuckFrom(context.Background(), txAddress(1)); promoted != 1 {
+		t.Fatalf("expected 1 tx to be promoted, got %d", promoted)
+	}
+
+	if tx, ok := left[1]; !ok || tx.Pool != "replaced" || !tx.UnstuckAt.IsZero() {
+		t.Fatalf("expected tx with already used nonce to leave as replaced, got %+v", tx)
+	}
+
+	if tx, ok := left[2]; !ok || tx.Pool != "unstuck" || tx.UnstuckAt.IsZero() {
+		t.Fatalf("expected executable tx to leave as unstuck, got %+v", tx)
+	}
+
+	if _, ok := left[3]; ok || !pool.Queued.Exists(gapped.Hash) {
+		t.Fatal("expected tx beyond nonce gap to stay queued")
+	}
+
+	if pool.Pending.Exists(replaced.Hash) || !pool.Pending.Exists(executable.Hash) {
+		t.Fatal("expected only executable tx to reach pending pool")
+	}
+
+}
@@ -2,20 +2,211 @@
 
 package model
 
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type ConfirmationLatencyStats struct {
+	Samples    int              `json:"samples"`
+	Buckets    []*LatencyBucket `json:"buckets"`
+	ComputedAt string           `json:"computedAt"`
+}
+
+type GasPriceRecommendation struct {
+	Rapid    string `json:"rapid"`
+	Fast     string `json:"fast"`
+	Standard string `json:"standard"`
+	Slow     string `json:"slow"`
+	BaseFee  string `json:"baseFee"`
+}
+
+type GasPriceStats struct {
+	Count      int    `json:"count"`
+	Min        string `json:"min"`
+	Max        string `json:"max"`
+	Mean       string `json:"mean"`
+	P10        string `json:"p10"`
+	P25        string `json:"p25"`
+	P50        string `json:"p50"`
+	P75        string `json:"p75"`
+	P90        string `json:"p90"`
+	P99        string `json:"p99"`
+	ComputedAt string `json:"computedAt"`
+}
+
+type LatencyBucket struct {
+	Decile      int    `json:"decile"`
+	MinGasPrice string `json:"minGasPrice"`
+	MaxGasPrice string `json:"maxGasPrice"`
+	Count       int    `json:"count"`
+	P50         string `json:"p50"`
+	P90         string `json:"p90"`
+	P99         string `json:"p99"`
+}
+
+type MemPoolAggregates struct {
+	Pending *PoolAggregates `json:"pending"`
+	Queued  *PoolAggregates `json:"queued"`
+}
+
+type MemPoolStats struct {
+	Pending          *PoolStats    `json:"pending"`
+	Queued           *PoolStats    `json:"queued"`
+	Topics           []*TopicStats `json:"topics"`
+	LastPollDuration string        `json:"lastPollDuration"`
+	ComputedAt       string        `json:"computedAt"`
+}
+
 type MemPoolTx struct {
-	From         string  `json:"from"`
-	Gas          string  `json:"gas"`
-	GasPrice     string  `json:"gasPrice"`
-	GasPriceGwei float64 `json:"gasPriceGwei"`
-	Hash         string  `json:"hash"`
-	Input        string  `json:"input"`
-	Nonce        string  `json:"nonce"`
-	To           string  `json:"to"`
-	Value        string  `json:"value"`
-	V            string  `json:"v"`
-	R            string  `json:"r"`
-	S            string  `json:"s"`
-	PendingFor   string  `json:"pendingFor"`
-	QueuedFor    string  `json:"queuedFor"`
-	Pool         string  `json:"pool"`
+	From                 string  `json:"from"`
+	Gas                  string  `json:"gas"`
+	GasPrice             string  `json:"gasPrice"`
+	GasPriceGwei         float64 `json:"gasPriceGwei"`
+	MaxFeePerGas         string  `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas string  `json:"maxPriorityFeePerGas"`
+	Hash                 string  `json:"hash"`
+	Input                string  `json:"input"`
+	Nonce                string  `json:"nonce"`
+	To                   string  `json:"to"`
+	Value                string  `json:"value"`
+	V                    string  `json:"v"`
+	R                    string  `json:"r"`
+	S                    string  `json:"s"`
+	PendingFor           string  `json:"pendingFor"`
+	QueuedFor            string  `json:"queuedFor"`
+	Pool                 string  `json:"pool"`
+	Method               string  `json:"method"`
+	Cost                 string  `json:"cost"`
+	ReplacedBy           string  `json:"replacedBy"`
+	Type                 int     `json:"type"`
+	EvictionReason       string  `json:"evictionReason"`
+	Promoted             bool    `json:"promoted"`
+}
+
+type MemPoolTxConnection struct {
+	Txs         []*MemPoolTx `json:"txs"`
+	TotalCount  int          `json:"totalCount"`
+	EndCursor   string       `json:"endCursor"`
+	HasNextPage bool         `json:"hasNextPage"`
+}
+
+type MemPoolTxPage struct {
+	Txs   []*MemPoolTx `json:"txs"`
+	Total int          `json:"total"`
+}
+
+type NonceGap struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type NonceReport struct {
+	Address        string       `json:"address"`
+	ConfirmedNonce string       `json:"confirmedNonce"`
+	Pending        []string     `json:"pending"`
+	Queued         []string     `json:"queued"`
+	Missing        []*NonceGap  `json:"missing"`
+	Blocked        []*MemPoolTx `json:"blocked"`
+}
+
+type PoolAggregates struct {
+	Count             int          `json:"count"`
+	TotalGas          string       `json:"totalGas"`
+	TotalValue        string       `json:"totalValue"`
+	UniqueSenders     int          `json:"uniqueSenders"`
+	ContractCreations int          `json:"contractCreations"`
+	ByType            []*TypeCount `json:"byType"`
+	SenderEntries     int          `json:"senderEntries"`
+}
+
+type PoolStats struct {
+	Count         int    `json:"count"`
+	Limit         int    `json:"limit"`
+	UniqueSenders int    `json:"uniqueSenders"`
+	TotalGas      string `json:"totalGas"`
+	TotalValue    string `json:"totalValue"`
+	P10           string `json:"p10"`
+	P25           string `json:"p25"`
+	P50           string `json:"p50"`
+	P75           string `json:"p75"`
+	P90           string `json:"p90"`
+	P99           string `json:"p99"`
+	OldestAge     string `json:"oldestAge"`
+	Added         int    `json:"added"`
+	Removed       int    `json:"removed"`
+}
+
+type SenderCount struct {
+	Address string `json:"address"`
+	Count   int    `json:"count"`
+}
+
+type SenderGap struct {
+	Address         string   `json:"address"`
+	ExecutableNonce string   `json:"executableNonce"`
+	Queued          []string `json:"queued"`
+	BlockingNonce   string   `json:"blockingNonce"`
+	Blocked         int      `json:"blocked"`
+}
+
+type TopicStats struct {
+	Topic     string `json:"topic"`
+	Enabled   bool   `json:"enabled"`
+	Published int    `json:"published"`
+}
+
+type TxLineage struct {
+	Tx         *MemPoolTx   `json:"tx"`
+	Duplicates []*MemPoolTx `json:"duplicates"`
+	Prunables  []*MemPoolTx `json:"prunables"`
+	ReplacedBy *MemPoolTx   `json:"replacedBy"`
+	Replaces   []*MemPoolTx `json:"replaces"`
+}
+
+type TypeCount struct {
+	Type  int `json:"type"`
+	Count int `json:"count"`
+}
+
+type Order string
+
+const (
+	OrderAsc  Order = "ASC"
+	OrderDesc Order = "DESC"
+)
+
+var AllOrder = []Order{
+	OrderAsc,
+	OrderDesc,
+}
+
+func (e Order) IsValid() bool {
+	switch e {
+	case OrderAsc, OrderDesc:
+		return true
+	}
+	return false
+}
+
+func (e Order) String() string {
+	return string(e)
+}
+
+func (e *Order) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Order(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Order", str)
+	}
+	return nil
+}
+
+func (e Order) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
@@ -12,6 +12,106 @@ import (
 	"github.com/itzmeanjan/harmony/app/graph/model"
 )
 
+func (r *queryResolver) RecommendedGasPrice(ctx context.Context) (*model.GasPriceRecommendation, error) {
+	rec, err := memPool.GasPriceRecommendation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.GasPriceRecommendation{
+		Rapid:    rec.Rapid,
+		Fast:     rec.Fast,
+		Standard: rec.Standard,
+		Slow:     rec.Slow,
+		BaseFee:  rec.BaseFee,
+	}, nil
+}
+
+func (r *queryResolver) RecommendedGasPriceFor(ctx context.Context, blocks int) (string, error) {
+	if blocks <= 0 {
+		return "", errors.New("bad #-of blocks")
+	}
+
+	price, err := memPool.RecommendedGasPrice(ctx, uint64(blocks))
+	if err != nil {
+		return "", err
+	}
+
+	return price.String(), nil
+}
+
+func (r *queryResolver) PendingGasPriceStats(ctx context.Context) (*model.GasPriceStats, error) {
+	return toGraphQLGasPriceStats(memPool.PendingGasPriceStats()), nil
+}
+
+func (r *queryResolver) PoolAggregates(ctx context.Context) (*model.MemPoolAggregates, error) {
+	return toGraphQLAggregates(memPool.PoolAggregates()), nil
+}
+
+func (r *queryResolver) MemPoolStats(ctx context.Context) (*model.MemPoolStats, error) {
+	return toGraphQLStats(memPool.Stats()), nil
+}
+
+func (r *queryResolver) ConfirmationLatency(ctx context.Context) (*model.ConfirmationLatencyStats, error) {
+	return toGraphQLLatencyStats(memPool.ConfirmationLatencyStats()), nil
+}
+
+func (r *queryResolver) PendingPage(ctx context.Context, first *int, after *int, desc *bool) (*model.MemPoolTxPage, error) {
+	order, offset, limit, err := parsePage(first, after, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := memPool.PendingPageWithContext(ctx, order, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGraphQLPage(page), nil
+}
+
+func (r *queryResolver) QueuedPage(ctx context.Context, first *int, after *int, desc *bool) (*model.MemPoolTxPage, error) {
+	order, offset, limit, err := parsePage(first, after, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := memPool.QueuedPageWithContext(ctx, order, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGraphQLPage(page), nil
+}
+
+func (r *queryResolver) PendingTxs(ctx context.Context, first *int, after *string, order *model.Order) (*model.MemPoolTxConnection, error) {
+	_order, cursor, limit, err := parseCursorPage(first, after, order)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := memPool.PendingAfterWithContext(ctx, _order, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGraphQLConnection(page), nil
+}
+
+func (r *queryResolver) QueuedTxs(ctx context.Context, first *int, after *string, order *model.Order) (*model.MemPoolTxConnection, error) {
+	_order, cursor, limit, err := parseCursorPage(first, after, order)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := memPool.QueuedAfterWithContext(ctx, _order, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGraphQLConnection(page), nil
+}
+
 func (r *queryResolver) PendingForMoreThan(ctx context.Context, x string) ([]*model.MemPoolTx, error) {
 	dur, err := parseDuration(x)
 	if err != nil {
@@ -53,7 +153,20 @@ func (r *queryResolver) PendingFrom(ctx context.Context, addr string) ([]*model.
 		return nil, errors.New("invalid address")
 	}
 
-	return toGraphQL(memPool.PendingFrom(common.HexToAddress(addr))), nil
+	txs, err := memPool.PendingFromWithContext(ctx, common.HexToAddress(addr))
+	if err != nil {
+		return nil, err
+	}
+
+	return toGraphQL(txs), nil
+}
+
+func (r *queryResolver) PendingCountFrom(ctx context.Context, addr string) (int, error) {
+	if !checkAddress(addr) {
+		return 0, errors.New("invalid address")
+	}
+
+	return int(memPool.PendingCountFrom(common.HexToAddress(addr))), nil
 }
 
 func (r *queryResolver) PendingTo(ctx context.Context, addr string) ([]*model.MemPoolTx, error) {
@@ -64,12 +177,94 @@ func (r *queryResolver) PendingTo(ctx context.Context, addr string) ([]*model.Me
 	return toGraphQL(memPool.PendingTo(common.HexToAddress(addr))), nil
 }
 
+func (r *queryResolver) PendingToWithMethod(ctx context.Context, addr string, method string) ([]*model.MemPoolTx, error) {
+	if !checkAddress(addr) {
+		return nil, errors.New("invalid address")
+	}
+
+	if !checkSelector(method) {
+		return nil, errors.New("invalid method selector")
+	}
+
+	var selector [4]byte
+	copy(selector[:], common.FromHex(method))
+
+	return toGraphQL(memPool.PendingToWithMethod(common.HexToAddress(addr), selector)), nil
+}
+
+func (r *queryResolver) PendingContractCreations(ctx context.Context) ([]*model.MemPoolTx, error) {
+	return toGraphQL(memPool.PendingContractCreations()), nil
+}
+
+func (r *queryResolver) PendingOfType(ctx context.Context, typeArg int) ([]*model.MemPoolTx, error) {
+	if typeArg < 0 {
+		return nil, errors.New("bad tx type")
+	}
+
+	return toGraphQL(memPool.PendingOfType(uint64(typeArg))), nil
+}
+
+func (r *queryResolver) PendingStuck(ctx context.Context) ([]*model.MemPoolTx, error) {
+	return toGraphQL(memPool.PendingStuck()), nil
+}
+
 func (r *queryResolver) QueuedFrom(ctx context.Context, addr string) ([]*model.MemPoolTx, error) {
 	if !checkAddress(addr) {
 		return nil, errors.New("invalid address")
 	}
 
-	return toGraphQL(memPool.QueuedFrom(common.HexToAddress(addr))), nil
+	txs, err := memPool.QueuedFromWithContext(ctx, common.HexToAddress(addr))
+	if err != nil {
+		return nil, err
+	}
+
+	return toGraphQL(txs), nil
+}
+
+func (r *queryResolver) QueuedCountFrom(ctx context.Context, addr string) (int, error) {
+	if !checkAddress(addr) {
+		return 0, errors.New("invalid address")
+	}
+
+	return int(memPool.QueuedCountFrom(common.HexToAddress(addr))), nil
+}
+
+func (r *queryResolver) QueuedOfType(ctx context.Context, typeArg int) ([]*model.MemPoolTx, error) {
+	if typeArg < 0 {
+		return nil, errors.New("bad tx type")
+	}
+
+	return toGraphQL(memPool.QueuedOfType(uint64(typeArg))), nil
+}
+
+func (r *queryResolver) NonceReport(ctx context.Context, addr string) (*model.NonceReport, error) {
+	if !checkAddress(addr) {
+		return nil, errors.New("invalid address")
+	}
+
+	report, err := memPool.NonceReport(ctx, common.HexToAddress(addr))
+	if err != nil {
+		return nil, err
+	}
+
+	return toGraphQLNonceReport(report), nil
+}
+
+func (r *queryResolver) AllFrom(ctx context.Context, addr string) ([]*model.MemPoolTx, error) {
+	if !checkAddress(addr) {
+		return nil, errors.New("invalid address")
+	}
+
+	return toGraphQL(memPool.AllTxsFrom(common.HexToAddress(addr))), nil
+}
+
+func (r *queryResolver) QueuedGapReport(ctx context.Context) ([]*model.SenderGap, error) {
+	gaps, err := memPool.QueuedGapReport(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGraphQLSenderGaps(gaps), nil
 }
 
 func (r *queryResolver) QueuedTo(ctx context.Context, addr string) ([]*model.MemPoolTx, error) {
@@ -104,6 +299,14 @@ func (r *queryResolver) TopXPendingWithLowGasPrice(ctx context.Context, x int) (
 	return toGraphQL(memPool.TopXPendingWithLowGasPrice(uint64(x))), nil
 }
 
+func (r *queryResolver) TopXPendingByCost(ctx context.Context, x int) ([]*model.MemPoolTx, error) {
+	if x <= 0 {
+		return nil, errors.New("bad argument")
+	}
+
+	return toGraphQL(memPool.TopXPendingByCost(uint64(x))), nil
+}
+
 func (r *queryResolver) TopXQueuedWithLowGasPrice(ctx context.Context, x int) ([]*model.MemPoolTx, error) {
 	if x <= 0 {
 		return nil, errors.New("bad argument")
@@ -112,6 +315,30 @@ func (r *queryResolver) TopXQueuedWithLowGasPrice(ctx context.Context, x int) ([
 	return toGraphQL(memPool.TopXQueuedWithLowGasPrice(uint64(x))), nil
 }
 
+func (r *queryResolver) TopXLongestQueued(ctx context.Context, x int) ([]*model.MemPoolTx, error) {
+	if x <= 0 {
+		return nil, errors.New("bad argument")
+	}
+
+	return toGraphQL(memPool.TopXLongestQueued(uint64(x))), nil
+}
+
+func (r *queryResolver) TopXPendingSenders(ctx context.Context, x int) ([]*model.SenderCount, error) {
+	if x <= 0 {
+		return nil, errors.New("bad argument")
+	}
+
+	return toGraphQLSenders(memPool.TopXPendingSenders(uint64(x))), nil
+}
+
+func (r *queryResolver) TopXQueuedSenders(ctx context.Context, x int) ([]*model.SenderCount, error) {
+	if x <= 0 {
+		return nil, errors.New("bad argument")
+	}
+
+	return toGraphQLSenders(memPool.TopXQueuedSenders(uint64(x))), nil
+}
+
 func (r *queryResolver) PendingDuplicates(ctx context.Context, hash string) ([]*model.MemPoolTx, error) {
 	if !checkHash(hash) {
 		return nil, errors.New("invalid txHash")
@@ -120,6 +347,47 @@ func (r *queryResolver) PendingDuplicates(ctx context.Context, hash string) ([]*
 	return toGraphQL(memPool.PendingDuplicates(common.HexToHash(hash))), nil
 }
 
+func (r *queryResolver) PendingReplacementsOf(ctx context.Context, hash string) ([]*model.MemPoolTx, error) {
+	if !checkHash(hash) {
+		return nil, errors.New("invalid txHash")
+	}
+
+	return toGraphQL(memPool.PendingReplacementsOf(common.HexToHash(hash))), nil
+}
+
+func (r *queryResolver) Duplicates(ctx context.Context, hash string) ([]*model.MemPoolTx, error) {
+	if !checkHash(hash) {
+		return nil, errors.New("invalid txHash")
+	}
+
+	return toGraphQL(memPool.Duplicates(common.HexToHash(hash))), nil
+}
+
+func (r *queryResolver) TxByNonce(ctx context.Context, from string, nonce int) ([]*model.MemPoolTx, error) {
+	if !checkAddress(from) {
+		return nil, errors.New("invalid address")
+	}
+
+	if nonce < 0 {
+		return nil, errors.New("invalid nonce")
+	}
+
+	return toGraphQL(memPool.TxsByNonce(common.HexToAddress(from), uint64(nonce))), nil
+}
+
+func (r *queryResolver) TxLineage(ctx context.Context, hash string) (*model.TxLineage, error) {
+	if !checkHash(hash) {
+		return nil, errors.New("invalid txHash")
+	}
+
+	lineage := memPool.Lineage(common.HexToHash(hash))
+	if lineage == nil {
+		return nil, errors.New("tx not found")
+	}
+
+	return toGraphQLLineage(lineage), nil
+}
+
 func (r *queryResolver) QueuedDuplicates(ctx context.Context, hash string) ([]*model.MemPoolTx, error) {
 	if !checkHash(hash) {
 		return nil, errors.New("invalid txHash")
@@ -144,6 +412,24 @@ func (r *queryResolver) PendingWithLessThan(ctx context.Context, x float64) ([]*
 	return toGraphQL(memPool.PendingWithLTE(x)), nil
 }
 
+func (r *queryResolver) PendingWithValueGTE(ctx context.Context, x string) ([]*model.MemPoolTx, error) {
+	amount, ok := parseAmount(x)
+	if !ok {
+		return nil, errors.New("bad value ( in wei )")
+	}
+
+	return toGraphQL(memPool.PendingWithValueGTE(amount)), nil
+}
+
+func (r *queryResolver) PendingWithGasPriceBetween(ctx context.Context, low *string, high *string) ([]*model.MemPoolTx, error) {
+	_low, _high, err := parseGasPriceRange(low, high)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGraphQL(memPool.PendingWithGasPriceBetween(_low, _high)), nil
+}
+
 func (r *queryResolver) QueuedWithMoreThan(ctx context.Context, x float64) ([]*model.MemPoolTx, error) {
 	if !(x >= 0) {
 		return nil, errors.New("bad gas price ( in Gwei )")
@@ -160,6 +446,24 @@ func (r *queryResolver) QueuedWithLessThan(ctx context.Context, x float64) ([]*m
 	return toGraphQL(memPool.QueuedWithLTE(x)), nil
 }
 
+func (r *queryResolver) QueuedWithValueGTE(ctx context.Context, x string) ([]*model.MemPoolTx, error) {
+	amount, ok := parseAmount(x)
+	if !ok {
+		return nil, errors.New("bad value ( in wei )")
+	}
+
+	return toGraphQL(memPool.QueuedWithValueGTE(amount)), nil
+}
+
+func (r *queryResolver) QueuedWithGasPriceBetween(ctx context.Context, low *string, high *string) ([]*model.MemPoolTx, error) {
+	_low, _high, err := parseGasPriceRange(low, high)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGraphQL(memPool.QueuedWithGasPriceBetween(_low, _high)), nil
+}
+
 func (r *subscriptionResolver) NewPendingTx(ctx context.Context) (<-chan *model.MemPoolTx, error) {
 	_pubsub, err := SubscribeToPendingTxEntry(ctx)
 	if err != nil {
@@ -360,6 +664,26 @@ func (r *subscriptionResolver) NewTxFromAInMemPool(ctx context.Context, address
 	return comm, nil
 }
 
+func (r *subscriptionResolver) MemPoolFrom(ctx context.Context, address []string) (<-chan *model.MemPoolTx, error) {
+	addrs, err := parseAddresses(address)
+	if err != nil {
+		return nil, err
+	}
+
+	_pubsub, err := SubscribeToMemPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	comm := make(chan *model.MemPoolTx, 4)
+	// Only tx(s) sent from any of these addresses, entering/ leaving
+	// mem pool, are delivered, where subscription gets torn down as soon
+	// as client goes away
+	go ListenToMessages(ctx, _pubsub, comm, CheckFromAnyOf, addrs)
+
+	return comm, nil
+}
+
 func (r *subscriptionResolver) NewPendingTxTo(ctx context.Context, address string) (<-chan *model.MemPoolTx, error) {
 	if !checkAddress(address) {
 		return nil, errors.New("invalid address")
@@ -472,17 +796,41 @@ func (r *subscriptionResolver) NewTxToAInMemPool(ctx context.Context, address st
 	return comm, nil
 }
 
+func (r *subscriptionResolver) MemPoolTo(ctx context.Context, address []string) (<-chan *model.MemPoolTx, error) {
+	addrs, err := parseAddresses(address)
+	if err != nil {
+		return nil, err
+	}
+
+	_pubsub, err := SubscribeToMemPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	comm := make(chan *model.MemPoolTx, 4)
+	// Only tx(s) sent to any of these addresses, entering/ leaving
+	// mem pool, are delivered, where subscription gets torn down as soon
+	// as client goes away
+	go ListenToMessages(ctx, _pubsub, comm, CheckToAnyOf, addrs)
+
+	return comm, nil
+}
+
 func (r *subscriptionResolver) WatchTx(ctx context.Context, hash string) (<-chan *model.MemPoolTx, error) {
 	if !checkHash(hash) {
 		return nil, errors.New("invalid txHash")
 	}
 
-	tx := memPool.Get(common.HexToHash(hash))
+	tx, err := memPool.GetWithContext(ctx, common.HexToHash(hash))
+	if err != nil {
+		return nil, err
+	}
+
 	if tx == nil {
 		return nil, errors.New("tx not in mempool")
 	}
 
-	_pubsub, err := SubscribeToMemPool(ctx)
+	_pubsub, err := SubscribeToTxUpdates(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -38,6 +38,25 @@ func CheckFromAddress(m *data.MemPoolTx, params ...interface{}) bool {
 
 }
 
+// CheckFromAnyOf - Checks whether `from` address of tx is any of given set of
+// addresses, so that client watching multiple senders, over single subscription,
+// is only notified about their tx(s)
+func CheckFromAnyOf(m *data.MemPoolTx, params ...interface{}) bool {
+
+	if len(params) != 1 {
+		return false
+	}
+
+	// Attempting to assert type
+	addrs, ok := params[0].(map[common.Address]bool)
+	if !ok {
+		return false
+	}
+
+	return addrs[m.From]
+
+}
+
 // CheckToAddress - Just checks `to` address of tx, so that client
 // is only notified when tx `to` that address is detected to be entering/ leaving
 // mempool
@@ -67,6 +86,31 @@ func CheckToAddress(m *data.MemPoolTx, params ...interface{}) bool {
 
 }
 
+// CheckToAnyOf - Checks whether `to` address of tx is any of given set of
+// addresses, so that client watching multiple contracts, over single
+// subscription, is only notified about tx(s) interacting with them
+func CheckToAnyOf(m *data.MemPoolTx, params ...interface{}) bool {
+
+	if len(params) != 1 {
+		return false
+	}
+
+	// Attempting to assert type
+	addrs, ok := params[0].(map[common.Address]bool)
+	if !ok {
+		return false
+	}
+
+	// Contract creation tx(s) don't have `to` address,
+	// so they never match
+	if m.To == nil {
+		return false
+	}
+
+	return addrs[*m.To]
+
+}
+
 // LinkedTx - Given a tx in mempool, which we're tracking, will be matched
 // against before deciding whether just received tx is somehow associated with it or not
 //
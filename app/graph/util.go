@@ -4,9 +4,14 @@ import (
 	"context"
 	"errors"
 	"log"
+	"math/big"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/itzmeanjan/harmony/app/config"
 	"github.com/itzmeanjan/harmony/app/data"
 	"github.com/itzmeanjan/harmony/app/graph/model"
@@ -15,6 +20,7 @@ import (
 )
 
 var memPool *data.MemPool
+var codec data.Codec
 var parentCtx context.Context
 
 // InitMemPool - Initializing mempool handle, in this module
@@ -28,6 +34,17 @@ func InitMemPool(pool *data.MemPool) error {
 	return errors.New("bad mempool received in graphQL handler")
 }
 
+// InitCodec - Initializing codec handle, to be used for deserializing
+// tx(s) received over pubsub topics
+func InitCodec(_codec data.Codec) error {
+	if _codec != nil {
+		codec = _codec
+		return nil
+	}
+
+	return errors.New("bad codec received in graphQL handler")
+}
+
 // InitParentContext - Initializing parent context, to be listened by all
 // graphQL subscribers so that graceful shutdown can be done
 func InitParentContext(ctx context.Context) {
@@ -49,6 +66,278 @@ func toGraphQL(txs []*data.MemPoolTx) []*model.MemPoolTx {
 
 }
 
+// Given window of mempool tx(s), convert it to compatible
+// graphql page, carrying total #-of tx(s) in pool
+func toGraphQLPage(page data.TxPage) *model.MemPoolTxPage {
+
+	return &model.MemPoolTxPage{
+		Txs:   toGraphQL(page.Txs),
+		Total: int(page.Total),
+	}
+
+}
+
+// Converts tx, along with tx(s) it's related to, to graphQL compatible
+// data structure, where tx replacing it may not be known anymore
+func toGraphQLLineage(lineage *data.TxLineage) *model.TxLineage {
+
+	var replacedBy *model.MemPoolTx
+	if lineage.ReplacedBy != nil {
+		replacedBy = lineage.ReplacedBy.ToGraphQL()
+	}
+
+	return &model.TxLineage{
+		Tx:         lineage.Tx.ToGraphQL(),
+		Duplicates: toGraphQL(lineage.Duplicates),
+		Prunables:  toGraphQL(lineage.Prunables),
+		ReplacedBy: replacedBy,
+		Replaces:   toGraphQL(lineage.Replaces),
+	}
+
+}
+
+// Attempts to parse pagination arguments, obtained from user query,
+// where absent `first` denotes all tx(s) after `after`
+func parsePage(first *int, after *int, desc *bool) (int, uint64, uint64, error) {
+
+	order := data.ASC
+	if desc != nil && *desc {
+		order = data.DESC
+	}
+
+	var offset, limit uint64
+
+	if after != nil {
+		if *after < 0 {
+			return 0, 0, 0, errors.New("bad offset to paginate from")
+		}
+
+		offset = uint64(*after)
+	}
+
+	if first != nil {
+		if *first <= 0 {
+			return 0, 0, 0, errors.New("bad page size")
+		}
+
+		limit = uint64(*first)
+	}
+
+	return order, offset, limit, nil
+
+}
+
+// Given window of mempool tx(s), convert it to compatible graphql
+// connection, where cursor of last tx is to be passed as `after`,
+// for fetching next window
+func toGraphQLConnection(page data.TxPage) *model.MemPoolTxConnection {
+
+	var endCursor string
+	if len(page.Txs) != 0 {
+		endCursor = page.Txs[len(page.Txs)-1].Hash.Hex()
+	}
+
+	return &model.MemPoolTxConnection{
+		HasNextPage: page.Offset+uint64(len(page.Txs)) < page.Total,
+		EndCursor:   endCursor,
+		TotalCount:  int(page.Total),
+		Txs:         toGraphQL(page.Txs),
+	}
+
+}
+
+// Attempts to parse cursor based pagination arguments, obtained from
+// user query, where absent `first` denotes all tx(s) after cursor &
+// absent cursor denotes very beginning
+func parseCursorPage(first *int, after *string, order *model.Order) (int, *common.Hash, uint64, error) {
+
+	_order := data.ASC
+	if order != nil && *order == model.OrderDesc {
+		_order = data.DESC
+	}
+
+	var cursor *common.Hash
+
+	if after != nil {
+		if !checkHash(*after) {
+			return 0, nil, 0, errors.New("bad cursor to paginate from")
+		}
+
+		hash := common.HexToHash(*after)
+		cursor = &hash
+	}
+
+	var limit uint64
+
+	if first != nil {
+		if *first <= 0 {
+			return 0, nil, 0, errors.New("bad page size")
+		}
+
+		limit = uint64(*first)
+	}
+
+	return _order, cursor, limit, nil
+
+}
+
+// Given nonce report of sender, convert it to compatible
+// graphql form, where nonces are decimal encoded
+func toGraphQLNonceReport(report *data.NonceReport) *model.NonceReport {
+
+	toDecimal := func(nonces []hexutil.Uint64) []string {
+		res := make([]string, 0, len(nonces))
+		for _, nonce := range nonces {
+			res = append(res, data.HexToDecimal(nonce))
+		}
+
+		return res
+	}
+
+	missing := make([]*model.NonceGap, 0, len(report.Missing))
+	for _, gap := range report.Missing {
+		missing = append(missing, &model.NonceGap{
+			From: data.HexToDecimal(gap.From),
+			To:   data.HexToDecimal(gap.To),
+		})
+	}
+
+	return &model.NonceReport{
+		Address:        report.Address.Hex(),
+		ConfirmedNonce: data.HexToDecimal(report.ConfirmedNonce),
+		Pending:        toDecimal(report.Pending),
+		Queued:         toDecimal(report.Queued),
+		Missing:        missing,
+		Blocked:        toGraphQL(report.Blocked),
+	}
+
+}
+
+// Given per sender nonce gaps of queued pool, convert those to
+// compatible graphql form, where nonces are decimal encoded
+func toGraphQLSenderGaps(gaps []*data.SenderGap) []*model.SenderGap {
+
+	res := make([]*model.SenderGap, 0, len(gaps))
+
+	for _, gap := range gaps {
+
+		queued := make([]string, 0, len(gap.Queued))
+		for _, nonce := range gap.Queued {
+			queued = append(queued, data.HexToDecimal(nonce))
+		}
+
+		res = append(res, &model.SenderGap{
+			Address:         gap.Address.Hex(),
+			ExecutableNonce: data.HexToDecimal(gap.ExecutableNonce),
+			Queued:          queued,
+			BlockingNonce:   data.HexToDecimal(gap.BlockingNonce),
+			Blocked:         int(gap.Blocked),
+		})
+
+	}
+
+	return res
+
+}
+
+// Given gas price distribution of pool, convert it to compatible
+// graphql form, where prices are decimal encoded wei amounts
+func toGraphQLGasPriceStats(stats data.GasPriceStats) *model.GasPriceStats {
+
+	toDecimal := func(v *big.Int) string {
+		if v == nil {
+			return "0"
+		}
+
+		return v.String()
+	}
+
+	return &model.GasPriceStats{
+		Count:      int(stats.Count),
+		Min:        toDecimal(stats.Min),
+		Max:        toDecimal(stats.Max),
+		Mean:       toDecimal(stats.Mean),
+		P10:        toDecimal(stats.P10),
+		P25:        toDecimal(stats.P25),
+		P50:        toDecimal(stats.P50),
+		P75:        toDecimal(stats.P75),
+		P90:        toDecimal(stats.P90),
+		P99:        toDecimal(stats.P99),
+		ComputedAt: stats.ComputedAt.Format(time.RFC3339),
+	}
+
+}
+
+// Converting running totals over tx(s) living in pools, to graphQL
+// compatible type, where big amounts are decimal encoded
+func toGraphQLAggregates(aggregates data.MemPoolAggregates) *model.MemPoolAggregates {
+
+	convert := func(a data.PoolAggregates) *model.PoolAggregates {
+		byType := make([]*model.TypeCount, 0, len(a.ByType))
+		for _, t := range a.Types() {
+			byType = append(byType, &model.TypeCount{Type: int(t), Count: int(a.ByType[t])})
+		}
+
+		return &model.PoolAggregates{
+			Count:             int(a.Count),
+			TotalGas:          strconv.FormatUint(a.TotalGas, 10),
+			TotalValue:        a.TotalValue.String(),
+			UniqueSenders:     int(a.UniqueSenders),
+			ContractCreations: int(a.ContractCreations),
+			ByType:            byType,
+			SenderEntries:     int(a.SenderEntries),
+		}
+	}
+
+	return &model.MemPoolAggregates{
+		Pending: convert(aggregates.Pending),
+		Queued:  convert(aggregates.Queued),
+	}
+
+}
+
+// Converting confirmation latency distribution to graphQL compatible
+// type, where prices are decimal encoded wei amounts
+func toGraphQLLatencyStats(stats data.ConfirmationLatencyStats) *model.ConfirmationLatencyStats {
+
+	buckets := make([]*model.LatencyBucket, 0, len(stats.Buckets))
+
+	for _, b := range stats.Buckets {
+		buckets = append(buckets, &model.LatencyBucket{
+			Decile:      int(b.Decile),
+			MinGasPrice: b.MinGasPrice.String(),
+			MaxGasPrice: b.MaxGasPrice.String(),
+			Count:       int(b.Count),
+			P50:         b.P50.String(),
+			P90:         b.P90.String(),
+			P99:         b.P99.String(),
+		})
+	}
+
+	return &model.ConfirmationLatencyStats{
+		Samples:    int(stats.Samples),
+		Buckets:    buckets,
+		ComputedAt: stats.ComputedAt.Format(time.RFC3339),
+	}
+
+}
+
+// Converting senders, having most tx(s) in pool, to graphQL compatible type
+func toGraphQLSenders(senders []data.SenderCount) []*model.SenderCount {
+
+	res := make([]*model.SenderCount, 0, len(senders))
+
+	for _, s := range senders {
+		res = append(res, &model.SenderCount{
+			Address: s.Address.Hex(),
+			Count:   int(s.Count),
+		})
+	}
+
+	return res
+
+}
+
 // Attempts to parse duration, obtained from user query
 func parseDuration(d string) (time.Duration, error) {
 
@@ -76,6 +365,146 @@ func checkAddress(address string) bool {
 
 }
 
+// Attempts to parse all addresses, obtained from user query, as
+// set, where at least one address must be present
+//
+// Addresses are compared as bytes, so case of hex digits doesn't matter
+func parseAddresses(addresses []string) (map[common.Address]bool, error) {
+
+	if len(addresses) == 0 {
+		return nil, errors.New("no address given")
+	}
+
+	set := make(map[common.Address]bool, len(addresses))
+
+	for _, address := range addresses {
+
+		if !checkAddress(address) {
+			return nil, errors.New("invalid address")
+		}
+
+		set[common.HexToAddress(address)] = true
+
+	}
+
+	return set, nil
+
+}
+
+// Parses amount in wei, given either as hex ( 0x prefixed ) or
+// decimal string, rejecting negative ones
+func parseAmount(amount string) (*big.Int, bool) {
+
+	var (
+		num *big.Int
+		ok  bool
+	)
+
+	if strings.HasPrefix(amount, "0x") || strings.HasPrefix(amount, "0X") {
+
+		_num, err := hexutil.DecodeBig(amount)
+		num, ok = _num, err == nil
+
+	} else {
+
+		num, ok = new(big.Int).SetString(amount, 10)
+
+	}
+
+	if !ok || num.Sign() < 0 {
+		return nil, false
+	}
+
+	return num, true
+
+}
+
+// Parses optional lower & upper bounds of gas price range in wei, where
+// absent one denotes open-ended range, rejecting when lower one exceeds upper
+func parseGasPriceRange(low *string, high *string) (*big.Int, *big.Int, error) {
+
+	var _low, _high *big.Int
+
+	if low != nil {
+
+		v, ok := parseAmount(*low)
+		if !ok {
+			return nil, nil, errors.New("bad lower bound of gas price ( in wei )")
+		}
+
+		_low = v
+
+	}
+
+	if high != nil {
+
+		v, ok := parseAmount(*high)
+		if !ok {
+			return nil, nil, errors.New("bad upper bound of gas price ( in wei )")
+		}
+
+		_high = v
+
+	}
+
+	if _low != nil && _high != nil && _low.Cmp(_high) > 0 {
+		return nil, nil, errors.New("lower bound of gas price exceeds upper bound")
+	}
+
+	return _low, _high, nil
+
+}
+
+// Converts composition of pending & queued pools to graphQL compatible
+// data structure, where gas price percentiles of empty pool are zero
+func toGraphQLStats(stats data.MemPoolStats) *model.MemPoolStats {
+
+	toDecimal := func(v *big.Int) string {
+		if v == nil {
+			return "0"
+		}
+
+		return v.String()
+	}
+
+	convert := func(s data.PoolStats) *model.PoolStats {
+		return &model.PoolStats{
+			Count:         int(s.Count),
+			Limit:         int(s.Limit),
+			UniqueSenders: int(s.UniqueSenders),
+			TotalGas:      strconv.FormatUint(s.TotalGas, 10),
+			TotalValue:    toDecimal(s.TotalValue),
+			P10:           toDecimal(s.P10),
+			P25:           toDecimal(s.P25),
+			P50:           toDecimal(s.P50),
+			P75:           toDecimal(s.P75),
+			P90:           toDecimal(s.P90),
+			P99:           toDecimal(s.P99),
+			OldestAge:     s.OldestAge.String(),
+			Added:         int(s.Added),
+			Removed:       int(s.Removed),
+		}
+	}
+
+	topics := make([]*model.TopicStats, 0, len(stats.Topics))
+	for _, t := range stats.Topics {
+		topics = append(topics, &model.TopicStats{
+			Topic:     t.Topic,
+			Enabled:   t.Enabled,
+			Published: int(t.Published),
+		})
+	}
+
+	return &model.MemPoolStats{
+		Pending:          convert(stats.Pending),
+		Queued:           convert(stats.Queued),
+		Topics:           topics,
+		LastPollDuration: stats.LastPollDuration.String(),
+		ComputedAt:       stats.ComputedAt.Format(time.RFC3339),
+	}
+
+}
+
 // Checks whether received string is valid txHash or not
 func checkHash(hash string) bool {
 
@@ -91,6 +520,21 @@ func checkHash(hash string) bool {
 
 }
 
+// Checks whether received string is valid 4-byte method selector or not
+func checkSelector(selector string) bool {
+
+	reg, err := regexp.Compile(`^(0x[0-9a-fA-F]{8})$`)
+	if err != nil {
+
+		log.Printf("[❗️] Failed to compile regular expression : %s\n", err.Error())
+		return false
+
+	}
+
+	return reg.MatchString(selector)
+
+}
+
 // SubscribeToTopic - Subscribes to PubSub topic(s), while configuring subscription such
 // that at max 256 messages can be kept in buffer at a time. If client is consuming slowly
 // buffer size will be extended.
@@ -138,6 +582,19 @@ func SubscribeToMemPool(ctx context.Context) (*subscriber.Subscriber, error) {
 		config.GetPendingTxExitPublishTopic())
 }
 
+// SubscribeToTxUpdates - Subscribes to all mempool topics, along with
+// topic where pending tx(s), replaced by fee bumped ones, are published
+//
+// Helpful when watching lifecycle of specific tx
+func SubscribeToTxUpdates(ctx context.Context) (*subscriber.Subscriber, error) {
+	return SubscribeToTopic(ctx,
+		config.GetQueuedTxEntryPublishTopic(),
+		config.GetQueuedTxExitPublishTopic(),
+		config.GetPendingTxEntryPublishTopic(),
+		config.GetPendingTxExitPublishTopic(),
+		config.GetPendingTxReplacementPublishTopic())
+}
+
 // SubscribeToPendingTxEntry - Subscribe to topic where new pending tx(s)
 // are published
 func SubscribeToPendingTxEntry(ctx context.Context) (*subscriber.Subscriber, error) {
@@ -162,6 +619,16 @@ func SubscribeToQueuedTxExit(ctx context.Context) (*subscriber.Subscriber, error
 	return SubscribeToTopic(ctx, config.GetQueuedTxExitPublishTopic())
 }
 
+// MessageSource - Subscription to Pub/Sub topic(s), messages are consumed
+// from, which is what pub0sub subscriber does
+type MessageSource interface {
+	Watch() chan struct{}
+	Queued() bool
+	Next() *ops.PushedMessage
+	UnsubscribeAll() (uint32, error)
+	Disconnect() error
+}
+
 // ListenToMessages - Attempts to listen to messages being published
 // on topic to which graphQL client has subscribed to over websocket transport
 //
@@ -173,7 +640,7 @@ func SubscribeToQueuedTxExit(ctx context.Context) (*subscriber.Subscriber, error
 //
 // You can always blindly return `true` in your `evaluationCriteria` function,
 // so that you get to receive any tx being published on topic of your interest
-func ListenToMessages(ctx context.Context, subscriber *subscriber.Subscriber, comm chan<- *model.MemPoolTx, pubCriteria PublishingCriteria, params ...interface{}) {
+func ListenToMessages(ctx context.Context, subscriber MessageSource, comm chan<- *model.MemPoolTx, pubCriteria PublishingCriteria, params ...interface{}) {
 
 	defer func() {
 		if err := subscriber.Disconnect(); err != nil {
@@ -183,22 +650,31 @@ func ListenToMessages(ctx context.Context, subscriber *subscriber.Subscriber, co
 	}()
 
 	consume := func(msg *ops.PushedMessage) {
-		unmarshalled := UnmarshalPubSubMessage(msg.Data)
-		if unmarshalled == nil || !pubCriteria(unmarshalled, params...) {
+		unmarshalled, err := UnmarshalPubSubMessage(msg.Data)
+		if err != nil {
+			log.Printf("[❗️] Failed to deserialise pubsub message : %s\n", err.Error())
 			return
 		}
 
-		// Only publish non-nil data i.e. if (de)-serialisation
-		// fails some how, it's better to send nothing, rather than
-		// sending client `nil`
-		if sendable := unmarshalled.ToGraphQL(); sendable != nil {
-			// Client must be ready to accept message
-			// It doesn't block if it finds not enough buffer
-			// space available in channel between
-			// server & subscriber ( graphql client )
-			if len(comm) < cap(comm) {
-				comm <- sendable
+		for _, tx := range unmarshalled {
+
+			if !pubCriteria(tx, params...) {
+				continue
+			}
+
+			// Only publish non-nil data i.e. if (de)-serialisation
+			// fails some how, it's better to send nothing, rather than
+			// sending client `nil`
+			if sendable := tx.ToGraphQL(); sendable != nil {
+				// Client must be ready to accept message
+				// It doesn't block if it finds not enough buffer
+				// space available in channel between
+				// server & subscriber ( graphql client )
+				if len(comm) < cap(comm) {
+					comm <- sendable
+				}
 			}
+
 		}
 	}
 	duration := time.Duration(256) * time.Millisecond
@@ -239,7 +715,7 @@ func ListenToMessages(ctx context.Context, subscriber *subscriber.Subscriber, co
 				}
 
 				started := time.Now()
-				for received := subscriber.Next(); received != nil; {
+				for received := subscriber.Next(); received != nil; received = subscriber.Next() {
 					consume(received)
 
 					if time.Since(started) > duration {
@@ -253,15 +729,23 @@ func ListenToMessages(ctx context.Context, subscriber *subscriber.Subscriber, co
 
 }
 
-// UnmarshalPubSubMessage - Attempts to unmarshal message pack serialized
-// pubsub message as structured tx data, which is to be sent to subscriber
-func UnmarshalPubSubMessage(message []byte) *data.MemPoolTx {
+// UnmarshalPubSubMessage - Attempts to unmarshal pubsub message, serialized
+// using configured codec, as structured tx data, which is to be sent to subscriber
+//
+// @note Message can carry either single tx or batch of tx(s), which
+// might be snappy compressed, if it was large
+func UnmarshalPubSubMessage(message []byte) ([]*data.MemPoolTx, error) {
 
-	_message, err := data.FromMessagePack(message)
+	message, err := data.MaybeDecompress(message)
 	if err != nil {
-		return nil
+		return nil, err
+	}
+
+	_message, err := data.DeserializeMany(codec, message)
+	if err != nil {
+		return nil, err
 	}
 
-	return _message
+	return _message, nil
 
 }
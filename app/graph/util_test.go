@@ -0,0 +1,230 @@
+package graph
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/itzmeanjan/harmony/app/data"
+	"github.com/itzmeanjan/harmony/app/graph/model"
+	"github.com/itzmeanjan/pub0sub/ops"
+)
+
+// fakeSubscriber - In-memory stand in for Pub/Sub subscription, where
+// published messages are queued up, until consumed
+type fakeSubscriber struct {
+	lock         sync.Mutex
+	queue        []*ops.PushedMessage
+	watch        chan struct{}
+	unsubscribed bool
+	disconnected bool
+}
+
+func newFakeSubscriber() *fakeSubscriber {
+	return &fakeSubscriber{watch: make(chan struct{}, 1)}
+}
+
+// publish - Serializes tx(s) as one message & queues it up, signalling
+// availability, same as it's done when message is received from hub
+func (f *fakeSubscriber) publish(tb testing.TB, topic string, txs ...*data.MemPoolTx) {
+	tb.Helper()
+
+	msg, err := data.SerializeMany(codec, txs)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	f.lock.Lock()
+	f.queue = append(f.queue, &ops.PushedMessage{Topic: topic, Data: msg})
+	f.lock.Unlock()
+
+	select {
+	case f.watch <- struct{}{}:
+	default:
+	}
+}
+
+func (f *fakeSubscriber) Watch() chan struct{} {
+	return f.watch
+}
+
+func (f *fakeSubscriber) Queued() bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return len(f.queue) != 0
+}
+
+func (f *fakeSubscriber) Next() *ops.PushedMessage {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if len(f.queue) == 0 {
+		return nil
+	}
+
+	msg := f.queue[0]
+	f.queue = f.queue[1:]
+	return msg
+}
+
+func (f *fakeSubscriber) UnsubscribeAll() (uint32, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.unsubscribed = true
+	return 0, nil
+}
+
+func (f *fakeSubscriber) Disconnect() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.disconnected = true
+	return nil
+}
+
+// withCodec - Runs test with message pack codec & parent context, which
+// never gets cancelled, as listeners expect
+func withCodec(tb testing.TB) {
+	tb.Helper()
+
+	previous, previousCtx := codec, parentCtx
+	codec, parentCtx = data.NewCodec("msgpack"), context.Background()
+
+	tb.Cleanup(func() {
+		codec, parentCtx = previous, previousCtx
+	})
+}
+
+// pendingTx - Pending tx with given nonce, sent to given address, where
+// nil address denotes contract deployment
+func pendingTx(nonce uint64, to *common.Address) *data.MemPoolTx {
+	return &data.MemPoolTx{
+		Hash:        common.BigToHash(big.NewInt(int64(nonce) + 1)),
+		From:        common.HexToAddress("0x1000000000000000000000000000000000000001"),
+		To:          to,
+		Nonce:       hexutil.Uint64(nonce),
+		Gas:         21000,
+		GasPrice:    (*hexutil.Big)(big.NewInt(1_000_000_000)),
+		Value:       (*hexutil.Big)(big.NewInt(0)),
+		Pool:        "pending",
+		PendingFrom: time.Now().UTC(),
+	}
+}
+
+func TestCheckToAnyOf(t *testing.T) {
+
+	router := common.HexToAddress("0x7a250d5630b4cf539739df2c5dacb4c659f2488d")
+	bridge := common.HexToAddress("0xa0c68c638235ee32657e8f720a23cec1bfc77c77")
+	stranger := common.HexToAddress("0x2000000000000000000000000000000000000002")
+
+	addrs, err := parseAddresses([]string{router.Hex(), bridge.Hex(), common.Address{}.Hex()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name   string
+		tx     *data.MemPoolTx
+		params []interface{}
+		want   bool
+	}{
+		{"sent to watched", pendingTx(0, &router), []interface{}{addrs}, true},
+		{"sent to other watched", pendingTx(1, &bridge), []interface{}{addrs}, true},
+		{"sent to unwatched", pendingTx(2, &stranger), []interface{}{addrs}, false},
+		{"deployment", pendingTx(3, nil), []interface{}{addrs}, false},
+		{"without watched set", pendingTx(4, &router), nil, false},
+		{"with malformed watched set", pendingTx(5, &router), []interface{}{[]common.Address{router}}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+
+			if got := CheckToAnyOf(c.tx, c.params...); got != c.want {
+				t.Fatalf("expected %v, got %v", c.want, got)
+			}
+
+		})
+	}
+
+}
+
+func TestListenToMessagesSentTo(t *testing.T) {
+
+	withCodec(t)
+
+	router := common.HexToAddress("0x7a250d5630b4cf539739df2c5dacb4c659f2488d")
+	bridge := common.HexToAddress("0xa0c68c638235ee32657e8f720a23cec1bfc77c77")
+	stranger := common.HexToAddress("0x2000000000000000000000000000000000000002")
+
+	addrs, err := parseAddresses([]string{router.Hex(), bridge.Hex()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub := newFakeSubscriber()
+	comm := make(chan *model.MemPoolTx, 16)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go ListenToMessages(ctx, sub, comm, CheckToAnyOf, addrs)
+
+	toRouter := pendingTx(0, &router)
+	toStranger := pendingTx(1, &stranger)
+	deployment := pendingTx(2, nil)
+	toBridge := pendingTx(3, &bridge)
+	last := pendingTx(4, &router)
+
+	sub.publish(t, "pending_pool_entry", toRouter)
+	sub.publish(t, "pending_pool_entry", toStranger)
+	sub.publish(t, "pending_pool_entry", deployment)
+	// Batched message is filtered tx by tx
+	sub.publish(t, "pending_pool_exit", toStranger, toBridge, deployment)
+	sub.publish(t, "pending_pool_exit", last)
+
+	want := []*data.MemPoolTx{toRouter, toBridge, last}
+	for _, tx := range want {
+
+		select {
+
+		case got := <-comm:
+			if got.Hash != tx.Hash.Hex() {
+				t.Fatalf("expected %s to be delivered, got %s", tx.Hash.Hex(), got.Hash)
+			}
+
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected %s to be delivered", tx.Hash.Hex())
+
+		}
+
+	}
+
+	// Client going away tears down subscription
+	cancel()
+
+	select {
+
+	case got, ok := <-comm:
+		if ok {
+			t.Fatalf("expected nothing more to be delivered, got %s", got.Hash)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected delivery channel to be closed")
+
+	}
+
+	sub.lock.Lock()
+	defer sub.lock.Unlock()
+
+	if !sub.unsubscribed || !sub.disconnected {
+		t.Fatal("expected subscriber to be unsubscribed & disconnected")
+	}
+
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/itzmeanjan/harmony/app/upstream"
 )
 
 // CaughtTx - Tx caught by block head subscriber, passed to
@@ -16,6 +17,14 @@ import (
 type CaughtTx struct {
 	Hash  common.Hash
 	Nonce uint64
+	Block uint64
+}
+
+// Head - Block seen by block head subscriber, along with its base fee, which
+// is nil for blocks mined before London fork
+type Head struct {
+	Number  uint64
+	BaseFee *big.Int
 }
 
 // CaughtTxs - Just a slice of txs, which we found to be present in a recently
@@ -25,8 +34,13 @@ type CaughtTxs []*CaughtTx
 // SubscribeHead - Subscribe to block headers & as soon as new block gets mined
 // its txs are picked up & published on a go channel, which will be listened
 // to by pending pool watcher, so that it can prune its state
-func SubscribeHead(ctx context.Context, client *ethclient.Client, lastSeenBlock uint64, commChan chan<- CaughtTxs, lastSeenBlockChan chan<- uint64, healthChan chan struct{}) {
+//
+// Hashes of last `depth` blocks are remembered, so that when some of them get
+// replaced, due to chain reorganization, pending pool watcher is let known
+// on `reorgChan`, before new versions of those blocks are processed
+func SubscribeHead(ctx context.Context, client *ethclient.Client, lastSeenBlock uint64, depth uint64, commChan chan<- CaughtTxs, lastSeenBlockChan chan<- Head, reorgChan chan<- Reorg, healthChan chan struct{}) {
 
+	recent := newRecentBlocks(depth)
 	retryTable := make(map[*big.Int]struct{})
 	lastRetried := time.Now()
 	headerChan := make(chan *types.Header, 64)
@@ -36,6 +50,24 @@ func SubscribeHead(ctx context.Context, client *ethclient.Client, lastSeenBlock
 		return
 	}
 
+	// Blocks mined while previous worker was dead, are processed
+	// right away, so that pruning doesn't lag behind
+	if lastSeenBlock != 0 {
+
+		_ctx, cancel := upstream.WithTimeout(ctx)
+		latest, err := client.BlockNumber(_ctx)
+		cancel()
+
+		if err != nil {
+			upstream.Observe(ctx, err)
+			log.Printf("❗️ Failed to fetch latest block number : %s\n", err.Error())
+		} else if latest > lastSeenBlock {
+			backfill(ctx, client, lastSeenBlock+1, latest, commChan, lastSeenBlockChan, recent, retryTable)
+			lastSeenBlock = latest
+		}
+
+	}
+
 	for {
 
 		select {
@@ -57,17 +89,38 @@ func SubscribeHead(ctx context.Context, client *ethclient.Client, lastSeenBlock
 
 		case header := <-headerChan:
 
-			// If this go routine dies in mid, supervisor will spawn a new one
-			// after some delay, which will require processing missed blocks
-			if lastSeenBlock != 0 && header.Number.Uint64()-lastSeenBlock > 1 {
+			// Previously seen blocks got replaced, their new versions
+			// are processed as missed ones, see below
+			if from, ok := recent.replacedFrom(ctx, client, header); ok {
 
-				for i := lastSeenBlock + 1; i < header.Number.Uint64(); i++ {
-					retryTable[big.NewInt(int64(i))] = struct{}{}
+				to := recent.highest()
+				if to < from {
+					to = from
 				}
 
+				log.Printf("🔀 Chain reorganized, block(s) %d..%d replaced\n", from, to)
+
+				recent.forget(from, to)
+				reorgChan <- Reorg{From: from, To: to}
+
+				lastSeenBlock = from - 1
+
+			}
+
+			// Already processed, during backfilling
+			if header.Number.Uint64() <= lastSeenBlock {
+				break
 			}
 
-			if !ProcessBlock(ctx, client, header.Number, commChan, lastSeenBlockChan) {
+			// Some blocks were missed, they're processed in order, before
+			// this one, so that pruning decisions are made on complete view
+			if lastSeenBlock != 0 && header.Number.Uint64()-lastSeenBlock > 1 {
+				backfill(ctx, client, lastSeenBlock+1, header.Number.Uint64()-1, commChan, lastSeenBlockChan, recent, retryTable)
+			}
+
+			if hash, ok := ProcessBlock(ctx, client, header.Number, commChan, lastSeenBlockChan); ok {
+				recent.record(header.Number.Uint64(), hash)
+			} else {
 				// Put entry in table that we failed to fetch this block, to be
 				// attempted in some time future
 				retryTable[header.Number] = struct{}{}
@@ -91,7 +144,8 @@ func SubscribeHead(ctx context.Context, client *ethclient.Client, lastSeenBlock
 
 			successC := 0
 			for num := range retryTable {
-				if ProcessBlock(ctx, client, num, commChan, lastSeenBlockChan) {
+				if hash, ok := ProcessBlock(ctx, client, num, commChan, lastSeenBlockChan); ok {
+					recent.record(num.Uint64(), hash)
 					delete(retryTable, num)
 					successC++
 				}
@@ -104,23 +158,55 @@ func SubscribeHead(ctx context.Context, client *ethclient.Client, lastSeenBlock
 
 }
 
-// ProcessBlock - Fetches all txs present in mined block & passes those to pending pool pruning worker
-func ProcessBlock(ctx context.Context, client *ethclient.Client, number *big.Int, commChan chan<- CaughtTxs, lastSeenBlockChan chan<- uint64) bool {
+// backfill - Processes blocks in given range, in ascending order, where failed
+// ones are put in retry table, to be attempted in some time future
+func backfill(ctx context.Context, client *ethclient.Client, from uint64, to uint64, commChan chan<- CaughtTxs, lastSeenBlockChan chan<- Head, recent *recentBlocks, retryTable map[*big.Int]struct{}) {
+
+	log.Printf("🔁 Backfilling %d missed block(s)\n", to-from+1)
+
+	for i := from; i <= to; i++ {
+
+		num := new(big.Int).SetUint64(i)
+
+		hash, ok := ProcessBlock(ctx, client, num, commChan, lastSeenBlockChan)
+		if !ok {
+			retryTable[num] = struct{}{}
+			continue
+		}
+
+		recent.record(i, hash)
+
+	}
+
+}
+
+// ProcessBlock - Fetches all txs present in mined block & passes those to pending pool pruning worker,
+// returning hash of processed block
+func ProcessBlock(ctx context.Context, client *ethclient.Client, number *big.Int, commChan chan<- CaughtTxs, lastSeenBlockChan chan<- Head) (common.Hash, bool) {
+
+	_ctx, cancel := upstream.WithTimeout(ctx)
+	block, err := client.BlockByNumber(_ctx, number)
+	cancel()
 
-	block, err := client.BlockByNumber(ctx, number)
 	if err != nil {
 
+		upstream.Observe(ctx, err)
+
 		log.Printf("❗️ Failed to fetch block : %d\n", number)
-		return false
+		return common.Hash{}, false
 
 	}
 
 	txCount := len(block.Transactions())
 	log.Printf("🧱 Block %d mined with %d tx(s)\n", number, txCount)
 
-	// We've nothing to share with pruning worker
+	head := Head{Number: number.Uint64(), BaseFee: block.BaseFee()}
+
+	// We've nothing to share with pruning worker, but base fee of this
+	// block is still of interest to pool
 	if txCount == 0 {
-		return true
+		lastSeenBlockChan <- head
+		return block.Hash(), true
 	}
 
 	txs := make([]*CaughtTx, 0, txCount)
@@ -130,12 +216,13 @@ func ProcessBlock(ctx context.Context, client *ethclient.Client, number *big.Int
 		txs = append(txs, &CaughtTx{
 			Hash:  tx.Hash(),
 			Nonce: tx.Nonce(),
+			Block: number.Uint64(),
 		})
 
 	}
 
 	commChan <- txs
-	lastSeenBlockChan <- number.Uint64()
-	return true
+	lastSeenBlockChan <- head
+	return block.Hash(), true
 
 }
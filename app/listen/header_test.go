@@ -0,0 +1,195 @@
+package listen
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// fakeEth - In-memory stand in for upstream node's `eth` namespace,
+// knowing only blocks it's told about
+type fakeEth struct {
+	lock   sync.RWMutex
+	blocks map[uint64]json.RawMessage
+}
+
+// GetBlockByNumber - Known block, always with full tx objects, `null`
+// for unknown one
+func (f *fakeEth) GetBlockByNumber(number rpc.BlockNumber, full bool) (json.RawMessage, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	if block, ok := f.blocks[uint64(number)]; ok {
+		return block, nil
+	}
+
+	return json.RawMessage("null"), nil
+}
+
+// mine - Lets node know of block, encoded same as node does
+func (f *fakeEth) mine(t *testing.T, block *types.Block) {
+	t.Helper()
+
+	header, err := json.Marshal(block.Header())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(header, &fields); err != nil {
+		t.Fatal(err)
+	}
+
+	fields["transactions"] = block.Transactions()
+	fields["uncles"] = []common.Hash{}
+
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.blocks[block.NumberU64()] = encoded
+}
+
+// newFakeClient - Client talking to in-memory fake node
+func newFakeClient(t *testing.T) (*fakeEth, *ethclient.Client) {
+	t.Helper()
+
+	eth := &fakeEth{blocks: make(map[uint64]json.RawMessage)}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", eth); err != nil {
+		t.Fatal(err)
+	}
+
+	client := ethclient.NewClient(rpc.DialInProc(server))
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+
+	return eth, client
+}
+
+// londonBlock - Post London block at given height, on top of given parent,
+// carrying given tx(s)
+func londonBlock(number uint64, parent common.Hash, txs ...*types.Transaction) *types.Block {
+
+	header := &types.Header{
+		ParentHash: parent,
+		Number:     new(big.Int).SetUint64(number),
+		GasLimit:   30_000_000,
+		Time:       1_630_000_000 + number*13,
+		Difficulty: big.NewInt(1),
+		BaseFee:    big.NewInt(50_000_000_000),
+	}
+
+	return types.NewBlock(header, &types.Body{Transactions: txs}, nil, trie.NewStackTrie(nil))
+
+}
+
+// signedTxs - Tx of each type, signed for mainnet, with consecutive nonces
+func signedTxs(t *testing.T) []*types.Transaction {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chainID := big.NewInt(1)
+	to := common.HexToAddress("0x7a250d5630b4cf539739df2c5dacb4c659f2488d")
+	gasPrice := big.NewInt(60_000_000_000)
+
+	inners := []types.TxData{
+		&types.LegacyTx{Nonce: 0, GasPrice: gasPrice, Gas: 21000, To: &to, Value: big.NewInt(1)},
+		&types.AccessListTx{ChainID: chainID, Nonce: 1, GasPrice: gasPrice, Gas: 30000, To: &to, AccessList: types.AccessList{{Address: to, StorageKeys: []common.Hash{{1}}}}},
+		&types.DynamicFeeTx{ChainID: chainID, Nonce: 2, GasTipCap: big.NewInt(2_000_000_000), GasFeeCap: big.NewInt(100_000_000_000), Gas: 21000, To: &to},
+		&types.DynamicFeeTx{ChainID: chainID, Nonce: 3, GasTipCap: big.NewInt(2_000_000_000), GasFeeCap: big.NewInt(100_000_000_000), Gas: 100000, Data: []byte{0x60, 0x80}},
+	}
+
+	txs := make([]*types.Transaction, 0, len(inners))
+	for _, inner := range inners {
+
+		tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), inner)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		txs = append(txs, tx)
+
+	}
+
+	return txs
+
+}
+
+func TestProcessBlockWithDynamicFeeTxs(t *testing.T) {
+
+	eth, client := newFakeClient(t)
+
+	txs := signedTxs(t)
+	block := londonBlock(13_000_000, common.Hash{1}, txs...)
+	eth.mine(t, block)
+
+	commChan := make(chan CaughtTxs, 1)
+	lastSeenBlockChan := make(chan Head, 1)
+
+	hash, ok := ProcessBlock(context.Background(), client, block.Number(), commChan, lastSeenBlockChan)
+	if !ok {
+		t.Fatal("expected block with dynamic fee tx(s) to be processed")
+	}
+
+	if hash != block.Hash() {
+		t.Fatalf("expected block hash %s, got %s", block.Hash().Hex(), hash.Hex())
+	}
+
+	select {
+
+	case caught := <-commChan:
+		if len(caught) != len(txs) {
+			t.Fatalf("expected %d tx(s) to be caught, got %d", len(txs), len(caught))
+		}
+
+		for i, tx := range txs {
+			if caught[i].Hash != tx.Hash() || caught[i].Nonce != tx.Nonce() || caught[i].Block != block.NumberU64() {
+				t.Fatalf("tx %d : expected %s with nonce %d, got %s with nonce %d", i, tx.Hash().Hex(), tx.Nonce(), caught[i].Hash.Hex(), caught[i].Nonce)
+			}
+		}
+
+	case <-time.After(time.Second):
+		t.Fatal("expected caught tx(s) to be passed to pruning worker")
+
+	}
+
+	if seen := <-lastSeenBlockChan; seen.Number != block.NumberU64() || seen.BaseFee.Cmp(block.BaseFee()) != 0 {
+		t.Fatalf("expected last seen block %d, with base fee %s, got %d, with %s", block.NumberU64(), block.BaseFee(), seen.Number, seen.BaseFee)
+	}
+
+}
+
+func TestProcessBlockUnknown(t *testing.T) {
+
+	_, client := newFakeClient(t)
+
+	commChan := make(chan CaughtTxs, 1)
+	lastSeenBlockChan := make(chan Head, 1)
+
+	if _, ok := ProcessBlock(context.Background(), client, big.NewInt(1), commChan, lastSeenBlockChan); ok {
+		t.Fatal("expected unknown block not to be processed")
+	}
+
+}
@@ -0,0 +1,38 @@
+package listen
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/itzmeanjan/harmony/app/config"
+)
+
+// testConfig - Config keys tests are run with, on top of defaults
+var testConfig = map[string]string{
+	"RPCUrl":      "http://localhost:8545",
+	"WSUrl":       "ws://localhost:8546",
+	"Pub0SubHost": "127.0.0.1",
+	"Pub0SubPort": "13000",
+}
+
+// readTestConfig - Reads config from overridden keys only, config file
+// is never present
+func readTestConfig() error {
+	return config.Read(filepath.Join(os.TempDir(), "harmony-test-absent.env"))
+}
+
+func TestMain(m *testing.M) {
+
+	for k, v := range testConfig {
+		config.Override(k, v)
+	}
+
+	if err := readTestConfig(); err != nil {
+		log.Fatalf("[❗️] Failed to read test config : %s\n", err.Error())
+	}
+
+	os.Exit(m.Run())
+
+}
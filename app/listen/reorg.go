@@ -0,0 +1,129 @@
+package listen
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/itzmeanjan/harmony/app/upstream"
+)
+
+// Reorg - Range of block numbers, whose previously seen versions got
+// replaced, due to chain reorganization
+type Reorg struct {
+	From uint64
+	To   uint64
+}
+
+// recentBlocks - Hashes of last few processed blocks, by number, for
+// finding out whether newly seen block replaces any of them
+type recentBlocks struct {
+	hashes map[uint64]common.Hash
+	depth  uint64
+}
+
+// newRecentBlocks - Keeps track of at max `depth` recent blocks
+func newRecentBlocks(depth uint64) *recentBlocks {
+
+	return &recentBlocks{
+		hashes: make(map[uint64]common.Hash),
+		depth:  depth,
+	}
+
+}
+
+// record - Remembers hash of processed block, while forgetting
+// those which are too old to be reorganized now
+func (r *recentBlocks) record(number uint64, hash common.Hash) {
+
+	r.hashes[number] = hash
+
+	for num := range r.hashes {
+		if num+r.depth <= number {
+			delete(r.hashes, num)
+		}
+	}
+
+}
+
+// forget - Replaced blocks are no more to be considered part of chain
+func (r *recentBlocks) forget(from uint64, to uint64) {
+
+	for num := from; num <= to; num++ {
+		delete(r.hashes, num)
+	}
+
+}
+
+// highest - Highest block number being remembered
+func (r *recentBlocks) highest() uint64 {
+
+	var highest uint64
+
+	for num := range r.hashes {
+		if num > highest {
+			highest = num
+		}
+	}
+
+	return highest
+
+}
+
+// replacedFrom - Given newly seen block header, checks whether it replaces
+// any of recently processed blocks, returning lowest such block number
+//
+// Canonical headers are fetched only after mismatch is found, while walking
+// back, until some remembered block is found to be still part of chain
+func (r *recentBlocks) replacedFrom(ctx context.Context, client *ethclient.Client, header *types.Header) (uint64, bool) {
+
+	number := header.Number.Uint64()
+
+	var from uint64
+	var replaced bool
+
+	// Competing block at already seen height
+	if hash, ok := r.hashes[number]; ok && hash != header.Hash() {
+		from, replaced = number, true
+	}
+
+	// Parent isn't the one we've seen
+	if number > 0 {
+		if hash, ok := r.hashes[number-1]; ok && hash != header.ParentHash {
+			from, replaced = number-1, true
+		}
+	}
+
+	if !replaced {
+		return 0, false
+	}
+
+	for from > 0 {
+
+		hash, ok := r.hashes[from-1]
+		if !ok {
+			break
+		}
+
+		_ctx, cancel := upstream.WithTimeout(ctx)
+		canonical, err := client.HeaderByNumber(_ctx, new(big.Int).SetUint64(from-1))
+		cancel()
+
+		if err != nil {
+			upstream.Observe(ctx, err)
+			break
+		}
+
+		if canonical.Hash() == hash {
+			break
+		}
+
+		from--
+
+	}
+
+	return from, true
+
+}
@@ -0,0 +1,129 @@
+package listen
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// viaJSON - Header as it's received from node, over subscription
+func viaJSON(t *testing.T, header *types.Header) *types.Header {
+	t.Helper()
+
+	encoded, err := json.Marshal(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var received types.Header
+	if err := json.Unmarshal(encoded, &received); err != nil {
+		t.Fatal(err)
+	}
+
+	return &received
+}
+
+// forkedBlock - Post London block competing with one at same height, on
+// top of given parent
+func forkedBlock(number uint64, parent common.Hash) *types.Block {
+
+	block := londonBlock(number, parent)
+
+	header := block.Header()
+	header.Extra = []byte("fork")
+
+	return types.NewBlockWithHeader(header)
+
+}
+
+func TestReplacedFrom(t *testing.T) {
+
+	b1 := londonBlock(100, common.Hash{1})
+	b2 := londonBlock(101, b1.Hash())
+	b3 := londonBlock(102, b2.Hash())
+
+	f1 := forkedBlock(100, common.Hash{1})
+	f2 := forkedBlock(101, b1.Hash())
+	f2OnF1 := forkedBlock(101, f1.Hash())
+
+	cases := []struct {
+		name      string
+		canonical []*types.Block
+		header    *types.Header
+		want      uint64
+		replaced  bool
+	}{
+		{
+			name:      "consecutive london headers",
+			canonical: []*types.Block{b1, b2, b3},
+			header:    b3.Header(),
+		},
+		{
+			name:      "same header again",
+			canonical: []*types.Block{b1, b2},
+			header:    b2.Header(),
+		},
+		{
+			name:      "competing block at seen height",
+			canonical: []*types.Block{b1, f2},
+			header:    f2.Header(),
+			want:      101,
+			replaced:  true,
+		},
+		{
+			name:      "child of competing block",
+			canonical: []*types.Block{b1, f2},
+			header:    forkedBlock(102, f2.Hash()).Header(),
+			want:      101,
+			replaced:  true,
+		},
+		{
+			name:      "child of competing chain replacing all seen",
+			canonical: []*types.Block{f1, f2OnF1},
+			header:    forkedBlock(102, f2OnF1.Hash()).Header(),
+			want:      100,
+			replaced:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+
+			eth, client := newFakeClient(t)
+
+			// Blocks seen so far, hashed same as when they're processed
+			recent := newRecentBlocks(64)
+			for _, block := range []*types.Block{b1, b2} {
+
+				eth.mine(t, block)
+
+				fetched, err := client.BlockByNumber(context.Background(), block.Number())
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				recent.record(fetched.NumberU64(), fetched.Hash())
+
+			}
+
+			// Node might have switched to another chain since
+			for _, block := range c.canonical {
+				eth.mine(t, block)
+			}
+
+			from, replaced := recent.replacedFrom(context.Background(), client, viaJSON(t, c.header))
+			if replaced != c.replaced {
+				t.Fatalf("expected replaced = %v, got %v, from block %d", c.replaced, replaced, from)
+			}
+
+			if replaced && from != c.want {
+				t.Fatalf("expected blocks to be replaced from %d, got %d", c.want, from)
+			}
+
+		})
+	}
+
+}
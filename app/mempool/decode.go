@@ -0,0 +1,248 @@
+package mempool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/harmony/app/data"
+	"github.com/itzmeanjan/harmony/app/upstream"
+)
+
+// Fields of tx, which are expected to be hex encoded quantities, while some
+// clients send them as decimal strings/ numbers
+var quantityFields = []string{
+	"blockNumber", "gas", "gasPrice", "maxFeePerGas", "maxPriorityFeePerGas",
+	"nonce", "transactionIndex", "value", "type", "chainId", "v", "r", "s",
+}
+
+// quirks - Differences in `txpool_content` response of upstream client,
+// which are to be tolerated
+type quirks struct {
+	// Quantities may be sent as decimal strings/ numbers
+	decimalQuantities bool
+	// Calldata may be sent as `data`, instead of `input`
+	dataAsInput bool
+}
+
+// quirksOf - Given name of upstream client, returns differences to be
+// tolerated, where unknown client gets all of them tolerated
+func quirksOf(client string) quirks {
+
+	switch client {
+
+	case "geth":
+		return quirks{}
+
+	case "erigon", "besu":
+		return quirks{decimalQuantities: true}
+
+	default:
+		return quirks{decimalQuantities: true, dataAsInput: true}
+
+	}
+
+}
+
+// detectQuirks - Finds out which client is behind RPC endpoint, using
+// `web3_clientVersion`, unless configured explicitly
+func detectQuirks(ctx context.Context, client *rpc.Client) quirks {
+
+	if name := config.GetUpstreamClient(); name != "auto" {
+		return quirksOf(name)
+	}
+
+	var version string
+	if err := upstream.Call(ctx, client, &version, "web3_clientVersion"); err != nil {
+
+		log.Printf("[❗️] Failed to detect upstream client, tolerating all known differences : %s\n", err.Error())
+		return quirksOf("")
+
+	}
+
+	version = strings.ToLower(version)
+
+	for _, name := range []string{"geth", "erigon", "nethermind", "besu"} {
+
+		if strings.HasPrefix(version, name) {
+			log.Printf("[🔎] Detected upstream client `%s`\n", name)
+			return quirksOf(name)
+		}
+
+	}
+
+	log.Printf("[❗️] Unknown upstream client `%s`, tolerating all known differences\n", version)
+	return quirksOf("")
+
+}
+
+// decodeTxPoolContent - Decodes each tx of `txpool_content` response on its
+// own, so that one malformed tx doesn't fail whole poll, while normalizing
+// it only when it can't be decoded as is
+func decodeTxPoolContent(raw map[string]map[string]map[string]json.RawMessage, q quirks) map[string]map[string]map[string]*data.MemPoolTx {
+
+	result := make(map[string]map[string]map[string]*data.MemPoolTx, len(raw))
+	var skipped uint64
+
+	for section, senders := range raw {
+
+		result[section] = make(map[string]map[string]*data.MemPoolTx, len(senders))
+
+		for sender, txs := range senders {
+
+			decoded := make(map[string]*data.MemPoolTx, len(txs))
+
+			for key, msg := range txs {
+
+				tx, err := decodeTx(msg, q)
+				if err != nil {
+					skipped++
+					continue
+				}
+
+				decoded[key] = tx
+
+			}
+
+			result[section][sender] = decoded
+
+		}
+
+	}
+
+	if skipped != 0 {
+		log.Printf("[❗️] Skipped %d malformed tx(s) in mempool content\n", skipped)
+	}
+
+	return result
+
+}
+
+// decodeTx - Decodes tx as is, falling back to normalizing it as per
+// quirks of upstream client, when that fails
+func decodeTx(msg json.RawMessage, q quirks) (*data.MemPoolTx, error) {
+
+	var tx data.MemPoolTx
+
+	err := json.Unmarshal(msg, &tx)
+	if err == nil && !(q.dataAsInput && len(tx.Input) == 0) {
+		return &tx, nil
+	}
+
+	normalized, _err := normalizeTx(msg, q)
+	if _err != nil {
+		return nil, _err
+	}
+
+	tx = data.MemPoolTx{}
+	if err := json.Unmarshal(normalized, &tx); err != nil {
+		return nil, err
+	}
+
+	return &tx, nil
+
+}
+
+// normalizeTx - Rewrites tx fields, sent differently by upstream client,
+// in form expected by `harmony`, while leaving unknown fields as they're
+func normalizeTx(msg json.RawMessage, q quirks) (json.RawMessage, error) {
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(msg, &fields); err != nil {
+		return nil, err
+	}
+
+	if q.decimalQuantities {
+
+		for key, value := range fields {
+
+			for _, field := range quantityFields {
+
+				if !strings.EqualFold(key, field) {
+					continue
+				}
+
+				if quantity, ok := hexQuantity(value); ok {
+					fields[key] = quantity
+				}
+
+			}
+
+		}
+
+	}
+
+	if q.dataAsInput {
+
+		var input json.RawMessage
+		for key, value := range fields {
+			if strings.EqualFold(key, "input") {
+				input = value
+			}
+		}
+
+		if len(input) == 0 || string(input) == "null" || string(input) == `""` || string(input) == `"0x"` {
+
+			for key, value := range fields {
+				if strings.EqualFold(key, "data") {
+					fields["input"] = value
+				}
+			}
+
+		}
+
+	}
+
+	return json.Marshal(fields)
+
+}
+
+// hexQuantity - Given quantity sent as decimal string/ number, encodes it
+// as hex string, leaving already hex encoded ones as they're
+func hexQuantity(value json.RawMessage) (json.RawMessage, bool) {
+
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, false
+	}
+
+	var decimal string
+
+	switch v := v.(type) {
+
+	case json.Number:
+		decimal = v.String()
+
+	case string:
+		if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+			return nil, false
+		}
+		decimal = v
+
+	default:
+		return nil, false
+
+	}
+
+	n, ok := new(big.Int).SetString(decimal, 10)
+	if !ok || n.Sign() < 0 {
+		return nil, false
+	}
+
+	encoded, err := json.Marshal(hexutil.EncodeBig(n))
+	if err != nil {
+		return nil, false
+	}
+
+	return encoded, true
+
+}
@@ -0,0 +1,105 @@
+package mempool
+
+import (
+	"time"
+
+	"github.com/itzmeanjan/harmony/app/config"
+)
+
+// pollingInterval - Adapts mempool polling interval to recent activity,
+// staying within configured bounds
+type pollingInterval struct {
+	current time.Duration
+	min     time.Duration
+	max     time.Duration
+	burst   uint64
+	// #-of consecutive polls which found nothing new
+	idle uint64
+}
+
+// newPollingInterval - Reads bounds from configuration, starting
+// from min interval
+func newPollingInterval() *pollingInterval {
+
+	min := time.Duration(config.GetMemPoolPollingPeriodMin()) * time.Millisecond
+
+	return &pollingInterval{
+		current: min,
+		min:     min,
+		max:     time.Duration(config.GetMemPoolPollingPeriodMax()) * time.Millisecond,
+		burst:   config.GetMemPoolPollingBurst(),
+	}
+
+}
+
+// refresh - Picks up bounds from configuration, if those have been
+// reloaded, keeping current interval within them
+func (p *pollingInterval) refresh() {
+
+	min := time.Duration(config.GetMemPoolPollingPeriodMin()) * time.Millisecond
+	max := time.Duration(config.GetMemPoolPollingPeriodMax()) * time.Millisecond
+
+	if min == p.min && max == p.max {
+		return
+	}
+
+	p.min, p.max = min, max
+
+	if p.current < p.min {
+		p.current = p.min
+	}
+
+	if p.current > p.max {
+		p.current = p.max
+	}
+
+}
+
+// observe - Given how many new tx(s) were found in last poll, shrinks
+// interval when it's burst, grows it when consecutive polls found
+// nothing, returning interval to be used now
+func (p *pollingInterval) observe(added uint64) time.Duration {
+
+	switch {
+
+	case added >= p.burst:
+
+		p.idle = 0
+		p.current /= 2
+
+		if p.current < p.min {
+			p.current = p.min
+		}
+
+	case added == 0:
+
+		p.idle++
+		if p.idle < 2 {
+			break
+		}
+
+		p.current *= 2
+
+		if p.current > p.max {
+			p.current = p.max
+		}
+
+	default:
+		p.idle = 0
+
+	}
+
+	return p.current
+
+}
+
+// reset - New block is seen, mempool content is likely to change,
+// so it's better to get back to polling at min interval
+func (p *pollingInterval) reset() time.Duration {
+
+	p.idle = 0
+	p.current = p.min
+
+	return p.current
+
+}
@@ -2,29 +2,76 @@ package mempool
 
 import (
 	"context"
+	"encoding/json"
 	"log"
 	"strings"
 	"time"
 
 	"github.com/itzmeanjan/harmony/app/config"
 	"github.com/itzmeanjan/harmony/app/data"
+	"github.com/itzmeanjan/harmony/app/networking"
+	"github.com/itzmeanjan/harmony/app/upstream"
 )
 
 // PollTxPoolContent - Poll current content of Ethereum Mempool periodically & do further
 // processing with data received back i.e. attempt to keep most fresh view of
 // mempool in `harmony`
 //
-// Emit events on PubSub topics for listening to state changes
-func PollTxPoolContent(ctx context.Context, res *data.Resource, comm chan struct{}) {
+// Emit events on PubSub topics for listening to state changes. After each
+// successful poll, supervisor is notified on `polled`, so that it can keep
+// track of consecutive failures, while `comm` is closed when it's dying
+//
+// Interval between polls is adapted to recent activity, while it's reset to
+// min as soon as new block is seen
+//
+// Full mempool content is fetched only when `txpool_status` reports change
+// in pending/ queued counts, new block is seen or last fetch is too old
+func PollTxPoolContent(ctx context.Context, res *data.Resource, comm chan struct{}, polled chan struct{}) {
+
+	interval := newPollingInterval()
+	res.SetPollingInterval(interval.current)
+
+	staleness := time.Duration(config.GetMemPoolMaxStaleness()) * time.Millisecond
+	// Differences in `txpool_content` response of upstream client
+	tolerate := detectQuirks(ctx, res.RPCClient)
+
+	// Upstream mempool status, as seen during last full fetch
+	var lastStatus *txPoolStatus
+	var lastFetched time.Time
+	var newBlock bool
 
 	for {
 
+		// Bounds might have been changed, by reloading config
+		interval.refresh()
+
 		// Starting to fetch latest state of mempool
 		start := time.Now().UTC()
 
-		var result map[string]map[string]map[string]*data.MemPoolTx
+		// Nodes not supporting `txpool_status`, always get full
+		// content fetched
+		status, err := fetchTxPoolStatus(ctx, res.RPCClient)
+		if err == nil && lastStatus != nil && *status == *lastStatus && !newBlock && time.Now().UTC().Sub(lastFetched) < staleness {
+
+			res.SkippedPoll()
+
+			wait := interval.observe(0)
+			res.SetPollingInterval(wait)
+
+			notifyPolled(res, polled)
+
+			newBlock = sleep(ctx, res, interval, wait)
+			if ctx.Err() != nil {
+				return
+			}
+
+			continue
 
-		if err := res.RPCClient.CallContext(ctx, &result, "txpool_content"); err != nil {
+		}
+
+		var raw map[string]map[string]map[string]json.RawMessage
+
+		if err := upstream.Call(ctx, res.RPCClient, &raw, "txpool_content"); err != nil {
 
 			log.Printf("[❗️] Failed to fetch mempool content : %s\n", err.Error())
 
@@ -34,6 +81,23 @@ func PollTxPoolContent(ctx context.Context, res *data.Resource, comm chan struct
 				break
 			}
 
+			// Node is taking too long to respond, even after retrying,
+			// this iteration is considered failed, but it's not worth
+			// dying for, trying again after a while
+			if upstream.IsTimeout(err) {
+
+				wait := interval.observe(0)
+				res.SetPollingInterval(wait)
+
+				newBlock = sleep(ctx, res, interval, wait) || newBlock
+				if ctx.Err() != nil {
+					return
+				}
+
+				continue
+
+			}
+
 			// Letting supervisor know, pool polling go routine is dying
 			// it must take care of spawning another one to continue functioning
 			close(comm)
@@ -41,12 +105,74 @@ func PollTxPoolContent(ctx context.Context, res *data.Resource, comm chan struct
 
 		}
 
+		result := decodeTxPoolContent(raw, tolerate)
+
+		lastStatus = status
+		lastFetched = time.Now().UTC()
+		newBlock = false
+
+		// Tx(s) restored from last run's pool state, which are still
+		// living in mempool, get their original timestamps back, before
+		// being admitted into pools, only done once
+		if res.RestoredState != nil {
+
+			count := res.RestoredState.Reconcile(result["pending"], result["queued"])
+			log.Printf("[💾] Restored %d tx(s) from pool state, persisted at %s\n", count, res.RestoredState.TakenAt)
+
+			res.RestoredState = nil
+
+		}
+
 		// Process current tx pool content
-		res.Pool.Process(ctx, result["pending"], result["queued"])
-		res.Pool.Stat(start)
+		addedP, addedQ := res.Pool.Process(ctx, result["pending"], result["queued"])
+
+		wait := interval.observe(addedP + addedQ)
+		res.SetPollingInterval(wait)
+
+		res.Pool.Stat(ctx, start, wait, res.SkippedPolls())
+		networking.Stat()
+
+		notifyPolled(res, polled)
 
 		// Sleep for desired amount of time & get to work again
-		<-time.After(time.Duration(config.GetMemPoolPollingPeriod()) * time.Millisecond)
+		newBlock = sleep(ctx, res, interval, wait)
+		if ctx.Err() != nil {
+			return
+		}
+
+	}
+
+}
+
+// notifyPolled - Letting supervisor know last poll went fine, without
+// blocking, in case it's already aware of that, while recording when it
+// happened, for readiness probe
+func notifyPolled(res *data.Resource, polled chan struct{}) {
+
+	res.Polled()
+
+	select {
+	case polled <- struct{}{}:
+	default:
+	}
+
+}
+
+// sleep - Waits for given duration, unless new block is seen in between, in
+// that case polling interval is reset to min & returns true
+func sleep(ctx context.Context, res *data.Resource, interval *pollingInterval, wait time.Duration) bool {
+
+	select {
+
+	case <-ctx.Done():
+		return false
+
+	case <-res.Pool.Pending.NewBlockChan:
+		res.SetPollingInterval(interval.reset())
+		return true
+
+	case <-time.After(wait):
+		return false
 
 	}
 
@@ -0,0 +1,30 @@
+package mempool
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/itzmeanjan/harmony/app/upstream"
+)
+
+// txPoolStatus - #-of tx(s) living in upstream node's mempool, as
+// reported by `txpool_status`
+type txPoolStatus struct {
+	Pending hexutil.Uint64 `json:"pending"`
+	Queued  hexutil.Uint64 `json:"queued"`
+}
+
+// fetchTxPoolStatus - Cheap RPC call, for checking whether upstream node's
+// mempool content might have changed, before fetching whole of it
+func fetchTxPoolStatus(ctx context.Context, client *rpc.Client) (*txPoolStatus, error) {
+
+	var status txPoolStatus
+
+	if err := upstream.Call(ctx, client, &status, "txpool_status"); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+
+}
@@ -0,0 +1,59 @@
+package mempool
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/harmony/app/data"
+	"github.com/itzmeanjan/harmony/app/upstream"
+)
+
+// Backoff - How long to wait before spawning new mempool poller, after it
+// has failed `failures` times consecutively, doubling from configured
+// initial wait time, but never exceeding configured max
+func Backoff(failures uint64) time.Duration {
+
+	backoff := config.GetPollerBackoffInitial()
+	max := config.GetPollerBackoffMax()
+
+	for i := uint64(1); i < failures && backoff < max; i++ {
+		backoff *= 2
+	}
+
+	if backoff > max {
+		backoff = max
+	}
+
+	return time.Duration(backoff) * time.Millisecond
+
+}
+
+// Redial - Checks whether RPC client used by mempool poller is still able to
+// talk to node, if not, attempts to dial a new one, which replaces it. Old
+// client is not closed, because pools still hold reference to it
+//
+// @note Supposed to be invoked only when no poller is alive
+func Redial(ctx context.Context, res *data.Resource) error {
+
+	var version string
+	if err := upstream.Call(ctx, res.RPCClient, &version, "net_version"); err == nil {
+		return nil
+	}
+
+	_ctx, cancel := upstream.WithTimeout(ctx)
+	defer cancel()
+
+	client, err := rpc.DialContext(_ctx, config.GetRPCUrl())
+	if err != nil {
+		return err
+	}
+
+	res.RPCClient = client
+	log.Printf("[🔌] Re-dialed RPC node, for polling mempool\n")
+
+	return nil
+
+}
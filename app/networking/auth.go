@@ -0,0 +1,92 @@
+package networking
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/pnet"
+)
+
+// allowlist - Only these peers are accepted/ dialed, when set
+var allowlist map[peer.ID]struct{}
+
+// rejectedPeers - #-of connection attempts rejected because peer
+// is not allowlisted
+var rejectedPeers uint64
+
+// AllowedPeers - Parses user supplied peer allowlist, returning error pointing
+// to bad entry, if any. Empty allowlist denotes all peers are allowed
+func AllowedPeers() (map[peer.ID]struct{}, error) {
+
+	given := config.GetPeerAllowlist()
+	if len(given) == 0 {
+		return nil, nil
+	}
+
+	allowed := make(map[peer.ID]struct{}, len(given))
+
+	for i, v := range given {
+
+		id, err := peer.Decode(v)
+		if err != nil {
+			return nil, fmt.Errorf("bad allowlisted peer %d `%s` : %s", i+1, v, err.Error())
+		}
+
+		allowed[id] = struct{}{}
+
+	}
+
+	return allowed, nil
+
+}
+
+// isAllowed - Checks whether peer is allowed to be part of our mesh
+func isAllowed(peerId peer.ID) bool {
+
+	if allowlist == nil {
+		return true
+	}
+
+	_, ok := allowlist[peerId]
+	return ok
+
+}
+
+// rejected - Keeps track of connection attempt rejected because
+// peer is not allowlisted
+func rejected() {
+	atomic.AddUint64(&rejectedPeers, 1)
+}
+
+// RejectedPeers - #-of connection attempts rejected so far, because
+// peer was not allowlisted
+func RejectedPeers() uint64 {
+	return atomic.LoadUint64(&rejectedPeers)
+}
+
+// PreSharedKey - Parses user supplied hex encoded 32 bytes key, to be used
+// for forming libp2p private network, so that handshake with anyone not
+// knowing it fails. Nil key denotes private network is not to be formed
+func PreSharedKey() (pnet.PSK, error) {
+
+	v := config.GetNetworkingPSK()
+	if len(v) == 0 {
+		return nil, nil
+	}
+
+	key, err := hex.DecodeString(v)
+	if err != nil {
+		return nil, fmt.Errorf("bad pre-shared key : %s", err.Error())
+	}
+
+	if len(key) != 32 {
+		return nil, errors.New("bad pre-shared key : must be 32 bytes")
+	}
+
+	return pnet.PSK(key), nil
+
+}
@@ -3,13 +3,18 @@ package networking
 import (
 	"context"
 	"errors"
+	"log"
 
+	"github.com/itzmeanjan/harmony/app/config"
 	"github.com/itzmeanjan/harmony/app/data"
+	"github.com/libp2p/go-libp2p-core/host"
 )
 
 var memPool *data.MemPool
+var codec data.Codec
 var parentCtx context.Context
 var connectionManager *ConnectionManager
+var localHost host.Host
 
 // InitMemPool - Initializing mempool handle, in this module
 // so that it can be used updating local mempool state, when new
@@ -23,6 +28,17 @@ func InitMemPool(pool *data.MemPool) error {
 	return errors.New("bad mempool received in p2p networking handler")
 }
 
+// InitCodec - Initializing codec handle, to be used for serializing
+// tx(s) being sent to peers
+func InitCodec(_codec data.Codec) error {
+	if _codec != nil {
+		codec = _codec
+		return nil
+	}
+
+	return errors.New("bad codec received in p2p networking handler")
+}
+
 // To be used for listening to event when `harmony` asks its
 // workers to stop gracefully
 func InitParentContext(ctx context.Context) {
@@ -36,6 +52,23 @@ func Setup(ctx context.Context, comm chan struct{}) error {
 		return errors.New("mempool instance not initialised")
 	}
 
+	// Bad bootstrap node address is better caught before
+	// anything is started
+	bootstrapPeers, err := BootstrapPeers()
+	if err != nil {
+		return err
+	}
+
+	allowlist, err = AllowedPeers()
+	if err != nil {
+		return err
+	}
+
+	staticPeers, err := StaticPeers()
+	if err != nil {
+		return err
+	}
+
 	// Attempt to create a new `harmony` node
 	// with p2p networking capabilities
 	host, err := CreateHost(ctx)
@@ -43,17 +76,153 @@ func Setup(ctx context.Context, comm chan struct{}) error {
 		return err
 	}
 
-	// Display info regarding this node
-	ShowHost(host)
-	// Start listening for incoming streams, for supported protocol
-	Listen(host)
-
-	go SetUpPeerDiscovery(ctx, host, comm)
+	// Chunks sent to peers are signed using host key
+	signingKey = host.Peerstore().PrivKey(host.ID())
+	selfId = host.ID()
+	localHost = host
 
 	// Starting this worker as a seperate go routine,
 	// so that they can manage their own life cycle independently
+	//
+	// It must be ready before any stream is accepted/ dialed
 	connectionManager = NewConnectionManager()
 	go connectionManager.Start(ctx)
 
+	// Display info regarding this node
+	ShowHost(host)
+	go WatchReachability(ctx, host)
+
+	if gossipMode() {
+
+		// Mempool changes to be propagated over gossipsub topic
+		if err := SetUpGossip(ctx, host); err != nil {
+			return err
+		}
+
+	} else {
+
+		// Start listening for incoming streams, for supported protocol
+		Listen(host)
+
+	}
+
+	// Static peers are kept connected with, by connection manager,
+	// dialing them right away & whenever they're dropped
+	for _, info := range staticPeers {
+		connectionManager.KeepConnected(info)
+	}
+
+	go RedialPeers(ctx, host)
+
+	if config.GetDisableDiscovery() {
+
+		if len(staticPeers) != 0 {
+			log.Printf("[❃] Peer discovery disabled, only static peers to be connected with\n")
+			return nil
+		}
+
+		log.Printf("[❗️] Peer discovery can't be disabled, when no static peers are given\n")
+
+	}
+
+	go SetUpPeerDiscovery(ctx, host, bootstrapPeers, comm)
+
 	return nil
 }
+
+// Identity - Peer identifier of this node, multi addresses it can be dialed
+// at & what other nodes need to agree upon for joining cluster, which is nil
+// when running in solo mode
+func Identity() *data.NodeIdentity {
+
+	if localHost == nil {
+		return nil
+	}
+
+	addrs := make([]string, 0)
+	for _, addr := range dialableAddrs(localHost) {
+		addrs = append(addrs, addr.String())
+	}
+
+	protocols := make([]string, 0)
+	for _, id := range streamProtocols() {
+		protocols = append(protocols, string(id))
+	}
+
+	return &data.NodeIdentity{
+		PeerID:       localHost.ID().Pretty(),
+		Addrs:        addrs,
+		Rendezvous:   config.GetNetworkingRendezvous(),
+		Protocols:    protocols,
+		Transport:    config.GetNetworkingTransport(),
+		Reachability: Reachability().String(),
+	}
+
+}
+
+// PeerScores - Misbehaviour scores of peers & until when they're banned,
+// which is empty when running in solo mode
+func PeerScores() []*data.PeerScore {
+
+	if connectionManager == nil {
+		return []*data.PeerScore{}
+	}
+
+	return connectionManager.Scores()
+
+}
+
+// PeerTraffic - Traffic & tx contribution of each peer seen in this session,
+// which is empty when running in solo mode
+func PeerTraffic() []*data.PeerTraffic {
+
+	if connectionManager == nil {
+		return []*data.PeerTraffic{}
+	}
+
+	return connectionManager.TrafficTable()
+
+}
+
+// Stat - Log traffic totals, across all peers seen in this session,
+// when running with networking enabled
+func Stat() {
+
+	if connectionManager == nil {
+		return
+	}
+
+	var total data.PeerTraffic
+	var connected uint64
+
+	for _, t := range connectionManager.TrafficTable() {
+
+		if t.Connected {
+			connected++
+		}
+
+		total.BytesIn += t.BytesIn
+		total.BytesOut += t.BytesOut
+		total.FramesIn += t.FramesIn
+		total.FramesOut += t.FramesOut
+		total.TxsAccepted += t.TxsAccepted
+		total.TxsRejected += t.TxsRejected
+
+	}
+
+	log.Printf("📊 Peers : %d connected | In : %d bytes, %d frame(s) | Out : %d bytes, %d frame(s) | Tx(s) : %d accepted, %d rejected\n",
+		connected, total.BytesIn, total.FramesIn, total.BytesOut, total.FramesOut, total.TxsAccepted, total.TxsRejected)
+
+}
+
+// ConnectedPeers - #-of connected peers, split by who initiated connection,
+// which is zero when running in solo mode
+func ConnectedPeers() PeerCount {
+
+	if connectionManager == nil {
+		return PeerCount{}
+	}
+
+	return connectionManager.PeerCount()
+
+}
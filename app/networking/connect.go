@@ -2,9 +2,20 @@ package networking
 
 import (
 	"context"
+	"errors"
+	"math/rand"
 	"time"
 
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/harmony/app/data"
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// Reasons for not accepting/ dialing peer
+var (
+	ErrAlreadyConnected = errors.New("already connected")
+	ErrTooManyPeers     = errors.New("too many peers")
 )
 
 // IsConnected - Queries can be sent to connection manager
@@ -15,23 +26,157 @@ type IsConnected struct {
 	Response chan bool
 }
 
+// Reserve - Worker go routine, about to start managing interaction with
+// remote peer, asks connection manager for a slot, where response denotes
+// why it can't be given, if so
+type Reserve struct {
+	Peer     peer.ID
+	Inbound  bool
+	Relayed  bool
+	Response chan error
+}
+
+// HasRoom - Checking whether there's room for one more inbound/ outbound
+// peer, before dialing/ accepting it
+type HasRoom struct {
+	Inbound  bool
+	Response chan bool
+}
+
+// Misbehaved - Worker go routine, managing interaction with remote peer, lets
+// connection manager know peer sent something, which couldn't be validated &
+// gets back whether peer is banned now, over `response` channel
+type Misbehaved struct {
+	Peer     peer.ID
+	Weight   float64
+	Response chan bool
+}
+
+// TrafficOf - Asking for traffic counters of peer, which are created
+// if not present, response to be sent back over `response` channel
+type TrafficOf struct {
+	Peer     peer.ID
+	Response chan *traffic
+}
+
+// Fresh - Checking whether sequence number of signed envelope received
+// from peer is higher than all seen before, response to be sent back over
+// `response` channel
+type Fresh struct {
+	Peer     peer.ID
+	Seq      uint64
+	Response chan bool
+}
+
+// IsPenalized - Checking whether peer is being penalized for protocol
+// violation, response to be sent back over `response` channel
+type IsPenalized struct {
+	Peer     peer.ID
+	Response chan bool
+}
+
+// PeerState - Connected peer, along with which side initiated connection,
+// whether it goes through circuit relay & since when it's connected
+type PeerState struct {
+	Inbound bool
+	Relayed bool
+	Since   time.Time
+}
+
+// Drop - Connection with peer is dropped, along with addresses it
+// was known to be reachable at
+type Drop struct {
+	Peer  peer.ID
+	Addrs []multiaddr.Multiaddr
+}
+
+// redial - Dropped peer to be attempted to be reconnected with, at addresses
+// remembered when it got dropped, where wait time doubles after each attempt
+//
+// Static peer is never given up
+type redial struct {
+	addrs    []multiaddr.Multiaddr
+	attempts uint64
+	next     time.Time
+	static   bool
+}
+
+// PeerCount - #-of connected peers, split by who initiated connection,
+// along with how many of them are connected via circuit relay
+type PeerCount struct {
+	Inbound  uint64
+	Outbound uint64
+	Relayed  uint64
+}
+
 // ConnectionManager - All connected peers to be kept track of, so that we don't attempt
 // to reconnect to same peer again
+//
+// #-of connected peers is capped, while inbound peers can take up only some of
+// those slots, so that we can't be fully eclipsed by peers connecting to us.
+// When there's no room, newcomer is turned away, keeping longer-lived peers.
+//
+// Dropped peers are remembered, so that they can be attempted to be
+// reconnected with, backing off exponentially per peer.
 type ConnectionManager struct {
-	Peers           map[peer.ID]bool
-	NewPeerChan     chan peer.ID
-	DroppedPeerChan chan peer.ID
+	Peers           map[peer.ID]*PeerState
+	Misbehaviour    map[peer.ID]*score
+	Penalized       map[peer.ID]time.Time
+	Redials         map[peer.ID]*redial
+	Static          map[peer.ID][]multiaddr.Multiaddr
+	Seqs            map[peer.ID]uint64
+	Traffic         map[peer.ID]*traffic
+	ReserveChan     chan Reserve
+	HasRoomChan     chan HasRoom
+	DroppedPeerChan chan Drop
+	KeepChan        chan peer.AddrInfo
 	IsConnectedChan chan IsConnected
+	MisbehavedChan  chan Misbehaved
+	ScoresChan      chan chan []*data.PeerScore
+	PenalizeChan    chan peer.ID
+	IsPenalizedChan chan IsPenalized
+	PeerCountChan   chan chan PeerCount
+	DueChan         chan chan []peer.AddrInfo
+	FreshChan       chan Fresh
+	TrafficOfChan   chan TrafficOf
+	TrafficChan     chan chan []*data.PeerTraffic
+}
+
+// Reserve - When new connection is about to be established, asks for slot,
+// returning error, if peer can't be accepted/ dialed
+func (c *ConnectionManager) Reserve(peerId peer.ID, inbound bool, relayed bool) error {
+
+	responseChan := make(chan error)
+	c.ReserveChan <- Reserve{Peer: peerId, Inbound: inbound, Relayed: relayed, Response: responseChan}
+
+	// This is a blocking call
+	return <-responseChan
+
+}
+
+// HasRoom - Before dialing/ accepting peer, check whether there's room
+// for it
+func (c *ConnectionManager) HasRoom(inbound bool) bool {
+
+	responseChan := make(chan bool)
+	c.HasRoomChan <- HasRoom{Inbound: inbound, Response: responseChan}
+
+	// This is a blocking call
+	return <-responseChan
+
 }
 
-// Added - When new connection is established
-func (c *ConnectionManager) Added(peerId peer.ID) {
-	c.NewPeerChan <- peerId
+// Dropped - When connection with some peer is dropped, addresses it's known
+// to be reachable at are remembered, so that it can be reconnected with, even
+// when peer store doesn't keep them anymore
+func (c *ConnectionManager) Dropped(peerId peer.ID, addrs []multiaddr.Multiaddr) {
+	c.DroppedPeerChan <- Drop{Peer: peerId, Addrs: addrs}
 }
 
-// Dropped - When connection with some peer is dropped
-func (c *ConnectionManager) Dropped(peerId peer.ID) {
-	c.DroppedPeerChan <- peerId
+// KeepConnected - Static peer to be dialed right away & whenever it gets
+// dropped, with backoff, but it's never given up
+func (c *ConnectionManager) KeepConnected(info peer.AddrInfo) {
+	c.KeepChan <- info
 }
 
 // IsConnected - Before attempting to (re-)establish connection
@@ -46,9 +191,236 @@ func (c *ConnectionManager) IsConnected(peerId peer.ID) bool {
 
 }
 
+// Misbehaved - When peer sends something, which can't be validated, given
+// weight is added to its misbehaviour score, which decays over time. Peer
+// crossing ban score is banned i.e. it's neither accepted nor dialed until
+// penalty period is over, where returned flag denotes whether it's banned now,
+// so that caller can disconnect it
+func (c *ConnectionManager) Misbehaved(peerId peer.ID, weight float64) bool {
+
+	responseChan := make(chan bool)
+	c.MisbehavedChan <- Misbehaved{Peer: peerId, Weight: weight, Response: responseChan}
+
+	// This is a blocking call
+	return <-responseChan
+
+}
+
+// Penalize - Peer violated protocol, it's not to be accepted/ dialed
+// until penalty period is over
+func (c *ConnectionManager) Penalize(peerId peer.ID) {
+	c.PenalizeChan <- peerId
+}
+
+// Scores - Returns current misbehaviour scores of peers & until when
+// they're banned, if they're
+func (c *ConnectionManager) Scores() []*data.PeerScore {
+
+	responseChan := make(chan []*data.PeerScore)
+	c.ScoresChan <- responseChan
+
+	// This is a blocking call
+	return <-responseChan
+
+}
+
+// scores - Misbehaviour score of each peer, having non-negligible
+// score or being banned
+//
+// @note Supposed to be invoked from connection manager's own go routine
+func (c *ConnectionManager) scores() []*data.PeerScore {
+
+	now := time.Now().UTC()
+	scores := make(map[peer.ID]*data.PeerScore)
+
+	for id, s := range c.Misbehaviour {
+		scores[id] = &data.PeerScore{Peer: id.String(), Score: s.decayed(now)}
+	}
+
+	for id, until := range c.Penalized {
+
+		if _, ok := scores[id]; !ok {
+			scores[id] = &data.PeerScore{Peer: id.String()}
+		}
+
+		until := until
+		scores[id].BannedUntil = &until
+
+	}
+
+	result := make([]*data.PeerScore, 0, len(scores))
+	for _, v := range scores {
+		result = append(result, v)
+	}
+
+	return result
+
+}
+
+// ban - Peer is neither accepted nor dialed until penalty period is over
+//
+// @note Supposed to be invoked from connection manager's own go routine
+func (c *ConnectionManager) ban(peerId peer.ID) {
+
+	c.Penalized[peerId] = time.Now().UTC().Add(time.Duration(config.GetPeerPenaltyPeriod()) * time.Millisecond)
+	delete(c.Misbehaviour, peerId)
+
+	// Static peer keeps being redialed, it'll be accepted
+	// once ban is over
+	if _, ok := c.Static[peerId]; !ok {
+		delete(c.Redials, peerId)
+	}
+
+}
+
+// Fresh - Checks whether envelope with given sequence number, received from
+// peer, is not a replay, while remembering it. Sequence numbers are remembered
+// even after peer gets dropped, so that they can't be replayed over new stream
+func (c *ConnectionManager) Fresh(peerId peer.ID, seq uint64) bool {
+
+	responseChan := make(chan bool)
+	c.FreshChan <- Fresh{Peer: peerId, Seq: seq, Response: responseChan}
+
+	// This is a blocking call
+	return <-responseChan
+
+}
+
+// TrafficOf - Returns traffic counters of peer, to be updated by stream
+// workers, which are kept for whole session
+func (c *ConnectionManager) TrafficOf(peerId peer.ID) *traffic {
+
+	responseChan := make(chan *traffic)
+	c.TrafficOfChan <- TrafficOf{Peer: peerId, Response: responseChan}
+
+	// This is a blocking call
+	return <-responseChan
+
+}
+
+// TrafficTable - Returns traffic of each peer seen in this session
+func (c *ConnectionManager) TrafficTable() []*data.PeerTraffic {
+
+	responseChan := make(chan []*data.PeerTraffic)
+	c.TrafficChan <- responseChan
+
+	// This is a blocking call
+	return <-responseChan
+
+}
+
+// IsPenalized - Before accepting/ dialing peer, check whether it's
+// being penalized for protocol violation
+func (c *ConnectionManager) IsPenalized(peerId peer.ID) bool {
+
+	responseChan := make(chan bool)
+	c.IsPenalizedChan <- IsPenalized{Peer: peerId, Response: responseChan}
+
+	// This is a blocking call
+	return <-responseChan
+
+}
+
+// PeerCount - Returns #-of connected peers, split by who initiated connection
+func (c *ConnectionManager) PeerCount() PeerCount {
+
+	responseChan := make(chan PeerCount)
+	c.PeerCountChan <- responseChan
+
+	// This is a blocking call
+	return <-responseChan
+
+}
+
+// Due - Returns dropped peers, which are due to be attempted to be
+// reconnected with, while scheduling their next attempt
+func (c *ConnectionManager) Due() []peer.AddrInfo {
+
+	responseChan := make(chan []peer.AddrInfo)
+	c.DueChan <- responseChan
+
+	// This is a blocking call
+	return <-responseChan
+
+}
+
+// due - Finds dropped peers, whose wait time is over, scheduling their
+// next attempt. Peer which couldn't be reconnected with, even after
+// configured #-of attempts, is forgotten, though it can still be
+// found again by discovery
+//
+// @note Supposed to be invoked from connection manager's own go routine
+func (c *ConnectionManager) due() []peer.AddrInfo {
+
+	now := time.Now().UTC()
+	peers := make([]peer.AddrInfo, 0)
+
+	for id, r := range c.Redials {
+
+		if now.Before(r.next) {
+			continue
+		}
+
+		if !r.static && r.attempts >= config.GetPeerRedialAttempts() {
+			delete(c.Redials, id)
+			continue
+		}
+
+		r.next = now.Add(jitter(RedialBackoff(r.attempts)))
+		r.attempts++
+
+		peers = append(peers, peer.AddrInfo{ID: id, Addrs: r.addrs})
+
+	}
+
+	return peers
+
+}
+
+// count - #-of connected peers, split by who initiated connection
+//
+// @note Supposed to be invoked from connection manager's own go routine
+func (c *ConnectionManager) count() PeerCount {
+
+	var count PeerCount
+
+	for _, state := range c.Peers {
+
+		if state.Relayed {
+			count.Relayed++
+		}
+
+		if state.Inbound {
+			count.Inbound++
+			continue
+		}
+
+		count.Outbound++
+
+	}
+
+	return count
+
+}
+
+// hasRoom - Checks whether one more inbound/ outbound peer can be connected
+//
+// @note Supposed to be invoked from connection manager's own go routine
+func (c *ConnectionManager) hasRoom(inbound bool) bool {
+
+	count := c.count()
+
+	if count.Inbound+count.Outbound >= config.GetMaxPeers() {
+		return false
+	}
+
+	return !inbound || count.Inbound < config.GetMaxInboundPeers()
+
+}
+
 // Start - Listen for new peer we're getting connected to/ dropped
 //
-// Also clean up list of dropped peers after every `n` time unit,
+// Also clean up list of penalized peers after every `n` time unit,
 // to avoid lock contention as much as possible
 func (c *ConnectionManager) Start(ctx context.Context) {
 
@@ -59,13 +431,57 @@ func (c *ConnectionManager) Start(ctx context.Context) {
 			// Just stop doing what you're doing
 			return
 
-		case peer := <-c.NewPeerChan:
+		case req := <-c.ReserveChan:
 
-			c.Peers[peer] = true
+			if _, ok := c.Peers[req.Peer]; ok {
+				req.Response <- ErrAlreadyConnected
+				break
+			}
 
-		case peer := <-c.DroppedPeerChan:
+			if !c.hasRoom(req.Inbound) {
+				req.Response <- ErrTooManyPeers
+				break
+			}
+
+			c.Peers[req.Peer] = &PeerState{Inbound: req.Inbound, Relayed: req.Relayed, Since: time.Now().UTC()}
+			delete(c.Redials, req.Peer)
+			req.Response <- nil
+
+		case req := <-c.HasRoomChan:
+
+			req.Response <- c.hasRoom(req.Inbound)
+
+		case drop := <-c.DroppedPeerChan:
+
+			if state, ok := c.Peers[drop.Peer]; ok {
+				if t, ok := c.Traffic[drop.Peer]; ok {
+					t.connectedFor += time.Now().UTC().Sub(state.Since)
+				}
+			}
+
+			delete(c.Peers, drop.Peer)
+
+			if addrs, ok := c.Static[drop.Peer]; ok {
+				c.Redials[drop.Peer] = &redial{addrs: addrs, static: true, next: time.Now().UTC().Add(jitter(RedialBackoff(0)))}
+				break
+			}
+
+			// Peer violating protocol is not to be dialed again
+			if _, ok := c.Penalized[drop.Peer]; ok {
+				break
+			}
+
+			// Nowhere to reconnect to
+			if len(drop.Addrs) == 0 {
+				break
+			}
+
+			c.Redials[drop.Peer] = &redial{addrs: drop.Addrs, next: time.Now().UTC().Add(jitter(RedialBackoff(0)))}
 
-			c.Peers[peer] = false
+		case info := <-c.KeepChan:
+
+			c.Static[info.ID] = info.Addrs
+			c.Redials[info.ID] = &redial{addrs: info.Addrs, static: true, next: time.Now().UTC()}
 
 		case query := <-c.IsConnectedChan:
 			// When worker go routines i.e. managing interaction
@@ -76,29 +492,83 @@ func (c *ConnectionManager) Start(ctx context.Context) {
 			//
 			// Client is expected to listen on this channel for response
 
-			v, ok := c.Peers[query.Peer]
+			_, ok := c.Peers[query.Peer]
+			query.Response <- ok
 
-			switch ok {
+		case req := <-c.MisbehavedChan:
 
-			case true:
-				query.Response <- v
-			case false:
-				query.Response <- false
+			s, ok := c.Misbehaviour[req.Peer]
+			if !ok {
+				s = &score{at: time.Now().UTC()}
+				c.Misbehaviour[req.Peer] = s
+			}
 
+			if s.add(time.Now().UTC(), req.Weight) < banScore {
+				req.Response <- false
+				break
 			}
 
-		case <-time.After(time.Duration(10) * time.Millisecond):
+			c.ban(req.Peer)
+			req.Response <- true
+
+		case peer := <-c.PenalizeChan:
+
+			c.ban(peer)
+
+		case req := <-c.ScoresChan:
+
+			req <- c.scores()
+
+		case req := <-c.TrafficOfChan:
+
+			t, ok := c.Traffic[req.Peer]
+			if !ok {
+				t = &traffic{}
+				c.Traffic[req.Peer] = t
+			}
+
+			req.Response <- t
+
+		case req := <-c.TrafficChan:
 
-			peers := make([]peer.ID, 0, len(c.Peers))
+			req <- c.trafficTable()
 
-			for k, v := range c.Peers {
-				if !v {
-					peers = append(peers, k)
+		case query := <-c.IsPenalizedChan:
+
+			until, ok := c.Penalized[query.Peer]
+			query.Response <- ok && time.Now().UTC().Before(until)
+
+		case req := <-c.PeerCountChan:
+
+			req <- c.count()
+
+		case req := <-c.DueChan:
+
+			req <- c.due()
+
+		case req := <-c.FreshChan:
+
+			if last, ok := c.Seqs[req.Peer]; ok && req.Seq <= last {
+				req.Response <- false
+				break
+			}
+
+			c.Seqs[req.Peer] = req.Seq
+			req.Response <- true
+
+		case <-time.After(time.Duration(10) * time.Millisecond):
+
+			for k, until := range c.Penalized {
+				if time.Now().UTC().After(until) {
+					delete(c.Penalized, k)
 				}
 			}
 
-			for _, v := range peers {
-				delete(c.Peers, v)
+			// Score decayed enough, is forgotten
+			for k, s := range c.Misbehaviour {
+				if s.decayed(time.Now().UTC()) < banScore/100 {
+					delete(c.Misbehaviour, k)
+				}
 			}
 
 		}
@@ -108,9 +578,58 @@ func (c *ConnectionManager) Start(ctx context.Context) {
 
 func NewConnectionManager() *ConnectionManager {
 	return &ConnectionManager{
-		Peers:           make(map[peer.ID]bool),
-		NewPeerChan:     make(chan peer.ID, 100),
-		DroppedPeerChan: make(chan peer.ID, 100),
+		Peers:           make(map[peer.ID]*PeerState),
+		Misbehaviour:    make(map[peer.ID]*score),
+		Penalized:       make(map[peer.ID]time.Time),
+		Redials:         make(map[peer.ID]*redial),
+		Static:          make(map[peer.ID][]multiaddr.Multiaddr),
+		Seqs:            make(map[peer.ID]uint64),
+		Traffic:         make(map[peer.ID]*traffic),
+		ReserveChan:     make(chan Reserve, 100),
+		HasRoomChan:     make(chan HasRoom, 100),
+		DroppedPeerChan: make(chan Drop, 100),
+		KeepChan:        make(chan peer.AddrInfo, 100),
 		IsConnectedChan: make(chan IsConnected, 100),
+		MisbehavedChan:  make(chan Misbehaved, 100),
+		ScoresChan:      make(chan chan []*data.PeerScore, 100),
+		PenalizeChan:    make(chan peer.ID, 100),
+		IsPenalizedChan: make(chan IsPenalized, 100),
+		PeerCountChan:   make(chan chan PeerCount, 100),
+		DueChan:         make(chan chan []peer.AddrInfo, 100),
+		FreshChan:       make(chan Fresh, 100),
+		TrafficOfChan:   make(chan TrafficOf, 100),
+		TrafficChan:     make(chan chan []*data.PeerTraffic, 100),
+	}
+}
+
+// RedialBackoff - How long to wait before attempting to reconnect with
+// dropped peer, after `attempts` failed attempts, doubling from configured
+// initial wait time, but never exceeding configured max
+func RedialBackoff(attempts uint64) time.Duration {
+
+	backoff := config.GetPeerRedialBackoffInitial()
+	max := config.GetPeerRedialBackoffMax()
+
+	for i := uint64(0); i < attempts && backoff < max; i++ {
+		backoff *= 2
+	}
+
+	if backoff > max {
+		backoff = max
+	}
+
+	return time.Duration(backoff) * time.Millisecond
+
+}
+
+// jitter - Adds up to half of given wait time, as random jitter, so that
+// peers dropped together don't get redialed together
+func jitter(wait time.Duration) time.Duration {
+
+	if wait/2 <= 0 {
+		return wait
 	}
+
+	return wait + time.Duration(rand.Int63n(int64(wait/2)))
+
 }
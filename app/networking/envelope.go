@@ -0,0 +1,141 @@
+package networking
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// Flag byte, put in front of signed chunk's payload, which is followed by
+// envelope, wrapping chunk as it'd have been sent without signing
+const chunkSigned byte = 0x02
+
+// envelopeDomain - Prepended to signed bytes, so that signature made for
+// some other purpose, using same host key, can't be passed off as ours
+const envelopeDomain = "harmony/envelope/v1"
+
+// Reasons for not accepting signed chunk
+var (
+	errBadEnvelope = errors.New("malformed envelope")
+	errBadSender   = errors.New("envelope not sent by remote peer")
+	errBadSig      = errors.New("bad envelope signature")
+)
+
+// signingKey - Private key of libp2p host, used for signing chunks
+// sent to peers
+var signingKey crypto.PrivKey
+
+// selfId - Peer identifier of libp2p host, put in each envelope
+var selfId peer.ID
+
+// sequence - Last sequence number put in envelope, which starts from time
+// of boot up, so that it keeps increasing across restarts
+var sequence = uint64(time.Now().UTC().UnixNano())
+
+// signable - Bytes to be signed/ verified for given envelope
+func signable(id []byte, seq uint64, inner []byte) []byte {
+
+	buf := make([]byte, 0, len(envelopeDomain)+len(id)+8+len(inner))
+	buf = append(buf, envelopeDomain...)
+	buf = append(buf, id...)
+	buf = append(buf, make([]byte, 8)...)
+	binary.LittleEndian.PutUint64(buf[len(buf)-8:], seq)
+
+	return append(buf, inner...)
+
+}
+
+// seal - Wraps chunk, without length prefix, in envelope, carrying peer
+// identifier of this node, next sequence number & signature made using
+// host key, over all these
+//
+// Layout : flag | id length ( 2 ) | id | sequence ( 8 ) | signature length ( 2 ) | signature | chunk
+func seal(inner []byte) ([]byte, error) {
+
+	id := []byte(selfId)
+	seq := atomic.AddUint64(&sequence, 1)
+
+	sig, err := signingKey.Sign(signable(id, seq, inner))
+	if err != nil {
+		return nil, err
+	}
+
+	sealed := make([]byte, 0, 1+2+len(id)+8+2+len(sig)+len(inner))
+	sealed = append(sealed, chunkSigned)
+
+	sealed = append(sealed, make([]byte, 2)...)
+	binary.LittleEndian.PutUint16(sealed[len(sealed)-2:], uint16(len(id)))
+	sealed = append(sealed, id...)
+
+	sealed = append(sealed, make([]byte, 8)...)
+	binary.LittleEndian.PutUint64(sealed[len(sealed)-8:], seq)
+
+	sealed = append(sealed, make([]byte, 2)...)
+	binary.LittleEndian.PutUint16(sealed[len(sealed)-2:], uint16(len(sig)))
+	sealed = append(sealed, sig...)
+
+	return append(sealed, inner...), nil
+
+}
+
+// isSealed - Checks whether chunk is wrapped in envelope
+func isSealed(chunk []byte) bool {
+	return len(chunk) != 0 && chunk[0] == chunkSigned
+}
+
+// unseal - Verifies envelope was sent by remote peer, on other end of
+// stream, by checking signature using its public key, returning sequence
+// number & wrapped chunk
+func unseal(sealed []byte, remote peer.ID, key crypto.PubKey) (uint64, []byte, error) {
+
+	rest := sealed[1:]
+
+	take := func(n int) []byte {
+		if len(rest) < n {
+			return nil
+		}
+
+		taken := rest[:n]
+		rest = rest[n:]
+		return taken
+	}
+
+	idLen := take(2)
+	if idLen == nil {
+		return 0, nil, errBadEnvelope
+	}
+
+	id := take(int(binary.LittleEndian.Uint16(idLen)))
+	rawSeq := take(8)
+	if id == nil || rawSeq == nil {
+		return 0, nil, errBadEnvelope
+	}
+
+	sigLen := take(2)
+	if sigLen == nil {
+		return 0, nil, errBadEnvelope
+	}
+
+	sig := take(int(binary.LittleEndian.Uint16(sigLen)))
+	if sig == nil {
+		return 0, nil, errBadEnvelope
+	}
+
+	if peer.ID(id) != remote {
+		return 0, nil, errBadSender
+	}
+
+	seq := binary.LittleEndian.Uint64(rawSeq)
+
+	ok, err := key.Verify(signable(id, seq, rest), sig)
+	if err != nil || !ok {
+		return 0, nil, errBadSig
+	}
+
+	return seq, rest, nil
+
+}
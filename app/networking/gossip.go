@@ -0,0 +1,249 @@
+package networking
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/harmony/app/data"
+	"github.com/itzmeanjan/harmony/app/graph"
+	"github.com/itzmeanjan/pub0sub/ops"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+// gossipMode - Whether mempool changes are propagated over gossipsub
+// topic, instead of dedicated stream with each peer
+func gossipMode() bool {
+	return config.GetNetworkingTransport() == "gossipsub"
+}
+
+// gossipTopic - All nodes of cluster advertising with same rendezvous,
+// join same topic
+func gossipTopic() string {
+	return "/harmony/" + config.GetNetworkingRendezvous() + "/txs"
+}
+
+// SetUpGossip - Joins gossipsub topic, where mempool changes are published
+// by each node of cluster, while reading changes published by others
+func SetUpGossip(ctx context.Context, _host host.Host) error {
+
+	ps, err := pubsub.NewGossipSub(ctx, _host, pubsub.WithMaxMessageSize(int(config.GetMaxPeerMessageSize())))
+	if err != nil {
+		return err
+	}
+
+	// Messages published/ relayed by peers, which are not allowlisted, or
+	// relayed by banned peers, are neither delivered to us nor relayed further, while messages published
+	// by this node are always accepted
+	if err := ps.RegisterTopicValidator(gossipTopic(), func(_ context.Context, from peer.ID, msg *pubsub.Message) bool {
+		if from == _host.ID() {
+			return true
+		}
+
+		return isAllowed(from) && isAllowed(msg.GetFrom()) && !connectionManager.IsPenalized(from)
+	}); err != nil {
+		return err
+	}
+
+	topic, err := ps.Join(gossipTopic())
+	if err != nil {
+		return err
+	}
+
+	sub, err := topic.Subscribe()
+	if err != nil {
+		return err
+	}
+
+	go ReadGossip(ctx, _host, sub)
+	go WriteGossip(ctx, topic)
+
+	log.Printf("✅ Joined gossip topic : %s\n", gossipTopic())
+	return nil
+
+}
+
+// ReadGossip - Reads mempool changes published by peers on gossipsub topic,
+// feeding tx(s) to mempool, same as it's done for tx(s) received over stream
+func ReadGossip(ctx context.Context, _host host.Host, sub *pubsub.Subscription) {
+
+	defer sub.Cancel()
+
+	for {
+
+		msg, err := sub.Next(ctx)
+		if err != nil {
+			// Context got cancelled i.e. application is going down
+			return
+		}
+
+		// Published by this node itself
+		if msg.ReceivedFrom == _host.ID() {
+			continue
+		}
+
+		origin := msg.GetFrom()
+
+		payload, err := unframe(msg.Data)
+		if err != nil {
+			log.Printf("[❗️] Failed to decompress gossip message : %s | %s\n", err.Error(), origin)
+
+			if connectionManager.Misbehaved(msg.ReceivedFrom, undecodableWeight()) {
+				dropGossiper(_host, msg.ReceivedFrom)
+			}
+
+			continue
+		}
+
+		txs, err := graph.UnmarshalPubSubMessage(payload)
+		if err != nil {
+			log.Printf("[❗️] Failed to deserialise gossip message : %s | %s\n", err.Error(), origin)
+
+			if connectionManager.Misbehaved(msg.ReceivedFrom, undecodableWeight()) {
+				dropGossiper(_host, msg.ReceivedFrom)
+			}
+
+			continue
+		}
+
+		for _, tx := range txs {
+
+			// Keeping entry of which node originally published this
+			// tx, so that we don't end up publishing it again
+			tx.ReceivedFrom = origin.String()
+
+			status, err := memPool.HandleTxFromPeer(ctx, tx)
+			if err != nil {
+
+				log.Printf("[❗️] Bad tx from peer : %s | %s | %s\n", err.Error(), tx.Hash.Hex(), origin)
+
+				// Peer keeps publishing tx(s), which can't be accepted,
+				// probably it's tracking some other chain
+				if connectionManager.Misbehaved(msg.ReceivedFrom, badTxWeight()) {
+					dropGossiper(_host, msg.ReceivedFrom)
+					break
+				}
+
+				continue
+
+			}
+
+			if status {
+				log.Printf("✅ New tx from peer : %s | %s\n", tx.Hash.Hex(), origin)
+				continue
+			}
+
+			log.Printf("👍 Seen tx from peer : %s | %s\n", tx.Hash.Hex(), origin)
+
+		}
+
+	}
+
+}
+
+// dropGossiper - Peer relaying too much, which can't be validated, is
+// already banned, so it's disconnected
+func dropGossiper(_host host.Host, peerId peer.ID) {
+
+	log.Printf("[❗️] Too many bad messages from peer, banning : %s\n", peerId)
+
+	if err := _host.Network().ClosePeer(peerId); err != nil {
+		log.Printf("[❗️] Failed to close connection with peer : %s\n", err.Error())
+	}
+
+}
+
+// WriteGossip - Publishes mempool changes on gossipsub topic, except
+// tx(s) received from peers, because gossipsub already takes care of
+// delivering them to all nodes of cluster
+func WriteGossip(ctx context.Context, topic *pubsub.Topic) {
+
+	defer func() {
+		if err := topic.Close(); err != nil {
+			log.Printf("[❗️] Failed to leave gossip topic : %s\n", err.Error())
+		}
+	}()
+
+	subscriber, err := graph.SubscribeToMemPool(ctx)
+	if err != nil {
+		log.Printf("[❗️] Failed to subscribe to mempool changes : %s\n", err.Error())
+		return
+	}
+
+	defer func() {
+		if _, err := subscriber.UnsubscribeAll(); err != nil {
+			log.Printf("[❗️] Failed to unsubscribe : %s\n", err.Error())
+		}
+		if err := subscriber.Disconnect(); err != nil {
+			log.Printf("[❗️] Failed to destroy subscriber : %s\n", err.Error())
+		}
+	}()
+
+	process := func(msg *ops.PushedMessage) {
+		unmarshalled, err := graph.UnmarshalPubSubMessage(msg.Data)
+		if err != nil {
+			log.Printf("[❗️] Failed to deserialise pubsub message : %s\n", err.Error())
+			return
+		}
+
+		publishable := make([]*data.MemPoolTx, 0, len(unmarshalled))
+		for _, tx := range unmarshalled {
+			if len(tx.ReceivedFrom) == 0 {
+				publishable = append(publishable, tx)
+			}
+		}
+
+		if len(publishable) == 0 {
+			return
+		}
+
+		payload := msg.Data
+
+		// Some of batched tx(s) were received from peers,
+		// rest of them to be serialized again
+		if len(publishable) != len(unmarshalled) {
+			payload, err = data.SerializeMany(codec, publishable)
+			if err != nil {
+				log.Printf("[❗️] Failed to serialize tx(s) for gossip : %s\n", err.Error())
+				return
+			}
+
+			payload = data.MaybeCompress(payload)
+		}
+
+		// Same as chunk sent over stream, without length prefix,
+		// because gossipsub already frames messages
+		gossip := frame(payload)[4:]
+
+		if uint64(len(gossip)) > config.GetMaxPeerMessageSize() {
+			log.Printf("[❗️] Not publishing gossip of %d bytes, exceeds max message size\n", len(gossip))
+			return
+		}
+
+		if err := topic.Publish(ctx, gossip); err != nil {
+			log.Printf("[❗️] Failed to publish gossip : %s\n", err.Error())
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-subscriber.Watch():
+			for received := subscriber.Next(); received != nil; received = subscriber.Next() {
+				process(received)
+			}
+
+		case <-time.After(time.Duration(256) * time.Millisecond):
+			// Explicitly checking for message availability in queue
+			for received := subscriber.Next(); received != nil; received = subscriber.Next() {
+				process(received)
+			}
+
+		}
+	}
+}
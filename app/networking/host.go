@@ -5,6 +5,7 @@ import (
 	crand "crypto/rand"
 	"fmt"
 	"log"
+	"os"
 	"time"
 
 	"github.com/itzmeanjan/harmony/app/config"
@@ -17,11 +18,69 @@ import (
 	ma "github.com/multiformats/go-multiaddr"
 )
 
+// identityKey - Loads private key of this node from file, if configured, so
+// that it keeps same peer identifier across restarts, where key is generated
+// & persisted in very first run or when regeneration is explicitly asked for.
+// Otherwise new key is generated each time
+//
+// Key file readable by anyone other than owner is refused
+func identityKey() (crypto.PrivKey, error) {
+
+	path := config.GetNetworkingIdentity()
+	if len(path) == 0 {
+		priv, _, err := crypto.GenerateKeyPairWithReader(crypto.RSA, 2048, crand.Reader)
+		return priv, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if err == nil && !config.GetRegenerateIdentity() {
+
+		if perm := info.Mode().Perm(); perm&0077 != 0 {
+			return nil, fmt.Errorf("identity key file `%s` is accessible by others ( %#o ), restrict it to owner", path, perm)
+		}
+
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return crypto.UnmarshalPrivateKey(raw)
+
+	}
+
+	priv, _, err := crypto.GenerateKeyPairWithReader(crypto.RSA, 2048, crand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := crypto.MarshalPrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(path, raw, 0600); err != nil {
+		return nil, err
+	}
+
+	// Existing file keeps its mode, when being overwritten
+	if err := os.Chmod(path, 0600); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[❃] Generated identity key, persisted in `%s`\n", path)
+	return priv, nil
+
+}
+
 // CreateHost - Creates a libp2p host, to be used for communicating
 // with other `harmony` peers
 func CreateHost(ctx context.Context) (host.Host, error) {
 
-	priv, _, err := crypto.GenerateKeyPairWithReader(crypto.RSA, 2048, crand.Reader)
+	priv, err := identityKey()
 	if err != nil {
 		return nil, err
 	}
@@ -29,7 +88,7 @@ func CreateHost(ctx context.Context) (host.Host, error) {
 	identity := libp2p.Identity(priv)
 
 	addrs := libp2p.ListenAddrStrings([]string{
-		fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", config.GetNetworkingPort()),
+		fmt.Sprintf("/ip4/%s/tcp/%d", config.GetNetworkingListenIP(), config.GetNetworkingPort()),
 	}...)
 
 	_tls := libp2p.Security(tls.ID, tls.New)
@@ -41,18 +100,52 @@ func CreateHost(ctx context.Context) (host.Host, error) {
 
 	opts := []libp2p.Option{identity, addrs, _tls, _noise, transports, connManager}
 
+	// Handshake with nodes not knowing pre-shared key fails
+	psk, err := PreSharedKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if psk != nil {
+		opts = append(opts, libp2p.PrivateNetwork(psk))
+	}
+
+	nat, err := natOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, nat...)
+
 	return libp2p.New(ctx, opts...)
 
 }
 
-// ShowHost - Showing on which multi addresses given host is listening on
-func ShowHost(_host host.Host) {
+// dialableAddrs - Multi addresses given host is listening on, each carrying
+// peer identifier, so that they can be put in other nodes' bootstrap/ static
+// peer list, as it is
+func dialableAddrs(_host host.Host) []ma.Multiaddr {
 
 	hostAddr, _ := ma.NewMultiaddr(fmt.Sprintf("/ipfs/%s", _host.ID().Pretty()))
 
+	addrs := make([]ma.Multiaddr, 0, len(_host.Addrs()))
 	for _, addr := range _host.Addrs() {
+		addrs = append(addrs, addr.Encapsulate(hostAddr))
+	}
+
+	return addrs
+
+}
+
+// ShowHost - Showing identity of given host & on which multi addresses
+// it's listening on
+func ShowHost(_host host.Host) {
+
+	log.Printf("🆔 Peer : %s, rendezvous : %s, protocol : %s\n", _host.ID().Pretty(), config.GetNetworkingRendezvous(), streamProtocols()[0])
+
+	for _, addr := range dialableAddrs(_host) {
 
-		log.Printf("📞 Listening on : %s\n", addr.Encapsulate(hostAddr))
+		log.Printf("📞 Listening on : %s\n", addr)
 
 	}
 
@@ -9,76 +9,281 @@ import (
 	"time"
 
 	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/harmony/app/data"
 	"github.com/itzmeanjan/harmony/app/graph"
 	"github.com/itzmeanjan/pub0sub/ops"
+	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/host"
 	"github.com/libp2p/go-libp2p-core/network"
-	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/multiformats/go-multiaddr"
 )
 
+// Flag byte, put in front of each chunk's payload, denoting whether
+// payload is snappy compressed or not
+//
+// Payload of chunks sent by peers without compression support, starts
+// with neither of these, because no supported codec produces payload
+// starting with 0x00/ 0x01/ 0x02/ 0x03/ 0x04, so whole chunk is treated as payload
+const (
+	chunkUncompressed byte = 0x00
+	chunkCompressed   byte = 0x01
+)
+
+// unframe - Strips flag byte off chunk, if present & decompresses payload,
+// if it's flagged to be compressed
+func unframe(chunk []byte) ([]byte, error) {
+
+	if len(chunk) == 0 {
+		return chunk, nil
+	}
+
+	switch chunk[0] {
+
+	case chunkUncompressed:
+		return chunk[1:], nil
+
+	case chunkCompressed:
+		return data.Decompress(chunk[1:])
+
+	default:
+		return chunk, nil
+
+	}
+
+}
+
+// frame - Prepares length prefixed chunk for being sent to peer
+//
+// Flag byte is put only when compression is enabled, so that peers
+// without compression support can still read uncompressed chunks
+func frame(payload []byte) []byte {
+
+	if config.GetCompressionThreshold() == 0 && !data.IsCompressed(payload) {
+
+		chunk := make([]byte, 4+len(payload))
+		binary.LittleEndian.PutUint32(chunk[:4], uint32(len(payload)))
+		copy(chunk[4:], payload)
+
+		return chunk
+
+	}
+
+	flag := chunkUncompressed
+	if data.IsCompressed(payload) {
+		flag = chunkCompressed
+	}
+
+	chunk := make([]byte, 5+len(payload))
+	binary.LittleEndian.PutUint32(chunk[:4], uint32(1+len(payload)))
+	chunk[4] = flag
+	copy(chunk[5:], payload)
+
+	return chunk
+
+}
+
+// prefixed - Puts length prefix in front of chunk being sent to peer
+func prefixed(body []byte) []byte {
+
+	chunk := make([]byte, 4+len(body))
+	binary.LittleEndian.PutUint32(chunk[:4], uint32(len(body)))
+	copy(chunk[4:], body)
+
+	return chunk
+
+}
+
 // ReadFrom - Read from stream & attempt to deserialize length prefixed
 // tx data received from peer, which will be acted upon
-func ReadFrom(ctx context.Context, healthChan chan struct{}, rw *bufio.ReadWriter, peerId string, remote multiaddr.Multiaddr) {
+//
+// Signed chunk is verified using public key of peer, on other end of
+// stream, while unsigned ones are accepted only if asked for
+//
+// Peer speaking keepalive capable protocol, going silent for too long,
+// is considered dead
+//
+// Any failure in reading from stream, including peer closing it, makes it
+// return, letting stream handler know, so that it can tear down stream
+func ReadFrom(ctx context.Context, healthChan chan struct{}, stream network.Stream, rw *bufio.ReadWriter, peerId peer.ID, remoteKey crypto.PubKey, remote multiaddr.Multiaddr, counters *traffic) {
 	defer func() {
 		close(healthChan)
 	}()
 
-OUT:
+	keepalive := protocolVersion(stream) >= protocolV2
+
 	for {
-		select {
-		case <-ctx.Done():
-			break OUT
+		// Reads are blocking, so it's checked before each one
+		if ctx.Err() != nil {
+			return
+		}
 
-		default:
-			buf := make([]byte, 4)
+		if keepalive {
+			if err := stream.SetReadDeadline(time.Now().Add(deadAfter())); err != nil {
+				log.Printf("[❗️] Failed to set read deadline : %s | %s\n", err.Error(), remote)
+				return
+			}
+		}
 
-			if _, err := io.ReadFull(rw.Reader, buf); err != nil {
-				if err == io.EOF {
-					break
-				}
+		buf := make([]byte, 4)
 
+		if _, err := io.ReadFull(rw.Reader, buf); err != nil {
+			if err != io.EOF {
 				log.Printf("[❗️] Failed to read size of next chunk : %s | %s\n", err.Error(), remote)
-				break
 			}
 
-			size := binary.LittleEndian.Uint32(buf)
-			chunk := make([]byte, size)
+			return
+		}
 
-			if _, err := io.ReadFull(rw.Reader, chunk); err != nil {
-				if err == io.EOF {
-					break
-				}
+		// Peer can't make us allocate arbitrarily large buffer
+		size := binary.LittleEndian.Uint32(buf)
+		if size == 0 || uint64(size) > config.GetMaxPeerMessageSize() {
+			log.Printf("[❗️] Protocol violation, chunk of %d bytes announced by peer, dropping : %s\n", size, remote)
+			connectionManager.Penalize(peerId)
+			return
+		}
 
-				log.Printf("[❗️] Failed to read chunk from peer : %s | %s\n", err.Error(), remote)
-				break
+		chunk := make([]byte, size)
+
+		if _, err := io.ReadFull(rw.Reader, chunk); err != nil {
+			log.Printf("[❗️] Failed to read chunk from peer : %s | %s\n", err.Error(), remote)
+			return
+		}
+
+		counters.read(4 + len(chunk))
+
+		// Peer is alive, nothing else to do
+		if isPing(chunk) {
+			continue
+		}
+
+		if isSealed(chunk) {
+
+			seq, inner, err := unseal(chunk, peerId, remoteKey)
+			if err != nil {
+				log.Printf("[❗️] Protocol violation, %s, dropping : %s\n", err.Error(), remote)
+				connectionManager.Penalize(peerId)
+				return
 			}
 
-			tx := graph.UnmarshalPubSubMessage(chunk)
-			if tx == nil {
-				log.Printf("[❗️] Failed to deserialise message from peer | %s\n", remote)
+			if !connectionManager.Fresh(peerId, seq) {
+				log.Printf("[❗️] Replayed chunk from peer, ignoring : %d | %s\n", seq, remote)
 				continue
 			}
 
-			// Keeping entry of from which peer we received this tx
-			// so that we don't end up sending them again same tx
-			// when it'll be published on Pub/Sub topic
-			tx.ReceivedFrom = peerId
+			chunk = inner
+
+		} else if !config.GetAcceptUnsignedPeerMessages() {
+			log.Printf("[❗️] Unsigned chunk from peer, ignoring : %s\n", remote)
+			continue
+		}
+
+		bodies := [][]byte{chunk}
+
+		// Multiple chunks, sent by peer in one go
+		if isBatch(chunk) {
+
+			batched, err := unbatch(chunk)
+			if err != nil {
+				log.Printf("[❗️] Failed to unbatch chunk from peer : %s | %s\n", err.Error(), remote)
+
+				if connectionManager.Misbehaved(peerId, undecodableWeight()) {
+					log.Printf("[❗️] Too many undecodable chunks from peer, banning : %s\n", remote)
+					return
+				}
 
-			if memPool.HandleTxFromPeer(ctx, tx) {
-				log.Printf("✅ New tx from peer : %d bytes | %s\n", len(chunk), remote)
 				continue
 			}
 
-			log.Printf("👍 Seen tx from peer : %d bytes | %s\n", len(chunk), remote)
+			bodies = batched
+
+		}
 
+		for _, body := range bodies {
+			if !handleChunk(ctx, body, peerId, remote, counters) {
+				return
+			}
 		}
 	}
 }
 
+// handleChunk - Attempts to deserialize tx(s) from chunk, received from peer,
+// which are fed to mempool, returning false, when peer gets banned, so that
+// stream can be torn down
+func handleChunk(ctx context.Context, chunk []byte, peerId peer.ID, remote multiaddr.Multiaddr, counters *traffic) bool {
+
+	payload, err := unframe(chunk)
+	if err != nil {
+		log.Printf("[❗️] Failed to decompress chunk from peer : %s | %s\n", err.Error(), remote)
+
+		if connectionManager.Misbehaved(peerId, undecodableWeight()) {
+			log.Printf("[❗️] Too many undecodable chunks from peer, banning : %s\n", remote)
+			return false
+		}
+
+		return true
+	}
+
+	txs, err := graph.UnmarshalPubSubMessage(payload)
+	if err != nil {
+		log.Printf("[❗️] Failed to deserialise message from peer : %s | %s\n", err.Error(), remote)
+
+		if connectionManager.Misbehaved(peerId, undecodableWeight()) {
+			log.Printf("[❗️] Too many undecodable chunks from peer, banning : %s\n", remote)
+			return false
+		}
+
+		return true
+	}
+
+	for _, tx := range txs {
+
+		// Keeping entry of from which peer we received this tx
+		// so that we don't end up sending them again same tx
+		// when it'll be published on Pub/Sub topic
+		tx.ReceivedFrom = peerId.String()
+
+		status, err := memPool.HandleTxFromPeer(ctx, tx)
+		if err != nil {
+
+			log.Printf("[❗️] Bad tx from peer : %s | %s | %s\n", err.Error(), tx.Hash.Hex(), remote)
+			counters.rejected()
+
+			// Peer keeps sending tx(s), which can't be accepted,
+			// probably it's tracking some other chain
+			if connectionManager.Misbehaved(peerId, badTxWeight()) {
+				log.Printf("[❗️] Too many bad tx(s) from peer, banning : %s\n", remote)
+				return false
+			}
+
+			continue
+
+		}
+
+		if status {
+			log.Printf("✅ New tx from peer : %s | %s\n", tx.Hash.Hex(), remote)
+			counters.accepted()
+			continue
+		}
+
+		log.Printf("👍 Seen tx from peer : %s | %s\n", tx.Hash.Hex(), remote)
+
+	}
+
+	return true
+
+}
+
 // WriteTo - Write to mempool changes into stream i.e. connection
 // with some remote peer
-func WriteTo(ctx context.Context, healthChan chan struct{}, rw *bufio.ReadWriter, peerId string, remote multiaddr.Multiaddr) {
+//
+// Peer speaking keepalive capable protocol is pinged, when nothing is
+// written to it for a while, while peer not draining what's written,
+// is considered dead
+//
+// Peer speaking batching capable protocol gets queued up changes coalesced
+// into single frame, until it grows large enough or gets old enough
+func WriteTo(ctx context.Context, healthChan chan struct{}, stream network.Stream, rw *bufio.ReadWriter, peerId string, remote multiaddr.Multiaddr, counters *traffic) {
 	defer func() {
 		close(healthChan)
 	}()
@@ -98,38 +303,143 @@ func WriteTo(ctx context.Context, healthChan chan struct{}, rw *bufio.ReadWriter
 		}
 	}()
 
-	process := func(msg *ops.PushedMessage) error {
-		unmarshalled := graph.UnmarshalPubSubMessage(msg.Data)
-		if unmarshalled == nil {
+	lastWrite := time.Now()
+
+	write := func(chunk []byte) error {
+		if err := stream.SetWriteDeadline(time.Now().Add(deadAfter())); err != nil {
+			return err
+		}
+
+		if _, err := rw.Write(chunk); err != nil {
+			return err
+		}
+
+		if err := rw.Flush(); err != nil {
+			return err
+		}
+
+		lastWrite = time.Now()
+		counters.wrote(len(chunk))
+		return nil
+	}
+
+	// Peer attributes chunk to this node, by verifying signature
+	sealAndWrite := func(body []byte) error {
+		sealed, err := seal(body)
+		if err != nil {
+			log.Printf("[❗️] Failed to sign chunk for peer : %s\n", err.Error())
 			return nil
 		}
 
-		// Received from same peer, no need to let them
-		// know again
-		if unmarshalled.ReceivedFrom == peerId {
+		chunk := prefixed(sealed)
+
+		// Peer is going to consider it protocol violation
+		if uint64(len(chunk)-4) > config.GetMaxPeerMessageSize() {
+			log.Printf("[❗️] Not sending chunk of %d bytes, exceeds max message size : %s\n", len(chunk)-4, remote)
 			return nil
 		}
 
-		chunk := make([]byte, 4+len(msg.Data))
-		binary.LittleEndian.PutUint32(chunk[:4], uint32(len(msg.Data)))
-		n := copy(chunk[4:], msg.Data)
+		return write(chunk)
+	}
+
+	batching := protocolVersion(stream) >= protocolV3
+	limit := batchLimit()
+
+	pending := make([][]byte, 0)
+	var pendingSize int
+	// Fires when oldest pending chunk has waited for long enough
+	var flushAt <-chan time.Time
 
-		if n != len(msg.Data) {
+	flush := func() error {
+		if len(pending) == 0 {
 			return nil
 		}
 
-		if _, err := rw.Write(chunk); err != nil {
-			return err
+		body := pending[0]
+		if len(pending) > 1 {
+			body = batch(pending)
 		}
 
-		if err := rw.Flush(); err != nil {
-			return err
+		pending = pending[:0]
+		pendingSize = 0
+		flushAt = nil
+
+		return sealAndWrite(body)
+	}
+
+	send := func(body []byte) error {
+		if !batching {
+			return sealAndWrite(body)
+		}
+
+		// Batch can't grow beyond limit
+		if len(pending) != 0 && pendingSize+4+len(body) > limit {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+
+		pending = append(pending, body)
+		pendingSize += 4 + len(body)
+
+		if pendingSize >= limit {
+			return flush()
+		}
+
+		if flushAt == nil {
+			flushAt = time.After(batchDelay())
 		}
 
 		return nil
 	}
+
+	process := func(msg *ops.PushedMessage) error {
+		unmarshalled, err := graph.UnmarshalPubSubMessage(msg.Data)
+		if err != nil {
+			log.Printf("[❗️] Failed to deserialise pubsub message : %s\n", err.Error())
+			return nil
+		}
+
+		// Received from same peer, no need to let them
+		// know again
+		sendable := make([]*data.MemPoolTx, 0, len(unmarshalled))
+		for _, tx := range unmarshalled {
+			if tx.ReceivedFrom != peerId {
+				sendable = append(sendable, tx)
+			}
+		}
+
+		if len(sendable) == 0 {
+			return nil
+		}
+
+		payload := msg.Data
+
+		// Some of batched tx(s) were received from same peer,
+		// rest of them to be serialized again
+		if len(sendable) != len(unmarshalled) {
+			payload, err = data.SerializeMany(codec, sendable)
+			if err != nil {
+				log.Printf("[❗️] Failed to serialize tx(s) for peer : %s\n", err.Error())
+				return nil
+			}
+
+			payload = data.MaybeCompress(payload)
+		}
+
+		return send(frame(payload)[4:])
+	}
 	duration := time.Duration(256) * time.Millisecond
 
+	// Older peers don't understand pings
+	var pings <-chan time.Time
+	if protocolVersion(stream) >= protocolV2 {
+		ticker := time.NewTicker(pingInterval())
+		defer ticker.Stop()
+
+		pings = ticker.C
+	}
+
 OUT:
 	for {
 		select {
@@ -148,6 +458,22 @@ OUT:
 				break OUT
 			}
 
+		case <-flushAt:
+			if err := flush(); err != nil {
+				log.Printf("[❗️] Failed to notify peer : %s\n", err.Error())
+				break OUT
+			}
+
+		case <-pings:
+			if time.Since(lastWrite) < pingInterval() {
+				break
+			}
+
+			if err := write(prefixed([]byte{chunkPing})); err != nil {
+				log.Printf("[❗️] Failed to ping peer : %s | %s\n", err.Error(), remote)
+				break OUT
+			}
+
 		case <-time.After(duration):
 			// Explicitly checking for message availability in queue
 			if !subscriber.Queued() {
@@ -155,7 +481,7 @@ OUT:
 			}
 
 			started := time.Now()
-			for received := subscriber.Next(); received != nil; {
+			for received := subscriber.Next(); received != nil; received = subscriber.Next() {
 				if err := process(received); err != nil {
 					log.Printf("[❗️] Failed to notify peer : %s\n", err.Error())
 					break OUT
@@ -176,19 +502,29 @@ func HandleStream(stream network.Stream) {
 	remote := stream.Conn().RemoteMultiaddr()
 	peerId := stream.Conn().RemotePeer()
 
-	// We're already connected with this peer, we're closing this stream
-	if connectionManager.IsConnected(peerId) {
+	// Peer violated protocol recently, it's not welcome
+	if connectionManager.IsPenalized(peerId) {
 
-		log.Printf("[🙃] Duplicate connection to peer : %s, dropping\n", remote)
+		log.Printf("[🙃] Banned peer : %s, dropping\n", remote)
 
-		// Closing stream, may be it's already closed
-		if err := stream.Close(); err != nil {
-			log.Printf("[❗️] Failed to close stream : %s\n", err.Error())
+		if err := stream.Reset(); err != nil {
+			log.Printf("[❗️] Failed to reset stream : %s\n", err.Error())
+		}
+
+		return
+
+	}
+
+	// Not allowlisted peer is not welcome
+	if !isAllowed(peerId) {
+
+		log.Printf("[🙃] Not allowlisted peer : %s, dropping\n", remote)
+		rejected()
+
+		if err := stream.Reset(); err != nil {
+			log.Printf("[❗️] Failed to reset stream : %s\n", err.Error())
 		}
 
-		// Marking that we're not connected to this peer
-		// We can attempt to connect to it, in future iteration
-		connectionManager.Dropped(peerId)
 		return
 
 	}
@@ -196,17 +532,56 @@ func HandleStream(stream network.Stream) {
 	// Marking we're already connect to this peer, so
 	// when next time we start discovering peers, we don't
 	// connect to them again
-	connectionManager.Added(peerId)
+	//
+	// If we're already connected with this peer or there's no room
+	// for one more, this stream is closed, keeping existing ones
+	if err := connectionManager.Reserve(peerId, stream.Stat().Direction == network.DirInbound, isRelayed(remote)); err != nil {
+
+		log.Printf("[🙃] Not accepting peer : %s, %s\n", remote, err.Error())
+
+		// Closing stream, may be it's already closed
+		if err := stream.Close(); err != nil {
+			log.Printf("[❗️] Failed to close stream : %s\n", err.Error())
+		}
+
+		return
+
+	}
+
+	rw := bufio.NewReadWriter(bufio.NewReader(stream), bufio.NewWriter(stream))
+	remoteKey := stream.Conn().RemotePublicKey()
+
+	// Counters are kept for whole session, so they survive reconnections
+	counters := connectionManager.TrafficOf(peerId)
+
+	if isRelayed(remote) {
+		log.Printf("🤩 Got new stream from peer, via relay : %s\n", remote)
+	} else {
+		log.Printf("🤩 Got new stream from peer : %s\n", remote)
+	}
+
+	// @note This is a blocking call
+	serve(stream, peerId, remote,
+		func(ctx context.Context, healthChan chan struct{}) {
+			ReadFrom(ctx, healthChan, stream, rw, peerId, remoteKey, remote, counters)
+		},
+		func(ctx context.Context, healthChan chan struct{}) {
+			WriteTo(ctx, healthChan, stream, rw, peerId.String(), remote, counters)
+		})
+
+}
+
+// serve - Runs reader & writer of stream, which has got slot reserved for
+// peer, until either of them is done, after which stream is torn down &
+// slot is given back to connection manager
+func serve(stream network.Stream, peerId peer.ID, remote multiaddr.Multiaddr, reader func(context.Context, chan struct{}), writer func(context.Context, chan struct{})) {
 
 	ctx, cancel := context.WithCancel(parentCtx)
 	readerHealth := make(chan struct{})
 	writerHealth := make(chan struct{})
-	rw := bufio.NewReadWriter(bufio.NewReader(stream), bufio.NewWriter(stream))
 
-	go ReadFrom(ctx, readerHealth, rw, peerId.String(), remote)
-	go WriteTo(ctx, writerHealth, rw, peerId.String(), remote)
-
-	log.Printf("🤩 Got new stream from peer : %s\n", remote)
+	go reader(ctx, readerHealth)
+	go writer(ctx, writerHealth)
 
 	// @note This is a blocking call
 	select {
@@ -221,14 +596,30 @@ func HandleStream(stream network.Stream) {
 	}
 
 	// Connection manager also knows this peer can be attempted to be
-	// reconnected, if founded via discovery service
-	connectionManager.Dropped(peerId)
+	// reconnected, at addresses it's known to be reachable at
+	connectionManager.Dropped(peerId, knownAddrs(peerId))
 	log.Printf("🙂 Dropped peer connection : %s\n", remote)
 
 }
 
+// knownAddrs - Addresses peer is known to be reachable at, as per peer
+// store of local host, none when host isn't set up
+func knownAddrs(peerId peer.ID) []multiaddr.Multiaddr {
+
+	if localHost == nil {
+		return nil
+	}
+
+	return localHost.Peerstore().Addrs(peerId)
+
+}
+
 // Listen - Handle incoming connection of other harmony peer for certain supported
 // protocol(s)
+//
+// Handler is set for each supported version of stream protocol
 func Listen(_host host.Host) {
-	_host.SetStreamHandler(protocol.ID(config.GetNetworkingStream()), HandleStream)
+	for _, id := range streamProtocols() {
+		_host.SetStreamHandler(id, HandleStream)
+	}
 }
@@ -0,0 +1,253 @@
+package networking
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"math"
+	"net"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// pipeStream - Stream backed by one end of in-memory pipe, where anything
+// not needed by stream workers isn't implemented
+type pipeStream struct {
+	network.Stream
+	conn     net.Conn
+	protocol protocol.ID
+}
+
+func (p *pipeStream) Read(b []byte) (int, error)  { return p.conn.Read(b) }
+func (p *pipeStream) Write(b []byte) (int, error) { return p.conn.Write(b) }
+func (p *pipeStream) Close() error                { return p.conn.Close() }
+func (p *pipeStream) Reset() error                { return p.conn.Close() }
+func (p *pipeStream) Protocol() protocol.ID       { return p.protocol }
+
+func (p *pipeStream) SetDeadline(t time.Time) error      { return p.conn.SetDeadline(t) }
+func (p *pipeStream) SetReadDeadline(t time.Time) error  { return p.conn.SetReadDeadline(t) }
+func (p *pipeStream) SetWriteDeadline(t time.Time) error { return p.conn.SetWriteDeadline(t) }
+
+// testRemote - Address of peer on other end of pipe, used for logging only
+var testRemote = multiaddr.StringCast("/ip4/127.0.0.1/tcp/7001")
+
+// newPipeStream - Stream speaking latest protocol version, along with other
+// end of pipe, playing remote peer, both closed once test is done
+func newPipeStream(tb testing.TB) (*pipeStream, net.Conn) {
+	tb.Helper()
+
+	local, remote := net.Pipe()
+	tb.Cleanup(func() {
+		local.Close()
+		remote.Close()
+	})
+
+	return &pipeStream{conn: local, protocol: streamProtocols()[0]}, remote
+}
+
+// startReading - Runs reader of stream, returning channel which gets closed
+// when reader is done
+func startReading(tb testing.TB, ctx context.Context, stream *pipeStream, peerId peer.ID) chan struct{} {
+	tb.Helper()
+
+	healthChan := make(chan struct{})
+	rw := bufio.NewReadWriter(bufio.NewReader(stream), bufio.NewWriter(stream))
+
+	go ReadFrom(ctx, healthChan, stream, rw, peerId, nil, testRemote, connectionManager.TrafficOf(peerId))
+
+	return healthChan
+}
+
+// waitDone - Waits for channel to be closed, failing test if it takes
+// too long
+func waitDone(tb testing.TB, done chan struct{}, what string) {
+	tb.Helper()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		tb.Fatalf("expected %s", what)
+	}
+}
+
+// announce - Writes length prefix of next chunk, as peer would
+func announce(tb testing.TB, remote net.Conn, size uint32) {
+	tb.Helper()
+
+	prefix := make([]byte, 4)
+	binary.LittleEndian.PutUint32(prefix, size)
+
+	if _, err := remote.Write(prefix); err != nil {
+		tb.Fatal(err)
+	}
+}
+
+func TestStreamCloseReleasesSlot(t *testing.T) {
+
+	cases := []struct {
+		name  string
+		close func(t *testing.T, stream *pipeStream, remote net.Conn)
+	}{
+		{
+			name: "peer closes",
+			close: func(t *testing.T, stream *pipeStream, remote net.Conn) {
+				remote.Close()
+			},
+		},
+		{
+			name: "peer closes mid chunk",
+			close: func(t *testing.T, stream *pipeStream, remote net.Conn) {
+				announce(t, remote, 100)
+				if _, err := remote.Write(make([]byte, 10)); err != nil {
+					t.Fatal(err)
+				}
+				remote.Close()
+			},
+		},
+		{
+			name: "we close",
+			close: func(t *testing.T, stream *pipeStream, remote net.Conn) {
+				stream.Close()
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+
+			manager := withConnectionManager(t)
+			stream, remote := newPipeStream(t)
+			peerId := peer.ID("peer-" + c.name)
+
+			if err := manager.Reserve(peerId, true, false); err != nil {
+				t.Fatal(err)
+			}
+
+			served := make(chan struct{})
+			go func() {
+				defer close(served)
+
+				serve(stream, peerId, testRemote,
+					func(ctx context.Context, healthChan chan struct{}) {
+						rw := bufio.NewReadWriter(bufio.NewReader(stream), bufio.NewWriter(stream))
+						ReadFrom(ctx, healthChan, stream, rw, peerId, nil, testRemote, manager.TrafficOf(peerId))
+					},
+					// Writer only stops when it's asked to
+					func(ctx context.Context, healthChan chan struct{}) {
+						defer close(healthChan)
+						<-ctx.Done()
+					})
+			}()
+
+			c.close(t, stream, remote)
+			waitDone(t, served, "stream to be torn down once it's closed")
+
+			if manager.IsConnected(peerId) {
+				t.Fatal("expected peer's slot to be released")
+			}
+
+			if err := manager.Reserve(peerId, true, false); err != nil {
+				t.Fatalf("expected peer to be accepted again, got %s", err.Error())
+			}
+
+		})
+	}
+
+}
+
+func TestHostileLengthPrefix(t *testing.T) {
+
+	limit := config.GetMaxPeerMessageSize()
+
+	cases := []struct {
+		name string
+		size uint32
+	}{
+		{"zero length", 0},
+		{"just beyond limit", uint32(limit + 1)},
+		{"twice the limit", uint32(limit * 2)},
+		{"max length", math.MaxUint32},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+
+			manager := withConnectionManager(t)
+			stream, remote := newPipeStream(t)
+			peerId := peer.ID("peer-" + c.name)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			done := startReading(t, ctx, stream, peerId)
+
+			var before, after runtime.MemStats
+			runtime.ReadMemStats(&before)
+
+			announce(t, remote, c.size)
+			waitDone(t, done, "reader to return on hostile length prefix")
+
+			runtime.ReadMemStats(&after)
+
+			// Announced buffer must never be allocated
+			if c.size != 0 && after.TotalAlloc-before.TotalAlloc >= uint64(c.size) {
+				t.Fatalf("expected no buffer to be allocated, %d bytes allocated", after.TotalAlloc-before.TotalAlloc)
+			}
+
+			if !manager.IsPenalized(peerId) {
+				t.Fatal("expected peer to be penalized")
+			}
+
+			// Nothing more is read from peer
+			if err := remote.SetWriteDeadline(time.Now().Add(100 * time.Millisecond)); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := remote.Write(make([]byte, 1)); err == nil {
+				t.Fatal("expected reader to stop reading from peer")
+			}
+
+		})
+	}
+
+}
+
+func TestLengthPrefixWithinLimit(t *testing.T) {
+
+	withConfig(t, map[string]string{"MaxPeerMessageSize": "64"})
+
+	manager := withConnectionManager(t)
+	stream, remote := newPipeStream(t)
+	peerId := peer.ID("peer-within-limit")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := startReading(t, ctx, stream, peerId)
+
+	for _, size := range []uint32{1, 64} {
+
+		announce(t, remote, size)
+
+		// Unsigned chunk is ignored, without penalizing peer
+		if _, err := remote.Write(make([]byte, size)); err != nil {
+			t.Fatal(err)
+		}
+
+	}
+
+	remote.Close()
+	waitDone(t, done, "reader to return once peer closes stream")
+
+	if manager.IsPenalized(peerId) {
+		t.Fatal("expected peer sending chunks within limit not to be penalized")
+	}
+
+}
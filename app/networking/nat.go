@@ -0,0 +1,116 @@
+package networking
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/event"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// reachability - Latest reachability of this node, as observed by AutoNAT,
+// kept as `network.Reachability`
+var reachability int32
+
+// Reachability - Whether this node is found to be dialable by peers
+func Reachability() network.Reachability {
+	return network.Reachability(atomic.LoadInt32(&reachability))
+}
+
+// Relays - Returns user supplied circuit relays, where each of them must be
+// valid multi address, carrying peer identifier
+func Relays() ([]peer.AddrInfo, error) {
+
+	return addrInfos("relay", config.GetNetworkingRelays())
+
+}
+
+// natOptions - Host options for being reachable from behind NAT, where port
+// mapping is attempted & AutoNAT service is run, when asked for. Relay client
+// is enabled only when relays are given
+//
+// @note Hole punching isn't enabled, because `libp2p.EnableHolePunching` first
+// appears in go-libp2p v0.16, while this module is still on v0.13 & its
+// go-libp2p-core based APIs. Until that upgrade lands, relayed connections
+// stay relayed
+func natOptions() ([]libp2p.Option, error) {
+
+	opts := make([]libp2p.Option, 0)
+
+	if config.GetNetworkingNATTraversal() {
+		opts = append(opts, libp2p.NATPortMap(), libp2p.EnableNATService())
+	}
+
+	relays, err := Relays()
+	if err != nil {
+		return nil, err
+	}
+
+	// Relay addresses are advertised, only when AutoNAT finds
+	// this node to be unreachable
+	if len(relays) != 0 {
+		opts = append(opts, libp2p.EnableRelay(), libp2p.EnableAutoRelay(), libp2p.StaticRelays(relays))
+	}
+
+	return opts, nil
+
+}
+
+// WatchReachability - Keeps track of reachability of this node, as it's
+// observed by AutoNAT, logging each change, until asked to stop
+func WatchReachability(ctx context.Context, _host host.Host) {
+
+	sub, err := _host.EventBus().Subscribe(new(event.EvtLocalReachabilityChanged))
+	if err != nil {
+
+		log.Printf("[❗️] Failed to watch reachability : %s\n", err.Error())
+		return
+
+	}
+
+	defer func() {
+
+		if err := sub.Close(); err != nil {
+			log.Printf("[❗️] Failed to stop watching reachability : %s\n", err.Error())
+		}
+
+	}()
+
+	for {
+		select {
+
+		case <-ctx.Done():
+			return
+
+		case e, ok := <-sub.Out():
+			if !ok {
+				return
+			}
+
+			changed, ok := e.(event.EvtLocalReachabilityChanged)
+			if !ok {
+				break
+			}
+
+			atomic.StoreInt32(&reachability, int32(changed.Reachability))
+			log.Printf("[❃] Reachability : %s\n", changed.Reachability)
+
+		}
+	}
+
+}
+
+// isRelayed - Whether connection with peer, at given remote address,
+// goes through circuit relay
+func isRelayed(remote multiaddr.Multiaddr) bool {
+
+	_, err := remote.ValueForProtocol(multiaddr.P_CIRCUIT)
+	return err == nil
+
+}
@@ -2,44 +2,105 @@ package networking
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
 	"github.com/itzmeanjan/harmony/app/config"
 	_discovery "github.com/libp2p/go-libp2p-core/discovery"
 	"github.com/libp2p/go-libp2p-core/host"
-	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/peerstore"
-	"github.com/libp2p/go-libp2p-core/protocol"
 	discovery "github.com/libp2p/go-libp2p-discovery"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	"github.com/multiformats/go-multiaddr"
 )
 
-// BootstrapPeers - Returns addresses of bootstrap nodes, if none are given
-// using default ones
-func BootstrapPeers() []multiaddr.Multiaddr {
+// BootstrapPeers - Returns addresses of user supplied bootstrap nodes, along
+// with IPFS provided default ones, only if explicitly asked for
+//
+// Each of user supplied ones must be valid multi address, carrying peer
+// identifier, otherwise error pointing to bad entry is returned. Same
+// address given more than once is dialed only once
+func BootstrapPeers() ([]multiaddr.Multiaddr, error) {
 
-	addr, err := multiaddr.NewMultiaddr(config.GetBootstrapPeer())
-	if err != nil {
+	given := config.GetBootstrapPeers()
+	peers := make([]multiaddr.Multiaddr, 0, len(given))
+	seen := make(map[string]bool, len(given))
+
+	for i, v := range given {
+
+		addr, err := multiaddr.NewMultiaddr(v)
+		if err != nil {
+			return nil, fmt.Errorf("bad bootstrap node address %d `%s` : %s", i+1, v, err.Error())
+		}
+
+		if _, err := peer.AddrInfoFromP2pAddr(addr); err != nil {
+			return nil, fmt.Errorf("bad bootstrap node address %d `%s` : %s", i+1, v, err.Error())
+		}
+
+		if seen[addr.String()] {
+			continue
+		}
+
+		seen[addr.String()] = true
+		peers = append(peers, addr)
+
+	}
+
+	if config.GetDefaultBootstrapPeers() {
+		peers = append(peers, dht.DefaultBootstrapPeers...)
+	}
+
+	return peers, nil
+
+}
+
+// StaticPeers - Returns user supplied static peers, to be dialed directly &
+// kept connected with, where each of them must be valid multi address,
+// carrying peer identifier, otherwise error pointing to bad entry is returned
+func StaticPeers() ([]peer.AddrInfo, error) {
+
+	return addrInfos("static peer", config.GetStaticPeers())
+
+}
+
+// addrInfos - Parses each given multi address, which must carry peer
+// identifier, otherwise error pointing to bad entry of `kind` is returned
+func addrInfos(kind string, given []string) ([]peer.AddrInfo, error) {
+
+	peers := make([]peer.AddrInfo, 0, len(given))
+
+	for i, v := range given {
+
+		addr, err := multiaddr.NewMultiaddr(v)
+		if err != nil {
+			return nil, fmt.Errorf("bad %s address %d `%s` : %s", kind, i+1, v, err.Error())
+		}
+
+		info, err := peer.AddrInfoFromP2pAddr(addr)
+		if err != nil {
+			return nil, fmt.Errorf("bad %s address %d `%s` : %s", kind, i+1, v, err.Error())
+		}
 
-		log.Printf("[❗️] Using default bootstrap nodes : %s\n", err.Error())
-		return dht.DefaultBootstrapPeers
+		peers = append(peers, *info)
 
 	}
 
-	return []multiaddr.Multiaddr{addr}
+	return peers, nil
 
 }
 
 // ConnectToBootstraps - Attempting to connect to bootstrap nodes concurrently
 // Waiting for all of them to complete, after that returning back how many
 // attempts went successful among total attempts, respectively
-func ConnectToBootstraps(ctx context.Context, _host host.Host) (int, int) {
+func ConnectToBootstraps(ctx context.Context, _host host.Host, bootstrapPeers []multiaddr.Multiaddr) (int, int) {
 
-	bootstrapPeers := BootstrapPeers()
 	expected := len(bootstrapPeers)
+	if expected == 0 {
+		return 0, 0
+	}
+
 	connectBoot := make(chan bool, expected)
 
 	for _, addr := range bootstrapPeers {
@@ -97,11 +158,32 @@ func ConnectToBootstraps(ctx context.Context, _host host.Host) (int, int) {
 
 }
 
-// LookForPeers - Asks this node to start looking for peers, does for some time & attempts to connect
-// to them, while setting up stream for further chit-chat
-func LookForPeers(ctx context.Context, _host host.Host, _dht *dht.IpfsDHT, routing *discovery.RoutingDiscovery, comm chan struct{}) {
+// dial - Attempts to open stream with peer, which is handled in its own
+// go routine, returning whether it went successful or not
+//
+// When mempool changes are propagated over gossipsub, only connection
+// is established, gossipsub takes care of rest
+func dial(ctx context.Context, _host host.Host, info peer.AddrInfo) bool {
+
+	if gossipMode() {
+		return _host.Connect(ctx, info) == nil
+	}
+
+	// Newest version of stream protocol, peer speaks, is negotiated
+	stream, err := _host.NewStream(ctx, info.ID, streamProtocols()...)
+	if err != nil {
+		return false
+	}
+
+	go HandleStream(stream)
+	return true
 
-	defer close(comm)
+}
+
+// LookForPeers - Asks this node to look for peers with same rendezvous, until
+// discovery service is done or given context is cancelled & attempts to connect
+// to them, while setting up stream for further chit-chat
+func LookForPeers(ctx context.Context, _host host.Host, routing *discovery.RoutingDiscovery) {
 
 	peerChan, err := routing.FindPeers(ctx, config.GetNetworkingRendezvous())
 	if err != nil {
@@ -111,79 +193,133 @@ func LookForPeers(ctx context.Context, _host host.Host, _dht *dht.IpfsDHT, routi
 
 	}
 
-	{
-	OUTER:
-		for {
+	for found := range peerChan {
 
-		INNER:
-			select {
+		// this is me 😅
+		if found.ID == _host.ID() {
+			continue
+		}
 
-			// Keeping track of signal, whether main go routine is asking
-			// this one to stop, because application is going done, so it's better to
-			// attempt graceful shutdown
-			case <-ctx.Done():
+		// Peer violated protocol recently/ it's not allowlisted
+		if connectionManager.IsPenalized(found.ID) || !isAllowed(found.ID) {
+			continue
+		}
 
-				if err := _dht.Close(); err != nil {
-					log.Printf("[❗️] Failed to stop peer discovery mechanism : %s\n", err.Error())
-				}
+		// We're already connected with this peer
+		if connectionManager.IsConnected(found.ID) {
 
-				break OUTER
+			log.Printf("[🙂] Discovered already connected peer : %s\n", found)
+			continue
 
-			case found := <-peerChan:
+		}
 
-				if found.ID.String() == "" {
-					break OUTER
-				}
+		// Peer table is full, newly discovered peer can't be dialed
+		if !connectionManager.HasRoom(false) {
 
-				// this is me 😅
-				if found.ID == _host.ID() {
-					break INNER
-				}
+			log.Printf("[🙂] No room for discovered peer : %s\n", found)
+			continue
 
-				// We're already connected with this peer
-				if connectionManager.IsConnected(found.ID) {
+		}
 
-					log.Printf("[🙂] Discovered already connected peer : %s\n", found)
-					break INNER
+		// Adding peer info in local peer store, so that we can attempt to connect to
+		// this peer in near future, if connection is found to be lost due to some
+		// unforeseeable reasons
+		_host.Peerstore().AddAddrs(found.ID, found.Addrs, peerstore.PermanentAddrTTL)
 
-				}
+		if !dial(ctx, _host, found) {
 
-				// Adding peer info in local peer store, so that we can attempt to connect to
-				// this peer in near future, if connection is found to be lost due to some
-				// unforeseeable reasons
-				_host.Peerstore().AddAddrs(found.ID, found.Addrs, peerstore.PermanentAddrTTL)
+			log.Printf("[❗️] Failed to connect to discovered peer : %s\n", found)
+			continue
 
-				stream, err := _host.NewStream(ctx, found.ID, protocol.ID(config.GetNetworkingStream()))
-				if err != nil {
+		}
 
-					log.Printf("[❗️] Failed to connect to discovered peer : %s\n", found)
-					break INNER
+		log.Printf("✅ Connected to new discovered peer : %s\n", found)
 
-				}
+	}
 
-				func(stream network.Stream) {
-					go HandleStream(stream)
-				}(stream)
+}
 
-				log.Printf("✅ Connected to new discovered peer : %s\n", found)
+// RedialPeers - Every second, attempts to reconnect with dropped & static
+// peers, whose wait time is over, as long as there's room for them, until
+// asked to stop
+func RedialPeers(ctx context.Context, _host host.Host) {
 
-			case <-time.After(time.Duration(1) * time.Millisecond):
+	ticker := time.NewTicker(time.Duration(1) * time.Second)
+	defer ticker.Stop()
 
-				break INNER
+	for {
 
-			}
+		select {
+
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+
+			redialDue(ctx, _host)
 
 		}
+
+	}
+
+}
+
+// redialDue - Attempts to reconnect with dropped peers, whose wait time is
+// over, each in its own go routine
+func redialDue(ctx context.Context, _host host.Host) {
+
+	if !connectionManager.HasRoom(false) {
+		return
+	}
+
+	for _, info := range connectionManager.Due() {
+
+		// Remembered addresses to be used for dialing
+		_host.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.TempAddrTTL)
+
+		go func(info peer.AddrInfo) {
+
+			if !dial(ctx, _host, info) {
+
+				log.Printf("[❗️] Failed to reconnect to dropped peer : %s\n", info)
+				return
+
+			}
+
+			log.Printf("✅ Reconnected to dropped peer : %s\n", info)
+
+		}(info)
+
+	}
+
+}
+
+// discoveryBackoff - How long to wait before looking for peers again, when
+// peer table is found to be full in `full` consecutive rounds
+func discoveryBackoff(full uint64) time.Duration {
+
+	backoff := config.GetPeerDiscoveryPeriod()
+	max := config.GetPeerDiscoveryPeriodMax()
+
+	for i := uint64(0); i < full && backoff < max; i++ {
+		backoff *= 2
+	}
+
+	if backoff > max {
+		backoff = max
 	}
 
+	return time.Duration(backoff) * time.Millisecond
+
 }
 
 // SetUpPeerDiscovery - Setting up peer discovery mechanism, by connecting
-// to bootstrap nodes first, then advertises self with rendezvous & attempts to
-// discover peers with same rendezvous, which are to be eventually connected with
-func SetUpPeerDiscovery(ctx context.Context, _host host.Host, comm chan struct{}) {
+// to bootstrap nodes first, then periodically advertises self with rendezvous
+// & attempts to discover peers with same rendezvous, which are to be eventually
+// connected with, until asked to stop
+func SetUpPeerDiscovery(ctx context.Context, _host host.Host, bootstrapPeers []multiaddr.Multiaddr, comm chan struct{}) {
 
-	connected, total := ConnectToBootstraps(ctx, _host)
+	connected, total := ConnectToBootstraps(ctx, _host, bootstrapPeers)
 	log.Printf("✅ Connected to %d/ %d bootstrap nodes\n", connected, total)
 
 	_dht, err := dht.New(ctx, _host, dht.Mode(dht.ModeOpt(config.GetPeerDiscoveryMode())))
@@ -196,6 +332,14 @@ func SetUpPeerDiscovery(ctx context.Context, _host host.Host, comm chan struct{}
 
 	}
 
+	defer func() {
+
+		if err := _dht.Close(); err != nil {
+			log.Printf("[❗️] Failed to stop peer discovery mechanism : %s\n", err.Error())
+		}
+
+	}()
+
 	if err := _dht.Bootstrap(ctx); err != nil {
 
 		log.Printf("[❗️] Failed to keep refreshing DHT : %s\n", err.Error())
@@ -206,24 +350,55 @@ func SetUpPeerDiscovery(ctx context.Context, _host host.Host, comm chan struct{}
 	}
 
 	routingDiscovery := discovery.NewRoutingDiscovery(_dht)
-	routingDiscovery.Advertise(
-		ctx,
-		config.GetNetworkingRendezvous(),
-		_discovery.TTL(time.Duration(1)*time.Hour), // Published record of self to stay floating for <= 1 hour
-		_discovery.Limit(100))
 
-	log.Printf("✅ Advertised self with rendezvous\n")
+	lookFor := time.After(0)
+	// #-of consecutive rounds, peer table was found to be full
+	var full uint64
 
 	for {
 
-		log.Printf("✅ Started looking for peers\n")
+		select {
+
+		case <-ctx.Done():
+			return
 
-		workerComm := make(chan struct{}, 1)
-		go LookForPeers(ctx, _host, _dht, routingDiscovery, workerComm)
-		<-workerComm
+		case <-lookFor:
 
-		log.Printf("✅ Stopped looking for peers\n")
-		<-time.After(time.Minute * time.Duration(2))
+			// Published record of self to stay floating for <= 1 hour, it's
+			// re-published in every round, so that it never expires
+			if _, err := routingDiscovery.Advertise(
+				ctx,
+				config.GetNetworkingRendezvous(),
+				_discovery.TTL(time.Duration(1)*time.Hour),
+				_discovery.Limit(100)); err != nil {
+				log.Printf("[❗️] Failed to advertise self with rendezvous : %s\n", err.Error())
+			}
+
+			if !connectionManager.HasRoom(false) {
+
+				full++
+				lookFor = time.After(discoveryBackoff(full))
+
+				log.Printf("[🙂] Peer table is full, not looking for peers\n")
+				break
+
+			}
+
+			full = 0
+
+			log.Printf("✅ Started looking for peers\n")
+
+			// Discovery round must not outlive its period, so that rounds
+			// never overlap
+			period := discoveryBackoff(0)
+			roundCtx, cancel := context.WithTimeout(ctx, period)
+			LookForPeers(roundCtx, _host, routingDiscovery)
+			cancel()
+
+			log.Printf("✅ Stopped looking for peers\n")
+			lookFor = time.After(period)
+
+		}
 
 	}
 
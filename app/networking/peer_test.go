@@ -0,0 +1,113 @@
+package networking
+
+import (
+	"strings"
+	"testing"
+
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+)
+
+func TestBootstrapPeers(t *testing.T) {
+
+	first := "/ip4/104.131.131.82/tcp/4001/p2p/QmaCpDMGvV2BGHeYERUEnRQAwe3N8SzbUtfsmvsqQLuvuJ"
+	second := "/dnsaddr/bootstrap.libp2p.io/p2p/QmNnooDu7bfjPFoTZYxMNLWUQJyrVwtbZg5gBMjTezGAJN"
+
+	cases := []struct {
+		name      string
+		bootstrap string
+		defaults  string
+		want      []string
+		wantErr   string
+	}{
+		{
+			name: "none",
+			want: []string{},
+		},
+		{
+			name:      "single",
+			bootstrap: first,
+			want:      []string{first},
+		},
+		{
+			name:      "comma separated",
+			bootstrap: first + "," + second,
+			want:      []string{first, second},
+		},
+		{
+			name:      "empty entries",
+			bootstrap: "," + first + ", ,," + second + ",",
+			want:      []string{first, second},
+		},
+		{
+			name:      "duplicates",
+			bootstrap: first + "," + second + "," + first,
+			want:      []string{first, second},
+		},
+		{
+			name:      "bad multi address",
+			bootstrap: "/ip4/104.131.131.82/tcpp/4001",
+			wantErr:   "address 1 `/ip4/104.131.131.82/tcpp/4001`",
+		},
+		{
+			name:      "not multi address",
+			bootstrap: first + ",104.131.131.82:4001",
+			wantErr:   "address 2 `104.131.131.82:4001`",
+		},
+		{
+			name:      "without peer identifier",
+			bootstrap: first + "," + second + ",/ip4/104.131.131.82/tcp/4001",
+			wantErr:   "address 3 `/ip4/104.131.131.82/tcp/4001`",
+		},
+		{
+			name:      "with defaults",
+			bootstrap: first,
+			defaults:  "true",
+			want:      []string{first},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+
+			withConfig(t, map[string]string{"NetworkingBootstrap": c.bootstrap, "NetworkingDefaultBootstrap": c.defaults})
+
+			peers, err := BootstrapPeers()
+
+			if len(c.wantErr) != 0 {
+
+				if err == nil {
+					t.Fatalf("expected bad entry to be rejected, got %v", peers)
+				}
+
+				if !strings.Contains(err.Error(), c.wantErr) {
+					t.Fatalf("expected error to point to %s, got %q", c.wantErr, err.Error())
+				}
+
+				return
+
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Defaults follow given ones
+			want := len(c.want)
+			if c.defaults == "true" {
+				want += len(dht.DefaultBootstrapPeers)
+			}
+
+			if len(peers) != want {
+				t.Fatalf("expected %d bootstrap node(s), got %d", want, len(peers))
+			}
+
+			for i, addr := range c.want {
+				if peers[i].String() != addr {
+					t.Fatalf("bootstrap node %d : expected %s, got %s", i+1, addr, peers[i])
+				}
+			}
+
+		})
+	}
+
+}
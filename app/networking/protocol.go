@@ -0,0 +1,170 @@
+package networking
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/protocol"
+)
+
+// Stream protocol versions, where newest one supported by both sides
+// is negotiated, when stream is opened
+//
+// v1 : Length prefixed tx chunks
+// v2 : Along with v1, keepalive pings
+// v3 : Along with v2, batched chunks
+const (
+	protocolV1 = iota + 1
+	protocolV2
+	protocolV3
+)
+
+// errBadBatch - Batch of chunks, received from peer, isn't well formed
+var errBadBatch = errors.New("malformed batch")
+
+// Flag byte, being only content of chunk, denoting it's keepalive ping,
+// sent only to peers speaking v2 or later
+const chunkPing byte = 0x03
+
+// Flag byte, put in front of batch of chunks, sent only to peers speaking
+// v3 or later, where it's followed by #-of chunks & each length prefixed
+// chunk, without its own signature, because whole batch is signed
+const chunkBatch byte = 0x04
+
+// streamProtocols - Protocol IDs this node speaks, newest first, where v1
+// is configured stream name itself & later ones are suffixed with version
+func streamProtocols() []protocol.ID {
+
+	base := config.GetNetworkingStream()
+	return []protocol.ID{protocol.ID(base + "/3"), protocol.ID(base + "/2"), protocol.ID(base)}
+
+}
+
+// protocolVersion - Version of protocol negotiated for stream
+func protocolVersion(stream network.Stream) int {
+
+	protocols := streamProtocols()
+
+	switch stream.Protocol() {
+	case protocols[0]:
+		return protocolV3
+	case protocols[1]:
+		return protocolV2
+	default:
+		return protocolV1
+	}
+
+}
+
+// pingInterval - Keepalive ping to be sent after being idle for this long
+func pingInterval() time.Duration {
+	return time.Duration(config.GetPeerPingInterval()) * time.Millisecond
+}
+
+// deadAfter - Peer not saying anything, not even ping, or not draining
+// what we're sending for this long, is considered dead
+func deadAfter() time.Duration {
+	return pingInterval() * time.Duration(config.GetPeerMaxMissedPings())
+}
+
+// isPing - Checks whether chunk is keepalive ping
+func isPing(chunk []byte) bool {
+	return len(chunk) == 1 && chunk[0] == chunkPing
+}
+
+// batchLimit - Batch is flushed as soon as it grows to these many bytes,
+// while leaving room for envelope, so that it never goes beyond max
+// message size, peer accepts
+func batchLimit() int {
+
+	limit := config.GetPeerBatchSize()
+
+	// Envelope carries peer identifier & signature, which are
+	// well within this
+	if max := config.GetMaxPeerMessageSize(); max <= limit+1024 {
+		limit = max / 2
+	}
+
+	return int(limit)
+
+}
+
+// batchDelay - Batch is flushed when oldest chunk in it has waited for this long
+func batchDelay() time.Duration {
+	return time.Duration(config.GetPeerBatchDelay()) * time.Millisecond
+}
+
+// isBatch - Checks whether chunk is batch of chunks
+func isBatch(chunk []byte) bool {
+	return len(chunk) != 0 && chunk[0] == chunkBatch
+}
+
+// batch - Puts chunks, without length prefix, in one batch
+//
+// Layout : flag | #-of chunks ( 4 ) | ( length ( 4 ) | chunk ) ...
+func batch(chunks [][]byte) []byte {
+
+	size := 1 + 4
+	for _, chunk := range chunks {
+		size += 4 + len(chunk)
+	}
+
+	batched := make([]byte, 5, size)
+	batched[0] = chunkBatch
+	binary.LittleEndian.PutUint32(batched[1:5], uint32(len(chunks)))
+
+	for _, chunk := range chunks {
+		batched = append(batched, prefixed(chunk)...)
+	}
+
+	return batched
+
+}
+
+// unbatch - Splits batch into chunks, making sure announced lengths
+// don't go beyond what's received
+func unbatch(batched []byte) ([][]byte, error) {
+
+	if len(batched) < 5 {
+		return nil, errBadBatch
+	}
+
+	count := binary.LittleEndian.Uint32(batched[1:5])
+	rest := batched[5:]
+
+	// Each chunk takes at least 5 bytes, so peer can't make us
+	// allocate arbitrarily large slice
+	if uint64(count)*5 > uint64(len(rest)) {
+		return nil, errBadBatch
+	}
+
+	chunks := make([][]byte, 0, count)
+
+	for i := uint32(0); i < count; i++ {
+
+		if len(rest) < 4 {
+			return nil, errBadBatch
+		}
+
+		size := binary.LittleEndian.Uint32(rest[:4])
+		rest = rest[4:]
+
+		if size == 0 || uint64(size) > uint64(len(rest)) {
+			return nil, errBadBatch
+		}
+
+		chunks = append(chunks, rest[:size])
+		rest = rest[size:]
+
+	}
+
+	if len(rest) != 0 {
+		return nil, errBadBatch
+	}
+
+	return chunks, nil
+
+}
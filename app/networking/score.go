@@ -0,0 +1,49 @@
+package networking
+
+import (
+	"math"
+	"time"
+
+	"github.com/itzmeanjan/harmony/app/config"
+)
+
+// Peer crossing this misbehaviour score gets banned, where each kind of
+// validation failure adds its own weight, as fraction of it
+const banScore = 1.0
+
+// score - Misbehaviour score of peer, halving every configured
+// half life period, as of when it was last updated
+type score struct {
+	value float64
+	at    time.Time
+}
+
+// decayed - Score of peer, as of given time
+func (s *score) decayed(now time.Time) float64 {
+
+	halfLife := float64(config.GetPeerScoreHalfLife()) * float64(time.Millisecond)
+	return s.value * math.Pow(0.5, float64(now.Sub(s.at))/halfLife)
+
+}
+
+// add - Decays score till now & adds weight of new validation failure
+func (s *score) add(now time.Time, weight float64) float64 {
+
+	s.value = s.decayed(now) + weight
+	s.at = now
+
+	return s.value
+
+}
+
+// undecodableWeight - Score added, when chunk received from peer can't
+// be decompressed/ deserialized
+func undecodableWeight() float64 {
+	return banScore / float64(config.GetPeerMaxUndecodableChunks())
+}
+
+// badTxWeight - Score added, when tx received from peer can't be accepted,
+// probably because it's signed for some other chain
+func badTxWeight() float64 {
+	return banScore / float64(config.GetMaxBadTxsPerPeer())
+}
@@ -0,0 +1,98 @@
+package networking
+
+import (
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/itzmeanjan/harmony/app/data"
+)
+
+// traffic - Traffic & tx contribution counters of peer, kept for whole
+// session, so that they survive stream churn, where counters are updated
+// atomically by stream workers
+type traffic struct {
+	bytesIn     uint64
+	bytesOut    uint64
+	framesIn    uint64
+	framesOut   uint64
+	txsAccepted uint64
+	txsRejected uint64
+	// Sum of life time of all connections, which are already dropped,
+	// only touched by connection manager
+	connectedFor time.Duration
+}
+
+// read - Frame of `n` bytes, including length prefix, is read from peer
+func (t *traffic) read(n int) {
+	atomic.AddUint64(&t.bytesIn, uint64(n))
+	atomic.AddUint64(&t.framesIn, 1)
+}
+
+// wrote - Frame of `n` bytes, including length prefix, is written to peer
+func (t *traffic) wrote(n int) {
+	atomic.AddUint64(&t.bytesOut, uint64(n))
+	atomic.AddUint64(&t.framesOut, 1)
+}
+
+// accepted - Tx received from peer is accepted into pool
+func (t *traffic) accepted() {
+	atomic.AddUint64(&t.txsAccepted, 1)
+}
+
+// rejected - Tx received from peer is rejected
+func (t *traffic) rejected() {
+	atomic.AddUint64(&t.txsRejected, 1)
+}
+
+// snapshot - Copy of counters, to be sent to client, where `state` is
+// of current connection with peer, if any
+func (t *traffic) snapshot(id string, state *PeerState) *data.PeerTraffic {
+
+	uptime := t.connectedFor
+	if state != nil {
+		uptime += time.Now().UTC().Sub(state.Since)
+	}
+
+	return &data.PeerTraffic{
+		Peer:        id,
+		Connected:   state != nil,
+		Relayed:     state != nil && state.Relayed,
+		BytesIn:     atomic.LoadUint64(&t.bytesIn),
+		BytesOut:    atomic.LoadUint64(&t.bytesOut),
+		FramesIn:    atomic.LoadUint64(&t.framesIn),
+		FramesOut:   atomic.LoadUint64(&t.framesOut),
+		TxsAccepted: atomic.LoadUint64(&t.txsAccepted),
+		TxsRejected: atomic.LoadUint64(&t.txsRejected),
+		Uptime:      uptime.String(),
+	}
+
+}
+
+// trafficTable - Traffic of each peer seen in this session, descending
+// ordered as per #-of tx(s) accepted from them
+//
+// @note Supposed to be invoked from connection manager's own go routine
+func (c *ConnectionManager) trafficTable() []*data.PeerTraffic {
+
+	table := make([]*data.PeerTraffic, 0, len(c.Traffic))
+
+	for id, t := range c.Traffic {
+
+		table = append(table, t.snapshot(id.String(), c.Peers[id]))
+
+	}
+
+	sort.Slice(table, func(i, j int) bool {
+
+		if table[i].TxsAccepted != table[j].TxsAccepted {
+			return table[i].TxsAccepted > table[j].TxsAccepted
+		}
+
+		return table[i].Peer < table[j].Peer
+
+	})
+
+	return table
+
+}
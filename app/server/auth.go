@@ -0,0 +1,155 @@
+package server
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/harmony/app/data"
+	"github.com/labstack/echo/v4"
+)
+
+// headerKeyLabel - Label of API key presented by client, set on request
+// after it's authenticated, so that it can be logged
+const headerKeyLabel = "X-Harmony-Key-Label"
+
+// apiKey - Key, which can be presented by client, along with label it's
+// known by, in logs
+type apiKey struct {
+	label string
+	key   []byte
+}
+
+// loadAPIKeys - Reads API keys from config & from keys file, if set, where
+// each entry is `label:key`
+func loadAPIKeys() ([]apiKey, error) {
+
+	entries := config.GetAPIKeys()
+
+	if file := config.GetAPIKeysFile(); len(file) != 0 {
+
+		fd, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+
+		defer fd.Close()
+
+		scanner := bufio.NewScanner(fd)
+		for scanner.Scan() {
+
+			line := strings.TrimSpace(scanner.Text())
+			if len(line) == 0 || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			entries = append(entries, line)
+
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+
+	}
+
+	keys := make([]apiKey, 0, len(entries))
+
+	for _, entry := range entries {
+
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 || len(strings.TrimSpace(parts[0])) == 0 || len(strings.TrimSpace(parts[1])) == 0 {
+			return nil, errors.New("bad API key entry, expected `label:key`")
+		}
+
+		keys = append(keys, apiKey{
+			label: strings.TrimSpace(parts[0]),
+			key:   []byte(strings.TrimSpace(parts[1])),
+		})
+
+	}
+
+	return keys, nil
+
+}
+
+// apiKeyAuth - Lets request through only when it presents one of given keys
+// as bearer token, recording label of key on request, for logging. Health
+// probes & admin API, having its own token, are skipped & when no key is
+// given, everything's let through
+func apiKeyAuth(keys []apiKey) echo.MiddlewareFunc {
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+
+		return func(c echo.Context) error {
+
+			// Label is only to be trusted when it's set here
+			c.Request().Header.Del(headerKeyLabel)
+
+			if len(keys) == 0 || !isGuarded(c.Request().URL.Path) {
+				return next(c)
+			}
+
+			presented := c.Request().Header.Get(echo.HeaderAuthorization)
+			if !strings.HasPrefix(presented, "Bearer ") {
+
+				return c.JSON(http.StatusUnauthorized, &data.Msg{
+					Message: "Missing API key",
+				})
+
+			}
+
+			label, ok := labelOf(keys, []byte(strings.TrimPrefix(presented, "Bearer ")))
+			if !ok {
+
+				return c.JSON(http.StatusUnauthorized, &data.Msg{
+					Message: "Bad API key",
+				})
+
+			}
+
+			c.Request().Header.Set(headerKeyLabel, label)
+			return next(c)
+
+		}
+
+	}
+
+}
+
+// isGuarded - Whether request to given path needs to present API key &
+// is subject to rate limiting
+func isGuarded(path string) bool {
+
+	if path == "/healthz" || path == "/readyz" {
+		return false
+	}
+
+	return !strings.HasPrefix(path, "/v1/admin/")
+
+}
+
+// labelOf - Finds label of presented key, comparing against all keys in
+// constant time, so that timing doesn't reveal how much of it matched
+func labelOf(keys []apiKey, presented []byte) (string, bool) {
+
+	var (
+		label string
+		found bool
+	)
+
+	for _, k := range keys {
+
+		if subtle.ConstantTimeCompare(k.key, presented) == 1 && !found {
+			label, found = k.label, true
+		}
+
+	}
+
+	return label, found
+
+}
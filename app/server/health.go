@@ -0,0 +1,80 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/harmony/app/data"
+	"github.com/labstack/echo/v4"
+)
+
+// liveness - Process is up, as long as it's able to respond
+func liveness(c echo.Context) error {
+
+	return c.JSON(http.StatusOK, &data.Msg{
+		Message: "alive",
+	})
+
+}
+
+// readiness - Checks whether mempool is being ingested i.e. last poll went
+// fine within 3x polling period & pools are alive, responding with 503,
+// listing failing components, otherwise
+//
+// Only state cached by poller & pools is looked at, so probing it doesn't
+// put any load on upstream node
+func readiness(res *data.Resource) echo.HandlerFunc {
+
+	return func(c echo.Context) error {
+
+		failing := make([]*data.FailingComponent, 0, 2)
+
+		// Effective interval may have been adapted down, below time it
+		// takes to fetch whole mempool, so configured one is used as floor
+		period := res.PollingInterval()
+		if configured := time.Duration(config.GetMemPoolPollingPeriod()) * time.Millisecond; period < configured {
+			period = configured
+		}
+
+		last := res.LastPolled()
+		if last.IsZero() {
+
+			failing = append(failing, &data.FailingComponent{
+				Component: "poller",
+				Reason:    "mempool not polled yet",
+			})
+
+		} else if ago := time.Now().UTC().Sub(last); ago > 3*period {
+
+			failing = append(failing, &data.FailingComponent{
+				Component: "poller",
+				Reason:    fmt.Sprintf("last polled %s ago, expected every %s", ago, period),
+			})
+
+		}
+
+		if !res.Pool.Pending.Alive() && !res.Pool.Queued.Alive() {
+
+			failing = append(failing, &data.FailingComponent{
+				Component: "pools",
+				Reason:    "pending & queued pools have stopped",
+			})
+
+		}
+
+		if len(failing) != 0 {
+
+			return c.JSON(http.StatusServiceUnavailable, &data.Readiness{
+				Ready:   false,
+				Failing: failing,
+			})
+
+		}
+
+		return c.JSON(http.StatusOK, &data.Readiness{Ready: true})
+
+	}
+
+}
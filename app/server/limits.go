@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/harmony/app/graph/model"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// queryLimits - Limits applied on each graphQL query resolver, so that
+// client asking for too much can neither hold pools up for long nor make
+// process run out of memory
+type queryLimits struct {
+	maxResults int
+	timeout    time.Duration
+	slots      chan struct{}
+}
+
+// newQueryLimits - Creates limits, as set in config, where each slot lets
+// one listing query resolver run
+func newQueryLimits() *queryLimits {
+
+	return &queryLimits{
+		maxResults: int(config.GetGraphQLMaxResults()),
+		timeout:    time.Duration(config.GetGraphQLTimeout()) * time.Millisecond,
+		slots:      make(chan struct{}, config.GetGraphQLMaxConcurrent()),
+	}
+
+}
+
+// limitError - GraphQL error carrying machine readable `code`, along with
+// limit client ran into, in extensions
+func limitError(code string, limit interface{}, message string) error {
+
+	return &gqlerror.Error{
+		Message: message,
+		Extensions: map[string]interface{}{
+			"code":  code,
+			"limit": limit,
+		},
+	}
+
+}
+
+// isListing - Whether query resolves to list of entries, either as it is
+// or wrapped in page, which is what can grow with pool
+func isListing(fc *graphql.FieldContext) bool {
+
+	if fc.Field.Field == nil || fc.Field.Definition == nil {
+		return false
+	}
+
+	typ := fc.Field.Definition.Type
+	return typ.Elem != nil || typ.NamedType == "MemPoolTxPage" || typ.NamedType == "MemPoolTxConnection"
+
+}
+
+// sizeOf - #-of entries query resolved to
+func sizeOf(res interface{}) int {
+
+	switch v := res.(type) {
+
+	case *model.MemPoolTxPage:
+		if v == nil {
+			return 0
+		}
+		return len(v.Txs)
+
+	case *model.MemPoolTxConnection:
+		if v == nil {
+			return 0
+		}
+		return len(v.Txs)
+
+	}
+
+	if v := reflect.ValueOf(res); v.Kind() == reflect.Slice {
+		return v.Len()
+	}
+
+	return 0
+
+}
+
+// around - Field middleware, which only looks at top level query fields,
+// resolving each one with timeout. Listing queries wait for free slot,
+// until timeout & get rejected when they resolve to too many entries
+//
+// Resolver keeps running on its own go routine, even after being given up
+// on, because pool request it's blocked on can't be withdrawn, but slot is
+// held until it's done, so that such resolvers can't pile up
+func (l *queryLimits) around(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || fc.Object != "Query" {
+		return next(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, l.timeout)
+	defer cancel()
+
+	timedOut := limitError("TIMEOUT", l.timeout.Milliseconds(), fmt.Sprintf("query couldn't be resolved within %s", l.timeout))
+
+	listing := isListing(fc)
+	if listing {
+
+		select {
+
+		case l.slots <- struct{}{}:
+
+		case <-ctx.Done():
+			return nil, limitError("TOO_MANY_CONCURRENT_QUERIES", cap(l.slots), "too many listing queries being resolved, try again later")
+
+		}
+
+	}
+
+	type result struct {
+		res interface{}
+		err error
+	}
+
+	done := make(chan result, 1)
+
+	go func() {
+
+		defer func() {
+
+			if listing {
+				<-l.slots
+			}
+
+			if r := recover(); r != nil {
+
+				log.Printf("[❗️] Recovered from panic, while resolving `%s` : %v\n", fc.Field.Name, r)
+				done <- result{err: errors.New("internal server error")}
+
+			}
+
+		}()
+
+		res, err := next(ctx)
+		done <- result{res: res, err: err}
+
+	}()
+
+	select {
+
+	case <-ctx.Done():
+		return nil, timedOut
+
+	case r := <-done:
+
+		if errors.Is(r.err, context.DeadlineExceeded) {
+			return nil, timedOut
+		}
+
+		if r.err != nil {
+			return nil, r.err
+		}
+
+		if n := sizeOf(r.res); listing && n > l.maxResults {
+			return nil, limitError("RESULT_TOO_LARGE", l.maxResults, fmt.Sprintf("query resolved to %d entries, above limit of %d, paginate using `pendingTxs`/ `queuedTxs`", n, l.maxResults))
+		}
+
+		return r.res, nil
+
+	}
+
+}
@@ -0,0 +1,236 @@
+package server
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/itzmeanjan/harmony/app/data"
+	"github.com/labstack/echo/v4"
+)
+
+// bucket - Tokens left for client, as of `last` time it was refilled,
+// along with how many of its requests were let through/ rejected
+type bucket struct {
+	tokens   float64
+	last     time.Time
+	allowed  uint64
+	rejected uint64
+}
+
+// rateLimiter - Token bucket per client, where each bucket is refilled
+// at `rate` tokens per second, never holding more than `burst` tokens
+type rateLimiter struct {
+	lock    sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[string]*bucket
+}
+
+// newRateLimiter - Given requests per second & burst, creates limiter,
+// which is disabled when rate is zero
+func newRateLimiter(rate float64, burst uint64) *rateLimiter {
+
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+	}
+
+}
+
+// enabled - Whether requests are to be rate limited at all
+func (r *rateLimiter) enabled() bool {
+
+	return r.rate > 0
+
+}
+
+// refill - Adds tokens accrued since bucket was last refilled
+func (r *rateLimiter) refill(b *bucket, now time.Time) {
+
+	b.tokens = math.Min(r.burst, b.tokens+now.Sub(b.last).Seconds()*r.rate)
+	b.last = now
+
+}
+
+// take - Attempts to take one token from client's bucket, when it's empty,
+// returns how long client needs to wait before it can try again
+func (r *rateLimiter) take(client string) (bool, time.Duration) {
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := time.Now().UTC()
+
+	b, ok := r.buckets[client]
+	if !ok {
+
+		b = &bucket{tokens: r.burst, last: now}
+		r.buckets[client] = b
+
+	}
+
+	r.refill(b, now)
+
+	if b.tokens >= 1 {
+
+		b.tokens--
+		b.allowed++
+		return true, 0
+
+	}
+
+	b.rejected++
+	return false, time.Duration((1 - b.tokens) / r.rate * float64(time.Second))
+
+}
+
+// sweep - Periodically forgets clients whose bucket is full again, so that
+// clients seen once don't keep occupying memory, until `ctx` is done
+func (r *rateLimiter) sweep(ctx context.Context, period time.Duration) {
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+
+		select {
+
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+
+			r.lock.Lock()
+
+			now := time.Now().UTC()
+			for client, b := range r.buckets {
+
+				r.refill(b, now)
+				if b.tokens >= r.burst {
+					delete(r.buckets, client)
+				}
+
+			}
+
+			r.lock.Unlock()
+
+		}
+
+	}
+
+}
+
+// usage - Limits along with usage of clients seen recently, ordered by
+// #-of requests rejected, so that misbehaving ones come first
+func (r *rateLimiter) usage() *data.RateLimitUsage {
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := time.Now().UTC()
+	clients := make([]*data.ClientUsage, 0, len(r.buckets))
+
+	for client, b := range r.buckets {
+
+		r.refill(b, now)
+
+		clients = append(clients, &data.ClientUsage{
+			Client:   client,
+			Tokens:   b.tokens,
+			Allowed:  b.allowed,
+			Rejected: b.rejected,
+			LastSeen: b.last,
+		})
+
+	}
+
+	sort.Slice(clients, func(i, j int) bool {
+
+		if clients[i].Rejected != clients[j].Rejected {
+			return clients[i].Rejected > clients[j].Rejected
+		}
+
+		return clients[i].Client < clients[j].Client
+
+	})
+
+	return &data.RateLimitUsage{
+		RequestsPerSecond: r.rate,
+		Burst:             uint64(r.burst),
+		Clients:           clients,
+	}
+
+}
+
+// ipExtractor - Client's IP address is taken from `X-Forwarded-For` header,
+// skipping hops through given trusted proxies, only when request comes from
+// one of them. Otherwise, or when no proxy is trusted, address of connection's
+// peer is used
+func ipExtractor(trusted []*net.IPNet) echo.IPExtractor {
+
+	if len(trusted) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	// By default loopback, link-local & private addresses are trusted too,
+	// only configured ones must be
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+
+	for _, ipRange := range trusted {
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...)
+
+}
+
+// rateLimit - Rejects requests of clients running out of tokens, with 429,
+// where client is identified by label of API key it presented, if any,
+// otherwise by IP address. Health probes & admin API are let through
+//
+// @note Subscription is one upgrade request, so it's counted only when
+// being established, not for each event pushed over it
+func rateLimit(limiter *rateLimiter) echo.MiddlewareFunc {
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+
+		return func(c echo.Context) error {
+
+			if !limiter.enabled() || !isGuarded(c.Request().URL.Path) {
+				return next(c)
+			}
+
+			client := "ip:" + c.RealIP()
+			if label := c.Request().Header.Get(headerKeyLabel); len(label) != 0 {
+				client = "key:" + label
+			}
+
+			ok, wait := limiter.take(client)
+			if !ok {
+
+				c.Response().Header().Set("Retry-After", strconv.FormatInt(int64(math.Ceil(wait.Seconds())), 10))
+
+				return c.JSON(http.StatusTooManyRequests, &data.Msg{
+					Message: "Rate limit exceeded",
+				})
+
+			}
+
+			return next(c)
+
+		}
+
+	}
+
+}
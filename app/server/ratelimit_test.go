@@ -0,0 +1,109 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+// limitedRouter - Router letting each client make one request, where client's
+// IP address is found as it's done when proxies in given ranges are trusted
+func limitedRouter(t *testing.T, trusted ...string) *echo.Echo {
+	t.Helper()
+
+	ranges := make([]*net.IPNet, 0, len(trusted))
+	for _, v := range trusted {
+
+		_, ipRange, err := net.ParseCIDR(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ranges = append(ranges, ipRange)
+
+	}
+
+	router := echo.New()
+	router.IPExtractor = ipExtractor(ranges)
+	router.Use(rateLimit(newRateLimiter(0.001, 1)))
+	router.GET("/v1/stat", func(c echo.Context) error {
+		return c.String(http.StatusOK, c.RealIP())
+	})
+
+	return router
+
+}
+
+// status - Sends request from given peer address, forwarded for given
+// addresses, if any, returning status code of response
+func status(router *echo.Echo, peer string, forwardedFor string) int {
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/stat", nil)
+	req.RemoteAddr = peer + ":40000"
+	if len(forwardedFor) != 0 {
+		req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	return rec.Code
+
+}
+
+func TestRateLimitIgnoresSpoofedForwardedFor(t *testing.T) {
+
+	cases := []struct {
+		name     string
+		trusted  []string
+		requests [][2]string
+		codes    []int
+	}{
+		{
+			name:     "no trusted proxy, header is ignored",
+			requests: [][2]string{{"203.0.113.7", "198.51.100.1"}, {"203.0.113.7", "198.51.100.2"}},
+			codes:    []int{http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			name:     "private peer isn't trusted by default",
+			requests: [][2]string{{"10.0.0.1", "198.51.100.1"}, {"10.0.0.1", "198.51.100.2"}},
+			codes:    []int{http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			name:     "untrusted peer behind trusted range",
+			trusted:  []string{"10.0.0.0/8"},
+			requests: [][2]string{{"203.0.113.7", "198.51.100.1"}, {"203.0.113.7", "198.51.100.2"}},
+			codes:    []int{http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			name:     "clients behind trusted proxy are told apart",
+			trusted:  []string{"10.0.0.0/8"},
+			requests: [][2]string{{"10.0.0.1", "198.51.100.1"}, {"10.0.0.1", "198.51.100.2"}, {"10.0.0.2", "198.51.100.1"}},
+			codes:    []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			name:     "address prepended by client behind trusted proxy is skipped",
+			trusted:  []string{"10.0.0.0/8"},
+			requests: [][2]string{{"10.0.0.1", "192.0.2.1, 198.51.100.1"}, {"10.0.0.1", "192.0.2.2, 198.51.100.1"}},
+			codes:    []int{http.StatusOK, http.StatusTooManyRequests},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+
+			router := limitedRouter(t, c.trusted...)
+
+			for i, r := range c.requests {
+				if code := status(router, r[0], r[1]); code != c.codes[i] {
+					t.Fatalf("request %d : expected status %d, got %d", i, c.codes[i], code)
+				}
+			}
+
+		})
+	}
+
+}
@@ -0,0 +1,273 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/itzmeanjan/harmony/app/data"
+	"github.com/labstack/echo/v4"
+)
+
+// pageOf - Given pool's cursor based listing function, returns handler
+// serving window of tx(s) from that pool, as asked for using `first`,
+// `after` & `order` query parameters
+func pageOf(list func(context.Context, int, *common.Hash, uint64) (data.TxPage, error)) echo.HandlerFunc {
+
+	return func(c echo.Context) error {
+
+		order, after, limit, err := parseCursorPage(c)
+		if err != nil {
+
+			return c.JSON(http.StatusBadRequest, &data.Msg{
+				Message: err.Error(),
+			})
+
+		}
+
+		page, err := list(c.Request().Context(), order, after, limit)
+		if err != nil {
+
+			status := http.StatusServiceUnavailable
+			if errors.Is(err, data.ErrUnknownCursor) {
+				status = http.StatusBadRequest
+			}
+
+			return c.JSON(status, &data.Msg{
+				Message: err.Error(),
+			})
+
+		}
+
+		return c.JSON(http.StatusOK, toPageResponse(page))
+
+	}
+
+}
+
+// txByHash - Looks up tx in both pools, responding with 404, when it's
+// living in none of them
+func txByHash(res *data.Resource) echo.HandlerFunc {
+
+	return func(c echo.Context) error {
+
+		hash := c.Param("hash")
+		if !isHash(hash) {
+
+			return c.JSON(http.StatusBadRequest, &data.Msg{
+				Message: "Bad tx hash",
+			})
+
+		}
+
+		tx, err := res.Pool.GetWithContext(c.Request().Context(), common.HexToHash(hash))
+		if err != nil {
+
+			return c.JSON(http.StatusServiceUnavailable, &data.Msg{
+				Message: err.Error(),
+			})
+
+		}
+
+		if tx == nil {
+
+			return c.JSON(http.StatusNotFound, &data.Msg{
+				Message: "Tx not found in pool",
+			})
+
+		}
+
+		return c.JSON(http.StatusOK, tx)
+
+	}
+
+}
+
+// pendingFrom - Lists tx(s) pending from given address, which is empty
+// list when there's none
+func pendingFrom(res *data.Resource) echo.HandlerFunc {
+
+	return func(c echo.Context) error {
+
+		addr := c.Param("addr")
+		if !common.IsHexAddress(addr) {
+
+			return c.JSON(http.StatusBadRequest, &data.Msg{
+				Message: "Bad address",
+			})
+
+		}
+
+		txs, err := res.Pool.PendingFromWithContext(c.Request().Context(), common.HexToAddress(addr))
+		if err != nil {
+
+			return c.JSON(http.StatusServiceUnavailable, &data.Msg{
+				Message: err.Error(),
+			})
+
+		}
+
+		if txs == nil {
+			txs = []*data.MemPoolTx{}
+		}
+
+		return c.JSON(http.StatusOK, txs)
+
+	}
+
+}
+
+// stats - Composition of pending & queued pools, in one go
+func stats(res *data.Resource) echo.HandlerFunc {
+
+	return func(c echo.Context) error {
+
+		return c.JSON(http.StatusOK, toStatsResponse(res.Pool.Stats()))
+
+	}
+
+}
+
+// parseCursorPage - Attempts to parse cursor based pagination query
+// parameters, where absent `first` denotes all tx(s) after cursor, absent
+// `after` denotes very beginning & tx(s) are ascending ordered, unless
+// `order` is `desc`
+func parseCursorPage(c echo.Context) (int, *common.Hash, uint64, error) {
+
+	order := data.ASC
+
+	switch strings.ToLower(c.QueryParam("order")) {
+
+	case "", "asc":
+	case "desc":
+		order = data.DESC
+	default:
+		return 0, nil, 0, errors.New("bad order")
+
+	}
+
+	var after *common.Hash
+
+	if v := c.QueryParam("after"); len(v) != 0 {
+
+		if !isHash(v) {
+			return 0, nil, 0, errors.New("bad cursor to paginate from")
+		}
+
+		hash := common.HexToHash(v)
+		after = &hash
+
+	}
+
+	var limit uint64
+
+	if v := c.QueryParam("first"); len(v) != 0 {
+
+		first, err := strconv.ParseUint(v, 10, 64)
+		if err != nil || first == 0 {
+			return 0, nil, 0, errors.New("bad page size")
+		}
+
+		limit = first
+
+	}
+
+	return order, after, limit, nil
+
+}
+
+// isHash - Checks whether given string is 0x prefixed 32 bytes hex string
+func isHash(hash string) bool {
+
+	if !(len(hash) == 66 && strings.HasPrefix(hash, "0x")) {
+		return false
+	}
+
+	for _, c := range hash[2:] {
+
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return false
+		}
+
+	}
+
+	return true
+
+}
+
+// toPageResponse - Converts window of tx(s) to response, where last tx's
+// hash is cursor for fetching next window
+func toPageResponse(page data.TxPage) *data.TxPageResponse {
+
+	var endCursor string
+	if len(page.Txs) != 0 {
+		endCursor = page.Txs[len(page.Txs)-1].Hash.Hex()
+	}
+
+	txs := page.Txs
+	if txs == nil {
+		txs = []*data.MemPoolTx{}
+	}
+
+	return &data.TxPageResponse{
+		Txs:         txs,
+		TotalCount:  page.Total,
+		EndCursor:   endCursor,
+		HasNextPage: page.Offset+uint64(len(page.Txs)) < page.Total,
+	}
+
+}
+
+// toStatsResponse - Converts pool composition to response, where big
+// numbers are decimal encoded & durations are human readable
+func toStatsResponse(stats data.MemPoolStats) *data.MemPoolStatsResponse {
+
+	toDecimal := func(v *big.Int) string {
+		if v == nil {
+			return "0"
+		}
+
+		return v.String()
+	}
+
+	convert := func(s data.PoolStats) data.PoolStatsResponse {
+		return data.PoolStatsResponse{
+			Count:         s.Count,
+			Limit:         s.Limit,
+			UniqueSenders: s.UniqueSenders,
+			TotalGas:      s.TotalGas,
+			TotalValue:    toDecimal(s.TotalValue),
+			P10:           toDecimal(s.P10),
+			P25:           toDecimal(s.P25),
+			P50:           toDecimal(s.P50),
+			P75:           toDecimal(s.P75),
+			P90:           toDecimal(s.P90),
+			P99:           toDecimal(s.P99),
+			OldestAge:     s.OldestAge.String(),
+			Added:         s.Added,
+			Removed:       s.Removed,
+		}
+	}
+
+	topics := make([]data.TopicStatsResponse, 0, len(stats.Topics))
+	for _, t := range stats.Topics {
+		topics = append(topics, data.TopicStatsResponse{
+			Topic:     t.Topic,
+			Enabled:   t.Enabled,
+			Published: t.Published,
+		})
+	}
+
+	return &data.MemPoolStatsResponse{
+		Pending:          convert(stats.Pending),
+		Queued:           convert(stats.Queued),
+		Topics:           topics,
+		LastPollDuration: stats.LastPollDuration.String(),
+		ComputedAt:       stats.ComputedAt,
+	}
+
+}
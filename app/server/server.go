@@ -2,20 +2,26 @@ package server
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/websocket"
 	"github.com/itzmeanjan/harmony/app/config"
 	"github.com/itzmeanjan/harmony/app/data"
 	"github.com/itzmeanjan/harmony/app/graph"
 	"github.com/itzmeanjan/harmony/app/graph/generated"
+	"github.com/itzmeanjan/harmony/app/networking"
+	"github.com/itzmeanjan/harmony/app/upstream"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
@@ -23,11 +29,28 @@ import (
 // Start - Life cycle definition of http server
 func Start(ctx context.Context, res *data.Resource) {
 
+	keys, err := loadAPIKeys()
+	if err != nil {
+
+		log.Printf("[❌] Failed to load API keys : %s\n", err.Error())
+		return
+
+	}
+
+	if len(keys) != 0 {
+		log.Printf("[🔑] Loaded %d API key(s), requests to be authenticated\n", len(keys))
+	}
+
 	router := echo.New()
 
+	// Client's IP address, as seen by logger & rate limiter, can't be
+	// picked by client itself
+	router.IPExtractor = ipExtractor(config.GetTrustedProxies())
+
+	// Label of API key presented, empty when authentication is disabled
 	router.Use(middleware.LoggerWithConfig(
 		middleware.LoggerConfig{
-			Format: "${time_rfc3339} [📩] ${method} | ${uri} | ${status} | ${remote_ip} | ${latency_human}\n",
+			Format: "${time_rfc3339} [📩] ${method} | ${uri} | ${status} | ${remote_ip} | ${latency_human} | ${header:" + headerKeyLabel + "}\n",
 		}))
 
 	router.Use(middleware.CORSWithConfig(
@@ -37,8 +60,30 @@ func Start(ctx context.Context, res *data.Resource) {
 			AllowMethods: []string{http.MethodGet, http.MethodPost},
 		}))
 
+	router.Use(apiKeyAuth(keys))
+
+	// Limiter stays in place even when disabled, so that usage endpoint
+	// can report so
+	limiter := newRateLimiter(config.GetRateLimit(), config.GetRateLimitBurst())
+	if limiter.enabled() {
+
+		log.Printf("[🚦] Rate limiting clients to %.2f request(s)/ second, with burst of %d\n", config.GetRateLimit(), config.GetRateLimitBurst())
+		go limiter.sweep(ctx, time.Minute)
+
+	}
+
+	router.Use(rateLimit(limiter))
+
+	// Probes, kept out of versioned API, as orchestrators expect them at root
+	router.GET("/healthz", liveness)
+	router.GET("/readyz", readiness(res))
+
 	v1 := router.Group("/v1")
 
+	// Single subscription to mempool changes, shared by all SSE clients
+	hub := newStreamHub()
+	go hub.run(ctx)
+
 	graphql := handler.NewDefaultServer(generated.NewExecutableSchema(
 		generated.Config{
 			Resolvers: &graph.Resolver{},
@@ -67,20 +112,201 @@ func Start(ctx context.Context, res *data.Resource) {
 
 	}
 
+	// Queries asking for too much are rejected, either before being
+	// resolved, as per complexity of selection set, or while resolving
+	graphql.Use(extension.FixedComplexityLimit(int(config.GetGraphQLMaxComplexity())))
+	graphql.AroundFields(newQueryLimits().around)
+
+	// Admin API, only to be invoked by operator, presenting
+	// configured bearer token
+	admin := v1.Group("/admin", adminAuth)
+
 	{
 
 		v1.GET("/stat", func(c echo.Context) error {
 
-			latestBlock := res.Pool.LastSeenBlock()
+			ctx := c.Request().Context()
+
+			// Pools may have stopped already, when shutting down, in that
+			// case there's nothing to report
+			unavailable := func(err error) error {
+
+				return c.JSON(http.StatusServiceUnavailable, &data.Msg{
+					Message: err.Error(),
+				})
+
+			}
+
+			latestBlock, err := res.Pool.LastSeenBlockWithContext(ctx)
+			if err != nil {
+				return unavailable(err)
+			}
+
+			pending, err := res.Pool.PendingPoolLengthWithContext(ctx)
+			if err != nil {
+				return unavailable(err)
+			}
+
+			queued, err := res.Pool.QueuedPoolLengthWithContext(ctx)
+			if err != nil {
+				return unavailable(err)
+			}
+
+			processed, err := res.Pool.DoneTxCountWithContext(ctx)
+			if err != nil {
+				return unavailable(err)
+			}
+
+			peers := networking.ConnectedPeers()
 
 			return c.JSON(http.StatusOK, &data.Stat{
-				PendingPoolSize: res.Pool.PendingPoolLength(),
-				QueuedPoolSize:  res.Pool.QueuedPoolLength(),
+				PendingPoolSize: pending,
+				QueuedPoolSize:  queued,
 				Uptime:          time.Now().UTC().Sub(res.StartedAt).String(),
-				Processed:       res.Pool.DoneTxCount(),
+				Processed:       processed,
 				LatestBlock:     latestBlock.Number,
 				SeenAgo:         time.Now().UTC().Sub(latestBlock.At).String(),
 				NetworkID:       res.NetworkID,
+				PollingInterval: res.PollingInterval().String(),
+				SkippedPolls:    res.SkippedPolls(),
+				TimedOutCalls:   upstream.TimedOut(),
+				InboundPeers:    peers.Inbound,
+				OutboundPeers:   peers.Outbound,
+				RelayedPeers:    peers.Relayed,
+				RejectedPeers:   networking.RejectedPeers(),
+			})
+
+		})
+
+		v1.GET("/stats", stats(res))
+		v1.GET("/pending", pageOf(res.Pool.PendingAfterWithContext))
+		v1.GET("/queued", pageOf(res.Pool.QueuedAfterWithContext))
+		v1.GET("/tx/:hash", txByHash(res))
+		v1.GET("/address/:addr/pending", pendingFrom(res))
+
+		v1.GET("/stream", stream(hub))
+
+		v1.GET("/identity", func(c echo.Context) error {
+
+			identity := networking.Identity()
+			if identity == nil {
+				return c.JSON(http.StatusNotFound, &data.Msg{
+					Message: "Networking not enabled",
+				})
+			}
+
+			return c.JSON(http.StatusOK, identity)
+
+		})
+
+		v1.GET("/stat/peers", func(c echo.Context) error {
+
+			return c.JSON(http.StatusOK, networking.PeerScores())
+
+		})
+
+		v1.GET("/stat/traffic", func(c echo.Context) error {
+
+			return c.JSON(http.StatusOK, networking.PeerTraffic())
+
+		})
+
+		v1.GET("/gas", func(c echo.Context) error {
+
+			rec, err := res.Pool.GasPriceRecommendation(c.Request().Context())
+			if err != nil {
+
+				return c.JSON(http.StatusInternalServerError, &data.Msg{
+					Message: err.Error(),
+				})
+
+			}
+
+			return c.JSON(http.StatusOK, rec)
+
+		})
+
+		v1.POST("/tx", func(c echo.Context) error {
+
+			var raw data.RawTx
+
+			if err := c.Bind(&raw); err != nil {
+
+				return c.JSON(http.StatusBadRequest, &data.Msg{
+					Message: "Bad request payload",
+				})
+
+			}
+
+			tx, err := res.Pool.AddRawTx(c.Request().Context(), raw.Data)
+			if err != nil {
+
+				status := http.StatusBadRequest
+				switch {
+				case errors.Is(err, data.ErrAlreadyKnown):
+					status = http.StatusConflict
+				case errors.Is(err, data.ErrUnknownChainID):
+					status = http.StatusServiceUnavailable
+				}
+
+				return c.JSON(status, &data.Msg{
+					Message: err.Error(),
+				})
+
+			}
+
+			return c.JSON(http.StatusOK, &data.Msg{
+				Message: tx.Hash.Hex(),
+			})
+
+		})
+
+		admin.POST("/export", func(c echo.Context) error {
+
+			file, err := res.Pool.Export(config.GetExportDirectory())
+			if err != nil {
+
+				return c.JSON(http.StatusInternalServerError, &data.Msg{
+					Message: err.Error(),
+				})
+
+			}
+
+			log.Printf("[💾] Exported pool snapshot : %s\n", file)
+
+			return c.JSON(http.StatusOK, &data.Msg{
+				Message: file,
+			})
+
+		})
+
+		admin.GET("/ratelimit", func(c echo.Context) error {
+
+			return c.JSON(http.StatusOK, limiter.usage())
+
+		})
+
+		admin.POST("/evict/:hash", func(c echo.Context) error {
+
+			hash := c.Param("hash")
+			if !(len(hash) == 66 && strings.HasPrefix(hash, "0x")) {
+
+				return c.JSON(http.StatusBadRequest, &data.Msg{
+					Message: "Bad tx hash",
+				})
+
+			}
+
+			if !res.Pool.ForceRemove(c.Request().Context(), common.HexToHash(hash)) {
+
+				return c.JSON(http.StatusNotFound, &data.Msg{
+					Message: "Tx not found in pool",
+				})
+
+			}
+
+			return c.JSON(http.StatusOK, &data.Msg{
+				Message: hash,
 			})
 
 		})
@@ -126,9 +352,69 @@ func Start(ctx context.Context, res *data.Resource) {
 
 	}
 
-	if err := router.Start(fmt.Sprintf(":%d", config.GetPortNumber())); err != nil {
+	addr := fmt.Sprintf(":%d", config.GetPortNumber())
+
+	if !config.IsTLSEnabled() {
+
+		if err := router.Start(addr); err != nil {
+
+			log.Printf("[❌] Failed to start http server : %s\n", err.Error())
+
+		}
+
+		return
+
+	}
+
+	cert, err := loadCertificate(config.GetTLSCertFile(), config.GetTLSKeyFile())
+	if err != nil {
+
+		log.Printf("[❌] Failed to load TLS certificate : %s\n", err.Error())
+		return
+
+	}
+
+	go cert.reloadOnSignal(ctx)
+
+	if port := config.GetTLSRedirectPort(); port != 0 {
+		go redirectToTLS(ctx, port, config.GetPortNumber())
+	}
+
+	// Plaintext requests on this port fail TLS handshake, so they're
+	// refused, while websocket subscriptions work over wss
+	if err := router.StartServer(&http.Server{Addr: addr, TLSConfig: tlsConfig(cert)}); err != nil {
+
+		log.Printf("[❌] Failed to start https server : %s\n", err.Error())
+
+	}
+
+}
+
+// adminAuth - Lets request through only when it presents configured admin
+// token as bearer token, admin API stays disabled when token isn't set
+func adminAuth(next echo.HandlerFunc) echo.HandlerFunc {
+
+	return func(c echo.Context) error {
+
+		token := config.GetAdminToken()
+		if len(token) == 0 {
+
+			return c.JSON(http.StatusForbidden, &data.Msg{
+				Message: "Admin API disabled",
+			})
+
+		}
+
+		presented := strings.TrimPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+
+			return c.JSON(http.StatusUnauthorized, &data.Msg{
+				Message: "Bad admin token",
+			})
+
+		}
 
-		log.Printf("[❌] Failed to start http server : %s\n", err.Error())
+		return next(c)
 
 	}
 
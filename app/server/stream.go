@@ -0,0 +1,428 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/itzmeanjan/harmony/app/config"
+	"github.com/itzmeanjan/harmony/app/data"
+	"github.com/itzmeanjan/harmony/app/graph"
+	"github.com/itzmeanjan/pub0sub/ops"
+	"github.com/itzmeanjan/pub0sub/subscriber"
+	"github.com/labstack/echo/v4"
+)
+
+// streamEvent - Tx entering/ leaving some pool, as it's sent to SSE
+// clients, where `id` keeps increasing, in order of being seen
+type streamEvent struct {
+	id   uint64
+	kind string
+	tx   *data.MemPoolTx
+	data []byte
+}
+
+// streamFilter - Criteria, tx needs to satisfy for being sent to
+// client, where absent criteria matches any tx
+type streamFilter struct {
+	from        map[common.Address]bool
+	to          map[common.Address]bool
+	minGasPrice *big.Int
+}
+
+// matches - Whether tx satisfies all criteria of filter
+func (f *streamFilter) matches(tx *data.MemPoolTx) bool {
+
+	if len(f.from) != 0 && !f.from[tx.From] {
+		return false
+	}
+
+	if len(f.to) != 0 && (tx.To == nil || !f.to[*tx.To]) {
+		return false
+	}
+
+	if f.minGasPrice != nil && tx.EffectiveGasPrice(nil).Cmp(f.minGasPrice) < 0 {
+		return false
+	}
+
+	return true
+
+}
+
+// streamClient - SSE client, to which matching events are handed over,
+// which is let go, as soon as it falls behind
+type streamClient struct {
+	filter *streamFilter
+	events chan *streamEvent
+	gone   chan struct{}
+}
+
+// streamHub - Single subscriber of mempool changes, fanning out those to
+// all SSE clients, while keeping recent events, so that clients can
+// resume from where they left
+type streamHub struct {
+	lock    sync.Mutex
+	recent  []*streamEvent
+	next    uint64
+	clients map[*streamClient]struct{}
+	kinds   map[string]string
+}
+
+// newStreamHub - Creates hub, where event type is decided by topic
+// tx was published on
+func newStreamHub() *streamHub {
+
+	return &streamHub{
+		recent:  make([]*streamEvent, 0, config.GetStreamReplayBuffer()),
+		next:    1,
+		clients: make(map[*streamClient]struct{}),
+		kinds: map[string]string{
+			config.GetPendingTxEntryPublishTopic(): "pending_entry",
+			config.GetPendingTxExitPublishTopic():  "pending_exit",
+			config.GetQueuedTxEntryPublishTopic():  "queued_entry",
+			config.GetQueuedTxExitPublishTopic():   "queued_exit",
+		},
+	}
+
+}
+
+// run - Subscribes to mempool changes & keeps fanning those out, until
+// `ctx` is done, while retrying subscription, if it fails
+func (h *streamHub) run(ctx context.Context) {
+
+	var sub *subscriber.Subscriber
+
+	for {
+
+		var err error
+
+		sub, err = graph.SubscribeToMemPool(ctx)
+		if err == nil {
+			break
+		}
+
+		log.Printf("[❗️] Failed to subscribe to mempool changes, for streaming : %s\n", err.Error())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(5 * time.Second):
+		}
+
+	}
+
+	defer func() {
+		if _, err := sub.UnsubscribeAll(); err != nil {
+			log.Printf("[❗️] Failed to unsubscribe : %s\n", err.Error())
+		}
+		if err := sub.Disconnect(); err != nil {
+			log.Printf("[❗️] Failed to destroy subscriber : %s\n", err.Error())
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-sub.Watch():
+			for received := sub.Next(); received != nil; received = sub.Next() {
+				h.consume(received)
+			}
+		}
+	}
+
+}
+
+// consume - Turns tx(s) carried by pubsub message into events, which are
+// remembered & handed over to matching clients
+func (h *streamHub) consume(msg *ops.PushedMessage) {
+
+	kind, ok := h.kinds[msg.Topic]
+	if !ok {
+		return
+	}
+
+	txs, err := graph.UnmarshalPubSubMessage(msg.Data)
+	if err != nil {
+		log.Printf("[❗️] Failed to deserialise pubsub message : %s\n", err.Error())
+		return
+	}
+
+	for _, tx := range txs {
+
+		encoded, err := json.Marshal(tx)
+		if err != nil {
+			log.Printf("[❗️] Failed to serialize tx for streaming : %s\n", err.Error())
+			continue
+		}
+
+		h.publish(kind, tx, encoded)
+
+	}
+
+}
+
+// publish - Remembers event, forgetting oldest one when replay buffer is
+// full & hands it over to matching clients, where client not having room
+// for it is disconnected, instead of letting its backlog grow
+func (h *streamHub) publish(kind string, tx *data.MemPoolTx, encoded []byte) {
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	event := &streamEvent{id: h.next, kind: kind, tx: tx, data: encoded}
+	h.next++
+
+	if limit := cap(h.recent); limit != 0 {
+
+		if len(h.recent) == limit {
+			copy(h.recent, h.recent[1:])
+			h.recent = h.recent[:limit-1]
+		}
+
+		h.recent = append(h.recent, event)
+
+	}
+
+	for client := range h.clients {
+
+		if !client.filter.matches(tx) {
+			continue
+		}
+
+		select {
+		case client.events <- event:
+		default:
+			delete(h.clients, client)
+			close(client.gone)
+		}
+
+	}
+
+}
+
+// subscribe - Registers client, returning remembered events seen after
+// `lastID`, matching its filter, so that client can resume without gap
+func (h *streamHub) subscribe(client *streamClient, lastID uint64) []*streamEvent {
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	replay := make([]*streamEvent, 0)
+	if lastID != 0 {
+
+		for _, event := range h.recent {
+
+			if event.id > lastID && client.filter.matches(event.tx) {
+				replay = append(replay, event)
+			}
+
+		}
+
+	}
+
+	h.clients[client] = struct{}{}
+	return replay
+
+}
+
+// unsubscribe - Client has gone away, no more events to be handed over
+func (h *streamHub) unsubscribe(client *streamClient) {
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if _, ok := h.clients[client]; ok {
+
+		delete(h.clients, client)
+		close(client.gone)
+
+	}
+
+}
+
+// stream - Serves mempool changes as server-sent events, filtered as per
+// query parameters, resuming after `Last-Event-ID`, if given
+func stream(hub *streamHub) echo.HandlerFunc {
+
+	return func(c echo.Context) error {
+
+		filter, err := parseStreamFilter(c)
+		if err != nil {
+
+			return c.JSON(http.StatusBadRequest, &data.Msg{
+				Message: err.Error(),
+			})
+
+		}
+
+		var lastID uint64
+		if v := c.Request().Header.Get("Last-Event-ID"); len(v) != 0 {
+
+			lastID, err = strconv.ParseUint(v, 10, 64)
+			if err != nil {
+
+				return c.JSON(http.StatusBadRequest, &data.Msg{
+					Message: "Bad last event id",
+				})
+
+			}
+
+		}
+
+		client := &streamClient{
+			filter: filter,
+			events: make(chan *streamEvent, config.GetStreamClientBuffer()),
+			gone:   make(chan struct{}),
+		}
+
+		replay := hub.subscribe(client, lastID)
+		defer hub.unsubscribe(client)
+
+		w := c.Response()
+		w.Header().Set(echo.HeaderContentType, "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		w.Flush()
+
+		write := func(event *streamEvent) error {
+
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.id, event.kind, event.data); err != nil {
+				return err
+			}
+
+			w.Flush()
+			return nil
+
+		}
+
+		for _, event := range replay {
+			if err := write(event); err != nil {
+				return nil
+			}
+		}
+
+		// Keeps idle connection from being closed by proxies in between
+		heartbeat := time.NewTicker(15 * time.Second)
+		defer heartbeat.Stop()
+
+		for {
+
+			select {
+
+			case <-c.Request().Context().Done():
+				return nil
+
+			case <-client.gone:
+				// Either fell behind or hub is gone, events already
+				// handed over are still delivered
+				for {
+					select {
+					case event := <-client.events:
+						if err := write(event); err != nil {
+							return nil
+						}
+					default:
+						return nil
+					}
+				}
+
+			case event := <-client.events:
+				if err := write(event); err != nil {
+					return nil
+				}
+
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+					return nil
+				}
+
+				w.Flush()
+
+			}
+
+		}
+
+	}
+
+}
+
+// parseStreamFilter - Attempts to parse `from`, `to` & `minGasPrice` query
+// parameters, where first two can be given multiple times, for matching
+// any of those addresses & gas price is in wei, either decimal or hex
+func parseStreamFilter(c echo.Context) (*streamFilter, error) {
+
+	addresses := func(param string) (map[common.Address]bool, error) {
+
+		set := make(map[common.Address]bool)
+
+		for _, v := range c.QueryParams()[param] {
+			for _, addr := range strings.Split(v, ",") {
+
+				if !common.IsHexAddress(addr) {
+					return nil, fmt.Errorf("bad `%s` address", param)
+				}
+
+				set[common.HexToAddress(addr)] = true
+
+			}
+		}
+
+		return set, nil
+
+	}
+
+	from, err := addresses("from")
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := addresses("to")
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &streamFilter{from: from, to: to}
+
+	if v := c.QueryParam("minGasPrice"); len(v) != 0 {
+
+		var (
+			price *big.Int
+			ok    bool
+		)
+
+		if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+
+			_price, err := hexutil.DecodeBig(v)
+			price, ok = _price, err == nil
+
+		} else {
+
+			price, ok = new(big.Int).SetString(v, 10)
+
+		}
+
+		if !ok || price.Sign() < 0 {
+			return nil, errors.New("bad min gas price ( in wei )")
+		}
+
+		filter.minGasPrice = price
+
+	}
+
+	return filter, nil
+
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// certificate - Certificate being presented by HTTP server, which can be
+// replaced, while server keeps running
+type certificate struct {
+	certFile string
+	keyFile  string
+	current  atomic.Value
+}
+
+// loadCertificate - Reads certificate & private key from given paths,
+// which are remembered, for reloading later
+func loadCertificate(certFile string, keyFile string) (*certificate, error) {
+
+	c := &certificate{certFile: certFile, keyFile: keyFile}
+	if err := c.reload(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+
+}
+
+// reload - Reads certificate & key again, replacing one being presented,
+// only when both are read successfully, otherwise older one stays
+func (c *certificate) reload() error {
+
+	pair, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return err
+	}
+
+	c.current.Store(&pair)
+	return nil
+
+}
+
+// get - Certificate to be presented during TLS handshake
+func (c *certificate) get(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+
+	return c.current.Load().(*tls.Certificate), nil
+
+}
+
+// reloadOnSignal - Reloads certificate each time process receives SIGHUP,
+// so that renewed certificate is picked up without restart, until `ctx`
+// is done
+func (c *certificate) reloadOnSignal(ctx context.Context) {
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+
+		select {
+
+		case <-ctx.Done():
+			return
+
+		case <-hup:
+
+			if err := c.reload(); err != nil {
+
+				log.Printf("[❗️] Failed to reload TLS certificate, keeping older one : %s\n", err.Error())
+				continue
+
+			}
+
+			log.Printf("[🔐] Reloaded TLS certificate\n")
+
+		}
+
+	}
+
+}
+
+// tlsConfig - Server side TLS config, presenting certificate, which can
+// be reloaded
+func tlsConfig(cert *certificate) *tls.Config {
+
+	return &tls.Config{
+		GetCertificate: cert.get,
+		MinVersion:     tls.VersionTLS12,
+	}
+
+}
+
+// redirectToTLS - Serves plaintext HTTP on given port, redirecting each
+// request to same host & path, over HTTPS, on `tlsPort`
+func redirectToTLS(ctx context.Context, port uint64, tlsPort uint64) {
+
+	server := &http.Server{
+		Addr: fmt.Sprintf(":%d", port),
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			host, _, err := net.SplitHostPort(r.Host)
+			if err != nil {
+				host = r.Host
+			}
+
+			http.Redirect(w, r, fmt.Sprintf("https://%s:%d%s", host, tlsPort, r.URL.RequestURI()), http.StatusPermanentRedirect)
+
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+
+		<-ctx.Done()
+		server.Close()
+
+	}()
+
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+
+		log.Printf("[❗️] Failed to redirect plaintext HTTP to HTTPS : %s\n", err.Error())
+
+	}
+
+}
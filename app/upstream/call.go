@@ -0,0 +1,140 @@
+package upstream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/itzmeanjan/harmony/app/config"
+)
+
+// #-of outbound RPC calls, which didn't complete within configured
+// timeout, since start up
+var timedOut uint64
+
+// TimedOut - Returns #-of outbound RPC calls, which timed out, since start up
+func TimedOut() uint64 {
+
+	return atomic.LoadUint64(&timedOut)
+
+}
+
+// WithTimeout - Derives context, which gets done after configured per
+// call timeout, to be used for single outbound RPC call
+func WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+
+	return context.WithTimeout(ctx, time.Duration(config.GetRPCTimeout())*time.Millisecond)
+
+}
+
+// Observe - Given error returned by outbound RPC call, made using context
+// derived from `ctx`, keeps track of it, if it's timed out, returning
+// whether it's worth retrying
+func Observe(ctx context.Context, err error) bool {
+
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		atomic.AddUint64(&timedOut, 1)
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+
+		if netErr.Timeout() {
+			atomic.AddUint64(&timedOut, 1)
+		}
+
+		return true
+
+	}
+
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+
+}
+
+// IsTimeout - Checks whether error returned by outbound RPC call denotes
+// it didn't complete within configured timeout
+func IsTimeout(err error) bool {
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+
+}
+
+// Call - Makes outbound RPC call, giving up after configured timeout, while
+// transient failures are retried, at max configured #-of times, with
+// exponentially growing, jittered wait in between
+func Call(ctx context.Context, client *rpc.Client, result interface{}, method string, args ...interface{}) error {
+
+	retries := config.GetRPCRetries()
+	var err error
+
+	for attempt := uint64(0); ; attempt++ {
+
+		_ctx, cancel := WithTimeout(ctx)
+		err = client.CallContext(_ctx, result, method, args...)
+		cancel()
+
+		if !Observe(ctx, err) || attempt >= retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff(attempt)):
+		}
+
+	}
+
+}
+
+// BatchCall - Same as `Call`, but sends all given calls in one request,
+// where only failure of whole request is retried. Outcome of each call is
+// found in its own element
+func BatchCall(ctx context.Context, client *rpc.Client, batch []rpc.BatchElem) error {
+
+	retries := config.GetRPCRetries()
+	var err error
+
+	for attempt := uint64(0); ; attempt++ {
+
+		_ctx, cancel := WithTimeout(ctx)
+		err = client.BatchCallContext(_ctx, batch)
+		cancel()
+
+		if !Observe(ctx, err) || attempt >= retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff(attempt)):
+		}
+
+	}
+
+}
+
+// backoff - Wait before retrying `attempt`-th failed call, doubling
+// from 100ms, with up to same amount of random jitter added
+func backoff(attempt uint64) time.Duration {
+
+	base := time.Duration(100<<attempt) * time.Millisecond
+	return base + time.Duration(rand.Int63n(int64(base)))
+
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
+	"net"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -20,7 +22,49 @@ func main() {
 
 	log.Printf("[😌] Harmony - Reducing Chaos in MemPool\n")
 
-	abs, err := filepath.Abs(".env")
+	file := flag.String("config", ".env", "Path to config `file`")
+
+	// Command line flags, which are set, take precedence over both
+	// environment variable & config file, for respective config key
+	keys := map[string]string{
+		"rpc-url":        "RPCUrl",
+		"http-port":      "Port",
+		"polling-period": "MemPoolPollingPeriod",
+		"log-level":      "LogLevel",
+	}
+
+	flag.String("rpc-url", "", "txpool RPC API enabled Ethereum Node's `URI`, overrides RPCUrl")
+	flag.String("http-port", "", "`port` to start HTTP server on, overrides Port")
+	flag.String("polling-period", "", "Mempool to be polled every `period`, as milliseconds or duration e.g. 750ms, overrides MemPoolPollingPeriod")
+	flag.String("log-level", "", "Either of {info, error}, overrides LogLevel")
+	pub0subAddr := flag.String("pub0sub-addr", "", "Pub/Sub Hub's `host:port`, overrides Pub0SubHost & Pub0SubPort")
+
+	flag.Parse()
+
+	flag.Visit(func(f *flag.Flag) {
+
+		if key, ok := keys[f.Name]; ok {
+			config.Override(key, f.Value.String())
+		}
+
+	})
+
+	if len(*pub0subAddr) != 0 {
+
+		host, port, err := net.SplitHostPort(*pub0subAddr)
+		if err != nil {
+
+			log.Printf("[❗️] Bad `--pub0sub-addr` : %s\n", err.Error())
+			os.Exit(1)
+
+		}
+
+		config.Override("Pub0SubHost", host)
+		config.Override("Pub0SubPort", port)
+
+	}
+
+	abs, err := filepath.Abs(*file)
 	if err != nil {
 
 		log.Printf("[❗️] Failed to find absolute path of file : %s\n", err.Error())
@@ -40,10 +84,33 @@ func main() {
 
 	}
 
+	// Config keys, which can be changed without restart, are reloaded
+	// on SIGHUP or when config file gets modified
+	go config.Watch(ctx, time.Second*time.Duration(5), func(keys []string) {
+
+		for _, key := range keys {
+
+			if key == "PendingPoolSize" || key == "QueuedPoolSize" {
+
+				resources.Pool.Resized()
+				break
+
+			}
+
+		}
+
+	})
+
 	// To be passed to worker go routines, for listening to
 	// their state changes
 	comm := make(chan struct{}, 1)
 
+	// Mempool poller gets its own channel, which is replaced with
+	// fresh one, every time new poller is spawned, while it lets
+	// supervisor know about successful polls on `polled`
+	pollerComm := make(chan struct{}, 1)
+	polled := make(chan struct{}, 1)
+
 	// Checking if user has explicitly asked to be part of
 	// larger `harmony` p2p network
 	if config.GetNetworkingChoice() {
@@ -81,6 +148,37 @@ func main() {
 
 		}()
 
+		shutdown := func() {
+
+			// Final snapshot of pool state is taken, while pools'
+			// life cycle managers are still alive
+			if file := config.GetStateFile(); len(file) != 0 {
+
+				if err := resources.Pool.SaveState(file); err != nil {
+					log.Printf("[❗️] Failed to persist pool state : %s\n", err.Error())
+				}
+
+			}
+
+			// Attempting to let all other go routines know, master go routine
+			// wants all to shut down, they must do a graceful shut down
+			// of what they're doing now
+			cancel()
+
+			// Giving workers 3 seconds, before forcing shutdown
+			//
+			// This is simply a blocking call i.e. blocks for 3 seconds
+			<-time.After(time.Second * time.Duration(3))
+
+		}
+
+		// Consecutive failures of mempool poller, without any
+		// successful poll in between
+		var failures uint64
+		// Fires when it's time to spawn new mempool poller, nil
+		// while one is alive
+		var respawn <-chan time.Time
+
 	OUTER:
 		for {
 
@@ -88,28 +186,60 @@ func main() {
 
 			case <-interruptChan:
 
-				// When interrupt is received, attempting to
-				// let all other go routines know, master go routine
-				// wants all to shut down, they must do a graceful shut down
-				// of what they're doing now
-				cancel()
-
-				// Giving workers 3 seconds, before forcing shutdown
-				//
-				// This is simply a blocking call i.e. blocks for 3 seconds
-				<-time.After(time.Second * time.Duration(3))
+				shutdown()
 				break OUTER
 
 			case <-comm:
+				// Networking stack has died
+				break OUTER
+
+			case <-polled:
+				failures = 0
+
+			case <-pollerComm:
 				// Supervisor go routine got to learn
 				// that go routine it spawned some time ago
 				// for polling ethereum mempool content periodically
 				// has died
-				//
-				// It's supposed to spawn new go routine for handling that op
-				//
-				// @note To be implemented
-				break OUTER
+				failures++
+
+				if failures >= config.GetPollerMaxFailures() {
+
+					log.Printf("[❗️] Mempool poller failed %d time(s) consecutively, shutting down\n", failures)
+
+					shutdown()
+					break OUTER
+
+				}
+
+				backoff := mempool.Backoff(failures)
+				log.Printf("[❗️] Mempool poller died, spawning new one after %s\n", backoff)
+
+				// Not to be selected again, until new poller
+				// is spawned with fresh channel
+				pollerComm = nil
+				respawn = time.After(backoff)
+
+			case <-respawn:
+
+				respawn = nil
+
+				if err := mempool.Redial(ctx, resources); err != nil {
+
+					log.Printf("[❗️] Failed to re-dial RPC node : %s\n", err.Error())
+
+					// Counted as failure of poller itself, so that
+					// backoff grows & eventually it gives up
+					pollerComm = make(chan struct{})
+					close(pollerComm)
+					break
+
+				}
+
+				pollerComm = make(chan struct{})
+				go mempool.PollTxPoolContent(ctx, resources, pollerComm, polled)
+
+				log.Printf("[✅] Spawned new mempool poller\n")
 
 			}
 
@@ -118,7 +248,7 @@ func main() {
 	}()
 
 	// Starting tx pool monitor as a seperate worker
-	go mempool.PollTxPoolContent(ctx, resources, comm)
+	go mempool.PollTxPoolContent(ctx, resources, pollerComm, polled)
 
 	// Main go routine, starts one http server &
 	// interfaces with external world